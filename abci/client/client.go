@@ -1,12 +1,15 @@
 package abcicli
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"sync"
 
 	"github.com/tendermint/tendermint/abci/types"
 
 	ocabci "github.com/Finschia/ostracon/abci/types"
+	tmnet "github.com/Finschia/ostracon/libs/net"
 	"github.com/Finschia/ostracon/libs/service"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 )
@@ -36,12 +39,16 @@ type Client interface {
 	InfoAsync(types.RequestInfo, ResponseCallback) *ReqRes
 	SetOptionAsync(types.RequestSetOption, ResponseCallback) *ReqRes
 	DeliverTxAsync(types.RequestDeliverTx, ResponseCallback) *ReqRes
+	DeliverTxBatchAsync(ocabci.RequestDeliverTxBatch, ResponseCallback) *ReqRes
 	CheckTxAsync(types.RequestCheckTx, ResponseCallback) *ReqRes
+	CheckTxBatchAsync(ocabci.RequestCheckTxBatch, ResponseCallback) *ReqRes
 	QueryAsync(types.RequestQuery, ResponseCallback) *ReqRes
 	CommitAsync(ResponseCallback) *ReqRes
 	InitChainAsync(types.RequestInitChain, ResponseCallback) *ReqRes
 	BeginBlockAsync(ocabci.RequestBeginBlock, ResponseCallback) *ReqRes
 	EndBlockAsync(types.RequestEndBlock, ResponseCallback) *ReqRes
+	AbortBlockAsync(ocabci.RequestAbortBlock, ResponseCallback) *ReqRes
+	TxAccessSetsAsync(ocabci.RequestTxAccessSets, ResponseCallback) *ReqRes
 	BeginRecheckTxAsync(ocabci.RequestBeginRecheckTx, ResponseCallback) *ReqRes
 	EndRecheckTxAsync(ocabci.RequestEndRecheckTx, ResponseCallback) *ReqRes
 	ListSnapshotsAsync(types.RequestListSnapshots, ResponseCallback) *ReqRes
@@ -54,12 +61,16 @@ type Client interface {
 	InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
 	SetOptionSync(types.RequestSetOption) (*types.ResponseSetOption, error)
 	DeliverTxSync(types.RequestDeliverTx) (*types.ResponseDeliverTx, error)
+	DeliverTxBatchSync(ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error)
 	CheckTxSync(types.RequestCheckTx) (*ocabci.ResponseCheckTx, error)
+	CheckTxBatchSync(ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error)
 	QuerySync(types.RequestQuery) (*types.ResponseQuery, error)
 	CommitSync() (*types.ResponseCommit, error)
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
 	BeginBlockSync(ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error)
-	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	EndBlockSync(types.RequestEndBlock) (*ocabci.ResponseEndBlock, error)
+	AbortBlockSync(ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error)
+	TxAccessSetsSync(ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error)
 	BeginRecheckTxSync(ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error)
 	EndRecheckTxSync(ocabci.RequestEndRecheckTx) (*ocabci.ResponseEndRecheckTx, error)
 	ListSnapshotsSync(types.RequestListSnapshots) (*types.ResponseListSnapshots, error)
@@ -72,18 +83,54 @@ type Client interface {
 
 // NewClient returns a new ABCI client of the specified transport type.
 // It returns an error if the transport is not "socket" or "grpc"
-func NewClient(addr, transport string, mustConnect bool) (client Client, err error) {
+func NewClient(addr, transport string, mustConnect bool, opts ...ClientOption) (client Client, err error) {
 	switch transport {
 	case "socket":
-		client = NewSocketClient(addr, mustConnect)
+		client = NewSocketClient(addr, mustConnect, opts...)
 	case "grpc":
-		client = NewGRPCClient(addr, mustConnect)
+		client = NewGRPCClient(addr, mustConnect, opts...)
 	default:
 		err = fmt.Errorf("unknown abci transport %s", transport)
 	}
 	return
 }
 
+// ClientOption sets an option of the socket and gRPC clients.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	tlsConfig  *tls.Config
+	maxMsgSize int
+}
+
+// WithTLS makes the client connect to the application over TLS with config.
+// If config has no server name, the host of the address of the application is
+// used.
+func WithTLS(config *tls.Config) ClientOption {
+	return func(opts *clientOptions) { opts.tlsConfig = config }
+}
+
+// WithMaxMessageSize limits the size of the responses the client receives. 0
+// means the default of the transport.
+func WithMaxMessageSize(size int) ClientOption {
+	return func(opts *clientOptions) { opts.maxMsgSize = size }
+}
+
+func newClientOptions(addr string, opts []ClientOption) clientOptions {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.tlsConfig != nil && options.tlsConfig.ServerName == "" {
+		options.tlsConfig = options.tlsConfig.Clone()
+		_, address := tmnet.ProtocolAndAddress(addr)
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			options.tlsConfig.ServerName = host
+		}
+	}
+	return options
+}
+
 type GlobalCallback func(*ocabci.Request, *ocabci.Response)
 type ResponseCallback func(*ocabci.Response)
 
@@ -8,6 +8,8 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/tendermint/tendermint/abci/types"
 
@@ -24,6 +26,7 @@ var _ Client = (*grpcClient)(nil)
 type grpcClient struct {
 	service.BaseService
 	mustConnect bool
+	opts        clientOptions
 
 	client ocabci.ABCIApplicationClient
 	conn   *grpc.ClientConn
@@ -36,10 +39,11 @@ type grpcClient struct {
 	globalCb    func(*ocabci.Request, *ocabci.Response) // listens to all callbacks
 }
 
-func NewGRPCClient(addr string, mustConnect bool) Client {
+func NewGRPCClient(addr string, mustConnect bool, opts ...ClientOption) Client {
 	cli := &grpcClient{
 		addr:        addr,
 		mustConnect: mustConnect,
+		opts:        newClientOptions(addr, opts),
 	}
 	cli.BaseService = *service.NewBaseService(nil, "grpcClient", cli)
 	return cli
@@ -54,10 +58,19 @@ func (cli *grpcClient) OnStart() error {
 		return err
 	}
 
+	dialOpts := []grpc.DialOption{grpc.WithContextDialer(dialerFunc)}
+	if cli.opts.tlsConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(cli.opts.tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if cli.opts.maxMsgSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cli.opts.maxMsgSize)))
+	}
+
 RETRY_LOOP:
 	for {
-		//nolint:staticcheck // SA1019 Existing use of deprecated but supported dial option.
-		conn, err := grpc.Dial(cli.addr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+		conn, err := grpc.Dial(cli.addr, dialOpts...)
 		if err != nil {
 			if cli.mustConnect {
 				return err
@@ -183,6 +196,16 @@ func (cli *grpcClient) DeliverTxAsync(params types.RequestDeliverTx, cb Response
 	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_DeliverTx{DeliverTx: res}}, cb)
 }
 
+func (cli *grpcClient) DeliverTxBatchAsync(params ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	req := ocabci.ToRequestDeliverTxBatch(params)
+	res, err := cli.client.DeliverTxBatch(context.Background(), req.GetDeliverTxBatch(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(
+		req, &ocabci.Response{Value: &ocabci.Response_DeliverTxBatch{DeliverTxBatch: res}}, cb)
+}
+
 func (cli *grpcClient) CheckTxAsync(params types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	req := ocabci.ToRequestCheckTx(params)
 	res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.WaitForReady(true))
@@ -192,6 +215,16 @@ func (cli *grpcClient) CheckTxAsync(params types.RequestCheckTx, cb ResponseCall
 	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_CheckTx{CheckTx: res}}, cb)
 }
 
+func (cli *grpcClient) CheckTxBatchAsync(params ocabci.RequestCheckTxBatch, cb ResponseCallback) *ReqRes {
+	req := ocabci.ToRequestCheckTxBatch(params)
+	res, err := cli.client.CheckTxBatch(context.Background(), req.GetCheckTxBatch(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(
+		req, &ocabci.Response{Value: &ocabci.Response_CheckTxBatch{CheckTxBatch: res}}, cb)
+}
+
 func (cli *grpcClient) QueryAsync(params types.RequestQuery, cb ResponseCallback) *ReqRes {
 	req := ocabci.ToRequestQuery(params)
 	res, err := cli.client.Query(context.Background(), req.GetQuery(), grpc.WaitForReady(true))
@@ -237,6 +270,24 @@ func (cli *grpcClient) EndBlockAsync(params types.RequestEndBlock, cb ResponseCa
 	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_EndBlock{EndBlock: res}}, cb)
 }
 
+func (cli *grpcClient) AbortBlockAsync(params ocabci.RequestAbortBlock, cb ResponseCallback) *ReqRes {
+	req := ocabci.ToRequestAbortBlock(params)
+	res, err := cli.client.AbortBlock(context.Background(), req.GetAbortBlock(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_AbortBlock{AbortBlock: res}}, cb)
+}
+
+func (cli *grpcClient) TxAccessSetsAsync(params ocabci.RequestTxAccessSets, cb ResponseCallback) *ReqRes {
+	req := ocabci.ToRequestTxAccessSets(params)
+	res, err := cli.client.TxAccessSets(context.Background(), req.GetTxAccessSets(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_TxAccessSets{TxAccessSets: res}}, cb)
+}
+
 func (cli *grpcClient) BeginRecheckTxAsync(params ocabci.RequestBeginRecheckTx, cb ResponseCallback) *ReqRes {
 	req := ocabci.ToRequestBeginRecheckTx(params)
 	res, err := cli.client.BeginRecheckTx(context.Background(), req.GetBeginRecheckTx(), grpc.WaitForReady(true))
@@ -342,12 +393,24 @@ func (cli *grpcClient) DeliverTxSync(params types.RequestDeliverTx) (*types.Resp
 	return reqres.Response.GetDeliverTx(), cli.Error()
 }
 
+func (cli *grpcClient) DeliverTxBatchSync(params ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	reqres := cli.DeliverTxBatchAsync(params, nil)
+	reqres.Wait()
+	return reqres.Response.GetDeliverTxBatch(), cli.Error()
+}
+
 func (cli *grpcClient) CheckTxSync(params types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	reqres := cli.CheckTxAsync(params, nil)
 	reqres.Wait()
 	return reqres.Response.GetCheckTx(), cli.Error()
 }
 
+func (cli *grpcClient) CheckTxBatchSync(params ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error) {
+	reqres := cli.CheckTxBatchAsync(params, nil)
+	reqres.Wait()
+	return reqres.Response.GetCheckTxBatch(), cli.Error()
+}
+
 func (cli *grpcClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
 	reqres := cli.QueryAsync(req, nil)
 	reqres.Wait()
@@ -372,12 +435,24 @@ func (cli *grpcClient) BeginBlockSync(params ocabci.RequestBeginBlock) (*types.R
 	return reqres.Response.GetBeginBlock(), cli.Error()
 }
 
-func (cli *grpcClient) EndBlockSync(params types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (cli *grpcClient) EndBlockSync(params types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
 	reqres := cli.EndBlockAsync(params, nil)
 	reqres.Wait()
 	return reqres.Response.GetEndBlock(), cli.Error()
 }
 
+func (cli *grpcClient) AbortBlockSync(params ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	reqres := cli.AbortBlockAsync(params, nil)
+	reqres.Wait()
+	return reqres.Response.GetAbortBlock(), cli.Error()
+}
+
+func (cli *grpcClient) TxAccessSetsSync(params ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	reqres := cli.TxAccessSetsAsync(params, nil)
+	reqres.Wait()
+	return reqres.Response.GetTxAccessSets(), cli.Error()
+}
+
 func (cli *grpcClient) BeginRecheckTxSync(params ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
 	reqres := cli.BeginRecheckTxAsync(params, nil)
 	reqres.Wait()
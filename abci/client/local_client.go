@@ -104,6 +104,15 @@ func (app *localClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseCa
 	return app.done(reqRes, ocabci.ToResponseDeliverTx(res))
 }
 
+func (app *localClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	reqRes := NewReqRes(ocabci.ToRequestDeliverTxBatch(req), cb)
+	res := app.Application.DeliverTxBatch(req)
+	return app.done(reqRes, ocabci.ToResponseDeliverTxBatch(res))
+}
+
 func (app *localClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
@@ -119,6 +128,16 @@ func (app *localClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallba
 	return reqRes
 }
 
+func (app *localClient) CheckTxBatchAsync(req ocabci.RequestCheckTxBatch, cb ResponseCallback) *ReqRes {
+	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
+	// app.mtx.Lock()
+	// defer app.mtx.Unlock()
+
+	reqRes := NewReqRes(ocabci.ToRequestCheckTxBatch(req), cb)
+	res := app.Application.CheckTxBatch(req)
+	return app.done(reqRes, ocabci.ToResponseCheckTxBatch(res))
+}
+
 func (app *localClient) QueryAsync(req types.RequestQuery, cb ResponseCallback) *ReqRes {
 	app.mtx.Lock()
 	defer app.mtx.Unlock()
@@ -164,6 +183,24 @@ func (app *localClient) EndBlockAsync(req types.RequestEndBlock, cb ResponseCall
 	return app.done(reqRes, ocabci.ToResponseEndBlock(res))
 }
 
+func (app *localClient) AbortBlockAsync(req ocabci.RequestAbortBlock, cb ResponseCallback) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	reqRes := NewReqRes(ocabci.ToRequestAbortBlock(req), cb)
+	res := app.Application.AbortBlock(req)
+	return app.done(reqRes, ocabci.ToResponseAbortBlock(res))
+}
+
+func (app *localClient) TxAccessSetsAsync(req ocabci.RequestTxAccessSets, cb ResponseCallback) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	reqRes := NewReqRes(ocabci.ToRequestTxAccessSets(req), cb)
+	res := app.Application.TxAccessSets(req)
+	return app.done(reqRes, ocabci.ToResponseTxAccessSets(res))
+}
+
 func (app *localClient) BeginRecheckTxAsync(req ocabci.RequestBeginRecheckTx, cb ResponseCallback) *ReqRes {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
@@ -257,6 +294,14 @@ func (app *localClient) DeliverTxSync(req types.RequestDeliverTx) (*types.Respon
 	return &res, nil
 }
 
+func (app *localClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.DeliverTxBatch(req)
+	return &res, nil
+}
+
 func (app *localClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
@@ -266,6 +311,15 @@ func (app *localClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseC
 	return &res, nil
 }
 
+func (app *localClient) CheckTxBatchSync(req ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error) {
+	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
+	// app.mtx.Lock()
+	// defer app.mtx.Unlock()
+
+	res := app.Application.CheckTxBatch(req)
+	return &res, nil
+}
+
 func (app *localClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
 	app.mtx.Lock()
 	defer app.mtx.Unlock()
@@ -298,7 +352,7 @@ func (app *localClient) BeginBlockSync(req ocabci.RequestBeginBlock) (*types.Res
 	return &res, nil
 }
 
-func (app *localClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (app *localClient) EndBlockSync(req types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
 	app.mtx.Lock()
 	defer app.mtx.Unlock()
 
@@ -306,6 +360,22 @@ func (app *localClient) EndBlockSync(req types.RequestEndBlock) (*types.Response
 	return &res, nil
 }
 
+func (app *localClient) AbortBlockSync(req ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.AbortBlock(req)
+	return &res, nil
+}
+
+func (app *localClient) TxAccessSetsSync(req ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.TxAccessSets(req)
+	return &res, nil
+}
+
 func (app *localClient) BeginRecheckTxSync(req ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
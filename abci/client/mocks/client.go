@@ -18,6 +18,48 @@ type Client struct {
 	mock.Mock
 }
 
+// AbortBlockAsync provides a mock function with given fields: _a0, _a1
+func (_m *Client) AbortBlockAsync(_a0 abcitypes.RequestAbortBlock, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestAbortBlock, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// AbortBlockSync provides a mock function with given fields: _a0
+func (_m *Client) AbortBlockSync(_a0 abcitypes.RequestAbortBlock) (*abcitypes.ResponseAbortBlock, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *abcitypes.ResponseAbortBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestAbortBlock) (*abcitypes.ResponseAbortBlock, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestAbortBlock) *abcitypes.ResponseAbortBlock); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcitypes.ResponseAbortBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(abcitypes.RequestAbortBlock) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ApplySnapshotChunkAsync provides a mock function with given fields: _a0, _a1
 func (_m *Client) ApplySnapshotChunkAsync(_a0 types.RequestApplySnapshotChunk, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
 	ret := _m.Called(_a0, _a1)
@@ -160,6 +202,48 @@ func (_m *Client) CheckTxAsync(_a0 types.RequestCheckTx, _a1 abcicli.ResponseCal
 	return r0
 }
 
+// CheckTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *Client) CheckTxBatchAsync(_a0 abcitypes.RequestCheckTxBatch, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestCheckTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// CheckTxBatchSync provides a mock function with given fields: _a0
+func (_m *Client) CheckTxBatchSync(_a0 abcitypes.RequestCheckTxBatch) (*abcitypes.ResponseCheckTxBatch, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *abcitypes.ResponseCheckTxBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestCheckTxBatch) (*abcitypes.ResponseCheckTxBatch, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestCheckTxBatch) *abcitypes.ResponseCheckTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcitypes.ResponseCheckTxBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(abcitypes.RequestCheckTxBatch) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckTxSync provides a mock function with given fields: _a0
 func (_m *Client) CheckTxSync(_a0 types.RequestCheckTx) (*abcitypes.ResponseCheckTx, error) {
 	ret := _m.Called(_a0)
@@ -244,6 +328,48 @@ func (_m *Client) DeliverTxAsync(_a0 types.RequestDeliverTx, _a1 abcicli.Respons
 	return r0
 }
 
+// DeliverTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *Client) DeliverTxBatchAsync(_a0 abcitypes.RequestDeliverTxBatch, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// DeliverTxBatchSync provides a mock function with given fields: _a0
+func (_m *Client) DeliverTxBatchSync(_a0 abcitypes.RequestDeliverTxBatch) (*abcitypes.ResponseDeliverTxBatch, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *abcitypes.ResponseDeliverTxBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) (*abcitypes.ResponseDeliverTxBatch, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) *abcitypes.ResponseDeliverTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcitypes.ResponseDeliverTxBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(abcitypes.RequestDeliverTxBatch) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeliverTxSync provides a mock function with given fields: _a0
 func (_m *Client) DeliverTxSync(_a0 types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
 	ret := _m.Called(_a0)
@@ -329,19 +455,19 @@ func (_m *Client) EndBlockAsync(_a0 types.RequestEndBlock, _a1 abcicli.ResponseC
 }
 
 // EndBlockSync provides a mock function with given fields: _a0
-func (_m *Client) EndBlockSync(_a0 types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (_m *Client) EndBlockSync(_a0 types.RequestEndBlock) (*abcitypes.ResponseEndBlock, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *types.ResponseEndBlock
+	var r0 *abcitypes.ResponseEndBlock
 	var r1 error
-	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) (*types.ResponseEndBlock, error)); ok {
+	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) (*abcitypes.ResponseEndBlock, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) *types.ResponseEndBlock); ok {
+	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) *abcitypes.ResponseEndBlock); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.ResponseEndBlock)
+			r0 = ret.Get(0).(*abcitypes.ResponseEndBlock)
 		}
 	}
 
@@ -891,6 +1017,48 @@ func (_m *Client) String() string {
 	return r0
 }
 
+// TxAccessSetsAsync provides a mock function with given fields: _a0, _a1
+func (_m *Client) TxAccessSetsAsync(_a0 abcitypes.RequestTxAccessSets, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestTxAccessSets, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// TxAccessSetsSync provides a mock function with given fields: _a0
+func (_m *Client) TxAccessSetsSync(_a0 abcitypes.RequestTxAccessSets) (*abcitypes.ResponseTxAccessSets, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *abcitypes.ResponseTxAccessSets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestTxAccessSets) (*abcitypes.ResponseTxAccessSets, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestTxAccessSets) *abcitypes.ResponseTxAccessSets); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcitypes.ResponseTxAccessSets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(abcitypes.RequestTxAccessSets) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -0,0 +1,564 @@
+package abcicli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	"github.com/Finschia/ostracon/libs/service"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+)
+
+// ErrNoHealthyClient is returned by the calls on a pool of clients when none
+// of them is healthy.
+var ErrNoHealthyClient = errors.New("no healthy client in the pool")
+
+// NewClientFunc returns a new client, not started yet.
+type NewClientFunc func() (Client, error)
+
+// poolClient is a Client sending each request to the next healthy client of a
+// pool, round-robin. The requests must not depend on each other, so it's only
+// meant for connections like the query connection.
+//
+// Every healthCheckInterval, each client of the pool is sent an Echo, and
+// marked unhealthy if it doesn't respond before the next check. A client which
+// failed is replaced by a new one once it connects. The pool itself never
+// fails: the calls return ErrNoHealthyClient while no client is healthy.
+type poolClient struct {
+	service.BaseService
+
+	healthCheckInterval time.Duration
+
+	mtx      tmsync.Mutex
+	members  []*poolMember
+	next     int
+	globalCb GlobalCallback
+}
+
+type poolMember struct {
+	newClient NewClientFunc
+	client    Client // nil if it isn't connected
+	healthy   bool
+}
+
+var _ Client = (*poolClient)(nil)
+
+// NewPoolClient returns a Client sending the requests round-robin to the
+// healthy clients returned by newClients, which must fail to start if they
+// can't connect. It checks the health of the clients every
+// healthCheckInterval.
+func NewPoolClient(newClients []NewClientFunc, healthCheckInterval time.Duration) Client {
+	cli := &poolClient{healthCheckInterval: healthCheckInterval}
+	for _, newClient := range newClients {
+		cli.members = append(cli.members, &poolMember{newClient: newClient})
+	}
+	cli.BaseService = *service.NewBaseService(nil, "poolClient", cli)
+	return cli
+}
+
+// OnStart connects the clients of the pool, and fails if none of them
+// connects.
+func (cli *poolClient) OnStart() error {
+	var err error
+	healthy := 0
+	for i, m := range cli.members {
+		if err = cli.connect(i, m); err == nil {
+			healthy++
+		}
+	}
+	if healthy == 0 {
+		return fmt.Errorf("no client of the pool connected: %w", err)
+	}
+	go cli.healthCheckRoutine()
+	return nil
+}
+
+// OnStop stops the clients of the pool.
+func (cli *poolClient) OnStop() {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	for _, m := range cli.members {
+		if m.client != nil {
+			if err := m.client.Stop(); err != nil && err != service.ErrAlreadyStopped {
+				cli.Logger.Error("Error stopping client of the pool", "err", err)
+			}
+		}
+		m.healthy = false
+	}
+}
+
+// connect replaces the client of the i-th member with a new one, marked
+// healthy once it's started.
+func (cli *poolClient) connect(i int, m *poolMember) error {
+	c, err := m.newClient()
+	if err != nil {
+		return err
+	}
+	c.SetLogger(cli.Logger.With("member", i))
+	cli.mtx.Lock()
+	if cli.globalCb != nil {
+		c.SetGlobalCallback(cli.globalCb)
+	}
+	cli.mtx.Unlock()
+	if err := c.Start(); err != nil {
+		cli.Logger.Error("Failed to connect a client of the pool", "member", i, "err", err)
+		return err
+	}
+
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if !cli.IsRunning() {
+		// stopped meanwhile
+		return c.Stop()
+	}
+	m.client = c
+	m.healthy = true
+	return nil
+}
+
+func (cli *poolClient) healthCheckRoutine() {
+	ticker := time.NewTicker(cli.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for i, m := range cli.members {
+				go cli.checkHealth(i, m)
+			}
+		case <-cli.Quit():
+			return
+		}
+	}
+}
+
+// checkHealth reconnects the i-th member if its client failed, or sends it an
+// Echo otherwise. A client which doesn't respond before the next check is
+// stopped, to be replaced by the next one.
+func (cli *poolClient) checkHealth(i int, m *poolMember) {
+	cli.mtx.Lock()
+	c := m.client
+	cli.mtx.Unlock()
+	if c == nil || !c.IsRunning() {
+		cli.setHealthy(m, c, false)
+		if err := cli.connect(i, m); err == nil {
+			cli.Logger.Info("Reconnected a client of the pool", "member", i)
+		}
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.EchoSync("health")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			cli.Logger.Error("Health check of a client of the pool failed", "member", i, "err", err)
+		}
+		cli.setHealthy(m, c, err == nil)
+	case <-time.After(cli.healthCheckInterval):
+		cli.Logger.Error("Health check of a client of the pool timed out", "member", i)
+		cli.setHealthy(m, c, false)
+		if err := c.Stop(); err != nil && err != service.ErrAlreadyStopped {
+			cli.Logger.Error("Error stopping client of the pool", "member", i, "err", err)
+		}
+	case <-cli.Quit():
+	}
+}
+
+// setHealthy sets whether the member is healthy, unless its client was
+// replaced since c.
+func (cli *poolClient) setHealthy(m *poolMember, c Client, healthy bool) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if m.client == c {
+		m.healthy = healthy
+	}
+}
+
+// pick returns the client of the next healthy member.
+func (cli *poolClient) pick() (Client, error) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	for i := 0; i < len(cli.members); i++ {
+		index := (cli.next + i) % len(cli.members)
+		m := cli.members[index]
+		if m.healthy && m.client.IsRunning() {
+			cli.next = (index + 1) % len(cli.members)
+			return m.client, nil
+		}
+	}
+	return nil, ErrNoHealthyClient
+}
+
+// failedReqRes returns a ReqRes done with an exception, as no client could
+// send req.
+func failedReqRes(req *ocabci.Request, cb ResponseCallback, err error) *ReqRes {
+	reqRes := NewReqRes(req, cb)
+	reqRes.SetDone(ocabci.ToResponseException(err.Error()))
+	return reqRes
+}
+
+// Error returns nil, as the pool doesn't fail when its clients do.
+func (cli *poolClient) Error() error {
+	return nil
+}
+
+func (cli *poolClient) SetGlobalCallback(globalCb GlobalCallback) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.globalCb = globalCb
+	for _, m := range cli.members {
+		if m.client != nil {
+			m.client.SetGlobalCallback(globalCb)
+		}
+	}
+}
+
+func (cli *poolClient) GetGlobalCallback() GlobalCallback {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.globalCb
+}
+
+//----------------------------------------
+
+func (cli *poolClient) FlushAsync(cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestFlush(), cb, err)
+	}
+	return c.FlushAsync(cb)
+}
+
+func (cli *poolClient) EchoAsync(msg string, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestEcho(msg), cb, err)
+	}
+	return c.EchoAsync(msg, cb)
+}
+
+func (cli *poolClient) InfoAsync(req types.RequestInfo, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestInfo(req), cb, err)
+	}
+	return c.InfoAsync(req, cb)
+}
+
+func (cli *poolClient) SetOptionAsync(req types.RequestSetOption, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestSetOption(req), cb, err)
+	}
+	return c.SetOptionAsync(req, cb)
+}
+
+func (cli *poolClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestDeliverTx(req), cb, err)
+	}
+	return c.DeliverTxAsync(req, cb)
+}
+
+func (cli *poolClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestDeliverTxBatch(req), cb, err)
+	}
+	return c.DeliverTxBatchAsync(req, cb)
+}
+
+func (cli *poolClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestCheckTx(req), cb, err)
+	}
+	return c.CheckTxAsync(req, cb)
+}
+
+func (cli *poolClient) CheckTxBatchAsync(req ocabci.RequestCheckTxBatch, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestCheckTxBatch(req), cb, err)
+	}
+	return c.CheckTxBatchAsync(req, cb)
+}
+
+func (cli *poolClient) QueryAsync(req types.RequestQuery, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestQuery(req), cb, err)
+	}
+	return c.QueryAsync(req, cb)
+}
+
+func (cli *poolClient) CommitAsync(cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestCommit(), cb, err)
+	}
+	return c.CommitAsync(cb)
+}
+
+func (cli *poolClient) InitChainAsync(req types.RequestInitChain, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestInitChain(req), cb, err)
+	}
+	return c.InitChainAsync(req, cb)
+}
+
+func (cli *poolClient) BeginBlockAsync(req ocabci.RequestBeginBlock, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestBeginBlock(req), cb, err)
+	}
+	return c.BeginBlockAsync(req, cb)
+}
+
+func (cli *poolClient) EndBlockAsync(req types.RequestEndBlock, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestEndBlock(req), cb, err)
+	}
+	return c.EndBlockAsync(req, cb)
+}
+
+func (cli *poolClient) AbortBlockAsync(req ocabci.RequestAbortBlock, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestAbortBlock(req), cb, err)
+	}
+	return c.AbortBlockAsync(req, cb)
+}
+
+func (cli *poolClient) TxAccessSetsAsync(req ocabci.RequestTxAccessSets, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestTxAccessSets(req), cb, err)
+	}
+	return c.TxAccessSetsAsync(req, cb)
+}
+
+func (cli *poolClient) BeginRecheckTxAsync(req ocabci.RequestBeginRecheckTx, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestBeginRecheckTx(req), cb, err)
+	}
+	return c.BeginRecheckTxAsync(req, cb)
+}
+
+func (cli *poolClient) EndRecheckTxAsync(req ocabci.RequestEndRecheckTx, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestEndRecheckTx(req), cb, err)
+	}
+	return c.EndRecheckTxAsync(req, cb)
+}
+
+func (cli *poolClient) ListSnapshotsAsync(req types.RequestListSnapshots, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestListSnapshots(req), cb, err)
+	}
+	return c.ListSnapshotsAsync(req, cb)
+}
+
+func (cli *poolClient) OfferSnapshotAsync(req types.RequestOfferSnapshot, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestOfferSnapshot(req), cb, err)
+	}
+	return c.OfferSnapshotAsync(req, cb)
+}
+
+func (cli *poolClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestLoadSnapshotChunk(req), cb, err)
+	}
+	return c.LoadSnapshotChunkAsync(req, cb)
+}
+
+func (cli *poolClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk, cb ResponseCallback) *ReqRes {
+	c, err := cli.pick()
+	if err != nil {
+		return failedReqRes(ocabci.ToRequestApplySnapshotChunk(req), cb, err)
+	}
+	return c.ApplySnapshotChunkAsync(req, cb)
+}
+
+func (cli *poolClient) FlushSync() (*types.ResponseFlush, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.FlushSync()
+}
+
+func (cli *poolClient) EchoSync(msg string) (*types.ResponseEcho, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.EchoSync(msg)
+}
+
+func (cli *poolClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.InfoSync(req)
+}
+
+func (cli *poolClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.SetOptionSync(req)
+}
+
+func (cli *poolClient) DeliverTxSync(req types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.DeliverTxSync(req)
+}
+
+func (cli *poolClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.DeliverTxBatchSync(req)
+}
+
+func (cli *poolClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.CheckTxSync(req)
+}
+
+func (cli *poolClient) CheckTxBatchSync(req ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.CheckTxBatchSync(req)
+}
+
+func (cli *poolClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.QuerySync(req)
+}
+
+func (cli *poolClient) CommitSync() (*types.ResponseCommit, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.CommitSync()
+}
+
+func (cli *poolClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.InitChainSync(req)
+}
+
+func (cli *poolClient) BeginBlockSync(req ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.BeginBlockSync(req)
+}
+
+func (cli *poolClient) EndBlockSync(req types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.EndBlockSync(req)
+}
+
+func (cli *poolClient) AbortBlockSync(req ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.AbortBlockSync(req)
+}
+
+func (cli *poolClient) TxAccessSetsSync(req ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.TxAccessSetsSync(req)
+}
+
+func (cli *poolClient) BeginRecheckTxSync(req ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.BeginRecheckTxSync(req)
+}
+
+func (cli *poolClient) EndRecheckTxSync(req ocabci.RequestEndRecheckTx) (*ocabci.ResponseEndRecheckTx, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.EndRecheckTxSync(req)
+}
+
+func (cli *poolClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSnapshotsSync(req)
+}
+
+func (cli *poolClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.OfferSnapshotSync(req)
+}
+
+func (cli *poolClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.LoadSnapshotChunkSync(req)
+}
+
+func (cli *poolClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	c, err := cli.pick()
+	if err != nil {
+		return nil, err
+	}
+	return c.ApplySnapshotChunkSync(req)
+}
@@ -0,0 +1,84 @@
+package abcicli_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	abcicli "github.com/Finschia/ostracon/abci/client"
+	"github.com/Finschia/ostracon/abci/server"
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/libs/service"
+)
+
+// namedApp responds to the queries with its name.
+type namedApp struct {
+	ocabci.BaseApplication
+	name string
+}
+
+func (app namedApp) Query(types.RequestQuery) types.ResponseQuery {
+	return types.ResponseQuery{Value: []byte(app.name)}
+}
+
+func startNamedServer(t *testing.T, addr, name string) service.Service {
+	s := server.NewSocketServer(addr, namedApp{name: name})
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if s.IsRunning() {
+			if err := s.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	return s
+}
+
+func TestPoolClient(t *testing.T) {
+	var addrs []string
+	var servers []service.Service
+	var newClients []abcicli.NewClientFunc
+	for i := 0; i < 2; i++ {
+		addr := fmt.Sprintf("tcp://127.0.0.1:%d", 20000+tmrand.Int32()%10000)
+		addrs = append(addrs, addr)
+		servers = append(servers, startNamedServer(t, addr, fmt.Sprint(i)))
+		newClients = append(newClients, func() (abcicli.Client, error) {
+			return abcicli.NewSocketClient(addr, true), nil
+		})
+	}
+	pool := abcicli.NewPoolClient(newClients, 50*time.Millisecond)
+	require.NoError(t, pool.Start())
+	t.Cleanup(func() {
+		if err := pool.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	query := func() string {
+		res, err := pool.QuerySync(types.RequestQuery{})
+		if err != nil {
+			return err.Error()
+		}
+		return string(res.Value)
+	}
+
+	// round-robin
+	assert.Equal(t, []string{"0", "1", "0", "1"}, []string{query(), query(), query(), query()})
+
+	// the failed clients are skipped
+	require.NoError(t, servers[0].Stop())
+	assert.Eventually(t, func() bool { return query() == "1" && query() == "1" }, time.Second, 10*time.Millisecond)
+	require.NoError(t, servers[1].Stop())
+	assert.Eventually(t, func() bool { return query() == abcicli.ErrNoHealthyClient.Error() },
+		time.Second, 10*time.Millisecond)
+	assert.NoError(t, pool.Error())
+
+	// the clients reconnect once their server is back
+	startNamedServer(t, addrs[0], "0")
+	assert.Eventually(t, func() bool { return query() == "0" }, time.Second, 10*time.Millisecond)
+}
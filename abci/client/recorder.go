@@ -0,0 +1,367 @@
+package abcicli
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	"github.com/Finschia/ostracon/libs/protoio"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+)
+
+const maxRecordSize = 2 * 104857600 // a request and a response of 100MB each
+
+// Recorder writes the requests sent to an ABCI application and the responses
+// to them to a file, as length-delimited ocabci.RecordedExchange messages. The
+// file can be read back with a RecordReader, e.g. to replay the requests
+// against another build of the application (see abci-cli replay).
+//
+// A Recorder can be shared by the clients of all the connections to the
+// application: the exchanges are written in the order they complete.
+type Recorder struct {
+	mtx    tmsync.Mutex
+	writer protoio.WriteCloser
+	err    error // first write error, after which nothing is recorded
+}
+
+// NewRecorder opens the file at path, creating it if needed, and returns a
+// Recorder appending to it.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{writer: protoio.NewDelimitedWriter(file)}, nil
+}
+
+// Record writes an exchange on the given connection.
+func (r *Recorder) Record(connection string, req *ocabci.Request, res *ocabci.Response) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	_, r.err = r.writer.WriteMsg(&ocabci.RecordedExchange{
+		Connection: connection,
+		Request:    req,
+		Response:   res,
+	})
+}
+
+// Close closes the file. It returns the error that stopped the recording, if
+// any.
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if err := r.writer.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// RecordReader reads the exchanges written by a Recorder.
+type RecordReader struct {
+	reader protoio.ReadCloser
+}
+
+// NewRecordReader returns a RecordReader reading from r.
+func NewRecordReader(r io.Reader) *RecordReader {
+	return &RecordReader{reader: protoio.NewDelimitedReader(bufio.NewReader(r), maxRecordSize)}
+}
+
+// Read returns the next exchange, or io.EOF once all of them were read.
+func (r *RecordReader) Read() (*ocabci.RecordedExchange, error) {
+	exchange := &ocabci.RecordedExchange{}
+	if _, err := r.reader.ReadMsg(exchange); err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+//----------------------------------------
+
+// recordingClient is a Client recording the requests it sends and the
+// responses to them. Flushes aren't recorded, and neither are the calls
+// failing with a client error.
+type recordingClient struct {
+	Client
+
+	connection string
+	recorder   *Recorder
+}
+
+var _ Client = (*recordingClient)(nil)
+
+// NewRecordingClient returns a Client sending the requests to client and
+// recording them with the responses as exchanges on the given connection.
+func NewRecordingClient(client Client, connection string, recorder *Recorder) Client {
+	return &recordingClient{
+		Client:     client,
+		connection: connection,
+		recorder:   recorder,
+	}
+}
+
+func (cli *recordingClient) record(req *ocabci.Request, res *ocabci.Response) {
+	cli.recorder.Record(cli.connection, req, res)
+}
+
+// recordingCb returns a callback recording the response to req before calling
+// cb, if it's set.
+func (cli *recordingClient) recordingCb(req *ocabci.Request, cb ResponseCallback) ResponseCallback {
+	return func(res *ocabci.Response) {
+		cli.record(req, res)
+		if cb != nil {
+			cb(res)
+		}
+	}
+}
+
+func (cli *recordingClient) EchoAsync(msg string, cb ResponseCallback) *ReqRes {
+	return cli.Client.EchoAsync(msg, cli.recordingCb(ocabci.ToRequestEcho(msg), cb))
+}
+
+func (cli *recordingClient) InfoAsync(req types.RequestInfo, cb ResponseCallback) *ReqRes {
+	return cli.Client.InfoAsync(req, cli.recordingCb(ocabci.ToRequestInfo(req), cb))
+}
+
+func (cli *recordingClient) SetOptionAsync(req types.RequestSetOption, cb ResponseCallback) *ReqRes {
+	return cli.Client.SetOptionAsync(req, cli.recordingCb(ocabci.ToRequestSetOption(req), cb))
+}
+
+func (cli *recordingClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseCallback) *ReqRes {
+	return cli.Client.DeliverTxAsync(req, cli.recordingCb(ocabci.ToRequestDeliverTx(req), cb))
+}
+
+func (cli *recordingClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	return cli.Client.DeliverTxBatchAsync(req, cli.recordingCb(ocabci.ToRequestDeliverTxBatch(req), cb))
+}
+
+func (cli *recordingClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
+	return cli.Client.CheckTxAsync(req, cli.recordingCb(ocabci.ToRequestCheckTx(req), cb))
+}
+
+func (cli *recordingClient) CheckTxBatchAsync(req ocabci.RequestCheckTxBatch, cb ResponseCallback) *ReqRes {
+	return cli.Client.CheckTxBatchAsync(req, cli.recordingCb(ocabci.ToRequestCheckTxBatch(req), cb))
+}
+
+func (cli *recordingClient) QueryAsync(req types.RequestQuery, cb ResponseCallback) *ReqRes {
+	return cli.Client.QueryAsync(req, cli.recordingCb(ocabci.ToRequestQuery(req), cb))
+}
+
+func (cli *recordingClient) CommitAsync(cb ResponseCallback) *ReqRes {
+	return cli.Client.CommitAsync(cli.recordingCb(ocabci.ToRequestCommit(), cb))
+}
+
+func (cli *recordingClient) InitChainAsync(req types.RequestInitChain, cb ResponseCallback) *ReqRes {
+	return cli.Client.InitChainAsync(req, cli.recordingCb(ocabci.ToRequestInitChain(req), cb))
+}
+
+func (cli *recordingClient) BeginBlockAsync(req ocabci.RequestBeginBlock, cb ResponseCallback) *ReqRes {
+	return cli.Client.BeginBlockAsync(req, cli.recordingCb(ocabci.ToRequestBeginBlock(req), cb))
+}
+
+func (cli *recordingClient) EndBlockAsync(req types.RequestEndBlock, cb ResponseCallback) *ReqRes {
+	return cli.Client.EndBlockAsync(req, cli.recordingCb(ocabci.ToRequestEndBlock(req), cb))
+}
+
+func (cli *recordingClient) AbortBlockAsync(req ocabci.RequestAbortBlock, cb ResponseCallback) *ReqRes {
+	return cli.Client.AbortBlockAsync(req, cli.recordingCb(ocabci.ToRequestAbortBlock(req), cb))
+}
+
+func (cli *recordingClient) TxAccessSetsAsync(req ocabci.RequestTxAccessSets, cb ResponseCallback) *ReqRes {
+	return cli.Client.TxAccessSetsAsync(req, cli.recordingCb(ocabci.ToRequestTxAccessSets(req), cb))
+}
+
+func (cli *recordingClient) BeginRecheckTxAsync(req ocabci.RequestBeginRecheckTx, cb ResponseCallback) *ReqRes {
+	return cli.Client.BeginRecheckTxAsync(req, cli.recordingCb(ocabci.ToRequestBeginRecheckTx(req), cb))
+}
+
+func (cli *recordingClient) EndRecheckTxAsync(req ocabci.RequestEndRecheckTx, cb ResponseCallback) *ReqRes {
+	return cli.Client.EndRecheckTxAsync(req, cli.recordingCb(ocabci.ToRequestEndRecheckTx(req), cb))
+}
+
+func (cli *recordingClient) ListSnapshotsAsync(req types.RequestListSnapshots, cb ResponseCallback) *ReqRes {
+	return cli.Client.ListSnapshotsAsync(req, cli.recordingCb(ocabci.ToRequestListSnapshots(req), cb))
+}
+
+func (cli *recordingClient) OfferSnapshotAsync(req types.RequestOfferSnapshot, cb ResponseCallback) *ReqRes {
+	return cli.Client.OfferSnapshotAsync(req, cli.recordingCb(ocabci.ToRequestOfferSnapshot(req), cb))
+}
+
+func (cli *recordingClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk, cb ResponseCallback) *ReqRes {
+	return cli.Client.LoadSnapshotChunkAsync(req, cli.recordingCb(ocabci.ToRequestLoadSnapshotChunk(req), cb))
+}
+
+func (cli *recordingClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk, cb ResponseCallback) *ReqRes {
+	return cli.Client.ApplySnapshotChunkAsync(req, cli.recordingCb(ocabci.ToRequestApplySnapshotChunk(req), cb))
+}
+
+//----------------------------------------
+
+func (cli *recordingClient) EchoSync(msg string) (*types.ResponseEcho, error) {
+	res, err := cli.Client.EchoSync(msg)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestEcho(msg), ocabci.ToResponseEcho(res.Message))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
+	res, err := cli.Client.InfoSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestInfo(req), ocabci.ToResponseInfo(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
+	res, err := cli.Client.SetOptionSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestSetOption(req), ocabci.ToResponseSetOption(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) DeliverTxSync(req types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
+	res, err := cli.Client.DeliverTxSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestDeliverTx(req), ocabci.ToResponseDeliverTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	res, err := cli.Client.DeliverTxBatchSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestDeliverTxBatch(req), ocabci.ToResponseDeliverTxBatch(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
+	res, err := cli.Client.CheckTxSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestCheckTx(req), ocabci.ToResponseCheckTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) CheckTxBatchSync(req ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error) {
+	res, err := cli.Client.CheckTxBatchSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestCheckTxBatch(req), ocabci.ToResponseCheckTxBatch(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
+	res, err := cli.Client.QuerySync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestQuery(req), ocabci.ToResponseQuery(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) CommitSync() (*types.ResponseCommit, error) {
+	res, err := cli.Client.CommitSync()
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestCommit(), ocabci.ToResponseCommit(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
+	res, err := cli.Client.InitChainSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestInitChain(req), ocabci.ToResponseInitChain(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) BeginBlockSync(req ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	res, err := cli.Client.BeginBlockSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestBeginBlock(req), ocabci.ToResponseBeginBlock(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) EndBlockSync(req types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
+	res, err := cli.Client.EndBlockSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestEndBlock(req), ocabci.ToResponseEndBlock(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) AbortBlockSync(req ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	res, err := cli.Client.AbortBlockSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestAbortBlock(req), ocabci.ToResponseAbortBlock(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) TxAccessSetsSync(req ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	res, err := cli.Client.TxAccessSetsSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestTxAccessSets(req), ocabci.ToResponseTxAccessSets(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) BeginRecheckTxSync(req ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
+	res, err := cli.Client.BeginRecheckTxSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestBeginRecheckTx(req), ocabci.ToResponseBeginRecheckTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) EndRecheckTxSync(req ocabci.RequestEndRecheckTx) (*ocabci.ResponseEndRecheckTx, error) {
+	res, err := cli.Client.EndRecheckTxSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestEndRecheckTx(req), ocabci.ToResponseEndRecheckTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	res, err := cli.Client.ListSnapshotsSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestListSnapshots(req), ocabci.ToResponseListSnapshots(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	res, err := cli.Client.OfferSnapshotSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestOfferSnapshot(req), ocabci.ToResponseOfferSnapshot(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) LoadSnapshotChunkSync(
+	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	res, err := cli.Client.LoadSnapshotChunkSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestLoadSnapshotChunk(req), ocabci.ToResponseLoadSnapshotChunk(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) ApplySnapshotChunkSync(
+	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	res, err := cli.Client.ApplySnapshotChunkSync(req)
+	if err == nil && res != nil {
+		cli.record(ocabci.ToRequestApplySnapshotChunk(req), ocabci.ToResponseApplySnapshotChunk(*res))
+	}
+	return res, err
+}
@@ -0,0 +1,100 @@
+package abcicli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Finschia/ostracon/abci/example/kvstore"
+	ocabci "github.com/Finschia/ostracon/abci/types"
+)
+
+// divergingApp is a kvstore returning another app hash.
+type divergingApp struct {
+	*kvstore.Application
+}
+
+func (app divergingApp) Commit() types.ResponseCommit {
+	res := app.Application.Commit()
+	res.Data = append(res.Data, 0xff)
+	return res
+}
+
+func recordBlock(t *testing.T, path string) {
+	recorder, err := NewRecorder(path)
+	require.NoError(t, err)
+	app := kvstore.NewApplication()
+	consensus := NewRecordingClient(NewLocalClient(nil, app), "consensus", recorder)
+	mempool := NewRecordingClient(NewLocalClient(nil, app), "mempool", recorder)
+
+	_, err = consensus.InitChainSync(types.RequestInitChain{})
+	require.NoError(t, err)
+	_, err = mempool.CheckTxSync(types.RequestCheckTx{Tx: []byte("a=1")})
+	require.NoError(t, err)
+	_, err = consensus.BeginBlockSync(ocabci.RequestBeginBlock{})
+	require.NoError(t, err)
+	consensus.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte("a=1")}, nil)
+	consensus.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte("b=2")}, nil)
+	_, err = consensus.FlushSync()
+	require.NoError(t, err)
+	_, err = consensus.EndBlockSync(types.RequestEndBlock{Height: 1})
+	require.NoError(t, err)
+	_, err = consensus.CommitSync()
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Close())
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abci_record.bin")
+	recordBlock(t, path)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	reader := NewRecordReader(file)
+
+	var connections []string
+	for {
+		exchange, err := reader.Read()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		require.NotNil(t, exchange.Request)
+		require.NotNil(t, exchange.Response)
+		connections = append(connections, exchange.Connection)
+	}
+	require.Equal(t,
+		[]string{"consensus", "mempool", "consensus", "consensus", "consensus", "consensus", "consensus"},
+		connections)
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abci_record.bin")
+	recordBlock(t, path)
+
+	replay := func(app ocabci.Application) (*Divergence, int) {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+		divergence, replayed, err := Replay(NewLocalClient(nil, app), NewRecordReader(file), "consensus")
+		require.NoError(t, err)
+		return divergence, replayed
+	}
+
+	divergence, replayed := replay(kvstore.NewApplication())
+	require.Nil(t, divergence)
+	require.Equal(t, 6, replayed)
+
+	divergence, replayed = replay(divergingApp{kvstore.NewApplication()})
+	require.NotNil(t, divergence)
+	require.Equal(t, 6, replayed)
+	require.Equal(t, 6, divergence.Index)
+	require.NotNil(t, divergence.Request.GetCommit())
+	require.NotEqual(t, divergence.Recorded.GetCommit().Data, divergence.Replayed.GetCommit().Data)
+}
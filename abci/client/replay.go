@@ -0,0 +1,238 @@
+package abcicli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	ocabci "github.com/Finschia/ostracon/abci/types"
+)
+
+// Divergence is a recorded exchange whose request the replayed application
+// responds to differently.
+type Divergence struct {
+	Index    int // index of the exchange among the recorded ones
+	Request  *ocabci.Request
+	Recorded *ocabci.Response
+	Replayed *ocabci.Response
+}
+
+// Replay sends the recorded requests of the given connection read by reader to
+// client, in order, and returns the first one the application responds to
+// differently than recorded, or nil if there's none. It also returns the
+// number of requests sent.
+//
+// Only the responses to DeliverTx, EndBlock and Commit are compared, and only
+// their fields which are part of the blockchain: the code, data and gas of the
+// txs, the validator and consensus params updates, and the app hash.
+func Replay(client Client, reader *RecordReader, connection string) (*Divergence, int, error) {
+	replayed := 0
+	for index := 0; ; index++ {
+		exchange, err := reader.Read()
+		if err == io.EOF {
+			return nil, replayed, nil
+		}
+		if err != nil {
+			return nil, replayed, fmt.Errorf("error reading exchange %d: %w", index, err)
+		}
+		if exchange.Connection != connection {
+			continue
+		}
+
+		res, err := sendRequest(client, exchange.Request)
+		if err != nil {
+			return nil, replayed, fmt.Errorf("error replaying exchange %d: %w", index, err)
+		}
+		replayed++
+		if !responsesMatch(exchange.Response, res) {
+			return &Divergence{
+				Index:    index,
+				Request:  exchange.Request,
+				Recorded: exchange.Response,
+				Replayed: res,
+			}, replayed, nil
+		}
+	}
+}
+
+// sendRequest sends req to client and returns the response.
+func sendRequest(client Client, req *ocabci.Request) (*ocabci.Response, error) {
+	switch r := req.Value.(type) {
+	case *ocabci.Request_Echo:
+		res, err := client.EchoSync(r.Echo.Message)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseEcho(res.Message), nil
+	case *ocabci.Request_Info:
+		res, err := client.InfoSync(*r.Info)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseInfo(*res), nil
+	case *ocabci.Request_SetOption:
+		res, err := client.SetOptionSync(*r.SetOption)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseSetOption(*res), nil
+	case *ocabci.Request_InitChain:
+		res, err := client.InitChainSync(*r.InitChain)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseInitChain(*res), nil
+	case *ocabci.Request_Query:
+		res, err := client.QuerySync(*r.Query)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseQuery(*res), nil
+	case *ocabci.Request_BeginBlock:
+		res, err := client.BeginBlockSync(*r.BeginBlock)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseBeginBlock(*res), nil
+	case *ocabci.Request_CheckTx:
+		res, err := client.CheckTxSync(*r.CheckTx)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseCheckTx(*res), nil
+	case *ocabci.Request_CheckTxBatch:
+		res, err := client.CheckTxBatchSync(*r.CheckTxBatch)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseCheckTxBatch(*res), nil
+	case *ocabci.Request_DeliverTx:
+		res, err := client.DeliverTxSync(*r.DeliverTx)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseDeliverTx(*res), nil
+	case *ocabci.Request_DeliverTxBatch:
+		res, err := client.DeliverTxBatchSync(*r.DeliverTxBatch)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseDeliverTxBatch(*res), nil
+	case *ocabci.Request_EndBlock:
+		res, err := client.EndBlockSync(*r.EndBlock)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseEndBlock(*res), nil
+	case *ocabci.Request_AbortBlock:
+		res, err := client.AbortBlockSync(*r.AbortBlock)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseAbortBlock(*res), nil
+	case *ocabci.Request_TxAccessSets:
+		res, err := client.TxAccessSetsSync(*r.TxAccessSets)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseTxAccessSets(*res), nil
+	case *ocabci.Request_Commit:
+		res, err := client.CommitSync()
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseCommit(*res), nil
+	case *ocabci.Request_BeginRecheckTx:
+		res, err := client.BeginRecheckTxSync(*r.BeginRecheckTx)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseBeginRecheckTx(*res), nil
+	case *ocabci.Request_EndRecheckTx:
+		res, err := client.EndRecheckTxSync(*r.EndRecheckTx)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseEndRecheckTx(*res), nil
+	case *ocabci.Request_ListSnapshots:
+		res, err := client.ListSnapshotsSync(*r.ListSnapshots)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseListSnapshots(*res), nil
+	case *ocabci.Request_OfferSnapshot:
+		res, err := client.OfferSnapshotSync(*r.OfferSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseOfferSnapshot(*res), nil
+	case *ocabci.Request_LoadSnapshotChunk:
+		res, err := client.LoadSnapshotChunkSync(*r.LoadSnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseLoadSnapshotChunk(*res), nil
+	case *ocabci.Request_ApplySnapshotChunk:
+		res, err := client.ApplySnapshotChunkSync(*r.ApplySnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return ocabci.ToResponseApplySnapshotChunk(*res), nil
+	default:
+		return nil, fmt.Errorf("unexpected request %T", req.Value)
+	}
+}
+
+// responsesMatch returns false if replayed differs from recorded in the
+// fields compared by Replay.
+func responsesMatch(recorded, replayed *ocabci.Response) bool {
+	switch r := recorded.Value.(type) {
+	case *ocabci.Response_DeliverTx:
+		return deliverTxMatch(r.DeliverTx, replayed.GetDeliverTx())
+	case *ocabci.Response_DeliverTxBatch:
+		batch := replayed.GetDeliverTxBatch()
+		if batch == nil || len(batch.Responses) != len(r.DeliverTxBatch.Responses) {
+			return false
+		}
+		for i, res := range r.DeliverTxBatch.Responses {
+			if !deliverTxMatch(res, batch.Responses[i]) {
+				return false
+			}
+		}
+		return true
+	case *ocabci.Response_EndBlock:
+		res := replayed.GetEndBlock()
+		if res == nil {
+			return false
+		}
+		return proto.Equal(
+			&ocabci.ResponseEndBlock{
+				ValidatorUpdates:      r.EndBlock.ValidatorUpdates,
+				ConsensusParamUpdates: r.EndBlock.ConsensusParamUpdates,
+				ValidatorKeyRotations: r.EndBlock.ValidatorKeyRotations,
+			},
+			&ocabci.ResponseEndBlock{
+				ValidatorUpdates:      res.ValidatorUpdates,
+				ConsensusParamUpdates: res.ConsensusParamUpdates,
+				ValidatorKeyRotations: res.ValidatorKeyRotations,
+			})
+	case *ocabci.Response_Commit:
+		res := replayed.GetCommit()
+		return res != nil && bytes.Equal(r.Commit.Data, res.Data)
+	default:
+		return true
+	}
+}
+
+func deliverTxMatch(recorded, replayed *types.ResponseDeliverTx) bool {
+	if recorded == nil || replayed == nil {
+		return recorded == replayed
+	}
+	return recorded.Code == replayed.Code &&
+		bytes.Equal(recorded.Data, replayed.Data) &&
+		recorded.GasWanted == replayed.GasWanted &&
+		recorded.GasUsed == replayed.GasUsed
+}
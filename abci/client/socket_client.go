@@ -3,6 +3,7 @@ package abcicli
 import (
 	"bufio"
 	"container/list"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ type socketClient struct {
 	addr        string
 	mustConnect bool
 	conn        net.Conn
+	opts        clientOptions
 
 	reqQueue   chan *ReqRes
 	flushTimer *timer.ThrottleTimer
@@ -49,11 +51,12 @@ var _ Client = (*socketClient)(nil)
 // NewSocketClient creates a new socket client, which connects to a given
 // address. If mustConnect is true, the client will return an error upon start
 // if it fails to connect.
-func NewSocketClient(addr string, mustConnect bool) Client {
+func NewSocketClient(addr string, mustConnect bool, opts ...ClientOption) Client {
 	cli := &socketClient{
 		reqQueue:    make(chan *ReqRes, reqQueueSize),
 		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottleMS),
 		mustConnect: mustConnect,
+		opts:        newClientOptions(addr, opts),
 
 		addr:     addr,
 		reqSent:  list.New(),
@@ -72,7 +75,7 @@ func (cli *socketClient) OnStart() error {
 	)
 
 	for {
-		conn, err = tmnet.Connect(cli.addr)
+		conn, err = cli.connect()
 		if err != nil {
 			if cli.mustConnect {
 				return err
@@ -91,6 +94,21 @@ func (cli *socketClient) OnStart() error {
 	}
 }
 
+// connect dials the server, and performs the TLS handshake if the client uses
+// TLS.
+func (cli *socketClient) connect() (net.Conn, error) {
+	conn, err := tmnet.Connect(cli.addr)
+	if err != nil || cli.opts.tlsConfig == nil {
+		return conn, err
+	}
+	tlsConn := tls.Client(conn, cli.opts.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("TLS handshake: %w", err)
+	}
+	return tlsConn, nil
+}
+
 // OnStop implements Service by closing connection and flushing all queues.
 func (cli *socketClient) OnStop() {
 	if cli.conn != nil {
@@ -158,10 +176,14 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 }
 
 func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
+	maxMsgSize := cli.opts.maxMsgSize
+	if maxMsgSize == 0 {
+		maxMsgSize = ocabci.DefaultMaxMessageSize
+	}
 	r := bufio.NewReader(conn)
 	for {
 		var res = &ocabci.Response{}
-		err := ocabci.ReadMessage(r, res)
+		err := ocabci.ReadMessageWithMaxSize(r, res, maxMsgSize)
 		if err != nil {
 			cli.stopForError(fmt.Errorf("read message: %w", err))
 			return
@@ -246,10 +268,18 @@ func (cli *socketClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseC
 	return cli.queueRequest(ocabci.ToRequestDeliverTx(req), cb)
 }
 
+func (cli *socketClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	return cli.queueRequest(ocabci.ToRequestDeliverTxBatch(req), cb)
+}
+
 func (cli *socketClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	return cli.queueRequest(ocabci.ToRequestCheckTx(req), cb)
 }
 
+func (cli *socketClient) CheckTxBatchAsync(req ocabci.RequestCheckTxBatch, cb ResponseCallback) *ReqRes {
+	return cli.queueRequest(ocabci.ToRequestCheckTxBatch(req), cb)
+}
+
 func (cli *socketClient) QueryAsync(req types.RequestQuery, cb ResponseCallback) *ReqRes {
 	return cli.queueRequest(ocabci.ToRequestQuery(req), cb)
 }
@@ -270,6 +300,14 @@ func (cli *socketClient) EndBlockAsync(req types.RequestEndBlock, cb ResponseCal
 	return cli.queueRequest(ocabci.ToRequestEndBlock(req), cb)
 }
 
+func (cli *socketClient) AbortBlockAsync(req ocabci.RequestAbortBlock, cb ResponseCallback) *ReqRes {
+	return cli.queueRequest(ocabci.ToRequestAbortBlock(req), cb)
+}
+
+func (cli *socketClient) TxAccessSetsAsync(req ocabci.RequestTxAccessSets, cb ResponseCallback) *ReqRes {
+	return cli.queueRequest(ocabci.ToRequestTxAccessSets(req), cb)
+}
+
 func (cli *socketClient) BeginRecheckTxAsync(req ocabci.RequestBeginRecheckTx, cb ResponseCallback) *ReqRes {
 	return cli.queueRequest(ocabci.ToRequestBeginRecheckTx(req), cb)
 }
@@ -341,6 +379,15 @@ func (cli *socketClient) DeliverTxSync(req types.RequestDeliverTx) (*types.Respo
 	return reqres.Response.GetDeliverTx(), cli.Error()
 }
 
+func (cli *socketClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	reqres := cli.queueRequest(ocabci.ToRequestDeliverTxBatch(req), nil)
+	if _, err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetDeliverTxBatch(), cli.Error()
+}
+
 func (cli *socketClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	reqres := cli.queueRequest(ocabci.ToRequestCheckTx(req), nil)
 	if _, err := cli.FlushSync(); err != nil {
@@ -350,6 +397,15 @@ func (cli *socketClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.Response
 	return reqres.Response.GetCheckTx(), cli.Error()
 }
 
+func (cli *socketClient) CheckTxBatchSync(req ocabci.RequestCheckTxBatch) (*ocabci.ResponseCheckTxBatch, error) {
+	reqres := cli.queueRequest(ocabci.ToRequestCheckTxBatch(req), nil)
+	if _, err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetCheckTxBatch(), cli.Error()
+}
+
 func (cli *socketClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
 	reqres := cli.queueRequest(ocabci.ToRequestQuery(req), nil)
 	if _, err := cli.FlushSync(); err != nil {
@@ -386,7 +442,7 @@ func (cli *socketClient) BeginBlockSync(req ocabci.RequestBeginBlock) (*types.Re
 	return reqres.Response.GetBeginBlock(), cli.Error()
 }
 
-func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
 	reqres := cli.queueRequest(ocabci.ToRequestEndBlock(req), nil)
 	if _, err := cli.FlushSync(); err != nil {
 		return nil, err
@@ -395,6 +451,24 @@ func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*types.Respons
 	return reqres.Response.GetEndBlock(), cli.Error()
 }
 
+func (cli *socketClient) AbortBlockSync(req ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	reqres := cli.queueRequest(ocabci.ToRequestAbortBlock(req), nil)
+	if _, err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetAbortBlock(), cli.Error()
+}
+
+func (cli *socketClient) TxAccessSetsSync(req ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	reqres := cli.queueRequest(ocabci.ToRequestTxAccessSets(req), nil)
+	if _, err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetTxAccessSets(), cli.Error()
+}
+
 func (cli *socketClient) BeginRecheckTxSync(req ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
 	reqres := cli.queueRequest(ocabci.ToRequestBeginRecheckTx(req), nil)
 	if _, err := cli.FlushSync(); err != nil {
@@ -505,8 +579,12 @@ func resMatchesReq(req *ocabci.Request, res *ocabci.Response) (ok bool) {
 		_, ok = res.Value.(*ocabci.Response_SetOption)
 	case *ocabci.Request_DeliverTx:
 		_, ok = res.Value.(*ocabci.Response_DeliverTx)
+	case *ocabci.Request_DeliverTxBatch:
+		_, ok = res.Value.(*ocabci.Response_DeliverTxBatch)
 	case *ocabci.Request_CheckTx:
 		_, ok = res.Value.(*ocabci.Response_CheckTx)
+	case *ocabci.Request_CheckTxBatch:
+		_, ok = res.Value.(*ocabci.Response_CheckTxBatch)
 	case *ocabci.Request_Commit:
 		_, ok = res.Value.(*ocabci.Response_Commit)
 	case *ocabci.Request_Query:
@@ -517,6 +595,10 @@ func resMatchesReq(req *ocabci.Request, res *ocabci.Response) (ok bool) {
 		_, ok = res.Value.(*ocabci.Response_BeginBlock)
 	case *ocabci.Request_EndBlock:
 		_, ok = res.Value.(*ocabci.Response_EndBlock)
+	case *ocabci.Request_AbortBlock:
+		_, ok = res.Value.(*ocabci.Response_AbortBlock)
+	case *ocabci.Request_TxAccessSets:
+		_, ok = res.Value.(*ocabci.Response_TxAccessSets)
 	case *ocabci.Request_BeginRecheckTx:
 		_, ok = res.Value.(*ocabci.Response_BeginRecheckTx)
 	case *ocabci.Request_EndRecheckTx:
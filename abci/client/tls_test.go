@@ -0,0 +1,200 @@
+package abcicli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Finschia/ostracon/abci/example/kvstore"
+	"github.com/Finschia/ostracon/abci/server"
+	tmnet "github.com/Finschia/ostracon/libs/net"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/libs/service"
+)
+
+// testCA issues the certificates of the tests, written to dir.
+type testCA struct {
+	dir    string
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	caFile string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{dir: t.TempDir(), cert: cert, key: key}
+	ca.caFile = ca.write(t, "ca.crt", "CERTIFICATE", der)
+	return ca
+}
+
+// issue returns the certificate and key files of a certificate for cn, valid
+// for localhost.
+func (ca *testCA) issue(t *testing.T, cn string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(tmrand.Int63()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return ca.write(t, cn+".crt", "CERTIFICATE", der), ca.write(t, cn+".key", "EC PRIVATE KEY", keyDER)
+}
+
+func (ca *testCA) write(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(ca.dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	require.NoError(t, err)
+	return path
+}
+
+func startTLSServer(t *testing.T, ca *testCA, transport string, opts ...server.ServerOption) string {
+	certFile, keyFile := ca.issue(t, "app")
+	tlsConfig, err := tmnet.NewServerTLSConfig(certFile, keyFile, ca.caFile, []string{"node"})
+	require.NoError(t, err)
+
+	addr := fmt.Sprintf("tcp://127.0.0.1:%d", 20000+tmrand.Int32()%10000)
+	s, err := server.NewServer(addr, transport, kvstore.NewApplication(), append(opts, server.WithTLS(tlsConfig))...)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return addr
+}
+
+func TestClientTLS(t *testing.T) {
+	for _, transport := range []string{"socket", "grpc"} {
+		transport := transport
+		t.Run(transport, func(t *testing.T) {
+			ca := newTestCA(t)
+			addr := startTLSServer(t, ca, transport)
+
+			certFile, keyFile := ca.issue(t, "node")
+			tlsConfig, err := tmnet.NewClientTLSConfig(certFile, keyFile, ca.caFile, "", []string{"app"})
+			require.NoError(t, err)
+			client, err := NewClient(addr, transport, true, WithTLS(tlsConfig))
+			require.NoError(t, err)
+			require.NoError(t, client.Start())
+			t.Cleanup(func() { stopClient(t, client) })
+
+			res, err := client.EchoSync("hello")
+			require.NoError(t, err)
+			require.Equal(t, "hello", res.Message)
+		})
+	}
+}
+
+func TestGRPCServerHealth(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startTLSServer(t, ca, "grpc")
+
+	certFile, keyFile := ca.issue(t, "node")
+	tlsConfig, err := tmnet.NewClientTLSConfig(certFile, keyFile, ca.caFile, "127.0.0.1", nil)
+	require.NoError(t, err)
+	conn, err := grpc.Dial(addr, grpc.WithContextDialer(dialerFunc),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+}
+
+func TestClientTLSRejected(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startTLSServer(t, ca, "socket")
+	_, address := tmnet.ProtocolAndAddress(addr)
+
+	// the server doesn't allow the client's common name
+	certFile, keyFile := ca.issue(t, "intruder")
+	tlsConfig, err := tmnet.NewClientTLSConfig(certFile, keyFile, ca.caFile, "", nil)
+	require.NoError(t, err)
+	client := NewSocketClient(addr, true, WithTLS(tlsConfig))
+	require.NoError(t, client.Start())
+	t.Cleanup(func() { stopClient(t, client) })
+	_, err = client.EchoSync("hello")
+	require.Error(t, err)
+
+	// the client doesn't allow the server's common name
+	certFile, keyFile = ca.issue(t, "node")
+	tlsConfig, err = tmnet.NewClientTLSConfig(certFile, keyFile, ca.caFile, "127.0.0.1", []string{"other-app"})
+	require.NoError(t, err)
+	_, err = tls.Dial("tcp", address, tlsConfig)
+	require.Error(t, err)
+
+	// the server isn't issued by the client's CA
+	tlsConfig, err = tmnet.NewClientTLSConfig(certFile, keyFile, newTestCA(t).caFile, "127.0.0.1", nil)
+	require.NoError(t, err)
+	_, err = tls.Dial("tcp", address, tlsConfig)
+	require.Error(t, err)
+}
+
+func TestClientMaxMessageSize(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startTLSServer(t, ca, "socket", server.WithMaxMessageSize(100))
+
+	certFile, keyFile := ca.issue(t, "node")
+	tlsConfig, err := tmnet.NewClientTLSConfig(certFile, keyFile, ca.caFile, "", nil)
+	require.NoError(t, err)
+	client := NewSocketClient(addr, true, WithTLS(tlsConfig))
+	require.NoError(t, client.Start())
+	t.Cleanup(func() { stopClient(t, client) })
+
+	_, err = client.EchoSync("hello")
+	require.NoError(t, err)
+	_, err = client.EchoSync(strings.Repeat("a", 100))
+	require.Error(t, err)
+}
+
+func stopClient(t *testing.T, client service.Service) {
+	if client.IsRunning() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	}
+}
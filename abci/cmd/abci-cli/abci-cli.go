@@ -27,6 +27,7 @@ import (
 	"github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto/encoding"
 	"github.com/Finschia/ostracon/libs/log"
+	tmnet "github.com/Finschia/ostracon/libs/net"
 	tmos "github.com/Finschia/ostracon/libs/os"
 )
 
@@ -44,6 +45,14 @@ var (
 	flagVerbose  bool   // for the println output
 	flagLogLevel string // for the logger
 
+	// mutual TLS and message size, for both the client and the servers
+	flagTLSCert       string
+	flagTLSKey        string
+	flagTLSCA         string
+	flagTLSServerName string
+	flagTLSAllowedCNs []string
+	flagMaxMsgSize    int
+
 	// query
 	flagPath   string
 	flagHeight int
@@ -57,6 +66,9 @@ var (
 
 	// voting power for make validator_tx
 	flagVotingPower int64
+
+	// replay
+	flagConnection string
 )
 
 var RootCmd = &cobra.Command{
@@ -80,8 +92,11 @@ var RootCmd = &cobra.Command{
 			logger = log.NewFilter(log.NewOCLogger(log.NewSyncWriter(os.Stdout)), allowLevel)
 		}
 		if client == nil {
-			var err error
-			client, err = abcicli.NewClient(flagAddress, flagAbci, false)
+			opts, err := clientOptions()
+			if err != nil {
+				return err
+			}
+			client, err = abcicli.NewClient(flagAddress, flagAbci, false, opts...)
 			if err != nil {
 				return err
 			}
@@ -125,6 +140,17 @@ func addGlobalFlags() {
 		"tcp://0.0.0.0:26658",
 		"address of application socket")
 	RootCmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket or grpc")
+	RootCmd.PersistentFlags().StringVarP(&flagTLSCert, "tls_cert", "", "",
+		"certificate to authenticate with over mutual TLS")
+	RootCmd.PersistentFlags().StringVarP(&flagTLSKey, "tls_key", "", "", "key of the TLS certificate")
+	RootCmd.PersistentFlags().StringVarP(&flagTLSCA, "tls_ca", "", "",
+		"certificate of the CA the peer's TLS certificate must be issued by")
+	RootCmd.PersistentFlags().StringVarP(&flagTLSServerName, "tls_server_name", "", "",
+		"name the server's TLS certificate must be issued for (default the host of the address)")
+	RootCmd.PersistentFlags().StringSliceVarP(&flagTLSAllowedCNs, "tls_allowed_cns", "", nil,
+		"common names of the peer's TLS certificate to accept (default any)")
+	RootCmd.PersistentFlags().IntVarP(&flagMaxMsgSize, "max_msg_size", "", 0,
+		"maximum size in bytes of a message received (default the transport's default)")
 	RootCmd.PersistentFlags().BoolVarP(&flagVerbose,
 		"verbose",
 		"v",
@@ -158,6 +184,11 @@ func addPersistKVStoreMakeValSetChangeTxFlags() {
 		"voting power for ValSetChangeTx")
 }
 
+func addReplayFlags() {
+	replayCmd.PersistentFlags().StringVarP(&flagConnection, "connection", "", "consensus",
+		"connection whose recorded requests to replay: consensus | mempool | query | snapshot")
+}
+
 func addCommands() {
 	RootCmd.AddCommand(batchCmd)
 	RootCmd.AddCommand(consoleCmd)
@@ -171,6 +202,8 @@ func addCommands() {
 	RootCmd.AddCommand(testCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
+	addReplayFlags()
+	RootCmd.AddCommand(replayCmd)
 
 	// examples
 	addCounterFlags()
@@ -286,6 +319,21 @@ var queryCmd = &cobra.Command{
 	RunE:  cmdQuery,
 }
 
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "replay recorded requests against an application",
+	Long: `replay recorded requests against an application
+
+This command sends the requests recorded in the abci_record_file of a node to
+an application, in order, and reports the first one it responds to differently.
+Only the responses to DeliverTx, EndBlock and Commit are compared:
+
+    abci-cli replay abci_record.bin --address tcp://127.0.0.1:26658
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: cmdReplay,
+}
+
 var counterCmd = &cobra.Command{
 	Use:   "counter",
 	Short: "ABCI demo example - counter",
@@ -652,12 +700,66 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Replay recorded requests and compare the responses
+func cmdReplay(cmd *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	divergence, replayed, err := abcicli.Replay(client, abcicli.NewRecordReader(file), flagConnection)
+	if err != nil {
+		return err
+	}
+	if divergence == nil {
+		fmt.Printf("replayed %d requests of the %s connection: no divergence\n", replayed, flagConnection)
+		return nil
+	}
+	fmt.Printf("replayed %d requests of the %s connection: exchange %d diverges\n",
+		replayed, flagConnection, divergence.Index)
+	fmt.Printf("-> request: %v\n", divergence.Request)
+	fmt.Printf("-> recorded response: %v\n", divergence.Recorded)
+	fmt.Printf("-> replayed response: %v\n", divergence.Replayed)
+	return fmt.Errorf("the responses diverge at exchange %d", divergence.Index)
+}
+
+// clientOptions returns the options of the client set by the flags.
+func clientOptions() ([]abcicli.ClientOption, error) {
+	opts := []abcicli.ClientOption{abcicli.WithMaxMessageSize(flagMaxMsgSize)}
+	if flagTLSCert == "" && flagTLSKey == "" && flagTLSCA == "" {
+		return opts, nil
+	}
+	tlsConfig, err := tmnet.NewClientTLSConfig(flagTLSCert, flagTLSKey, flagTLSCA, flagTLSServerName, flagTLSAllowedCNs)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, abcicli.WithTLS(tlsConfig)), nil
+}
+
+// serverOptions returns the options of the servers set by the flags.
+func serverOptions() ([]server.ServerOption, error) {
+	opts := []server.ServerOption{server.WithMaxMessageSize(flagMaxMsgSize)}
+	if flagTLSCert == "" && flagTLSKey == "" && flagTLSCA == "" {
+		return opts, nil
+	}
+	tlsConfig, err := tmnet.NewServerTLSConfig(flagTLSCert, flagTLSKey, flagTLSCA, flagTLSAllowedCNs)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, server.WithTLS(tlsConfig)), nil
+}
+
 func cmdCounter(cmd *cobra.Command, args []string) error {
 	app := counter.NewApplication(flagSerial)
 	logger := log.NewOCLogger(log.NewSyncWriter(os.Stdout))
 
 	// Start the listener
-	srv, err := server.NewServer(flagAddress, flagAbci, app)
+	opts, err := serverOptions()
+	if err != nil {
+		return err
+	}
+	srv, err := server.NewServer(flagAddress, flagAbci, app, opts...)
 	if err != nil {
 		return err
 	}
@@ -691,7 +793,11 @@ func cmdKVStore(cmd *cobra.Command, args []string) error {
 	}
 
 	// Start the listener
-	srv, err := server.NewServer(flagAddress, flagAbci, app)
+	opts, err := serverOptions()
+	if err != nil {
+		return err
+	}
+	srv, err := server.NewServer(flagAddress, flagAbci, app, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,81 @@
+package kvstore
+
+import (
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// branch buffers the writes to a db since the last commit, so they can be
+// discarded if the block they're made by isn't decided. Reads see the buffered
+// writes. It's safe for concurrent use.
+type branch struct {
+	mtx    sync.RWMutex
+	db     dbm.DB
+	writes map[string][]byte // key -> value, or nil if the key is deleted
+}
+
+func newBranch(db dbm.DB) *branch {
+	return &branch{db: db, writes: make(map[string][]byte)}
+}
+
+func (b *branch) Get(key []byte) ([]byte, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if value, ok := b.writes[string(key)]; ok {
+		return value, nil
+	}
+	return b.db.Get(key)
+}
+
+func (b *branch) Has(key []byte) (bool, error) {
+	value, err := b.Get(key)
+	return value != nil, err
+}
+
+func (b *branch) Set(key, value []byte) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if value == nil {
+		value = []byte{}
+	}
+	b.writes[string(key)] = value
+}
+
+func (b *branch) Delete(key []byte) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.writes[string(key)] = nil
+}
+
+// commit writes the buffered writes to the db.
+func (b *branch) commit() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	batch := b.db.NewBatch()
+	defer batch.Close()
+	for key, value := range b.writes {
+		var err error
+		if value == nil {
+			err = batch.Delete([]byte(key))
+		} else {
+			err = batch.Set([]byte(key), value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	b.writes = make(map[string][]byte)
+	return nil
+}
+
+// abort discards the buffered writes.
+func (b *branch) abort() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.writes = make(map[string][]byte)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tm-db"
@@ -68,12 +69,16 @@ type Application struct {
 	ocabci.BaseApplication
 
 	state        State
-	RetainBlocks int64 // blocks to retain after commit (via ResponseCommit.RetainHeight)
+	branch       *branch // writes of the txs delivered since the last commit
+	RetainBlocks int64   // blocks to retain after commit (via ResponseCommit.RetainHeight)
 }
 
 func NewApplication() *Application {
-	state := loadState(dbm.NewMemDB())
-	return &Application{state: state}
+	return newApplication(dbm.NewMemDB())
+}
+
+func newApplication(db dbm.DB) *Application {
+	return &Application{state: loadState(db), branch: newBranch(db)}
 }
 
 func (app *Application) Info(req types.RequestInfo) (resInfo types.ResponseInfo) {
@@ -87,20 +92,58 @@ func (app *Application) Info(req types.RequestInfo) (resInfo types.ResponseInfo)
 }
 
 // tx is either "key=value" or just arbitrary bytes
-func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
-	var key, value []byte
-	parts := bytes.Split(req.Tx, []byte("="))
+func parseTx(tx []byte) (key, value []byte) {
+	parts := bytes.Split(tx, []byte("="))
 	if len(parts) == 2 {
-		key, value = parts[0], parts[1]
-	} else {
-		key, value = req.Tx, req.Tx
+		return parts[0], parts[1]
 	}
+	return tx, tx
+}
 
-	err := app.state.db.Set(prefixKey(key), value)
-	if err != nil {
-		panic(err)
-	}
+func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	res := app.deliverTx(req)
 	app.state.Size++
+	return res
+}
+
+// TxAccessSets declares the key each tx writes, so the txs setting different
+// keys are delivered in the same batch.
+func (app *Application) TxAccessSets(req ocabci.RequestTxAccessSets) ocabci.ResponseTxAccessSets {
+	accessSets := make([]ocabci.TxAccessSet, len(req.Txs))
+	for i, tx := range req.Txs {
+		accessSets[i] = txAccessSet(tx)
+	}
+	return ocabci.ResponseTxAccessSets{AccessSets: accessSets}
+}
+
+func txAccessSet(tx []byte) ocabci.TxAccessSet {
+	key, _ := parseTx(tx)
+	return ocabci.TxAccessSet{WriteKeys: [][]byte{key}}
+}
+
+// DeliverTxBatch executes the txs concurrently. They write different keys, as
+// declared by TxAccessSets, so the results don't depend on their order.
+func (app *Application) DeliverTxBatch(req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	responses := make([]*types.ResponseDeliverTx, len(req.Txs))
+	var wg sync.WaitGroup
+	for i, tx := range req.Txs {
+		wg.Add(1)
+		go func(i int, tx *types.RequestDeliverTx) {
+			defer wg.Done()
+			res := app.deliverTx(*tx)
+			responses[i] = &res
+		}(i, tx)
+	}
+	wg.Wait()
+	app.state.Size += int64(len(req.Txs))
+	return ocabci.ResponseDeliverTxBatch{Responses: responses}
+}
+
+// deliverTx sets the key of the tx, without updating the size of the state, so
+// it's safe to call concurrently for txs with different keys.
+func (app *Application) deliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	key, value := parseTx(req.Tx)
+	app.branch.Set(prefixKey(key), value)
 
 	events := []types.Event{
 		{
@@ -125,8 +168,23 @@ func (app *Application) CheckTxAsync(req types.RequestCheckTx, callback ocabci.C
 	callback(app.checkTx(req))
 }
 
+func (app *Application) CheckTxBatch(req ocabci.RequestCheckTxBatch) ocabci.ResponseCheckTxBatch {
+	responses := make([]*ocabci.ResponseCheckTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.checkTx(*tx)
+		responses[i] = &res
+	}
+	return ocabci.ResponseCheckTxBatch{Responses: responses}
+}
+
 func (app *Application) checkTx(req types.RequestCheckTx) ocabci.ResponseCheckTx {
-	return ocabci.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
+	accessSet := txAccessSet(req.Tx)
+	return ocabci.ResponseCheckTx{
+		Code:      code.CodeTypeOK,
+		GasWanted: 1,
+		ReadKeys:  accessSet.ReadKeys,
+		WriteKeys: accessSet.WriteKeys,
+	}
 }
 
 func (app *Application) Commit() types.ResponseCommit {
@@ -135,6 +193,9 @@ func (app *Application) Commit() types.ResponseCommit {
 	binary.PutVarint(appHash, app.state.Size)
 	app.state.AppHash = appHash
 	app.state.Height++
+	if err := app.branch.commit(); err != nil {
+		panic(err)
+	}
 	saveState(app.state)
 
 	resp := types.ResponseCommit{Data: appHash}
@@ -144,6 +205,13 @@ func (app *Application) Commit() types.ResponseCommit {
 	return resp
 }
 
+// AbortBlock discards the changes of the txs delivered since the last commit.
+func (app *Application) AbortBlock(req ocabci.RequestAbortBlock) ocabci.ResponseAbortBlock {
+	app.branch.abort()
+	app.state = loadState(app.state.db)
+	return ocabci.ResponseAbortBlock{Code: code.CodeTypeOK}
+}
+
 // Returns an associated value or nil if missing.
 func (app *Application) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
 	if reqQuery.Prove {
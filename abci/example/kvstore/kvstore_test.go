@@ -87,6 +87,56 @@ func TestPersistentKVStoreKV(t *testing.T) {
 	testKVStore(t, kvstore, tx, key, value)
 }
 
+func TestDeliverTxBatchDeterminism(t *testing.T) {
+	txs := make([][]byte, 100)
+	for i := range txs {
+		txs[i] = []byte(fmt.Sprintf("key%d=value%d", i, i))
+		res := NewApplication().CheckTxSync(types.RequestCheckTx{Tx: txs[i]})
+		require.Equal(t, [][]byte{[]byte(fmt.Sprintf("key%d", i))}, res.WriteKeys)
+	}
+	res := NewApplication().TxAccessSets(ocabci.RequestTxAccessSets{Txs: txs})
+	require.Len(t, res.AccessSets, len(txs))
+	for i, set := range res.AccessSets {
+		require.Equal(t, [][]byte{[]byte(fmt.Sprintf("key%d", i))}, set.WriteKeys)
+	}
+
+	sequential := NewApplication()
+	expected := make([]*types.ResponseDeliverTx, len(txs))
+	for i, tx := range txs {
+		res := sequential.DeliverTx(types.RequestDeliverTx{Tx: tx})
+		expected[i] = &res
+	}
+	expectedHash := sequential.Commit().Data
+
+	// the txs write different keys, so executing them concurrently in batches
+	// of any size gives the results of the sequential execution
+	for _, batchSize := range []int{1, 7, 33, 100} {
+		for run := 0; run < 5; run++ {
+			app := NewApplication()
+			var responses []*types.ResponseDeliverTx
+			for start := 0; start < len(txs); start += batchSize {
+				end := start + batchSize
+				if end > len(txs) {
+					end = len(txs)
+				}
+				req := ocabci.RequestDeliverTxBatch{}
+				for _, tx := range txs[start:end] {
+					req.Txs = append(req.Txs, &types.RequestDeliverTx{Tx: tx})
+				}
+				res := app.DeliverTxBatch(req)
+				require.Len(t, res.Responses, end-start)
+				responses = append(responses, res.Responses...)
+			}
+			require.Equal(t, expected, responses)
+			require.Equal(t, expectedHash, app.Commit().Data)
+			for i := range txs {
+				res := app.Query(types.RequestQuery{Data: []byte(fmt.Sprintf("key%d", i))})
+				require.Equal(t, fmt.Sprintf("value%d", i), string(res.Value))
+			}
+		}
+	}
+}
+
 func TestPersistentKVStoreInfo(t *testing.T) {
 	dir, err := os.MkdirTemp("/tmp", "abci-kvstore-test") // TODO
 	if err != nil {
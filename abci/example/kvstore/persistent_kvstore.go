@@ -43,10 +43,8 @@ func NewPersistentKVStoreApplication(dbDir string) *PersistentKVStoreApplication
 		panic(err)
 	}
 
-	state := loadState(db)
-
 	return &PersistentKVStoreApplication{
-		app:                &Application{state: state},
+		app:                newApplication(db),
 		valAddrToPubKeyMap: make(map[string]pc.PublicKey),
 		logger:             log.NewNopLogger(),
 	}
@@ -81,12 +79,59 @@ func (app *PersistentKVStoreApplication) DeliverTx(req types.RequestDeliverTx) t
 	return app.app.DeliverTx(req)
 }
 
+// TxAccessSets doesn't declare the keys of the validator txs, so they aren't
+// delivered in the batch of any other tx.
+func (app *PersistentKVStoreApplication) TxAccessSets(req ocabci.RequestTxAccessSets) ocabci.ResponseTxAccessSets {
+	res := app.app.TxAccessSets(req)
+	for i, tx := range req.Txs {
+		if isValidatorTx(tx) {
+			res.AccessSets[i] = ocabci.TxAccessSet{}
+		}
+	}
+	return res
+}
+
+// DeliverTxBatch runs the txs sequentially if any of them updates the
+// validator set, which TxAccessSets doesn't declare keys for.
+func (app *PersistentKVStoreApplication) DeliverTxBatch(req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	for _, tx := range req.Txs {
+		if isValidatorTx(tx.Tx) {
+			responses := make([]*types.ResponseDeliverTx, len(req.Txs))
+			for i, tx := range req.Txs {
+				res := app.DeliverTx(*tx)
+				responses[i] = &res
+			}
+			return ocabci.ResponseDeliverTxBatch{Responses: responses}
+		}
+	}
+	return app.app.DeliverTxBatch(req)
+}
+
 func (app *PersistentKVStoreApplication) CheckTxSync(req types.RequestCheckTx) ocabci.ResponseCheckTx {
-	return app.app.CheckTxSync(req)
+	return app.checkTx(app.app.CheckTxSync(req), req)
 }
 
 func (app *PersistentKVStoreApplication) CheckTxAsync(req types.RequestCheckTx, callback ocabci.CheckTxCallback) {
-	app.app.CheckTxAsync(req, callback)
+	app.app.CheckTxAsync(req, func(res ocabci.ResponseCheckTx) {
+		callback(app.checkTx(res, req))
+	})
+}
+
+func (app *PersistentKVStoreApplication) CheckTxBatch(req ocabci.RequestCheckTxBatch) ocabci.ResponseCheckTxBatch {
+	res := app.app.CheckTxBatch(req)
+	for i, tx := range req.Txs {
+		*res.Responses[i] = app.checkTx(*res.Responses[i], *tx)
+	}
+	return res
+}
+
+// checkTx doesn't declare the keys of the validator txs, so they aren't
+// executed concurrently with any other tx.
+func (app *PersistentKVStoreApplication) checkTx(res ocabci.ResponseCheckTx, req types.RequestCheckTx) ocabci.ResponseCheckTx {
+	if isValidatorTx(req.Tx) {
+		res.ReadKeys, res.WriteKeys = nil, nil
+	}
+	return res
 }
 
 func (app *PersistentKVStoreApplication) BeginRecheckTx(req ocabci.RequestBeginRecheckTx) ocabci.ResponseBeginRecheckTx {
@@ -102,6 +147,22 @@ func (app *PersistentKVStoreApplication) Commit() types.ResponseCommit {
 	return app.app.Commit()
 }
 
+// AbortBlock discards the validator updates of the block along with the other
+// changes of its txs.
+func (app *PersistentKVStoreApplication) AbortBlock(req ocabci.RequestAbortBlock) ocabci.ResponseAbortBlock {
+	res := app.app.AbortBlock(req)
+	app.ValUpdates = make([]types.ValidatorUpdate, 0)
+	app.valAddrToPubKeyMap = make(map[string]pc.PublicKey)
+	for _, v := range app.Validators() {
+		pubkey, err := cryptoenc.PubKeyFromProto(&v.PubKey)
+		if err != nil {
+			panic(err)
+		}
+		app.valAddrToPubKeyMap[string(pubkey.Address())] = v.PubKey
+	}
+	return res
+}
+
 // When path=/val and data={validator address}, returns the validator update (types.ValidatorUpdate) varint encoded.
 // For any other path, returns an associated value or nil if missing.
 func (app *PersistentKVStoreApplication) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
@@ -129,6 +190,10 @@ func (app *PersistentKVStoreApplication) InitChain(req types.RequestInitChain) t
 			app.logger.Error("Error updating validators", "r", r)
 		}
 	}
+	// the genesis validators aren't part of a block which may be aborted
+	if err := app.app.branch.commit(); err != nil {
+		panic(err)
+	}
 	return types.ResponseInitChain{}
 }
 
@@ -159,8 +224,8 @@ func (app *PersistentKVStoreApplication) BeginBlock(req ocabci.RequestBeginBlock
 }
 
 // Update the validator set
-func (app *PersistentKVStoreApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
-	return types.ResponseEndBlock{ValidatorUpdates: app.ValUpdates}
+func (app *PersistentKVStoreApplication) EndBlock(req types.RequestEndBlock) ocabci.ResponseEndBlock {
+	return ocabci.ResponseEndBlock{ValidatorUpdates: app.ValUpdates}
 }
 
 func (app *PersistentKVStoreApplication) ListSnapshots(
@@ -287,7 +352,7 @@ func (app *PersistentKVStoreApplication) updateValidator(v types.ValidatorUpdate
 
 	if v.Power == 0 {
 		// remove validator
-		hasKey, err := app.app.state.db.Has(key)
+		hasKey, err := app.app.branch.Has(key)
 		if err != nil {
 			panic(err)
 		}
@@ -296,9 +361,7 @@ func (app *PersistentKVStoreApplication) updateValidator(v types.ValidatorUpdate
 				Code: code.CodeTypeUnauthorized,
 				Log:  fmt.Sprintf("Cannot remove non-existent validator %s", pubStr)}
 		}
-		if err = app.app.state.db.Delete(key); err != nil {
-			panic(err)
-		}
+		app.app.branch.Delete(key)
 		delete(app.valAddrToPubKeyMap, string(pubkey.Address()))
 	} else {
 		// add or update validator
@@ -308,9 +371,7 @@ func (app *PersistentKVStoreApplication) updateValidator(v types.ValidatorUpdate
 				Code: code.CodeTypeEncodingError,
 				Log:  fmt.Sprintf("Error encoding validator: %v", err)}
 		}
-		if err = app.app.state.db.Set(key, value.Bytes()); err != nil {
-			panic(err)
-		}
+		app.app.branch.Set(key, value.Bytes())
 		app.valAddrToPubKeyMap[string(pubkey.Address())] = v.PubKey
 	}
 
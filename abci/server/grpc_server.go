@@ -4,6 +4,9 @@ import (
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/Finschia/ostracon/abci/types"
 	tmnet "github.com/Finschia/ostracon/libs/net"
@@ -17,17 +20,21 @@ type GRPCServer struct {
 	addr     string
 	listener net.Listener
 	server   *grpc.Server
+	health   *health.Server
+	opts     serverOptions
 
 	app types.ABCIApplicationServer
 }
 
-// NewGRPCServer returns a new gRPC ABCI server
-func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) service.Service {
+// NewGRPCServer returns a new gRPC ABCI server. It also serves the gRPC health
+// checking protocol, reporting the server as serving until it's stopped.
+func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer, opts ...ServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &GRPCServer{
 		proto:    proto,
 		addr:     addr,
 		listener: nil,
+		opts:     newServerOptions(opts),
 		app:      app,
 	}
 	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
@@ -42,9 +49,19 @@ func (s *GRPCServer) OnStart() error {
 		return err
 	}
 
+	var serverOpts []grpc.ServerOption
+	if s.opts.tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(s.opts.tlsConfig)))
+	}
+	if s.opts.maxMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(s.opts.maxMsgSize))
+	}
+
 	s.listener = ln
-	s.server = grpc.NewServer()
+	s.server = grpc.NewServer(serverOpts...)
 	types.RegisterABCIApplicationServer(s.server, s.app)
+	s.health = health.NewServer()
+	healthpb.RegisterHealthServer(s.server, s.health)
 
 	s.Logger.Info("Listening", "proto", s.proto, "addr", s.addr)
 	go func() {
@@ -57,5 +74,6 @@ func (s *GRPCServer) OnStart() error {
 
 // OnStop stops the gRPC server.
 func (s *GRPCServer) OnStop() {
+	s.health.Shutdown()
 	s.server.Stop()
 }
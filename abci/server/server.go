@@ -8,22 +8,50 @@ It contains two server implementation:
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 
 	"github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/libs/service"
 )
 
-func NewServer(protoAddr, transport string, app types.Application) (service.Service, error) {
+func NewServer(protoAddr, transport string, app types.Application, opts ...ServerOption) (service.Service, error) {
 	var s service.Service
 	var err error
 	switch transport {
 	case "socket":
-		s = NewSocketServer(protoAddr, app)
+		s = NewSocketServer(protoAddr, app, opts...)
 	case "grpc":
-		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app))
+		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app), opts...)
 	default:
 		err = fmt.Errorf("unknown server type %s", transport)
 	}
 	return s, err
 }
+
+// ServerOption sets an option of the socket and gRPC servers.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	tlsConfig  *tls.Config
+	maxMsgSize int
+}
+
+// WithTLS makes the server accept connections over TLS with config only.
+func WithTLS(config *tls.Config) ServerOption {
+	return func(opts *serverOptions) { opts.tlsConfig = config }
+}
+
+// WithMaxMessageSize limits the size of the requests the server receives. 0
+// means the default of the transport.
+func WithMaxMessageSize(size int) ServerOption {
+	return func(opts *serverOptions) { opts.maxMsgSize = size }
+}
+
+func newServerOptions(opts []ServerOption) serverOptions {
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
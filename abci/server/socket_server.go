@@ -2,6 +2,7 @@ package server
 
 import (
 	"bufio"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -24,6 +25,7 @@ type SocketServer struct {
 	proto    string
 	addr     string
 	listener net.Listener
+	opts     serverOptions
 
 	connsMtx   tmsync.Mutex
 	conns      map[int]net.Conn
@@ -33,12 +35,13 @@ type SocketServer struct {
 	app    types.Application
 }
 
-func NewSocketServer(protoAddr string, app types.Application) service.Service {
+func NewSocketServer(protoAddr string, app types.Application, opts ...ServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &SocketServer{
 		proto:    proto,
 		addr:     addr,
 		listener: nil,
+		opts:     newServerOptions(opts),
 		app:      app,
 		conns:    make(map[int]net.Conn),
 	}
@@ -56,6 +59,9 @@ func (s *SocketServer) OnStart() error {
 	if err != nil {
 		return err
 	}
+	if s.opts.tlsConfig != nil {
+		ln = tls.NewListener(ln, s.opts.tlsConfig)
+	}
 
 	s.listener = ln
 	go s.acceptConnectionsRoutine()
@@ -155,6 +161,10 @@ func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
 func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- *types.Response) {
 	var count int
 	var bufReader = bufio.NewReader(conn)
+	maxMsgSize := s.opts.maxMsgSize
+	if maxMsgSize == 0 {
+		maxMsgSize = types.DefaultMaxMessageSize
+	}
 
 	defer func() {
 		// make sure to recover from any app-related panics to allow proper socket cleanup
@@ -175,7 +185,7 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 	for {
 
 		var req = &types.Request{}
-		err := types.ReadMessage(bufReader, req)
+		err := types.ReadMessageWithMaxSize(bufReader, req, maxMsgSize)
 		if err != nil {
 			if err == io.EOF {
 				closeConn <- err
@@ -206,9 +216,15 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_DeliverTx:
 		res := s.app.DeliverTx(*r.DeliverTx)
 		responses <- types.ToResponseDeliverTx(res)
+	case *types.Request_DeliverTxBatch:
+		res := s.app.DeliverTxBatch(*r.DeliverTxBatch)
+		responses <- types.ToResponseDeliverTxBatch(res)
 	case *types.Request_CheckTx:
 		res := s.app.CheckTxSync(*r.CheckTx)
 		responses <- types.ToResponseCheckTx(res)
+	case *types.Request_CheckTxBatch:
+		res := s.app.CheckTxBatch(*r.CheckTxBatch)
+		responses <- types.ToResponseCheckTxBatch(res)
 	case *types.Request_Commit:
 		res := s.app.Commit()
 		responses <- types.ToResponseCommit(res)
@@ -224,6 +240,12 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_EndBlock:
 		res := s.app.EndBlock(*r.EndBlock)
 		responses <- types.ToResponseEndBlock(res)
+	case *types.Request_AbortBlock:
+		res := s.app.AbortBlock(*r.AbortBlock)
+		responses <- types.ToResponseAbortBlock(res)
+	case *types.Request_TxAccessSets:
+		res := s.app.TxAccessSets(*r.TxAccessSets)
+		responses <- types.ToResponseTxAccessSets(res)
 	case *types.Request_BeginRecheckTx:
 		res := s.app.BeginRecheckTx(*r.BeginRecheckTx)
 		responses <- types.ToResponseBeginRecheckTx(res)
@@ -23,15 +23,19 @@ type Application interface {
 	// Mempool Connection
 	CheckTxSync(types.RequestCheckTx) ResponseCheckTx            // Validate a tx for the mempool
 	CheckTxAsync(types.RequestCheckTx, CheckTxCallback)          // Asynchronously validate a tx for the mempool
+	CheckTxBatch(RequestCheckTxBatch) ResponseCheckTxBatch       // Validate several txs for the mempool at once
 	BeginRecheckTx(RequestBeginRecheckTx) ResponseBeginRecheckTx // Signals the beginning of rechecking
 	EndRecheckTx(RequestEndRecheckTx) ResponseEndRecheckTx       // Signals the end of rechecking
 
 	// Consensus Connection
-	InitChain(types.RequestInitChain) types.ResponseInitChain // Initialize blockchain w validators/other info from OstraconCore
-	BeginBlock(RequestBeginBlock) types.ResponseBeginBlock    // Signals the beginning of a block
-	DeliverTx(types.RequestDeliverTx) types.ResponseDeliverTx // Deliver a tx for full processing
-	EndBlock(types.RequestEndBlock) types.ResponseEndBlock    // Signals the end of a block, returns changes to the validator set
-	Commit() types.ResponseCommit                             // Commit the state and return the application Merkle root hash
+	InitChain(types.RequestInitChain) types.ResponseInitChain    // Initialize blockchain w validators/other info from OstraconCore
+	BeginBlock(RequestBeginBlock) types.ResponseBeginBlock       // Signals the beginning of a block
+	DeliverTx(types.RequestDeliverTx) types.ResponseDeliverTx    // Deliver a tx for full processing
+	TxAccessSets(RequestTxAccessSets) ResponseTxAccessSets       // Return the keys the txs of a block read and write
+	DeliverTxBatch(RequestDeliverTxBatch) ResponseDeliverTxBatch // Deliver txs not conflicting with each other, which may run concurrently
+	EndBlock(types.RequestEndBlock) ResponseEndBlock             // Signals the end of a block, returns changes to the validator set
+	Commit() types.ResponseCommit                                // Commit the state and return the application Merkle root hash
+	AbortBlock(RequestAbortBlock) ResponseAbortBlock             // Discard the state changes of a block which wasn't decided
 
 	// State Sync Connection
 	ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots                // List available snapshots
@@ -64,6 +68,16 @@ func (BaseApplication) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliv
 	return types.ResponseDeliverTx{Code: CodeTypeOK}
 }
 
+// DeliverTxBatch is only called with several txs for the apps declaring the
+// read and write keys of their txs in CheckTx.
+func (BaseApplication) DeliverTxBatch(req RequestDeliverTxBatch) ResponseDeliverTxBatch {
+	responses := make([]*types.ResponseDeliverTx, len(req.Txs))
+	for i := range req.Txs {
+		responses[i] = &types.ResponseDeliverTx{Code: CodeTypeOK}
+	}
+	return ResponseDeliverTxBatch{Responses: responses}
+}
+
 func (BaseApplication) CheckTxSync(req types.RequestCheckTx) ResponseCheckTx {
 	return ResponseCheckTx{Code: CodeTypeOK}
 }
@@ -72,6 +86,16 @@ func (BaseApplication) CheckTxAsync(req types.RequestCheckTx, callback CheckTxCa
 	callback(ResponseCheckTx{Code: CodeTypeOK})
 }
 
+// CheckTxBatch is only called by the mempools configured to check txs in
+// batches.
+func (BaseApplication) CheckTxBatch(req RequestCheckTxBatch) ResponseCheckTxBatch {
+	responses := make([]*ResponseCheckTx, len(req.Txs))
+	for i := range req.Txs {
+		responses[i] = &ResponseCheckTx{Code: CodeTypeOK}
+	}
+	return ResponseCheckTxBatch{Responses: responses}
+}
+
 func (BaseApplication) BeginRecheckTx(req RequestBeginRecheckTx) ResponseBeginRecheckTx {
 	return ResponseBeginRecheckTx{Code: CodeTypeOK}
 }
@@ -84,6 +108,18 @@ func (BaseApplication) Commit() types.ResponseCommit {
 	return types.ResponseCommit{}
 }
 
+// TxAccessSets returns no access set, so the txs of a block are delivered one by
+// one.
+func (BaseApplication) TxAccessSets(req RequestTxAccessSets) ResponseTxAccessSets {
+	return ResponseTxAccessSets{}
+}
+
+// AbortBlock is only called by the nodes executing blocks optimistically, which
+// requires the app to discard the changes of a block it executed.
+func (BaseApplication) AbortBlock(req RequestAbortBlock) ResponseAbortBlock {
+	return ResponseAbortBlock{Code: CodeTypeOK}
+}
+
 func (BaseApplication) Query(req types.RequestQuery) types.ResponseQuery {
 	return types.ResponseQuery{Code: CodeTypeOK}
 }
@@ -96,8 +132,8 @@ func (BaseApplication) BeginBlock(req RequestBeginBlock) types.ResponseBeginBloc
 	return types.ResponseBeginBlock{}
 }
 
-func (BaseApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
-	return types.ResponseEndBlock{}
+func (BaseApplication) EndBlock(req types.RequestEndBlock) ResponseEndBlock {
+	return ResponseEndBlock{}
 }
 
 func (BaseApplication) ListSnapshots(req types.RequestListSnapshots) types.ResponseListSnapshots {
@@ -150,6 +186,12 @@ func (app *GRPCApplication) DeliverTx(ctx context.Context, req *types.RequestDel
 	return &res, nil
 }
 
+func (app *GRPCApplication) DeliverTxBatch(
+	ctx context.Context, req *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error) {
+	res := app.app.DeliverTxBatch(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) CheckTx(ctx context.Context, req *types.RequestCheckTx) (*ResponseCheckTx, error) {
 	res := app.app.CheckTxSync(*req)
 	return &res, nil
@@ -166,6 +208,23 @@ func (app *GRPCApplication) EndRecheckTx(ctx context.Context, req *RequestEndRec
 	return &res, nil
 }
 
+func (app *GRPCApplication) CheckTxBatch(
+	ctx context.Context, req *RequestCheckTxBatch) (*ResponseCheckTxBatch, error) {
+	res := app.app.CheckTxBatch(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) TxAccessSets(
+	ctx context.Context, req *RequestTxAccessSets) (*ResponseTxAccessSets, error) {
+	res := app.app.TxAccessSets(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) AbortBlock(ctx context.Context, req *RequestAbortBlock) (*ResponseAbortBlock, error) {
+	res := app.app.AbortBlock(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) Query(ctx context.Context, req *types.RequestQuery) (*types.ResponseQuery, error) {
 	res := app.app.Query(*req)
 	return &res, nil
@@ -186,7 +245,7 @@ func (app *GRPCApplication) BeginBlock(ctx context.Context, req *RequestBeginBlo
 	return &res, nil
 }
 
-func (app *GRPCApplication) EndBlock(ctx context.Context, req *types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (app *GRPCApplication) EndBlock(ctx context.Context, req *types.RequestEndBlock) (*ResponseEndBlock, error) {
 	res := app.app.EndBlock(*req)
 	return &res, nil
 }
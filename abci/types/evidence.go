@@ -0,0 +1,10 @@
+package types
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// EvidenceType_DUPLICATE_PROPOSAL is the type of the evidence of a proposer
+// signing two conflicting proposals for the same height and round. It extends
+// the EvidenceType of Tendermint, whose String returns its number.
+const EvidenceType_DUPLICATE_PROPOSAL types.EvidenceType = 1000 //nolint:revive,stylecheck
@@ -11,7 +11,9 @@ import (
 )
 
 const (
-	maxMsgSize = 104857600 // 100MB
+	// DefaultMaxMessageSize is the maximum size of a message read by
+	// ReadMessage.
+	DefaultMaxMessageSize = 104857600 // 100MB
 )
 
 // WriteMessage writes a varint length-delimited protobuf message.
@@ -25,7 +27,13 @@ func WriteMessage(msg proto.Message, w io.Writer) error {
 
 // ReadMessage reads a varint length-delimited protobuf message.
 func ReadMessage(r io.Reader, msg proto.Message) error {
-	return readProtoMsg(r, msg, maxMsgSize)
+	return readProtoMsg(r, msg, DefaultMaxMessageSize)
+}
+
+// ReadMessageWithMaxSize reads a varint length-delimited protobuf message of at
+// most maxSize bytes.
+func ReadMessageWithMaxSize(r io.Reader, msg proto.Message, maxSize int) error {
+	return readProtoMsg(r, msg, maxSize)
 }
 
 func readProtoMsg(r io.Reader, msg proto.Message, maxSize int) error {
@@ -149,6 +157,30 @@ func ToRequestEndRecheckTx(req RequestEndRecheckTx) *Request {
 	}
 }
 
+func ToRequestDeliverTxBatch(req RequestDeliverTxBatch) *Request {
+	return &Request{
+		Value: &Request_DeliverTxBatch{&req},
+	}
+}
+
+func ToRequestCheckTxBatch(req RequestCheckTxBatch) *Request {
+	return &Request{
+		Value: &Request_CheckTxBatch{&req},
+	}
+}
+
+func ToRequestAbortBlock(req RequestAbortBlock) *Request {
+	return &Request{
+		Value: &Request_AbortBlock{&req},
+	}
+}
+
+func ToRequestTxAccessSets(req RequestTxAccessSets) *Request {
+	return &Request{
+		Value: &Request_TxAccessSets{&req},
+	}
+}
+
 func ToRequestListSnapshots(req types.RequestListSnapshots) *Request {
 	return &Request{
 		Value: &Request_ListSnapshots{&req},
@@ -241,7 +273,7 @@ func ToResponseBeginBlock(res types.ResponseBeginBlock) *Response {
 	}
 }
 
-func ToResponseEndBlock(res types.ResponseEndBlock) *Response {
+func ToResponseEndBlock(res ResponseEndBlock) *Response {
 	return &Response{
 		Value: &Response_EndBlock{&res},
 	}
@@ -259,6 +291,30 @@ func ToResponseEndRecheckTx(res ResponseEndRecheckTx) *Response {
 	}
 }
 
+func ToResponseDeliverTxBatch(res ResponseDeliverTxBatch) *Response {
+	return &Response{
+		Value: &Response_DeliverTxBatch{&res},
+	}
+}
+
+func ToResponseCheckTxBatch(res ResponseCheckTxBatch) *Response {
+	return &Response{
+		Value: &Response_CheckTxBatch{&res},
+	}
+}
+
+func ToResponseAbortBlock(res ResponseAbortBlock) *Response {
+	return &Response{
+		Value: &Response_AbortBlock{&res},
+	}
+}
+
+func ToResponseTxAccessSets(res ResponseTxAccessSets) *Response {
+	return &Response{
+		Value: &Response_TxAccessSets{&res},
+	}
+}
+
 func ToResponseListSnapshots(res types.ResponseListSnapshots) *Response {
 	return &Response{
 		Value: &Response_ListSnapshots{&res},
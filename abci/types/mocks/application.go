@@ -14,6 +14,20 @@ type Application struct {
 	mock.Mock
 }
 
+// AbortBlock provides a mock function with given fields: _a0
+func (_m *Application) AbortBlock(_a0 abcitypes.RequestAbortBlock) abcitypes.ResponseAbortBlock {
+	ret := _m.Called(_a0)
+
+	var r0 abcitypes.ResponseAbortBlock
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestAbortBlock) abcitypes.ResponseAbortBlock); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(abcitypes.ResponseAbortBlock)
+	}
+
+	return r0
+}
+
 // ApplySnapshotChunk provides a mock function with given fields: _a0
 func (_m *Application) ApplySnapshotChunk(_a0 types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk {
 	ret := _m.Called(_a0)
@@ -61,6 +75,20 @@ func (_m *Application) CheckTxAsync(_a0 types.RequestCheckTx, _a1 abcitypes.Chec
 	_m.Called(_a0, _a1)
 }
 
+// CheckTxBatch provides a mock function with given fields: _a0
+func (_m *Application) CheckTxBatch(_a0 abcitypes.RequestCheckTxBatch) abcitypes.ResponseCheckTxBatch {
+	ret := _m.Called(_a0)
+
+	var r0 abcitypes.ResponseCheckTxBatch
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestCheckTxBatch) abcitypes.ResponseCheckTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(abcitypes.ResponseCheckTxBatch)
+	}
+
+	return r0
+}
+
 // CheckTxSync provides a mock function with given fields: _a0
 func (_m *Application) CheckTxSync(_a0 types.RequestCheckTx) abcitypes.ResponseCheckTx {
 	ret := _m.Called(_a0)
@@ -103,15 +131,29 @@ func (_m *Application) DeliverTx(_a0 types.RequestDeliverTx) types.ResponseDeliv
 	return r0
 }
 
+// DeliverTxBatch provides a mock function with given fields: _a0
+func (_m *Application) DeliverTxBatch(_a0 abcitypes.RequestDeliverTxBatch) abcitypes.ResponseDeliverTxBatch {
+	ret := _m.Called(_a0)
+
+	var r0 abcitypes.ResponseDeliverTxBatch
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) abcitypes.ResponseDeliverTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(abcitypes.ResponseDeliverTxBatch)
+	}
+
+	return r0
+}
+
 // EndBlock provides a mock function with given fields: _a0
-func (_m *Application) EndBlock(_a0 types.RequestEndBlock) types.ResponseEndBlock {
+func (_m *Application) EndBlock(_a0 types.RequestEndBlock) abcitypes.ResponseEndBlock {
 	ret := _m.Called(_a0)
 
-	var r0 types.ResponseEndBlock
-	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) types.ResponseEndBlock); ok {
+	var r0 abcitypes.ResponseEndBlock
+	if rf, ok := ret.Get(0).(func(types.RequestEndBlock) abcitypes.ResponseEndBlock); ok {
 		r0 = rf(_a0)
 	} else {
-		r0 = ret.Get(0).(types.ResponseEndBlock)
+		r0 = ret.Get(0).(abcitypes.ResponseEndBlock)
 	}
 
 	return r0
@@ -229,6 +271,20 @@ func (_m *Application) SetOption(_a0 types.RequestSetOption) types.ResponseSetOp
 	return r0
 }
 
+// TxAccessSets provides a mock function with given fields: _a0
+func (_m *Application) TxAccessSets(_a0 abcitypes.RequestTxAccessSets) abcitypes.ResponseTxAccessSets {
+	ret := _m.Called(_a0)
+
+	var r0 abcitypes.ResponseTxAccessSets
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestTxAccessSets) abcitypes.ResponseTxAccessSets); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(abcitypes.ResponseTxAccessSets)
+	}
+
+	return r0
+}
+
 // NewApplication creates a new instance of Application. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApplication(t interface {
@@ -19,3 +19,22 @@ func NewValidatorUpdate(pk crypto.PubKey, power int64) types.ValidatorUpdate {
 		Power:  power,
 	}
 }
+
+// NewValidatorKeyRotation returns the ValidatorKeyRotation of a validator
+// from oldPk to newPk. The validator keeps its voting power and proposer
+// priority under the new public key.
+func NewValidatorKeyRotation(oldPk, newPk crypto.PubKey) ValidatorKeyRotation {
+	oldPkp, err := cryptoenc.PubKeyToProto(oldPk)
+	if err != nil {
+		panic(err)
+	}
+	newPkp, err := cryptoenc.PubKeyToProto(newPk)
+	if err != nil {
+		panic(err)
+	}
+
+	return ValidatorKeyRotation{
+		OldPubKey: oldPkp,
+		NewPubKey: newPkp,
+	}
+}
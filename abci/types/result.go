@@ -38,7 +38,17 @@ func (r *ResponseCheckTx) MarshalJSON() ([]byte, error) {
 
 func (r *ResponseCheckTx) UnmarshalJSON(b []byte) error {
 	reader := bytes.NewBuffer(b)
-	return jsonpbUnmarshaller.Unmarshal(reader, r)
+	if err := jsonpbUnmarshaller.Unmarshal(reader, r); err != nil {
+		return err
+	}
+	// the emitted defaults of the optional access set are decoded as nil
+	if len(r.ReadKeys) == 0 {
+		r.ReadKeys = nil
+	}
+	if len(r.WriteKeys) == 0 {
+		r.WriteKeys = nil
+	}
+	return nil
 }
 
 // Some compile time assertions to ensure we don't
@@ -10,6 +10,7 @@ import (
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	types "github.com/tendermint/tendermint/abci/types"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -49,6 +50,10 @@ type Request struct {
 	//	*Request_ApplySnapshotChunk
 	//	*Request_BeginRecheckTx
 	//	*Request_EndRecheckTx
+	//	*Request_DeliverTxBatch
+	//	*Request_CheckTxBatch
+	//	*Request_AbortBlock
+	//	*Request_TxAccessSets
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -142,6 +147,18 @@ type Request_BeginRecheckTx struct {
 type Request_EndRecheckTx struct {
 	EndRecheckTx *RequestEndRecheckTx `protobuf:"bytes,1001,opt,name=end_recheck_tx,json=endRecheckTx,proto3,oneof" json:"end_recheck_tx,omitempty"`
 }
+type Request_DeliverTxBatch struct {
+	DeliverTxBatch *RequestDeliverTxBatch `protobuf:"bytes,1002,opt,name=deliver_tx_batch,json=deliverTxBatch,proto3,oneof" json:"deliver_tx_batch,omitempty"`
+}
+type Request_CheckTxBatch struct {
+	CheckTxBatch *RequestCheckTxBatch `protobuf:"bytes,1003,opt,name=check_tx_batch,json=checkTxBatch,proto3,oneof" json:"check_tx_batch,omitempty"`
+}
+type Request_AbortBlock struct {
+	AbortBlock *RequestAbortBlock `protobuf:"bytes,1004,opt,name=abort_block,json=abortBlock,proto3,oneof" json:"abort_block,omitempty"`
+}
+type Request_TxAccessSets struct {
+	TxAccessSets *RequestTxAccessSets `protobuf:"bytes,1005,opt,name=tx_access_sets,json=txAccessSets,proto3,oneof" json:"tx_access_sets,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -160,6 +177,10 @@ func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_BeginRecheckTx) isRequest_Value()     {}
 func (*Request_EndRecheckTx) isRequest_Value()       {}
+func (*Request_DeliverTxBatch) isRequest_Value()     {}
+func (*Request_CheckTxBatch) isRequest_Value()       {}
+func (*Request_AbortBlock) isRequest_Value()         {}
+func (*Request_TxAccessSets) isRequest_Value()       {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -287,6 +308,33 @@ func (m *Request) GetEndRecheckTx() *RequestEndRecheckTx {
 	return nil
 }
 
+func (m *Request) GetDeliverTxBatch() *RequestDeliverTxBatch {
+	if x, ok := m.GetValue().(*Request_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
+func (m *Request) GetCheckTxBatch() *RequestCheckTxBatch {
+	if x, ok := m.GetValue().(*Request_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+func (m *Request) GetAbortBlock() *RequestAbortBlock {
+	if x, ok := m.GetValue().(*Request_AbortBlock); ok {
+		return x.AbortBlock
+	}
+	return nil
+}
+
+func (m *Request) GetTxAccessSets() *RequestTxAccessSets {
+	if x, ok := m.GetValue().(*Request_TxAccessSets); ok {
+		return x.TxAccessSets
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -307,6 +355,10 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_BeginRecheckTx)(nil),
 		(*Request_EndRecheckTx)(nil),
+		(*Request_DeliverTxBatch)(nil),
+		(*Request_CheckTxBatch)(nil),
+		(*Request_AbortBlock)(nil),
+		(*Request_TxAccessSets)(nil),
 	}
 }
 
@@ -475,6 +527,184 @@ func (m *RequestEndRecheckTx) GetHeight() int64 {
 	return 0
 }
 
+type RequestDeliverTxBatch struct {
+	Txs []*types.RequestDeliverTx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestDeliverTxBatch) Reset()         { *m = RequestDeliverTxBatch{} }
+func (m *RequestDeliverTxBatch) String() string { return proto.CompactTextString(m) }
+func (*RequestDeliverTxBatch) ProtoMessage()    {}
+func (*RequestDeliverTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{4}
+}
+func (m *RequestDeliverTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestDeliverTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestDeliverTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestDeliverTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestDeliverTxBatch.Merge(m, src)
+}
+func (m *RequestDeliverTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestDeliverTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestDeliverTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestDeliverTxBatch proto.InternalMessageInfo
+
+func (m *RequestDeliverTxBatch) GetTxs() []*types.RequestDeliverTx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type RequestCheckTxBatch struct {
+	Txs []*types.RequestCheckTx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestCheckTxBatch) Reset()         { *m = RequestCheckTxBatch{} }
+func (m *RequestCheckTxBatch) String() string { return proto.CompactTextString(m) }
+func (*RequestCheckTxBatch) ProtoMessage()    {}
+func (*RequestCheckTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{5}
+}
+func (m *RequestCheckTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestCheckTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestCheckTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestCheckTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestCheckTxBatch.Merge(m, src)
+}
+func (m *RequestCheckTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestCheckTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestCheckTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestCheckTxBatch proto.InternalMessageInfo
+
+func (m *RequestCheckTxBatch) GetTxs() []*types.RequestCheckTx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type RequestAbortBlock struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestAbortBlock) Reset()         { *m = RequestAbortBlock{} }
+func (m *RequestAbortBlock) String() string { return proto.CompactTextString(m) }
+func (*RequestAbortBlock) ProtoMessage()    {}
+func (*RequestAbortBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{6}
+}
+func (m *RequestAbortBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestAbortBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestAbortBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestAbortBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestAbortBlock.Merge(m, src)
+}
+func (m *RequestAbortBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestAbortBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestAbortBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestAbortBlock proto.InternalMessageInfo
+
+func (m *RequestAbortBlock) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// RequestTxAccessSets asks the app for the keys of its state the txs of a
+// block read and write, so the txs may be delivered in batches.
+type RequestTxAccessSets struct {
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestTxAccessSets) Reset()         { *m = RequestTxAccessSets{} }
+func (m *RequestTxAccessSets) String() string { return proto.CompactTextString(m) }
+func (*RequestTxAccessSets) ProtoMessage()    {}
+func (*RequestTxAccessSets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{7}
+}
+func (m *RequestTxAccessSets) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestTxAccessSets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestTxAccessSets.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestTxAccessSets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestTxAccessSets.Merge(m, src)
+}
+func (m *RequestTxAccessSets) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestTxAccessSets) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestTxAccessSets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestTxAccessSets proto.InternalMessageInfo
+
+func (m *RequestTxAccessSets) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -495,6 +725,10 @@ type Response struct {
 	//	*Response_ApplySnapshotChunk
 	//	*Response_BeginRecheckTx
 	//	*Response_EndRecheckTx
+	//	*Response_DeliverTxBatch
+	//	*Response_CheckTxBatch
+	//	*Response_AbortBlock
+	//	*Response_TxAccessSets
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -502,7 +736,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{4}
+	return fileDescriptor_addf585b2317eb36, []int{8}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -568,7 +802,7 @@ type Response_DeliverTx struct {
 	DeliverTx *types.ResponseDeliverTx `protobuf:"bytes,10,opt,name=deliver_tx,json=deliverTx,proto3,oneof" json:"deliver_tx,omitempty"`
 }
 type Response_EndBlock struct {
-	EndBlock *types.ResponseEndBlock `protobuf:"bytes,11,opt,name=end_block,json=endBlock,proto3,oneof" json:"end_block,omitempty"`
+	EndBlock *ResponseEndBlock `protobuf:"bytes,11,opt,name=end_block,json=endBlock,proto3,oneof" json:"end_block,omitempty"`
 }
 type Response_Commit struct {
 	Commit *types.ResponseCommit `protobuf:"bytes,12,opt,name=commit,proto3,oneof" json:"commit,omitempty"`
@@ -591,6 +825,18 @@ type Response_BeginRecheckTx struct {
 type Response_EndRecheckTx struct {
 	EndRecheckTx *ResponseEndRecheckTx `protobuf:"bytes,1001,opt,name=end_recheck_tx,json=endRecheckTx,proto3,oneof" json:"end_recheck_tx,omitempty"`
 }
+type Response_DeliverTxBatch struct {
+	DeliverTxBatch *ResponseDeliverTxBatch `protobuf:"bytes,1002,opt,name=deliver_tx_batch,json=deliverTxBatch,proto3,oneof" json:"deliver_tx_batch,omitempty"`
+}
+type Response_CheckTxBatch struct {
+	CheckTxBatch *ResponseCheckTxBatch `protobuf:"bytes,1003,opt,name=check_tx_batch,json=checkTxBatch,proto3,oneof" json:"check_tx_batch,omitempty"`
+}
+type Response_AbortBlock struct {
+	AbortBlock *ResponseAbortBlock `protobuf:"bytes,1004,opt,name=abort_block,json=abortBlock,proto3,oneof" json:"abort_block,omitempty"`
+}
+type Response_TxAccessSets struct {
+	TxAccessSets *ResponseTxAccessSets `protobuf:"bytes,1005,opt,name=tx_access_sets,json=txAccessSets,proto3,oneof" json:"tx_access_sets,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -610,6 +856,10 @@ func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_BeginRecheckTx) isResponse_Value()     {}
 func (*Response_EndRecheckTx) isResponse_Value()       {}
+func (*Response_DeliverTxBatch) isResponse_Value()     {}
+func (*Response_CheckTxBatch) isResponse_Value()       {}
+func (*Response_AbortBlock) isResponse_Value()         {}
+func (*Response_TxAccessSets) isResponse_Value()       {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -688,7 +938,7 @@ func (m *Response) GetDeliverTx() *types.ResponseDeliverTx {
 	return nil
 }
 
-func (m *Response) GetEndBlock() *types.ResponseEndBlock {
+func (m *Response) GetEndBlock() *ResponseEndBlock {
 	if x, ok := m.GetValue().(*Response_EndBlock); ok {
 		return x.EndBlock
 	}
@@ -744,6 +994,33 @@ func (m *Response) GetEndRecheckTx() *ResponseEndRecheckTx {
 	return nil
 }
 
+func (m *Response) GetDeliverTxBatch() *ResponseDeliverTxBatch {
+	if x, ok := m.GetValue().(*Response_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
+func (m *Response) GetCheckTxBatch() *ResponseCheckTxBatch {
+	if x, ok := m.GetValue().(*Response_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+func (m *Response) GetAbortBlock() *ResponseAbortBlock {
+	if x, ok := m.GetValue().(*Response_AbortBlock); ok {
+		return x.AbortBlock
+	}
+	return nil
+}
+
+func (m *Response) GetTxAccessSets() *ResponseTxAccessSets {
+	if x, ok := m.GetValue().(*Response_TxAccessSets); ok {
+		return x.TxAccessSets
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -765,6 +1042,10 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_BeginRecheckTx)(nil),
 		(*Response_EndRecheckTx)(nil),
+		(*Response_DeliverTxBatch)(nil),
+		(*Response_CheckTxBatch)(nil),
+		(*Response_AbortBlock)(nil),
+		(*Response_TxAccessSets)(nil),
 	}
 }
 
@@ -782,13 +1063,17 @@ type ResponseCheckTx struct {
 	// mempool_error is set by Ostracon.
 	// ABCI applictions creating a ResponseCheckTX should not set mempool_error.
 	MempoolError string `protobuf:"bytes,11,opt,name=mempool_error,json=mempoolError,proto3" json:"mempool_error,omitempty"`
+	// read_keys and write_keys are the keys of the app state the tx reads and
+	// writes. They're optional; a tx without them conflicts with every other tx.
+	ReadKeys  [][]byte `protobuf:"bytes,12,rep,name=read_keys,json=readKeys,proto3" json:"read_keys,omitempty"`
+	WriteKeys [][]byte `protobuf:"bytes,13,rep,name=write_keys,json=writeKeys,proto3" json:"write_keys,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{5}
+	return fileDescriptor_addf585b2317eb36, []int{9}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -894,6 +1179,146 @@ func (m *ResponseCheckTx) GetMempoolError() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetReadKeys() [][]byte {
+	if m != nil {
+		return m.ReadKeys
+	}
+	return nil
+}
+
+func (m *ResponseCheckTx) GetWriteKeys() [][]byte {
+	if m != nil {
+		return m.WriteKeys
+	}
+	return nil
+}
+
+// ResponseEndBlock extends the ResponseEndBlock of Tendermint with the
+// validator key rotations.
+type ResponseEndBlock struct {
+	ValidatorUpdates      []types.ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *types.ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+	Events                []types.Event           `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// validator_key_rotations replace the public keys of validators, which keep
+	// their address, voting power and proposer priority.
+	ValidatorKeyRotations []ValidatorKeyRotation `protobuf:"bytes,1000,rep,name=validator_key_rotations,json=validatorKeyRotations,proto3" json:"validator_key_rotations,omitempty"`
+}
+
+func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
+func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseEndBlock) ProtoMessage()    {}
+func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{10}
+}
+func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseEndBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseEndBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseEndBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseEndBlock.Merge(m, src)
+}
+func (m *ResponseEndBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseEndBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseEndBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseEndBlock proto.InternalMessageInfo
+
+func (m *ResponseEndBlock) GetValidatorUpdates() []types.ValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetConsensusParamUpdates() *types.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetEvents() []types.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetValidatorKeyRotations() []ValidatorKeyRotation {
+	if m != nil {
+		return m.ValidatorKeyRotations
+	}
+	return nil
+}
+
+// ValidatorKeyRotation replaces the public key old_pub_key of a validator with
+// new_pub_key.
+type ValidatorKeyRotation struct {
+	OldPubKey crypto.PublicKey `protobuf:"bytes,1,opt,name=old_pub_key,json=oldPubKey,proto3" json:"old_pub_key"`
+	NewPubKey crypto.PublicKey `protobuf:"bytes,2,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+}
+
+func (m *ValidatorKeyRotation) Reset()         { *m = ValidatorKeyRotation{} }
+func (m *ValidatorKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*ValidatorKeyRotation) ProtoMessage()    {}
+func (*ValidatorKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{11}
+}
+func (m *ValidatorKeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorKeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorKeyRotation.Merge(m, src)
+}
+func (m *ValidatorKeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorKeyRotation proto.InternalMessageInfo
+
+func (m *ValidatorKeyRotation) GetOldPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.OldPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *ValidatorKeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
 type ResponseBeginRecheckTx struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 }
@@ -902,7 +1327,7 @@ func (m *ResponseBeginRecheckTx) Reset()         { *m = ResponseBeginRecheckTx{}
 func (m *ResponseBeginRecheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginRecheckTx) ProtoMessage()    {}
 func (*ResponseBeginRecheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{6}
+	return fileDescriptor_addf585b2317eb36, []int{12}
 }
 func (m *ResponseBeginRecheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -946,7 +1371,7 @@ func (m *ResponseEndRecheckTx) Reset()         { *m = ResponseEndRecheckTx{} }
 func (m *ResponseEndRecheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndRecheckTx) ProtoMessage()    {}
 func (*ResponseEndRecheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{7}
+	return fileDescriptor_addf585b2317eb36, []int{13}
 }
 func (m *ResponseEndRecheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -982,121 +1407,462 @@ func (m *ResponseEndRecheckTx) GetCode() uint32 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Request)(nil), "ostracon.abci.Request")
-	proto.RegisterType((*RequestBeginBlock)(nil), "ostracon.abci.RequestBeginBlock")
-	proto.RegisterType((*RequestBeginRecheckTx)(nil), "ostracon.abci.RequestBeginRecheckTx")
-	proto.RegisterType((*RequestEndRecheckTx)(nil), "ostracon.abci.RequestEndRecheckTx")
-	proto.RegisterType((*Response)(nil), "ostracon.abci.Response")
-	proto.RegisterType((*ResponseCheckTx)(nil), "ostracon.abci.ResponseCheckTx")
-	proto.RegisterType((*ResponseBeginRecheckTx)(nil), "ostracon.abci.ResponseBeginRecheckTx")
-	proto.RegisterType((*ResponseEndRecheckTx)(nil), "ostracon.abci.ResponseEndRecheckTx")
+type ResponseDeliverTxBatch struct {
+	Responses []*types.ResponseDeliverTx `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 }
 
-func init() { proto.RegisterFile("ostracon/abci/types.proto", fileDescriptor_addf585b2317eb36) }
-
-var fileDescriptor_addf585b2317eb36 = []byte{
-	// 1407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x98, 0x5d, 0x8f, 0xdb, 0x44,
-	0x17, 0xc7, 0xb3, 0x4d, 0x36, 0x59, 0x9f, 0xcd, 0xa6, 0xdb, 0xd3, 0x7d, 0xfa, 0xb8, 0xa6, 0xa4,
-	0x25, 0xa5, 0x50, 0x4a, 0xd9, 0x95, 0xb6, 0xa2, 0x2a, 0x02, 0x09, 0x9a, 0xb0, 0xab, 0x2c, 0x54,
-	0x44, 0x9d, 0x22, 0x90, 0x78, 0x69, 0xe4, 0xd8, 0xb3, 0xb1, 0xa9, 0xe3, 0x49, 0xed, 0xc9, 0xd2,
-	0x70, 0xcf, 0x3d, 0xdf, 0x84, 0x4b, 0xee, 0xb8, 0xee, 0x65, 0x2f, 0xb9, 0x40, 0x15, 0x6a, 0x6f,
-	0x80, 0x4f, 0x81, 0x66, 0xfc, 0x52, 0xe7, 0x65, 0x62, 0xef, 0xdd, 0xcc, 0xf8, 0x9c, 0xff, 0xf8,
-	0xcc, 0x1c, 0x9f, 0x5f, 0x4e, 0xe0, 0x22, 0x0b, 0x79, 0x60, 0x5a, 0xcc, 0xdf, 0x33, 0x07, 0x96,
-	0xbb, 0xc7, 0xa7, 0x63, 0x1a, 0xee, 0x8e, 0x03, 0xc6, 0x19, 0x6e, 0x25, 0x8f, 0x76, 0xc5, 0x23,
-	0xe3, 0x35, 0x4e, 0x7d, 0x9b, 0x06, 0x23, 0xd7, 0xe7, 0x0b, 0xb6, 0xc6, 0xa5, 0xcc, 0x43, 0xb9,
-	0x3e, 0xf3, 0xd4, 0x48, 0x37, 0x59, 0x7c, 0xb6, 0x33, 0x64, 0x43, 0x26, 0x87, 0x7b, 0x62, 0x14,
-	0xad, 0xb6, 0x7e, 0xd5, 0xa0, 0x46, 0xe8, 0xe3, 0x09, 0x0d, 0x39, 0xee, 0x43, 0x85, 0x5a, 0x0e,
-	0xd3, 0xd7, 0xae, 0xac, 0x5d, 0xdf, 0xdc, 0xbf, 0xb4, 0xfb, 0x6a, 0x2b, 0xf9, 0x62, 0xbb, 0xb1,
-	0xdd, 0x81, 0xe5, 0xb0, 0x6e, 0x89, 0x48, 0x5b, 0x7c, 0x1f, 0xd6, 0x8f, 0xbd, 0x49, 0xe8, 0xe8,
-	0x67, 0xa4, 0xd3, 0xeb, 0x2a, 0xa7, 0x43, 0x61, 0xd4, 0x2d, 0x91, 0xc8, 0x5a, 0x6c, 0xe5, 0xfa,
-	0xc7, 0x4c, 0x2f, 0xaf, 0xde, 0xea, 0xc8, 0x3f, 0x96, 0x5b, 0x09, 0x5b, 0x6c, 0x03, 0x84, 0x94,
-	0xf7, 0xd9, 0x98, 0xbb, 0xcc, 0xd7, 0x2b, 0xd2, 0xf3, 0x0d, 0x95, 0xe7, 0x03, 0xca, 0x7b, 0xd2,
-	0xb0, 0x5b, 0x22, 0x5a, 0x98, 0x4c, 0x84, 0x86, 0xeb, 0xbb, 0xbc, 0x6f, 0x39, 0xa6, 0xeb, 0xeb,
-	0xeb, 0xab, 0x35, 0x8e, 0x7c, 0x97, 0x77, 0x84, 0xa1, 0xd0, 0x70, 0x93, 0x89, 0x08, 0xf9, 0xf1,
-	0x84, 0x06, 0x53, 0xbd, 0xba, 0x3a, 0xe4, 0xfb, 0xc2, 0x48, 0x84, 0x2c, 0xad, 0xb1, 0x03, 0x9b,
-	0x03, 0x3a, 0x74, 0xfd, 0xfe, 0xc0, 0x63, 0xd6, 0x23, 0xbd, 0x26, 0x9d, 0xaf, 0xec, 0xce, 0xdc,
-	0x7d, 0xe2, 0xda, 0x16, 0x86, 0x6d, 0x61, 0xd7, 0x2d, 0x11, 0x18, 0xa4, 0x33, 0xfc, 0x08, 0x36,
-	0x2c, 0x87, 0x5a, 0x8f, 0xfa, 0xfc, 0x89, 0xbe, 0x21, 0x15, 0x2e, 0xab, 0xb6, 0xef, 0x08, 0xbb,
-	0x2f, 0x9f, 0x74, 0x4b, 0xa4, 0x66, 0x45, 0x43, 0x11, 0xbd, 0x4d, 0x3d, 0xf7, 0x84, 0x06, 0xc2,
-	0x5f, 0x5b, 0x1d, 0xfd, 0xa7, 0x91, 0xa5, 0x54, 0xd0, 0xec, 0x64, 0x82, 0x1f, 0x83, 0x46, 0x7d,
-	0x3b, 0x0e, 0x02, 0xe2, 0x20, 0x54, 0x99, 0xe2, 0xdb, 0x49, 0x10, 0x1b, 0x34, 0x1e, 0xe3, 0x1d,
-	0xa8, 0x5a, 0x6c, 0x34, 0x72, 0xb9, 0xbe, 0x29, 0xbd, 0x9b, 0xca, 0x00, 0xa4, 0x55, 0xb7, 0x44,
-	0x62, 0x7b, 0xfc, 0x02, 0x1a, 0x9e, 0x1b, 0xf2, 0x7e, 0xe8, 0x9b, 0xe3, 0xd0, 0x61, 0x3c, 0xd4,
-	0xeb, 0x52, 0xe1, 0x9a, 0x4a, 0xe1, 0x9e, 0x1b, 0xf2, 0x07, 0x89, 0x71, 0xb7, 0x44, 0xb6, 0xbc,
-	0xec, 0x82, 0xd0, 0x63, 0xc7, 0xc7, 0x34, 0x48, 0x05, 0xf5, 0xad, 0xd5, 0x7a, 0x3d, 0x61, 0x9d,
-	0xf8, 0x0b, 0x3d, 0x96, 0x5d, 0xc0, 0x6f, 0xe1, 0xbc, 0xc7, 0x4c, 0x3b, 0x95, 0xeb, 0x5b, 0xce,
-	0xc4, 0x7f, 0xa4, 0x37, 0xa4, 0xe8, 0x3b, 0xca, 0x97, 0x64, 0xa6, 0x9d, 0x48, 0x74, 0x84, 0x43,
-	0xb7, 0x44, 0xce, 0x79, 0xf3, 0x8b, 0xf8, 0x10, 0x76, 0xcc, 0xf1, 0xd8, 0x9b, 0xce, 0xab, 0x9f,
-	0x95, 0xea, 0x37, 0x54, 0xea, 0x77, 0x85, 0xcf, 0xbc, 0x3c, 0x9a, 0x0b, 0xab, 0x78, 0x1f, 0xb6,
-	0xa3, 0xf4, 0x0c, 0x68, 0x9a, 0x61, 0x7f, 0x47, 0x49, 0xfa, 0xe6, 0x8a, 0x24, 0x25, 0xd4, 0x4a,
-	0xf3, 0xac, 0x31, 0x98, 0x59, 0xc1, 0xcf, 0xa1, 0x21, 0x52, 0x25, 0x23, 0xf8, 0x4f, 0x24, 0xd8,
-	0x5a, 0x2e, 0x78, 0xe0, 0xdb, 0x59, 0xb9, 0x3a, 0xcd, 0xcc, 0xdb, 0x35, 0x58, 0x3f, 0x31, 0xbd,
-	0x09, 0x6d, 0xfd, 0x7e, 0x06, 0xce, 0x2d, 0x7c, 0x26, 0x88, 0x50, 0x71, 0xcc, 0xd0, 0x91, 0xb5,
-	0xab, 0x4e, 0xe4, 0x18, 0x6f, 0x43, 0xd5, 0xa1, 0xa6, 0x4d, 0x83, 0xb8, 0x38, 0xe9, 0xd9, 0x43,
-	0x8a, 0x4a, 0x63, 0x57, 0x3e, 0x6f, 0x57, 0x9e, 0x3e, 0xbf, 0x5c, 0x22, 0xb1, 0x35, 0xf6, 0x60,
-	0xdb, 0x33, 0x43, 0xde, 0x8f, 0xd2, 0xae, 0x9f, 0x29, 0x54, 0x8b, 0x1f, 0xdb, 0x3d, 0x33, 0x49,
-	0x54, 0x51, 0xab, 0x62, 0xa1, 0x86, 0x37, 0xb3, 0x8a, 0x04, 0x76, 0x06, 0xd3, 0x9f, 0x4c, 0x9f,
-	0xbb, 0x3e, 0xed, 0x9f, 0x98, 0x9e, 0x6b, 0x9b, 0x9c, 0x05, 0xa1, 0x5e, 0xb9, 0x52, 0xbe, 0xbe,
-	0xb9, 0x7f, 0x71, 0x41, 0xf4, 0xe0, 0xc4, 0xb5, 0xa9, 0x6f, 0xd1, 0x58, 0xee, 0x7c, 0xea, 0xfc,
-	0x55, 0xea, 0x8b, 0x77, 0xa0, 0x46, 0x7d, 0x1e, 0xb0, 0xf1, 0x34, 0xb9, 0xa6, 0xff, 0xbf, 0x3a,
-	0xd5, 0x28, 0xb8, 0x83, 0xe8, 0x79, 0xac, 0x92, 0x98, 0xb7, 0x7a, 0xf0, 0xbf, 0xa5, 0x37, 0x98,
-	0x39, 0xaf, 0xb5, 0xd3, 0x9c, 0x57, 0xeb, 0x3d, 0x38, 0xbf, 0xe4, 0x06, 0xf1, 0x82, 0x90, 0x73,
-	0x87, 0x0e, 0x97, 0x72, 0x65, 0x12, 0xcf, 0x5a, 0x3f, 0x03, 0x6c, 0x10, 0x1a, 0x8e, 0x99, 0x1f,
-	0x52, 0x6c, 0x83, 0x46, 0x9f, 0x58, 0x34, 0xaa, 0xe9, 0x6b, 0x71, 0x76, 0x2c, 0xe6, 0x72, 0x64,
-	0x7d, 0x90, 0x58, 0x8a, 0x92, 0x94, 0xba, 0xe1, 0xad, 0x98, 0x5b, 0x6a, 0x04, 0xc5, 0xee, 0x59,
-	0x70, 0xdd, 0x4e, 0xc0, 0x55, 0x56, 0x56, 0xa1, 0xc8, 0x6b, 0x8e, 0x5c, 0xb7, 0x62, 0x72, 0x55,
-	0x72, 0x36, 0x9b, 0x41, 0x57, 0x67, 0x06, 0x5d, 0xeb, 0x39, 0x61, 0x2a, 0xd8, 0xd5, 0x99, 0x61,
-	0x57, 0x35, 0x47, 0x44, 0x01, 0xaf, 0xdb, 0x09, 0xbc, 0x6a, 0x39, 0x61, 0xcf, 0xd1, 0xeb, 0x70,
-	0x96, 0x5e, 0x11, 0x7b, 0xae, 0x2a, 0xbd, 0x95, 0x00, 0xfb, 0x30, 0x03, 0x30, 0x2d, 0x7e, 0x85,
-	0xf9, 0x62, 0x10, 0x49, 0x2c, 0xe1, 0x57, 0x67, 0x86, 0x5f, 0x90, 0x73, 0x02, 0x0a, 0x80, 0x7d,
-	0x92, 0x05, 0xd8, 0xa6, 0x92, 0x81, 0x71, 0xca, 0x2c, 0x23, 0xd8, 0x07, 0x29, 0xc1, 0xea, 0x4a,
-	0x04, 0xc7, 0x31, 0xcc, 0x23, 0xac, 0xb7, 0x80, 0xb0, 0x08, 0x39, 0x6f, 0x29, 0x25, 0x72, 0x18,
-	0xd6, 0x5b, 0x60, 0x58, 0x23, 0x47, 0x30, 0x07, 0x62, 0xdf, 0x2d, 0x87, 0x98, 0x1a, 0x33, 0xf1,
-	0x6b, 0x16, 0xa3, 0x58, 0x5f, 0x41, 0xb1, 0x6d, 0x29, 0xff, 0xae, 0x52, 0xbe, 0x30, 0xc6, 0x88,
-	0x1a, 0x63, 0xd7, 0x14, 0x89, 0x96, 0xcb, 0xb1, 0x7b, 0x2a, 0x8e, 0x5d, 0x55, 0x28, 0x16, 0x03,
-	0xd9, 0x9f, 0x67, 0xe0, 0xec, 0x5c, 0xb2, 0x0b, 0x8c, 0x59, 0xcc, 0xa6, 0xb2, 0x12, 0x6e, 0x11,
-	0x39, 0x16, 0x6b, 0xb6, 0xc9, 0x4d, 0x59, 0xde, 0xea, 0x44, 0x8e, 0x71, 0x1b, 0xca, 0x1e, 0x1b,
-	0xca, 0xda, 0xa5, 0x11, 0x31, 0x14, 0x56, 0x69, 0x5d, 0xd2, 0xe2, 0xb2, 0xd3, 0x04, 0x18, 0x9a,
-	0x61, 0xff, 0x47, 0xd3, 0xe7, 0xd4, 0x96, 0x65, 0xa7, 0x4c, 0x32, 0x2b, 0x68, 0xc0, 0x86, 0x98,
-	0x4d, 0x42, 0x6a, 0xcb, 0x7a, 0x52, 0x26, 0xe9, 0x1c, 0xbb, 0x50, 0xa5, 0x27, 0xd4, 0xe7, 0xa1,
-	0x5e, 0x93, 0x94, 0xba, 0xb0, 0x84, 0x52, 0xd4, 0xe7, 0x6d, 0x5d, 0xa0, 0xe0, 0xdf, 0xe7, 0x97,
-	0xb7, 0x23, 0xeb, 0x9b, 0x6c, 0xe4, 0x72, 0x3a, 0x1a, 0xf3, 0x29, 0x89, 0xfd, 0xf1, 0x12, 0x68,
-	0x22, 0x8e, 0x70, 0x6c, 0x5a, 0x54, 0x16, 0x0e, 0x8d, 0xbc, 0x5a, 0x10, 0x94, 0x08, 0xa5, 0xb0,
-	0x2c, 0x07, 0x1a, 0x89, 0x67, 0xe2, 0xdd, 0xc6, 0x81, 0xcb, 0x02, 0x97, 0x4f, 0xe5, 0x97, 0x5e,
-	0x26, 0xe9, 0x1c, 0xaf, 0xc2, 0xd6, 0x88, 0x8e, 0xc6, 0x8c, 0x79, 0x7d, 0x1a, 0x04, 0x2c, 0x90,
-	0x9f, 0xb1, 0x46, 0xea, 0xf1, 0xe2, 0x81, 0x58, 0x6b, 0xdd, 0x84, 0x0b, 0xcb, 0x6f, 0x78, 0xd9,
-	0x21, 0xb7, 0x6e, 0xc0, 0xce, 0xb2, 0xdb, 0x5b, 0x66, 0xbb, 0xff, 0xdb, 0x26, 0x9c, 0xbd, 0xdb,
-	0xee, 0x1c, 0x89, 0xa4, 0x74, 0x2d, 0x33, 0x2e, 0xce, 0x15, 0x81, 0x17, 0x5c, 0xd9, 0x35, 0x19,
-	0xab, 0xd9, 0x84, 0x87, 0xb0, 0x2e, 0x69, 0x83, 0xab, 0xdb, 0x28, 0x23, 0x07, 0x56, 0xe2, 0x65,
-	0xe4, 0xef, 0x8e, 0x95, 0x7d, 0x95, 0xb1, 0x9a, 0x5d, 0x48, 0x40, 0x4b, 0x41, 0x84, 0xf9, 0x7d,
-	0x96, 0x51, 0x80, 0x67, 0x42, 0x33, 0xad, 0xca, 0x98, 0xdf, 0x79, 0x18, 0x05, 0x8a, 0x3b, 0x7e,
-	0x06, 0xb5, 0xe4, 0xeb, 0xc9, 0xeb, 0x85, 0x8c, 0x1c, 0xd6, 0x88, 0x0b, 0x90, 0xdc, 0xc3, 0xd5,
-	0x4d, 0x9d, 0x91, 0x83, 0x4d, 0x3c, 0x82, 0x6a, 0x54, 0xfa, 0x31, 0xa7, 0xbb, 0x31, 0xf2, 0xd8,
-	0x21, 0x8e, 0x2c, 0x45, 0x39, 0xe6, 0xb7, 0xaa, 0x46, 0x81, 0x5f, 0x04, 0xf8, 0x00, 0x20, 0xf3,
-	0xd3, 0x39, 0xb7, 0x07, 0x35, 0x8a, 0x70, 0x1e, 0x7b, 0xb0, 0x91, 0xd0, 0x12, 0x73, 0x3b, 0x42,
-	0x23, 0x1f, 0xb9, 0xf8, 0x10, 0xb6, 0x66, 0xe0, 0x87, 0xc5, 0xfa, 0x3c, 0xa3, 0x20, 0x4b, 0x85,
-	0xfe, 0x0c, 0x0b, 0xb1, 0x58, 0xdf, 0x67, 0x14, 0x44, 0x2b, 0xfe, 0x00, 0xe7, 0x16, 0xa8, 0x88,
-	0xc5, 0xdb, 0x40, 0xe3, 0x14, 0xb0, 0xc5, 0x11, 0xe0, 0x22, 0x22, 0xf1, 0x14, 0x5d, 0xa1, 0x71,
-	0x1a, 0xf6, 0xe2, 0xf7, 0xd0, 0x98, 0xab, 0xa9, 0x85, 0x7a, 0x44, 0xa3, 0x18, 0x82, 0xf1, 0x6b,
-	0xa8, 0xcf, 0x14, 0xe1, 0x02, 0xfd, 0xa2, 0x51, 0x84, 0xc5, 0xed, 0xbb, 0x4f, 0x5f, 0x34, 0xd7,
-	0x9e, 0xbd, 0x68, 0xae, 0xfd, 0xf5, 0xa2, 0xb9, 0xf6, 0xcb, 0xcb, 0x66, 0xe9, 0xd9, 0xcb, 0x66,
-	0xe9, 0x8f, 0x97, 0xcd, 0xd2, 0x37, 0x6f, 0x0f, 0x5d, 0xee, 0x4c, 0x06, 0xbb, 0x16, 0x1b, 0xed,
-	0x1d, 0xba, 0x7e, 0x68, 0x39, 0xae, 0xb9, 0xb7, 0xe4, 0x2f, 0xbb, 0x41, 0x55, 0xfe, 0x6f, 0x76,
-	0xeb, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x28, 0x00, 0x44, 0x1c, 0xd0, 0x13, 0x00, 0x00,
+func (m *ResponseDeliverTxBatch) Reset()         { *m = ResponseDeliverTxBatch{} }
+func (m *ResponseDeliverTxBatch) String() string { return proto.CompactTextString(m) }
+func (*ResponseDeliverTxBatch) ProtoMessage()    {}
+func (*ResponseDeliverTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{14}
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ABCIApplicationClient is the client API for ABCIApplication service.
-//
+func (m *ResponseDeliverTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseDeliverTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseDeliverTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseDeliverTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseDeliverTxBatch.Merge(m, src)
+}
+func (m *ResponseDeliverTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseDeliverTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseDeliverTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseDeliverTxBatch proto.InternalMessageInfo
+
+func (m *ResponseDeliverTxBatch) GetResponses() []*types.ResponseDeliverTx {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+type ResponseCheckTxBatch struct {
+	Responses []*ResponseCheckTx `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (m *ResponseCheckTxBatch) Reset()         { *m = ResponseCheckTxBatch{} }
+func (m *ResponseCheckTxBatch) String() string { return proto.CompactTextString(m) }
+func (*ResponseCheckTxBatch) ProtoMessage()    {}
+func (*ResponseCheckTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{15}
+}
+func (m *ResponseCheckTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseCheckTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseCheckTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseCheckTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseCheckTxBatch.Merge(m, src)
+}
+func (m *ResponseCheckTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseCheckTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseCheckTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseCheckTxBatch proto.InternalMessageInfo
+
+func (m *ResponseCheckTxBatch) GetResponses() []*ResponseCheckTx {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+type ResponseAbortBlock struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *ResponseAbortBlock) Reset()         { *m = ResponseAbortBlock{} }
+func (m *ResponseAbortBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseAbortBlock) ProtoMessage()    {}
+func (*ResponseAbortBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{16}
+}
+func (m *ResponseAbortBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseAbortBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseAbortBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseAbortBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseAbortBlock.Merge(m, src)
+}
+func (m *ResponseAbortBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseAbortBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseAbortBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseAbortBlock proto.InternalMessageInfo
+
+func (m *ResponseAbortBlock) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+// ResponseTxAccessSets has the access sets of the txs of a
+// RequestTxAccessSets, in the same order.
+type ResponseTxAccessSets struct {
+	AccessSets []TxAccessSet `protobuf:"bytes,1,rep,name=access_sets,json=accessSets,proto3" json:"access_sets"`
+}
+
+func (m *ResponseTxAccessSets) Reset()         { *m = ResponseTxAccessSets{} }
+func (m *ResponseTxAccessSets) String() string { return proto.CompactTextString(m) }
+func (*ResponseTxAccessSets) ProtoMessage()    {}
+func (*ResponseTxAccessSets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{17}
+}
+func (m *ResponseTxAccessSets) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseTxAccessSets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseTxAccessSets.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseTxAccessSets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseTxAccessSets.Merge(m, src)
+}
+func (m *ResponseTxAccessSets) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseTxAccessSets) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseTxAccessSets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseTxAccessSets proto.InternalMessageInfo
+
+func (m *ResponseTxAccessSets) GetAccessSets() []TxAccessSet {
+	if m != nil {
+		return m.AccessSets
+	}
+	return nil
+}
+
+// TxAccessSet has the keys of the app state a tx reads and writes. A tx
+// without keys conflicts with every other tx.
+type TxAccessSet struct {
+	ReadKeys  [][]byte `protobuf:"bytes,1,rep,name=read_keys,json=readKeys,proto3" json:"read_keys,omitempty"`
+	WriteKeys [][]byte `protobuf:"bytes,2,rep,name=write_keys,json=writeKeys,proto3" json:"write_keys,omitempty"`
+}
+
+func (m *TxAccessSet) Reset()         { *m = TxAccessSet{} }
+func (m *TxAccessSet) String() string { return proto.CompactTextString(m) }
+func (*TxAccessSet) ProtoMessage()    {}
+func (*TxAccessSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{18}
+}
+func (m *TxAccessSet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxAccessSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxAccessSet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxAccessSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxAccessSet.Merge(m, src)
+}
+func (m *TxAccessSet) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxAccessSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxAccessSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxAccessSet proto.InternalMessageInfo
+
+func (m *TxAccessSet) GetReadKeys() [][]byte {
+	if m != nil {
+		return m.ReadKeys
+	}
+	return nil
+}
+
+func (m *TxAccessSet) GetWriteKeys() [][]byte {
+	if m != nil {
+		return m.WriteKeys
+	}
+	return nil
+}
+
+type RecordedExchange struct {
+	Connection string    `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	Request    *Request  `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Response   *Response `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (m *RecordedExchange) Reset()         { *m = RecordedExchange{} }
+func (m *RecordedExchange) String() string { return proto.CompactTextString(m) }
+func (*RecordedExchange) ProtoMessage()    {}
+func (*RecordedExchange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{19}
+}
+func (m *RecordedExchange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordedExchange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordedExchange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecordedExchange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordedExchange.Merge(m, src)
+}
+func (m *RecordedExchange) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordedExchange) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordedExchange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordedExchange proto.InternalMessageInfo
+
+func (m *RecordedExchange) GetConnection() string {
+	if m != nil {
+		return m.Connection
+	}
+	return ""
+}
+
+func (m *RecordedExchange) GetRequest() *Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *RecordedExchange) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Request)(nil), "ostracon.abci.Request")
+	proto.RegisterType((*RequestBeginBlock)(nil), "ostracon.abci.RequestBeginBlock")
+	proto.RegisterType((*RequestBeginRecheckTx)(nil), "ostracon.abci.RequestBeginRecheckTx")
+	proto.RegisterType((*RequestEndRecheckTx)(nil), "ostracon.abci.RequestEndRecheckTx")
+	proto.RegisterType((*RequestDeliverTxBatch)(nil), "ostracon.abci.RequestDeliverTxBatch")
+	proto.RegisterType((*RequestCheckTxBatch)(nil), "ostracon.abci.RequestCheckTxBatch")
+	proto.RegisterType((*RequestAbortBlock)(nil), "ostracon.abci.RequestAbortBlock")
+	proto.RegisterType((*RequestTxAccessSets)(nil), "ostracon.abci.RequestTxAccessSets")
+	proto.RegisterType((*Response)(nil), "ostracon.abci.Response")
+	proto.RegisterType((*ResponseCheckTx)(nil), "ostracon.abci.ResponseCheckTx")
+	proto.RegisterType((*ResponseEndBlock)(nil), "ostracon.abci.ResponseEndBlock")
+	proto.RegisterType((*ValidatorKeyRotation)(nil), "ostracon.abci.ValidatorKeyRotation")
+	proto.RegisterType((*ResponseBeginRecheckTx)(nil), "ostracon.abci.ResponseBeginRecheckTx")
+	proto.RegisterType((*ResponseEndRecheckTx)(nil), "ostracon.abci.ResponseEndRecheckTx")
+	proto.RegisterType((*ResponseDeliverTxBatch)(nil), "ostracon.abci.ResponseDeliverTxBatch")
+	proto.RegisterType((*ResponseCheckTxBatch)(nil), "ostracon.abci.ResponseCheckTxBatch")
+	proto.RegisterType((*ResponseAbortBlock)(nil), "ostracon.abci.ResponseAbortBlock")
+	proto.RegisterType((*ResponseTxAccessSets)(nil), "ostracon.abci.ResponseTxAccessSets")
+	proto.RegisterType((*TxAccessSet)(nil), "ostracon.abci.TxAccessSet")
+	proto.RegisterType((*RecordedExchange)(nil), "ostracon.abci.RecordedExchange")
+}
+
+func init() { proto.RegisterFile("ostracon/abci/types.proto", fileDescriptor_addf585b2317eb36) }
+
+var fileDescriptor_addf585b2317eb36 = []byte{
+	// 1997 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0x5f, 0x73, 0x23, 0x47,
+	0x11, 0x97, 0x2c, 0xdb, 0xb2, 0x5a, 0xb2, 0xcf, 0x1e, 0xfb, 0x7c, 0x9b, 0xcd, 0xc5, 0x77, 0xd1,
+	0x11, 0x72, 0x24, 0xc1, 0x86, 0x73, 0x71, 0x15, 0x8a, 0x14, 0x60, 0x29, 0xbe, 0x92, 0xb1, 0xc1,
+	0x77, 0xe3, 0xe3, 0x5f, 0x80, 0x6c, 0x8d, 0x76, 0xc7, 0xd6, 0x62, 0x69, 0x47, 0xd9, 0x19, 0xf9,
+	0x2c, 0x3e, 0x05, 0x8f, 0xe4, 0x85, 0x0f, 0xc2, 0x03, 0xcf, 0x79, 0xcc, 0x23, 0x4f, 0x29, 0xea,
+	0xee, 0x05, 0x02, 0x14, 0x5f, 0x81, 0x9a, 0xd9, 0x3f, 0xde, 0x95, 0x76, 0x76, 0xf7, 0x8a, 0xb7,
+	0x9d, 0x99, 0xee, 0xdf, 0x76, 0xaf, 0x7a, 0xba, 0x7f, 0xdd, 0x82, 0x37, 0x18, 0x17, 0x3e, 0xb1,
+	0x99, 0xb7, 0x47, 0xfa, 0xb6, 0xbb, 0x27, 0xa6, 0x63, 0xca, 0x77, 0xc7, 0x3e, 0x13, 0x0c, 0xad,
+	0x46, 0x47, 0xbb, 0xf2, 0xc8, 0x7c, 0x53, 0x50, 0xcf, 0xa1, 0xfe, 0xc8, 0xf5, 0xc4, 0x9c, 0xac,
+	0x79, 0x37, 0x71, 0xa8, 0xf6, 0xb5, 0xa7, 0xb6, 0x3f, 0x1d, 0x0b, 0xb6, 0x77, 0x49, 0xa7, 0xd1,
+	0xa9, 0x19, 0x9b, 0x30, 0xaf, 0xb9, 0x75, 0xc1, 0x2e, 0x98, 0x7a, 0xdc, 0x93, 0x4f, 0xc1, 0x6e,
+	0xfb, 0x65, 0x13, 0xea, 0x98, 0x7e, 0x36, 0xa1, 0x5c, 0xa0, 0x47, 0xb0, 0x48, 0xed, 0x01, 0x33,
+	0xaa, 0xf7, 0xab, 0x0f, 0x9b, 0x8f, 0xee, 0xee, 0xde, 0xbc, 0x4a, 0x99, 0xbd, 0x1b, 0xca, 0x1d,
+	0xda, 0x03, 0xd6, 0xab, 0x60, 0x25, 0x8b, 0xbe, 0x07, 0x4b, 0xe7, 0xc3, 0x09, 0x1f, 0x18, 0x0b,
+	0x4a, 0xe9, 0x2d, 0x9d, 0xd2, 0x13, 0x29, 0xd4, 0xab, 0xe0, 0x40, 0x5a, 0xbe, 0xca, 0xf5, 0xce,
+	0x99, 0x51, 0xcb, 0x7f, 0xd5, 0x91, 0x77, 0xae, 0x5e, 0x25, 0x65, 0x51, 0x07, 0x80, 0x53, 0x61,
+	0xb1, 0xb1, 0x70, 0x99, 0x67, 0x2c, 0x2a, 0xcd, 0xb7, 0x75, 0x9a, 0x67, 0x54, 0x9c, 0x2a, 0xc1,
+	0x5e, 0x05, 0x37, 0x78, 0xb4, 0x90, 0x18, 0xae, 0xe7, 0x0a, 0xcb, 0x1e, 0x10, 0xd7, 0x33, 0x96,
+	0xf2, 0x31, 0x8e, 0x3c, 0x57, 0x74, 0xa5, 0xa0, 0xc4, 0x70, 0xa3, 0x85, 0x74, 0xf9, 0xb3, 0x09,
+	0xf5, 0xa7, 0xc6, 0x72, 0xbe, 0xcb, 0xcf, 0xa4, 0x90, 0x74, 0x59, 0x49, 0xa3, 0x2e, 0x34, 0xfb,
+	0xf4, 0xc2, 0xf5, 0xac, 0xfe, 0x90, 0xd9, 0x97, 0x46, 0x5d, 0x29, 0xdf, 0xdf, 0x4d, 0x45, 0x46,
+	0xa4, 0xda, 0x91, 0x82, 0x1d, 0x29, 0xd7, 0xab, 0x60, 0xe8, 0xc7, 0x2b, 0xf4, 0x11, 0xac, 0xd8,
+	0x03, 0x6a, 0x5f, 0x5a, 0xe2, 0xda, 0x58, 0x51, 0x08, 0xf7, 0x74, 0xaf, 0xef, 0x4a, 0xb9, 0xe7,
+	0xd7, 0xbd, 0x0a, 0xae, 0xdb, 0xc1, 0xa3, 0xf4, 0xde, 0xa1, 0x43, 0xf7, 0x8a, 0xfa, 0x52, 0xbf,
+	0x91, 0xef, 0xfd, 0xc7, 0x81, 0xa4, 0x42, 0x68, 0x38, 0xd1, 0x02, 0xfd, 0x08, 0x1a, 0xd4, 0x73,
+	0x42, 0x27, 0x20, 0x74, 0x42, 0x17, 0x29, 0x9e, 0x13, 0x39, 0xb1, 0x42, 0xc3, 0x67, 0xf4, 0x21,
+	0x2c, 0xdb, 0x6c, 0x34, 0x72, 0x85, 0xd1, 0x54, 0xda, 0x3b, 0x5a, 0x07, 0x94, 0x54, 0xaf, 0x82,
+	0x43, 0x79, 0xf4, 0x33, 0x58, 0x1b, 0xba, 0x5c, 0x58, 0xdc, 0x23, 0x63, 0x3e, 0x60, 0x82, 0x1b,
+	0x2d, 0x85, 0xf0, 0x8e, 0x0e, 0xe1, 0xc4, 0xe5, 0xe2, 0x2c, 0x12, 0xee, 0x55, 0xf0, 0xea, 0x30,
+	0xb9, 0x21, 0xf1, 0xd8, 0xf9, 0x39, 0xf5, 0x63, 0x40, 0x63, 0x35, 0x1f, 0xef, 0x54, 0x4a, 0x47,
+	0xfa, 0x12, 0x8f, 0x25, 0x37, 0xd0, 0x6f, 0x60, 0x73, 0xc8, 0x88, 0x13, 0xc3, 0x59, 0xf6, 0x60,
+	0xe2, 0x5d, 0x1a, 0x6b, 0x0a, 0xf4, 0x5b, 0x5a, 0x23, 0x19, 0x71, 0x22, 0x88, 0xae, 0x54, 0xe8,
+	0x55, 0xf0, 0xc6, 0x70, 0x76, 0x13, 0x7d, 0x0a, 0x5b, 0x64, 0x3c, 0x1e, 0x4e, 0x67, 0xd1, 0x6f,
+	0x29, 0xf4, 0xf7, 0x74, 0xe8, 0x07, 0x52, 0x67, 0x16, 0x1e, 0x91, 0xb9, 0x5d, 0xf4, 0x0c, 0xd6,
+	0x83, 0xf0, 0xf4, 0x69, 0x1c, 0x61, 0xff, 0x08, 0x82, 0xf4, 0x1b, 0x39, 0x41, 0x8a, 0xa9, 0x1d,
+	0xc7, 0xd9, 0x5a, 0x3f, 0xb5, 0x83, 0x8e, 0x61, 0x4d, 0x86, 0x4a, 0x02, 0xf0, 0x9f, 0x01, 0x60,
+	0x3b, 0x1b, 0xf0, 0xd0, 0x73, 0x92, 0x70, 0x2d, 0x9a, 0x58, 0x4b, 0xfb, 0x6e, 0x62, 0xd7, 0xea,
+	0x13, 0x61, 0x0f, 0x8c, 0xaf, 0x73, 0xed, 0x8b, 0x03, 0xb8, 0x23, 0x85, 0xa5, 0x7d, 0x4e, 0x6a,
+	0x47, 0xda, 0x17, 0x59, 0x16, 0x02, 0xfe, 0x2b, 0xd7, 0xbe, 0xf0, 0x46, 0x45, 0x70, 0x2d, 0x3b,
+	0xb1, 0x46, 0x1f, 0x43, 0x93, 0xf4, 0x99, 0x2f, 0xc2, 0x9b, 0xf1, 0xef, 0xdc, 0xfb, 0x7d, 0x20,
+	0x25, 0xe3, 0xfb, 0x4d, 0xe2, 0x95, 0x34, 0x49, 0x5c, 0x5b, 0xc4, 0xb6, 0x29, 0xe7, 0x16, 0xa7,
+	0x82, 0x1b, 0xff, 0xc9, 0x35, 0xe9, 0xf9, 0xf5, 0x81, 0x92, 0x3d, 0xa3, 0x2a, 0xc0, 0x5b, 0x22,
+	0xb1, 0xee, 0xd4, 0x61, 0xe9, 0x8a, 0x0c, 0x27, 0xb4, 0xfd, 0xd7, 0x05, 0xd8, 0x98, 0xcb, 0x2c,
+	0x08, 0xc1, 0xe2, 0x80, 0xf0, 0x81, 0x4a, 0xf7, 0x2d, 0xac, 0x9e, 0xd1, 0x63, 0x58, 0x1e, 0x50,
+	0xe2, 0x50, 0x3f, 0xcc, 0xe7, 0x46, 0x32, 0xae, 0x82, 0x6a, 0xd2, 0x53, 0xe7, 0x9d, 0xc5, 0x2f,
+	0xbe, 0xba, 0x57, 0xc1, 0xa1, 0x34, 0x3a, 0x85, 0xf5, 0x21, 0xe1, 0xc2, 0x0a, 0x6e, 0xaa, 0x95,
+	0xc8, 0xed, 0xf3, 0xf9, 0xe9, 0x84, 0x44, 0x77, 0x5b, 0xa6, 0xf7, 0x10, 0x68, 0x6d, 0x98, 0xda,
+	0x45, 0x18, 0xb6, 0xfa, 0xd3, 0x3f, 0x10, 0x4f, 0xb8, 0x1e, 0xb5, 0xae, 0xc8, 0xd0, 0x75, 0x88,
+	0x60, 0x3e, 0x37, 0x16, 0xef, 0xd7, 0x1e, 0x36, 0x1f, 0xbd, 0x31, 0x07, 0x7a, 0x78, 0xe5, 0x3a,
+	0xd4, 0xb3, 0x69, 0x08, 0xb7, 0x19, 0x2b, 0xff, 0x22, 0xd6, 0x45, 0x1f, 0x42, 0x9d, 0x7a, 0xc2,
+	0x67, 0xe3, 0x69, 0x14, 0xd9, 0x77, 0x6e, 0xbe, 0x6a, 0xe0, 0xdc, 0x61, 0x70, 0x1e, 0xa2, 0x44,
+	0xe2, 0xed, 0x53, 0xb8, 0x9d, 0x19, 0xf4, 0x89, 0xef, 0x55, 0x7d, 0x9d, 0xef, 0xd5, 0xfe, 0x36,
+	0x6c, 0x66, 0x04, 0x3d, 0xda, 0x96, 0x70, 0xee, 0xc5, 0x40, 0x28, 0xb8, 0x1a, 0x0e, 0x57, 0xed,
+	0x13, 0xb8, 0x9d, 0x19, 0xd4, 0x68, 0x1f, 0x6a, 0xe2, 0x9a, 0x1b, 0xd5, 0xfb, 0xb5, 0x52, 0xa9,
+	0x1c, 0x4b, 0xe9, 0x76, 0x0f, 0x36, 0x33, 0x22, 0x1a, 0x7d, 0x37, 0x89, 0x55, 0x54, 0x56, 0x02,
+	0xa4, 0xf7, 0x61, 0x63, 0x2e, 0xa2, 0xb5, 0x4e, 0xbc, 0x0b, 0x9b, 0x19, 0x51, 0x8b, 0xd6, 0x6f,
+	0x5e, 0xdb, 0x0a, 0x50, 0xff, 0xd2, 0x82, 0x15, 0x4c, 0xf9, 0x98, 0x79, 0x9c, 0xa2, 0x0e, 0x34,
+	0xe8, 0xb5, 0x4d, 0x83, 0xa2, 0x5f, 0x0d, 0xef, 0xc2, 0xbc, 0x6d, 0x81, 0xf4, 0x61, 0x24, 0x29,
+	0x6b, 0x56, 0xac, 0x86, 0xf6, 0x43, 0x62, 0xa3, 0xe7, 0x28, 0xa1, 0x7a, 0x92, 0xd9, 0x3c, 0x8e,
+	0x98, 0x4d, 0x4d, 0x5b, 0xa6, 0x02, 0xad, 0x19, 0x6a, 0xb3, 0x1f, 0x52, 0x9b, 0xc5, 0x82, 0x97,
+	0xa5, 0xb8, 0x4d, 0x37, 0xc5, 0x6d, 0x96, 0x0a, 0xdc, 0xd4, 0x90, 0x9b, 0x6e, 0x8a, 0xdc, 0x2c,
+	0x17, 0x80, 0x68, 0xd8, 0xcd, 0xe3, 0x88, 0xdd, 0xd4, 0x0b, 0xdc, 0x9e, 0xa1, 0x37, 0x4f, 0xd2,
+	0xf4, 0x26, 0x20, 0x27, 0x0f, 0xb4, 0xda, 0x5a, 0x86, 0xf3, 0x83, 0x04, 0xc3, 0x69, 0x84, 0x26,
+	0xcc, 0xa6, 0xbe, 0x00, 0x22, 0x83, 0xe0, 0x74, 0x53, 0x04, 0x07, 0x0a, 0xbe, 0x80, 0x86, 0xe1,
+	0xfc, 0x30, 0xc9, 0x70, 0x9a, 0x61, 0x12, 0xcb, 0x36, 0x21, 0x93, 0xe0, 0x7c, 0x3f, 0x26, 0x38,
+	0x2d, 0x2d, 0x43, 0x0b, 0x3d, 0x98, 0x65, 0x38, 0xa7, 0x73, 0x0c, 0x27, 0x60, 0x24, 0xdf, 0xd4,
+	0x42, 0x14, 0x50, 0x9c, 0xd3, 0x39, 0x8a, 0xb3, 0x56, 0x00, 0x58, 0xc0, 0x71, 0x7e, 0x9b, 0xcd,
+	0x71, 0xf4, 0x2c, 0x24, 0x34, 0xb3, 0x1c, 0xc9, 0xb1, 0x34, 0x24, 0x67, 0x5d, 0xc1, 0xbf, 0xaf,
+	0x85, 0x2f, 0xcd, 0x72, 0xb0, 0x9e, 0xe5, 0xbc, 0xa3, 0xf9, 0x8d, 0x0b, 0x69, 0xce, 0x89, 0x8e,
+	0xe6, 0x3c, 0xd0, 0x47, 0x8d, 0x9e, 0xe7, 0x60, 0x3d, 0xcf, 0xd1, 0x59, 0x58, 0x48, 0x74, 0x4e,
+	0x74, 0x44, 0xe7, 0x41, 0xfe, 0xd5, 0xca, 0x66, 0x3a, 0x87, 0x99, 0x4c, 0xe7, 0x6d, 0x0d, 0x94,
+	0x96, 0xea, 0x9c, 0xe8, 0xa8, 0x8e, 0xce, 0xa8, 0x72, 0x5c, 0xe7, 0x4f, 0x35, 0xb8, 0x35, 0xe3,
+	0x86, 0x64, 0x3a, 0x36, 0x73, 0xa8, 0x2a, 0x1f, 0xab, 0x58, 0x3d, 0xcb, 0x3d, 0x87, 0x08, 0xa2,
+	0x6a, 0x42, 0x0b, 0xab, 0x67, 0x59, 0x8a, 0x86, 0xec, 0x42, 0x25, 0xfc, 0x06, 0x96, 0x8f, 0x52,
+	0x2a, 0x4e, 0xe6, 0x8d, 0x30, 0x57, 0xef, 0x00, 0x5c, 0x10, 0x6e, 0xbd, 0x20, 0x9e, 0xa0, 0x8e,
+	0xca, 0xd5, 0x35, 0x9c, 0xd8, 0x41, 0x26, 0xac, 0xc8, 0xd5, 0x84, 0x53, 0x47, 0x25, 0xe1, 0x1a,
+	0x8e, 0xd7, 0xa8, 0x07, 0xcb, 0xf4, 0x8a, 0x7a, 0x82, 0x1b, 0x75, 0x55, 0x66, 0xb7, 0x33, 0x88,
+	0x0c, 0xf5, 0x44, 0xc7, 0x90, 0x6c, 0xe1, 0xeb, 0xaf, 0xee, 0xad, 0x07, 0xd2, 0x1f, 0xb0, 0x91,
+	0x2b, 0xe8, 0x68, 0x2c, 0xa6, 0x38, 0xd4, 0x47, 0x77, 0xa1, 0x21, 0xfd, 0xe0, 0x63, 0x62, 0x53,
+	0x95, 0x6d, 0x1b, 0xf8, 0x66, 0x43, 0xd6, 0x60, 0xae, 0x80, 0x55, 0x0e, 0x6d, 0xe0, 0x70, 0x25,
+	0x6d, 0x1b, 0xfb, 0x2e, 0xf3, 0x5d, 0x31, 0x55, 0xe9, 0xb1, 0x86, 0xe3, 0x35, 0x7a, 0x00, 0xab,
+	0x23, 0x3a, 0x1a, 0x33, 0x36, 0xb4, 0xa8, 0xef, 0x33, 0x5f, 0xe5, 0xbe, 0x06, 0x6e, 0x85, 0x9b,
+	0x87, 0x72, 0x0f, 0xbd, 0x09, 0x0d, 0x9f, 0x12, 0xc7, 0x92, 0x43, 0x07, 0xa3, 0xa5, 0x6a, 0xf6,
+	0x8a, 0xdc, 0x38, 0xa6, 0x53, 0x8e, 0xde, 0x02, 0x78, 0xe1, 0xbb, 0x82, 0x06, 0xa7, 0xab, 0xea,
+	0xb4, 0xa1, 0x76, 0xe4, 0x71, 0xfb, 0xbf, 0x0b, 0xb0, 0x3e, 0x9b, 0x39, 0xd1, 0x19, 0x6c, 0xc4,
+	0xf4, 0xce, 0x9a, 0x8c, 0x1d, 0x22, 0x68, 0xc4, 0x41, 0xe6, 0xfb, 0xca, 0x98, 0xcc, 0xfd, 0x5c,
+	0x09, 0x86, 0xa4, 0x6a, 0xfd, 0x2a, 0xbd, 0xcd, 0xd1, 0xaf, 0xe0, 0x8e, 0x2d, 0xdf, 0xe2, 0xf1,
+	0x09, 0xb7, 0xc6, 0xc4, 0x27, 0xa3, 0x18, 0x7a, 0x41, 0xd3, 0xb2, 0x76, 0x23, 0xf9, 0xa7, 0x52,
+	0x9c, 0xe3, 0xdb, 0x76, 0x6a, 0x23, 0x42, 0xbe, 0xf9, 0x01, 0x6b, 0xff, 0xe7, 0x0f, 0xd8, 0x87,
+	0x3b, 0x37, 0x8e, 0x5f, 0xd2, 0xa9, 0xe5, 0x33, 0x41, 0x64, 0x1d, 0xe7, 0x32, 0x23, 0xd5, 0x32,
+	0x2e, 0x42, 0xec, 0xfd, 0x31, 0x9d, 0xe2, 0x50, 0x38, 0xfc, 0x04, 0xb7, 0xaf, 0x32, 0xce, 0x78,
+	0xfb, 0xcf, 0x55, 0xd8, 0xca, 0xd2, 0x42, 0x1d, 0x68, 0xb2, 0xa1, 0x63, 0x8d, 0x27, 0x7d, 0xf9,
+	0xea, 0xac, 0x89, 0x4f, 0x30, 0x5c, 0xda, 0x7d, 0x3a, 0xe9, 0x0f, 0x5d, 0xfb, 0x98, 0x46, 0x94,
+	0xb8, 0xc1, 0x86, 0xce, 0xd3, 0x49, 0xff, 0x98, 0x4e, 0x25, 0x86, 0x47, 0x5f, 0xc4, 0x18, 0x0b,
+	0xe5, 0x31, 0x3c, 0xfa, 0x22, 0xc0, 0x68, 0x7f, 0x00, 0xdb, 0xd9, 0x79, 0x36, 0xeb, 0xce, 0xb6,
+	0xdf, 0x83, 0xad, 0xac, 0x1c, 0x9a, 0x29, 0xfb, 0x09, 0x6c, 0x67, 0xe7, 0x47, 0xf4, 0x63, 0x19,
+	0xc2, 0xc1, 0x49, 0x14, 0x69, 0x25, 0x38, 0x02, 0xbe, 0x51, 0x6a, 0x3f, 0x87, 0xad, 0x99, 0x14,
+	0x13, 0x20, 0x7f, 0x34, 0x8f, 0x5c, 0x40, 0x5e, 0x92, 0xa8, 0x0f, 0x01, 0xcd, 0x27, 0xcd, 0x4c,
+	0xdf, 0x7e, 0x0d, 0x5b, 0x59, 0x49, 0x11, 0x1d, 0x40, 0x33, 0x99, 0x4f, 0x03, 0x0b, 0xcc, 0x19,
+	0x0b, 0x12, 0x1a, 0xe1, 0xef, 0x01, 0x24, 0x86, 0x68, 0x1f, 0x41, 0x33, 0x21, 0x90, 0xbe, 0xee,
+	0xd5, 0xdc, 0xeb, 0xbe, 0x30, 0x7b, 0xdd, 0x3f, 0xaf, 0xca, 0xeb, 0x6e, 0x33, 0xdf, 0xa1, 0xce,
+	0xe1, 0xb5, 0x3d, 0x20, 0xde, 0x05, 0x95, 0xc9, 0xd3, 0x66, 0x9e, 0x47, 0xed, 0x98, 0xcf, 0x37,
+	0x70, 0x62, 0x07, 0x7d, 0x07, 0xea, 0x7e, 0xd0, 0x24, 0x84, 0x01, 0xb5, 0x9d, 0xdd, 0xf8, 0xe2,
+	0x48, 0x0c, 0xed, 0xc3, 0x4a, 0xf4, 0x0d, 0x8d, 0xda, 0x6c, 0x57, 0x97, 0xfa, 0xe6, 0x38, 0x16,
+	0x7c, 0xf4, 0xf9, 0x1a, 0xdc, 0x3a, 0xe8, 0x74, 0x8f, 0x24, 0x71, 0x70, 0x6d, 0x12, 0xd2, 0xe7,
+	0x45, 0xd9, 0x00, 0xa0, 0xdc, 0xc1, 0xa7, 0x99, 0xdf, 0x3d, 0xa0, 0x27, 0xb0, 0xa4, 0xfa, 0x01,
+	0x94, 0x3f, 0x09, 0x35, 0x0b, 0xda, 0x09, 0x69, 0x8c, 0xea, 0x83, 0x73, 0x47, 0xa3, 0x66, 0x7e,
+	0x77, 0x81, 0x30, 0x34, 0xe2, 0x56, 0x01, 0x15, 0x8f, 0x4a, 0xcd, 0x12, 0x1d, 0x87, 0xc4, 0x8c,
+	0xef, 0x04, 0x2a, 0xee, 0x38, 0xcd, 0x12, 0x57, 0x0b, 0xfd, 0x04, 0xea, 0x51, 0xa9, 0x2e, 0xea,
+	0x3b, 0xcd, 0x82, 0x0b, 0x25, 0x7f, 0x00, 0xd5, 0x99, 0xa0, 0xfc, 0xb9, 0xac, 0x59, 0xd0, 0xd8,
+	0xa0, 0x23, 0x58, 0x0e, 0xe8, 0x39, 0x2a, 0x18, 0x50, 0x9a, 0x45, 0xfc, 0x5e, 0x7e, 0xb2, 0xb8,
+	0xd9, 0x42, 0xc5, 0xd3, 0x66, 0xb3, 0x44, 0xcf, 0x86, 0xce, 0x00, 0x12, 0xa3, 0x9c, 0xc2, 0x31,
+	0xb2, 0x59, 0xa6, 0x13, 0x43, 0x3f, 0x85, 0x95, 0xb8, 0x2e, 0x17, 0x0e, 0x75, 0xcd, 0xa2, 0xa6,
+	0x08, 0x7d, 0x0a, 0xab, 0xa9, 0xf6, 0x04, 0x95, 0x1b, 0xd4, 0x9a, 0x25, 0xbb, 0x1d, 0x89, 0x9f,
+	0xea, 0x56, 0x50, 0xb9, 0xc1, 0xad, 0x59, 0xb2, 0xf9, 0x41, 0xbf, 0x87, 0x8d, 0xb9, 0xbe, 0x05,
+	0x95, 0x9f, 0xe3, 0x9a, 0xaf, 0xd1, 0x0e, 0xa1, 0x11, 0xa0, 0xf9, 0x26, 0x06, 0xbd, 0xc6, 0x58,
+	0xd7, 0x7c, 0x9d, 0xee, 0x08, 0xfd, 0x0e, 0xd6, 0x66, 0xea, 0x6d, 0xa9, 0x21, 0xaf, 0x59, 0xae,
+	0x49, 0x42, 0xbf, 0x84, 0x56, 0xaa, 0x40, 0x97, 0x18, 0xf8, 0x9a, 0x65, 0xba, 0x25, 0x69, 0xf7,
+	0x4c, 0x35, 0x2f, 0x35, 0xfc, 0x35, 0xcb, 0xb5, 0x4e, 0xd2, 0xee, 0x54, 0x41, 0x2f, 0x31, 0x08,
+	0x36, 0xcb, 0xf4, 0x50, 0xe8, 0x19, 0x40, 0xa2, 0xa6, 0x17, 0x4e, 0x85, 0xcd, 0xe2, 0x6e, 0x4a,
+	0xda, 0x9a, 0x2a, 0xfe, 0x25, 0x26, 0xc4, 0x66, 0x99, 0xd6, 0xaa, 0x73, 0xf0, 0xc5, 0xcb, 0x9d,
+	0xea, 0x97, 0x2f, 0x77, 0xaa, 0x7f, 0x7f, 0xb9, 0x53, 0xfd, 0xe3, 0xab, 0x9d, 0xca, 0x97, 0xaf,
+	0x76, 0x2a, 0x7f, 0x7b, 0xb5, 0x53, 0xf9, 0xe4, 0xdd, 0x0b, 0x57, 0x0c, 0x26, 0xfd, 0x5d, 0x9b,
+	0x8d, 0xf6, 0x9e, 0xb8, 0x1e, 0xb7, 0x07, 0x2e, 0xd9, 0xcb, 0xf8, 0xd7, 0xb3, 0xbf, 0xac, 0xfe,
+	0x5c, 0xdc, 0xff, 0xdf, 0x00, 0x82, 0x10, 0xfe, 0x46, 0x13, 0x1d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ABCIApplicationClient is the client API for ABCIApplication service.
+//
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ABCIApplicationClient interface {
 	Echo(ctx context.Context, in *types.RequestEcho, opts ...grpc.CallOption) (*types.ResponseEcho, error)
@@ -1109,13 +1875,17 @@ type ABCIApplicationClient interface {
 	Commit(ctx context.Context, in *types.RequestCommit, opts ...grpc.CallOption) (*types.ResponseCommit, error)
 	InitChain(ctx context.Context, in *types.RequestInitChain, opts ...grpc.CallOption) (*types.ResponseInitChain, error)
 	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*types.ResponseBeginBlock, error)
-	EndBlock(ctx context.Context, in *types.RequestEndBlock, opts ...grpc.CallOption) (*types.ResponseEndBlock, error)
+	EndBlock(ctx context.Context, in *types.RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
 	ListSnapshots(ctx context.Context, in *types.RequestListSnapshots, opts ...grpc.CallOption) (*types.ResponseListSnapshots, error)
 	OfferSnapshot(ctx context.Context, in *types.RequestOfferSnapshot, opts ...grpc.CallOption) (*types.ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(ctx context.Context, in *types.RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *types.RequestApplySnapshotChunk, opts ...grpc.CallOption) (*types.ResponseApplySnapshotChunk, error)
 	BeginRecheckTx(ctx context.Context, in *RequestBeginRecheckTx, opts ...grpc.CallOption) (*ResponseBeginRecheckTx, error)
 	EndRecheckTx(ctx context.Context, in *RequestEndRecheckTx, opts ...grpc.CallOption) (*ResponseEndRecheckTx, error)
+	DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error)
+	CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error)
+	AbortBlock(ctx context.Context, in *RequestAbortBlock, opts ...grpc.CallOption) (*ResponseAbortBlock, error)
+	TxAccessSets(ctx context.Context, in *RequestTxAccessSets, opts ...grpc.CallOption) (*ResponseTxAccessSets, error)
 }
 
 type aBCIApplicationClient struct {
@@ -1216,8 +1986,8 @@ func (c *aBCIApplicationClient) BeginBlock(ctx context.Context, in *RequestBegin
 	return out, nil
 }
 
-func (c *aBCIApplicationClient) EndBlock(ctx context.Context, in *types.RequestEndBlock, opts ...grpc.CallOption) (*types.ResponseEndBlock, error) {
-	out := new(types.ResponseEndBlock)
+func (c *aBCIApplicationClient) EndBlock(ctx context.Context, in *types.RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error) {
+	out := new(ResponseEndBlock)
 	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/EndBlock", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -1279,6 +2049,42 @@ func (c *aBCIApplicationClient) EndRecheckTx(ctx context.Context, in *RequestEnd
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error) {
+	out := new(ResponseDeliverTxBatch)
+	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/DeliverTxBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error) {
+	out := new(ResponseCheckTxBatch)
+	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/CheckTxBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) AbortBlock(ctx context.Context, in *RequestAbortBlock, opts ...grpc.CallOption) (*ResponseAbortBlock, error) {
+	out := new(ResponseAbortBlock)
+	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/AbortBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) TxAccessSets(ctx context.Context, in *RequestTxAccessSets, opts ...grpc.CallOption) (*ResponseTxAccessSets, error) {
+	out := new(ResponseTxAccessSets)
+	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/TxAccessSets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *types.RequestEcho) (*types.ResponseEcho, error)
@@ -1291,13 +2097,17 @@ type ABCIApplicationServer interface {
 	Commit(context.Context, *types.RequestCommit) (*types.ResponseCommit, error)
 	InitChain(context.Context, *types.RequestInitChain) (*types.ResponseInitChain, error)
 	BeginBlock(context.Context, *RequestBeginBlock) (*types.ResponseBeginBlock, error)
-	EndBlock(context.Context, *types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	EndBlock(context.Context, *types.RequestEndBlock) (*ResponseEndBlock, error)
 	ListSnapshots(context.Context, *types.RequestListSnapshots) (*types.ResponseListSnapshots, error)
 	OfferSnapshot(context.Context, *types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(context.Context, *types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
 	BeginRecheckTx(context.Context, *RequestBeginRecheckTx) (*ResponseBeginRecheckTx, error)
 	EndRecheckTx(context.Context, *RequestEndRecheckTx) (*ResponseEndRecheckTx, error)
+	DeliverTxBatch(context.Context, *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error)
+	CheckTxBatch(context.Context, *RequestCheckTxBatch) (*ResponseCheckTxBatch, error)
+	AbortBlock(context.Context, *RequestAbortBlock) (*ResponseAbortBlock, error)
+	TxAccessSets(context.Context, *RequestTxAccessSets) (*ResponseTxAccessSets, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -1334,7 +2144,7 @@ func (*UnimplementedABCIApplicationServer) InitChain(ctx context.Context, req *t
 func (*UnimplementedABCIApplicationServer) BeginBlock(ctx context.Context, req *RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BeginBlock not implemented")
 }
-func (*UnimplementedABCIApplicationServer) EndBlock(ctx context.Context, req *types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+func (*UnimplementedABCIApplicationServer) EndBlock(ctx context.Context, req *types.RequestEndBlock) (*ResponseEndBlock, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndBlock not implemented")
 }
 func (*UnimplementedABCIApplicationServer) ListSnapshots(ctx context.Context, req *types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
@@ -1355,6 +2165,18 @@ func (*UnimplementedABCIApplicationServer) BeginRecheckTx(ctx context.Context, r
 func (*UnimplementedABCIApplicationServer) EndRecheckTx(ctx context.Context, req *RequestEndRecheckTx) (*ResponseEndRecheckTx, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndRecheckTx not implemented")
 }
+func (*UnimplementedABCIApplicationServer) DeliverTxBatch(ctx context.Context, req *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverTxBatch not implemented")
+}
+func (*UnimplementedABCIApplicationServer) CheckTxBatch(ctx context.Context, req *RequestCheckTxBatch) (*ResponseCheckTxBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTxBatch not implemented")
+}
+func (*UnimplementedABCIApplicationServer) AbortBlock(ctx context.Context, req *RequestAbortBlock) (*ResponseAbortBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortBlock not implemented")
+}
+func (*UnimplementedABCIApplicationServer) TxAccessSets(ctx context.Context, req *RequestTxAccessSets) (*ResponseTxAccessSets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxAccessSets not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -1666,38 +2488,110 @@ func _ABCIApplication_EndRecheckTx_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ostracon.abci.ABCIApplication",
-	HandlerType: (*ABCIApplicationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Echo",
-			Handler:    _ABCIApplication_Echo_Handler,
-		},
-		{
-			MethodName: "Flush",
-			Handler:    _ABCIApplication_Flush_Handler,
-		},
-		{
-			MethodName: "Info",
-			Handler:    _ABCIApplication_Info_Handler,
-		},
-		{
-			MethodName: "SetOption",
-			Handler:    _ABCIApplication_SetOption_Handler,
-		},
-		{
-			MethodName: "DeliverTx",
-			Handler:    _ABCIApplication_DeliverTx_Handler,
-		},
-		{
-			MethodName: "CheckTx",
-			Handler:    _ABCIApplication_CheckTx_Handler,
-		},
-		{
-			MethodName: "Query",
-			Handler:    _ABCIApplication_Query_Handler,
-		},
+func _ABCIApplication_DeliverTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDeliverTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ostracon.abci.ABCIApplication/DeliverTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, req.(*RequestDeliverTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_CheckTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCheckTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ostracon.abci.ABCIApplication/CheckTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, req.(*RequestCheckTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_AbortBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAbortBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).AbortBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ostracon.abci.ABCIApplication/AbortBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).AbortBlock(ctx, req.(*RequestAbortBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_TxAccessSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTxAccessSets)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).TxAccessSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ostracon.abci.ABCIApplication/TxAccessSets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).TxAccessSets(ctx, req.(*RequestTxAccessSets))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ostracon.abci.ABCIApplication",
+	HandlerType: (*ABCIApplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler:    _ABCIApplication_Echo_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _ABCIApplication_Flush_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _ABCIApplication_Info_Handler,
+		},
+		{
+			MethodName: "SetOption",
+			Handler:    _ABCIApplication_SetOption_Handler,
+		},
+		{
+			MethodName: "DeliverTx",
+			Handler:    _ABCIApplication_DeliverTx_Handler,
+		},
+		{
+			MethodName: "CheckTx",
+			Handler:    _ABCIApplication_CheckTx_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _ABCIApplication_Query_Handler,
+		},
 		{
 			MethodName: "Commit",
 			Handler:    _ABCIApplication_Commit_Handler,
@@ -1738,6 +2632,22 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EndRecheckTx",
 			Handler:    _ABCIApplication_EndRecheckTx_Handler,
 		},
+		{
+			MethodName: "DeliverTxBatch",
+			Handler:    _ABCIApplication_DeliverTxBatch_Handler,
+		},
+		{
+			MethodName: "CheckTxBatch",
+			Handler:    _ABCIApplication_CheckTxBatch_Handler,
+		},
+		{
+			MethodName: "AbortBlock",
+			Handler:    _ABCIApplication_AbortBlock_Handler,
+		},
+		{
+			MethodName: "TxAccessSets",
+			Handler:    _ABCIApplication_TxAccessSets_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ostracon/abci/types.proto",
@@ -2136,6 +3046,98 @@ func (m *Request_EndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_DeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_DeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeliverTxBatch != nil {
+		{
+			size, err := m.DeliverTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_CheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_CheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CheckTxBatch != nil {
+		{
+			size, err := m.CheckTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xda
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_AbortBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_AbortBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AbortBlock != nil {
+		{
+			size, err := m.AbortBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_TxAccessSets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_TxAccessSets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.TxAccessSets != nil {
+		{
+			size, err := m.TxAccessSets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xea
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestBeginBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2273,7 +3275,7 @@ func (m *RequestEndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *RequestDeliverTxBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2283,83 +3285,217 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestDeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestDeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Value != nil {
-		{
-			size := m.Value.Size()
-			i -= size
-			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Txs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestCheckTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestCheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestCheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Exception != nil {
-		{
-			size, err := m.Exception.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Txs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0xa
 		}
-		i--
-		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *RequestAbortBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestAbortBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response_Echo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestAbortBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Echo != nil {
-		{
-			size, err := m.Echo.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Response_Flush) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *RequestTxAccessSets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestTxAccessSets) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response_Flush) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestTxAccessSets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Flush != nil {
-		{
-			size, err := m.Flush.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Exception != nil {
+		{
+			size, err := m.Exception.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Echo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Echo != nil {
+		{
+			size, err := m.Echo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_Flush) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Flush) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Flush != nil {
+		{
+			size, err := m.Flush.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
 			i -= size
 			i = encodeVarintTypes(dAtA, i, uint64(size))
 		}
@@ -2689,6 +3825,98 @@ func (m *Response_EndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_DeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_DeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeliverTxBatch != nil {
+		{
+			size, err := m.DeliverTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_CheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_CheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CheckTxBatch != nil {
+		{
+			size, err := m.CheckTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xda
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_AbortBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_AbortBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AbortBlock != nil {
+		{
+			size, err := m.AbortBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_TxAccessSets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_TxAccessSets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.TxAccessSets != nil {
+		{
+			size, err := m.TxAccessSets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xea
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseCheckTx) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2709,6 +3937,24 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.WriteKeys) > 0 {
+		for iNdEx := len(m.WriteKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.WriteKeys[iNdEx])
+			copy(dAtA[i:], m.WriteKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.WriteKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.ReadKeys) > 0 {
+		for iNdEx := len(m.ReadKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ReadKeys[iNdEx])
+			copy(dAtA[i:], m.ReadKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ReadKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
 	if len(m.MempoolError) > 0 {
 		i -= len(m.MempoolError)
 		copy(dAtA[i:], m.MempoolError)
@@ -2788,7 +4034,7 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ResponseBeginRecheckTx) Marshal() (dAtA []byte, err error) {
+func (m *ResponseEndBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2798,25 +4044,76 @@ func (m *ResponseBeginRecheckTx) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResponseBeginRecheckTx) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseEndBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseBeginRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseEndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	if len(m.ValidatorKeyRotations) > 0 {
+		for iNdEx := len(m.ValidatorKeyRotations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorKeyRotations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3e
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		{
+			size, err := m.ConsensusParamUpdates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0x12
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResponseEndRecheckTx) Marshal() (dAtA []byte, err error) {
+func (m *ValidatorKeyRotation) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2826,34 +4123,339 @@ func (m *ResponseEndRecheckTx) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResponseEndRecheckTx) MarshalTo(dAtA []byte) (int, error) {
+func (m *ValidatorKeyRotation) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseEndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ValidatorKeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
-		i--
-		dAtA[i] = 0x8
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.OldPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *ResponseBeginRecheckTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseBeginRecheckTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseBeginRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseEndRecheckTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseEndRecheckTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseEndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseDeliverTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseDeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseDeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseCheckTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseCheckTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseCheckTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseAbortBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseAbortBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseAbortBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseTxAccessSets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseTxAccessSets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseTxAccessSets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AccessSets) > 0 {
+		for iNdEx := len(m.AccessSets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccessSets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxAccessSet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxAccessSet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxAccessSet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.WriteKeys) > 0 {
+		for iNdEx := len(m.WriteKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.WriteKeys[iNdEx])
+			copy(dAtA[i:], m.WriteKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.WriteKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ReadKeys) > 0 {
+		for iNdEx := len(m.ReadKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ReadKeys[iNdEx])
+			copy(dAtA[i:], m.ReadKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ReadKeys[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordedExchange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordedExchange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordedExchange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Request != nil {
+		{
+			size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Connection) > 0 {
+		i -= len(m.Connection)
+		copy(dAtA[i:], m.Connection)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Connection)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
 }
 func (m *Request) Size() (n int) {
 	if m == nil {
@@ -3071,17 +4673,65 @@ func (m *Request_EndRecheckTx) Size() (n int) {
 	}
 	return n
 }
-func (m *RequestBeginBlock) Size() (n int) {
+func (m *Request_DeliverTxBatch) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.DeliverTxBatch != nil {
+		l = m.DeliverTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
 	}
-	l = m.Header.Size()
+	return n
+}
+func (m *Request_CheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTxBatch != nil {
+		l = m.CheckTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_AbortBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AbortBlock != nil {
+		l = m.AbortBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_TxAccessSets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TxAccessSets != nil {
+		l = m.TxAccessSets.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *RequestBeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
 	n += 1 + l + sovTypes(uint64(l))
 	l = m.LastCommitInfo.Size()
 	n += 1 + l + sovTypes(uint64(l))
@@ -3119,6 +4769,63 @@ func (m *RequestEndRecheckTx) Size() (n int) {
 	return n
 }
 
+func (m *RequestDeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, e := range m.Txs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestCheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, e := range m.Txs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestAbortBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RequestTxAccessSets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3347,6 +5054,54 @@ func (m *Response_EndRecheckTx) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_DeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverTxBatch != nil {
+		l = m.DeliverTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_CheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTxBatch != nil {
+		l = m.CheckTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_AbortBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AbortBlock != nil {
+		l = m.AbortBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_TxAccessSets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TxAccessSets != nil {
+		l = m.TxAccessSets.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseCheckTx) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3395,6 +5150,62 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.ReadKeys) > 0 {
+		for _, b := range m.ReadKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.WriteKeys) > 0 {
+		for _, b := range m.WriteKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseEndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ValidatorKeyRotations) > 0 {
+		for _, e := range m.ValidatorKeyRotations {
+			l = e.Size()
+			n += 2 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ValidatorKeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.OldPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -3422,6 +5233,105 @@ func (m *ResponseEndRecheckTx) Size() (n int) {
 	return n
 }
 
+func (m *ResponseDeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseCheckTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseAbortBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *ResponseTxAccessSets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AccessSets) > 0 {
+		for _, e := range m.AccessSets {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TxAccessSet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ReadKeys) > 0 {
+		for _, b := range m.ReadKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.WriteKeys) > 0 {
+		for _, b := range m.WriteKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RecordedExchange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Connection)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -4052,25 +5962,165 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_EndRecheckTx{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 1002:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxBatch", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestDeliverTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_DeliverTxBatch{v}
+			iNdEx = postIndex
+		case 1003:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTxBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestCheckTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_CheckTxBatch{v}
+			iNdEx = postIndex
+		case 1004:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AbortBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestAbortBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_AbortBlock{v}
+			iNdEx = postIndex
+		case 1005:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxAccessSets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestTxAccessSets{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_TxAccessSets{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
 	return nil
 }
 func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
@@ -4442,7 +6492,7 @@ func (m *RequestEndRecheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestDeliverTxBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4465,15 +6515,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestDeliverTxBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestDeliverTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4500,50 +6550,64 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, &types.RequestDeliverTx{})
+			if err := m.Txs[len(m.Txs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestCheckTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Value = &Response_Echo{v}
-			iNdEx = postIndex
-		case 3:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestCheckTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestCheckTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4570,52 +6634,66 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, &types.RequestCheckTx{})
+			if err := m.Txs[len(m.Txs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestAbortBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			m.Value = &Response_Info{v}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestAbortBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestAbortBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -4625,32 +6703,66 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseSetOption{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestTxAccessSets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			m.Value = &Response_SetOption{v}
-			iNdEx = postIndex
-		case 6:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestTxAccessSets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestTxAccessSets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -4660,30 +6772,77 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Value = &Response_InitChain{v}
-			iNdEx = postIndex
-		case 7:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4710,15 +6869,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseQuery{}
+			v := &types.ResponseException{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Query{v}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4745,15 +6904,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseBeginBlock{}
+			v := &types.ResponseEcho{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_BeginBlock{v}
+			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4780,15 +6939,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCheckTx{}
+			v := &types.ResponseFlush{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_CheckTx{v}
+			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
-		case 10:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4815,15 +6974,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseDeliverTx{}
+			v := &types.ResponseInfo{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_DeliverTx{v}
+			m.Value = &Response_Info{v}
 			iNdEx = postIndex
-		case 11:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4850,15 +7009,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseEndBlock{}
+			v := &types.ResponseSetOption{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_EndBlock{v}
+			m.Value = &Response_SetOption{v}
 			iNdEx = postIndex
-		case 12:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4885,15 +7044,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseCommit{}
+			v := &types.ResponseInitChain{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Commit{v}
+			m.Value = &Response_InitChain{v}
 			iNdEx = postIndex
-		case 13:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4920,15 +7079,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseListSnapshots{}
+			v := &types.ResponseQuery{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ListSnapshots{v}
+			m.Value = &Response_Query{v}
 			iNdEx = postIndex
-		case 14:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4955,15 +7114,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseOfferSnapshot{}
+			v := &types.ResponseBeginBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_OfferSnapshot{v}
+			m.Value = &Response_BeginBlock{v}
 			iNdEx = postIndex
-		case 15:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4990,15 +7149,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseLoadSnapshotChunk{}
+			v := &ResponseCheckTx{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_LoadSnapshotChunk{v}
+			m.Value = &Response_CheckTx{v}
 			iNdEx = postIndex
-		case 16:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5025,15 +7184,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseApplySnapshotChunk{}
+			v := &types.ResponseDeliverTx{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ApplySnapshotChunk{v}
+			m.Value = &Response_DeliverTx{v}
 			iNdEx = postIndex
-		case 1000:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginRecheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5060,15 +7219,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseBeginRecheckTx{}
+			v := &ResponseEndBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_BeginRecheckTx{v}
+			m.Value = &Response_EndBlock{v}
 			iNdEx = postIndex
-		case 1001:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndRecheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5095,67 +7254,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEndRecheckTx{}
+			v := &types.ResponseCommit{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_EndRecheckTx{v}
+			m.Value = &Response_Commit{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
 			}
-			m.Code = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5165,16 +7274,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &types.ResponseListSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ListSnapshots{v}
+			iNdEx = postIndex
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5184,31 +7309,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			v := &types.ResponseOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			m.Value = &Response_OfferSnapshot{v}
 			iNdEx = postIndex
-		case 3:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5218,29 +7344,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
+			v := &types.ResponseLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_LoadSnapshotChunk{v}
 			iNdEx = postIndex
-		case 4:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5250,29 +7379,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
+			v := &types.ResponseApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ApplySnapshotChunk{v}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginRecheckTx", wireType)
 			}
-			m.GasWanted = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5282,33 +7414,30 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GasWanted |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.GasUsed = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasUsed |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-		case 7:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseBeginRecheckTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_BeginRecheckTx{v}
+			iNdEx = postIndex
+		case 1001:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndRecheckTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5335,16 +7464,17 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, types.Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponseEndRecheckTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_EndRecheckTx{v}
 			iNdEx = postIndex
-		case 8:
+		case 1002:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxBatch", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5354,29 +7484,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			v := &ResponseDeliverTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_DeliverTxBatch{v}
 			iNdEx = postIndex
-		case 9:
+		case 1003:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTxBatch", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5386,29 +7519,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = string(dAtA[iNdEx:postIndex])
+			v := &ResponseCheckTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_CheckTxBatch{v}
 			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+		case 1004:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AbortBlock", wireType)
 			}
-			m.Priority = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5418,16 +7554,32 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseAbortBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_AbortBlock{v}
+			iNdEx = postIndex
+		case 1005:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxAccessSets", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5437,23 +7589,26 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MempoolError = string(dAtA[iNdEx:postIndex])
+			v := &ResponseTxAccessSets{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_TxAccessSets{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5476,7 +7631,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseBeginRecheckTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5499,10 +7654,10 @@ func (m *ResponseBeginRecheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBeginRecheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBeginRecheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5524,7 +7679,1119 @@ func (m *ResponseBeginRecheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Log = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Info = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+			}
+			m.GasWanted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasWanted |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, types.Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MempoolError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadKeys = append(m.ReadKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ReadKeys[len(m.ReadKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WriteKeys = append(m.WriteKeys, make([]byte, postIndex-iNdEx))
+			copy(m.WriteKeys[len(m.WriteKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, types.ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, types.Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorKeyRotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorKeyRotations = append(m.ValidatorKeyRotations, ValidatorKeyRotation{})
+			if err := m.ValidatorKeyRotations[len(m.ValidatorKeyRotations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorKeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorKeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorKeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OldPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseBeginRecheckTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseBeginRecheckTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseBeginRecheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEndRecheckTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEndRecheckTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEndRecheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseDeliverTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseDeliverTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseDeliverTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &types.ResponseDeliverTx{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseCheckTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseCheckTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseCheckTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &ResponseCheckTx{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseAbortBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseAbortBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseAbortBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseTxAccessSets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseTxAccessSets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseTxAccessSets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessSets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessSets = append(m.AccessSets, TxAccessSet{})
+			if err := m.AccessSets[len(m.AccessSets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
 			if err != nil {
@@ -5545,7 +8812,7 @@ func (m *ResponseBeginRecheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEndRecheckTx) Unmarshal(dAtA []byte) error {
+func (m *TxAccessSet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5568,17 +8835,17 @@ func (m *ResponseEndRecheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEndRecheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxAccessSet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEndRecheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxAccessSet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeys", wireType)
 			}
-			m.Code = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -5588,11 +8855,210 @@ func (m *ResponseEndRecheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadKeys = append(m.ReadKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ReadKeys[len(m.ReadKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WriteKeys = append(m.WriteKeys, make([]byte, postIndex-iNdEx))
+			copy(m.WriteKeys[len(m.WriteKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecordedExchange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordedExchange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordedExchange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connection", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Connection = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &Request{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Response == nil {
+				m.Response = &Response{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/Finschia/ostracon/config"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/p2p/pex"
+)
+
+// the address book database of the "db" addr_book_backend
+const addrBookDBName = "addrbook.db"
+
+// AddrBookCmd inspects the address book database of a stopped node.
+var AddrBookCmd = &cobra.Command{
+	Use:   "addrbook",
+	Short: "Inspect the address book database of a stopped node",
+}
+
+var addrBookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the known addresses with their source, buckets and dial history",
+	Example: `
+	ostracon addrbook list
+	ostracon addrbook list --bucket old
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadAddrBookEntries(config)
+		if err != nil {
+			return err
+		}
+		if bucketType != "" {
+			filtered := make([]pex.AddrBookEntry, 0, len(entries))
+			for _, entry := range entries {
+				if entry.BucketType == bucketType {
+					filtered = append(filtered, entry)
+				}
+			}
+			entries = filtered
+		}
+		return printJSON(cmd.OutOrStdout(), entries)
+	},
+}
+
+var addrBookShowCmd = &cobra.Command{
+	Use:   "show [node-id]",
+	Short: "Show the known address of a node ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadAddrBookEntries(config)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if string(entry.Addr.ID) == args[0] {
+				return printJSON(cmd.OutOrStdout(), entry)
+			}
+		}
+		return fmt.Errorf("no address for %s", args[0])
+	},
+}
+
+var addrBookStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count the known addresses by bucket type and status",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadAddrBookEntries(config)
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd.OutOrStdout(), newAddrBookStats(entries))
+	},
+}
+
+var bucketType string
+
+func init() {
+	addrBookListCmd.Flags().StringVar(&bucketType, "bucket", "", `list the addresses of "new" or "old" buckets only`)
+
+	AddrBookCmd.AddCommand(addrBookListCmd)
+	AddrBookCmd.AddCommand(addrBookShowCmd)
+	AddrBookCmd.AddCommand(addrBookStatsCmd)
+}
+
+type addrBookStats struct {
+	Total int `json:"total"`
+	New   int `json:"new"`
+	Old   int `json:"old"`
+	// addresses never connected to
+	NeverSucceeded int `json:"never_succeeded"`
+	// addresses whose last dial failed
+	LastDialFailed int `json:"last_dial_failed"`
+}
+
+func newAddrBookStats(entries []pex.AddrBookEntry) addrBookStats {
+	stats := addrBookStats{Total: len(entries)}
+	for _, entry := range entries {
+		if entry.BucketType == "old" {
+			stats.Old++
+		} else {
+			stats.New++
+		}
+		if entry.LastSuccess.IsZero() {
+			stats.NeverSucceeded++
+		}
+		if len(entry.DialHistory) > 0 && !entry.DialHistory[0].Success {
+			stats.LastDialFailed++
+		}
+	}
+	return stats
+}
+
+func loadAddrBookEntries(config *cfg.Config) ([]pex.AddrBookEntry, error) {
+	if !tmos.FileExists(filepath.Join(config.DBDir(), addrBookDBName)) {
+		return nil, fmt.Errorf("no address book database found in %v", config.DBDir())
+	}
+	db, err := dbm.NewDB("addrbook", dbm.BackendType(config.DBBackend), config.DBDir())
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return pex.LoadAddrBookEntries(db)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/pex"
+)
+
+func TestAddrBookCmd(t *testing.T) {
+	config = cfg.TestConfig()
+	config.SetRoot(t.TempDir())
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	_, err := loadAddrBookEntries(config)
+	assert.Error(t, err, "no database yet")
+
+	db, err := dbm.NewDB("addrbook", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+	book := pex.NewDBAddrBook(db, config.P2P.AddrBookFile(), true)
+	var addrs []*p2p.NetAddress
+	for i := 1; i <= 3; i++ {
+		id := p2p.PubKeyToID(ed25519.GenPrivKey().PubKey())
+		addr := p2p.NewNetAddressIPPort(net.IPv4(8, 8, 8, byte(i)), 26656)
+		addr.ID = id
+		require.NoError(t, book.AddAddress(addr, addr))
+		addrs = append(addrs, addr)
+	}
+	book.MarkGood(addrs[0].ID)
+	book.MarkAttempt(addrs[1])
+	book.Save()
+	require.NoError(t, db.Close())
+
+	var out bytes.Buffer
+	addrBookStatsCmd.SetOut(&out)
+	require.NoError(t, addrBookStatsCmd.RunE(addrBookStatsCmd, nil))
+	var stats addrBookStats
+	require.NoError(t, json.Unmarshal(out.Bytes(), &stats))
+	assert.Equal(t, addrBookStats{Total: 3, New: 2, Old: 1, NeverSucceeded: 2, LastDialFailed: 1}, stats)
+
+	out.Reset()
+	bucketType = "old"
+	t.Cleanup(func() { bucketType = "" })
+	addrBookListCmd.SetOut(&out)
+	require.NoError(t, addrBookListCmd.RunE(addrBookListCmd, nil))
+	var entries []pex.AddrBookEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, addrs[0].ID, entries[0].Addr.ID)
+
+	out.Reset()
+	addrBookShowCmd.SetOut(&out)
+	require.NoError(t, addrBookShowCmd.RunE(addrBookShowCmd, []string{string(addrs[1].ID)}))
+	var entry pex.AddrBookEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, addrs[1].ID, entry.Addr.ID)
+	assert.EqualValues(t, 1, entry.Attempts)
+	assert.Error(t, addrBookShowCmd.RunE(addrBookShowCmd, []string{"foo"}))
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/Finschia/ostracon/config"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/privval"
+)
+
+// EncryptValidatorKeyCmd migrates a plaintext priv_validator_key.json to an
+// encrypted one.
+var EncryptValidatorKeyCmd = &cobra.Command{
+	Use:   "encrypt-validator-key",
+	Short: "Encrypt this node's plaintext validator key file with its passphrase",
+	Long: `Encrypt this node's plaintext validator key file with the passphrase of
+priv_validator_key_passphrase_file or priv_validator_key_passphrase_env, derived
+into an encryption key by priv_validator_key_kdf.
+
+The validator keeps its key and address.`,
+	Example: `
+	OC_PRIV_VALIDATOR_KEY_PASSPHRASE_FILE=config/passphrase ostracon encrypt-validator-key
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return encryptValidatorKey(config)
+	},
+}
+
+func encryptValidatorKey(config *cfg.Config) error {
+	keyProvider, err := privValidatorKeyProvider(config)
+	if err != nil {
+		return err
+	}
+	if keyProvider == nil {
+		return errors.New("neither priv_validator_key_passphrase_file nor priv_validator_key_passphrase_env is set")
+	}
+
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	pvKey, err := privval.LoadFilePVKey(keyFilePath, keyProvider)
+	if err != nil {
+		return err
+	}
+	if pvKey.Encrypted() {
+		return fmt.Errorf("private validator file %s is already encrypted", keyFilePath)
+	}
+
+	if err := pvKey.EncryptWith(keyProvider, config.PrivValidatorKeyKDF); err != nil {
+		return err
+	}
+	pvKey.Save()
+	logger.Info("Encrypted private validator key", "keyFile", keyFilePath, "kdf", config.PrivValidatorKeyKDF)
+	return nil
+}
+
+// privValidatorKeyProvider returns the KeyProvider of the passphrase of the
+// priv_validator_key.json file, or nil if it isn't encrypted.
+func privValidatorKeyProvider(config *cfg.Config) (privval.KeyProvider, error) {
+	return privval.NewKeyProvider(config.PrivValidatorKeyPassphraseFile(), config.PrivValidatorKeyPassphraseEnv)
+}
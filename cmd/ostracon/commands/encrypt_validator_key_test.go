@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/Finschia/ostracon/config"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/privval"
+)
+
+func TestEncryptValidatorKey(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(config))
+	plaintext, err := privval.LoadFilePVKey(config.PrivValidatorKeyFile(), nil)
+	require.NoError(t, err)
+
+	// no passphrase
+	require.Error(t, encryptValidatorKey(config))
+
+	t.Setenv("OC_TEST_PASSPHRASE", "secret")
+	config.PrivValidatorKeyPassphraseEnv = "OC_TEST_PASSPHRASE"
+	require.NoError(t, encryptValidatorKey(config))
+	require.Error(t, encryptValidatorKey(config), "already encrypted")
+
+	_, err = privval.LoadFilePVKey(config.PrivValidatorKeyFile(), nil)
+	require.Error(t, err)
+	encrypted, err := privval.LoadFilePVKey(config.PrivValidatorKeyFile(), privval.PassphraseEnv("OC_TEST_PASSPHRASE"))
+	require.NoError(t, err)
+	require.True(t, encrypted.Encrypted())
+	require.Equal(t, plaintext.PrivKey, encrypted.PrivKey)
+
+	// the validator is still shown
+	output, err := captureStdout(func() {
+		require.NoError(t, showValidator(ShowValidatorCmd, nil, config))
+	})
+	require.NoError(t, err)
+	bz, err := tmjson.Marshal(plaintext.PubKey)
+	require.NoError(t, err)
+	require.Equal(t, string(bz), output)
+}
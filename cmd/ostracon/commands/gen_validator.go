@@ -15,12 +15,32 @@ var GenValidatorCmd = &cobra.Command{
 	Use:     "gen-validator",
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
-	PreRun:  deprecateSnakeCase,
-	Run:     genValidator,
+	Long: `Generate new validator keypair.
+
+If priv_validator_key_passphrase_file or priv_validator_key_passphrase_env is set,
+the key file encrypted with the passphrase is printed instead.`,
+	PreRun: deprecateSnakeCase,
+	Run:    genValidator,
 }
 
 func genValidator(cmd *cobra.Command, args []string) {
 	pv := privval.GenFilePV("", "")
+	keyProvider, err := privValidatorKeyProvider(config)
+	if err != nil {
+		panic(err)
+	}
+	if keyProvider != nil {
+		if err := pv.Key.EncryptWith(keyProvider, config.PrivValidatorKeyKDF); err != nil {
+			panic(err)
+		}
+		bz, err := pv.Key.Bytes()
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(bz))
+		return
+	}
+
 	jsbz, err := tmjson.Marshal(pv)
 	if err != nil {
 		panic(err)
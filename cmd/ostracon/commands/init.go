@@ -32,16 +32,20 @@ func initFilesWithConfig(config *cfg.Config) error {
 	// private validator
 	privValKeyFile := config.PrivValidatorKeyFile()
 	privValStateFile := config.PrivValidatorStateFile()
+	keyProvider, err := privValidatorKeyProvider(config)
+	if err != nil {
+		return err
+	}
 	var pv *privval.FilePV
 	if tmos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		pv = privval.LoadFilePVWithKeyProvider(privValKeyFile, privValStateFile, keyProvider)
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
-			"stateFile", privValStateFile)
+			"stateFile", privValStateFile, "encrypted", pv.Key.Encrypted())
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
-		pv.Save()
+		pv = privval.LoadOrGenFilePVWithKeyProvider(privValKeyFile, privValStateFile, keyProvider,
+			config.PrivValidatorKeyKDF)
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
-			"stateFile", privValStateFile)
+			"stateFile", privValStateFile, "encrypted", pv.Key.Encrypted())
 	}
 
 	nodeKeyFile := config.NodeKeyFile()
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmmath "github.com/Finschia/ostracon/libs/math"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/light"
+	dbs "github.com/Finschia/ostracon/light/store/db"
+)
+
+// MonitorCmd runs a light client following a chain to detect the attacks on
+// the light clients.
+var MonitorCmd = &cobra.Command{
+	Use:   "monitor [chainID]",
+	Short: "Monitor a chain for light client attacks",
+	Long: `Monitor a chain for light client attacks.
+
+The monitor follows the chain with a light client, verifying every height
+from the primary and cross-checking it with the witnesses. When a provider
+diverges, the evidence of the attack is reported to all the providers it
+doesn't accuse, and the accused providers are dropped: an accused primary is
+replaced by an honest witness.
+The attacks are alerted in the logs and counted in the Prometheus metrics.
+
+Furthermore to the chainID, a fresh instance of the monitor will need a primary
+RPC address, a trusted hash and height and witness RPC addresses. To restart the
+monitor, thereafter only the chainID is required.
+`,
+	RunE: runMonitor,
+	Args: cobra.ExactArgs(1),
+	Example: `monitor cosmoshub-3 -p http://52.57.29.196:26657 -w http://public-seed-node.cosmoshub.certus.one:26657
+	--height 962118 --hash 28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD`,
+}
+
+var (
+	monitorPrimaryAddr        string
+	monitorWitnessAddrsJoined string
+	monitorHome               string
+	monitorTrustingPeriod     time.Duration
+	monitorTrustedHeight      int64
+	monitorTrustedHash        []byte
+	monitorTrustLevelStr      string
+	monitorInterval           time.Duration
+	monitorPrometheusAddr     string
+)
+
+func init() {
+	MonitorCmd.Flags().StringVarP(&monitorPrimaryAddr, "primary", "p", "",
+		"connect to an Ostracon node at this address")
+	MonitorCmd.Flags().StringVarP(&monitorWitnessAddrsJoined, "witnesses", "w", "",
+		"ostracon nodes to cross-check the primary node, comma-separated")
+	MonitorCmd.Flags().StringVar(&monitorHome, "home-dir", os.ExpandEnv(filepath.Join("$HOME", ".ostracon-monitor")),
+		"specify the home directory")
+	MonitorCmd.Flags().DurationVar(&monitorTrustingPeriod, "trusting-period", 168*time.Hour,
+		"trusting period that headers can be verified within. Should be significantly less than the unbonding period")
+	MonitorCmd.Flags().Int64Var(&monitorTrustedHeight, "height", 1, "Trusted header's height")
+	MonitorCmd.Flags().BytesHexVar(&monitorTrustedHash, "hash", []byte{}, "Trusted header's hash")
+	MonitorCmd.Flags().StringVar(&monitorTrustLevelStr, "trust-level", "1/3",
+		"trust level. Must be between 1/3 and 3/3",
+	)
+	MonitorCmd.Flags().DurationVar(&monitorInterval, "interval", time.Second,
+		"interval of polling the primary for new heights")
+	MonitorCmd.Flags().StringVar(&monitorPrometheusAddr, "prometheus-laddr", ":26660",
+		"serve the Prometheus metrics on the given address, disabled if empty")
+}
+
+func runMonitor(cmd *cobra.Command, args []string) error {
+	monitorLogger := logger.With("module", "monitor")
+
+	chainID := args[0]
+	monitorLogger.Info("Creating client...", "chainID", chainID)
+
+	witnessesAddrs := []string{}
+	if monitorWitnessAddrsJoined != "" {
+		witnessesAddrs = strings.Split(monitorWitnessAddrsJoined, ",")
+	}
+
+	db, err := dbm.NewGoLevelDB("light-monitor-db", monitorHome)
+	if err != nil {
+		return fmt.Errorf("can't create a db: %w", err)
+	}
+
+	primaryAddr := monitorPrimaryAddr
+	if primaryAddr == "" { // check to see if we can start from an existing state
+		primaryAddr, witnessesAddrs, err = checkForExistingProviders(db)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve primary or witness from db: %w", err)
+		}
+		if primaryAddr == "" {
+			return errors.New("no primary address was provided nor found. Please provide a primary (using -p)." +
+				" Run the command: ostracon monitor --help for more information")
+		}
+	} else {
+		err := saveProviders(db, primaryAddr, monitorWitnessAddrsJoined)
+		if err != nil {
+			monitorLogger.Error("Unable to save primary and or witness addresses", "err", err)
+		}
+	}
+
+	trustLevel, err := tmmath.ParseFraction(monitorTrustLevelStr)
+	if err != nil {
+		return fmt.Errorf("can't parse trust level: %w", err)
+	}
+
+	options := []light.Option{
+		light.Logger(logger.With("module", "light")),
+		light.SkippingVerification(trustLevel),
+	}
+
+	var c *light.Client
+	if monitorTrustedHeight > 0 && len(monitorTrustedHash) > 0 { // fresh installation
+		c, err = light.NewHTTPClient(
+			context.Background(),
+			chainID,
+			light.TrustOptions{
+				Period: monitorTrustingPeriod,
+				Height: monitorTrustedHeight,
+				Hash:   monitorTrustedHash,
+			},
+			primaryAddr,
+			witnessesAddrs,
+			dbs.New(db, chainID),
+			options...,
+		)
+	} else { // continue from latest state
+		c, err = light.NewHTTPClientFromTrustedStore(
+			chainID,
+			monitorTrustingPeriod,
+			primaryAddr,
+			witnessesAddrs,
+			dbs.New(db, chainID),
+			options...,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	metrics := light.NopMetrics()
+	var prometheusSrv *http.Server
+	if monitorPrometheusAddr != "" {
+		metrics = light.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+		prometheusSrv = &http.Server{
+			Addr: monitorPrometheusAddr,
+			Handler: promhttp.InstrumentMetricHandler(
+				prometheus.DefaultRegisterer, promhttp.HandlerFor(
+					prometheus.DefaultGatherer,
+					promhttp.HandlerOpts{MaxRequestsInFlight: config.Instrumentation.MaxOpenConnections},
+				),
+			),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := prometheusSrv.ListenAndServe(); err != http.ErrServerClosed {
+				// Error starting or closing listener:
+				monitorLogger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+			}
+		}()
+	}
+
+	monitor := light.NewMonitor(c, monitorInterval, metrics)
+	monitor.SetLogger(monitorLogger)
+	monitorLogger.Info("Starting monitor...", "interval", monitorInterval)
+	if err := monitor.Start(); err != nil {
+		return err
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(monitorLogger, func() {
+		if err := monitor.Stop(); err != nil {
+			monitorLogger.Error("Error while stopping the monitor", "err", err)
+		}
+		if prometheusSrv != nil {
+			if err := prometheusSrv.Shutdown(context.Background()); err != nil {
+				monitorLogger.Error("Prometheus HTTP server Shutdown", "err", err)
+			}
+		}
+		if err := db.Close(); err != nil {
+			monitorLogger.Error("Error while closing the db", "err", err)
+		}
+	})
+
+	// Run forever.
+	select {}
+}
@@ -12,9 +12,10 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
-	prototmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	tmcfg "github.com/Finschia/ostracon/config"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	blockmocks "github.com/Finschia/ostracon/state/indexer/mocks"
 	"github.com/Finschia/ostracon/state/mocks"
 	txmocks "github.com/Finschia/ostracon/state/txindex/mocks"
@@ -142,9 +143,9 @@ func TestReIndexEvent(t *testing.T) {
 		On("LoadBlock", height).Return(&types.Block{Data: types.Data{Txs: types.Txs{make(types.Tx, 1)}}})
 
 	dtx := abcitypes.ResponseDeliverTx{}
-	abciResp := &prototmstate.ABCIResponses{
+	abciResp := &ocstate.ABCIResponses{
 		DeliverTxs: []*abcitypes.ResponseDeliverTx{&dtx},
-		EndBlock:   &abcitypes.ResponseEndBlock{},
+		EndBlock:   &ocabci.ResponseEndBlock{},
 		BeginBlock: &abcitypes.ResponseBeginBlock{},
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Finschia/ostracon/libs/log"
 	tmos "github.com/Finschia/ostracon/libs/os"
+	tmstrings "github.com/Finschia/ostracon/libs/strings"
 	"github.com/Finschia/ostracon/privval"
 )
 
@@ -59,11 +60,18 @@ func resetAllCmd(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	keyProvider, err := privValidatorKeyProvider(config)
+	if err != nil {
+		return err
+	}
+
 	return resetAll(
 		config.DBDir(),
 		config.P2P.AddrBookFile(),
 		config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(),
+		keyProvider,
+		config.PrivValidatorKeyKDF,
 		logger,
 	)
 }
@@ -76,19 +84,27 @@ func resetPrivValidator(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	keyProvider, err := privValidatorKeyProvider(config)
+	if err != nil {
+		return err
+	}
+
+	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), keyProvider, config.PrivValidatorKeyKDF, logger)
 	return nil
 }
 
 // resetAll removes address book files plus all data, and resets the privValdiator data.
-func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string,
+	keyProvider privval.KeyProvider, kdf string, logger log.Logger) error {
+	var keep []string
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
+		keep = append(keep, addrBookDBName)
 	} else {
 		removeAddrBook(addrBookFile, logger)
 	}
 
-	if err := os.RemoveAll(dbDir); err == nil {
+	if err := removeAllExcept(dbDir, keep...); err == nil {
 		logger.Info("Removed all blockchain history", "dir", dbDir)
 	} else {
 		logger.Error("Error removing all blockchain history", "dir", dbDir, "err", err)
@@ -99,7 +115,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	resetFilePV(privValKeyFile, privValStateFile, logger)
+	resetFilePV(privValKeyFile, privValStateFile, keyProvider, kdf, logger)
 	return nil
 }
 
@@ -157,9 +173,12 @@ func resetState(dbDir string, logger log.Logger) error {
 	return nil
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
+// resetFilePV resets the private validator state, or generates a new private
+// validator, encrypted with the kdf if the keyProvider isn't nil.
+func resetFilePV(privValKeyFile, privValStateFile string, keyProvider privval.KeyProvider, kdf string,
+	logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv := privval.LoadFilePVEmptyStateWithKeyProvider(privValKeyFile, privValStateFile, keyProvider)
 		pv.Reset()
 		logger.Info(
 			"Reset private validator file to genesis state",
@@ -167,8 +186,7 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 			"stateFile", privValStateFile,
 		)
 	} else {
-		pv := privval.GenFilePV(privValKeyFile, privValStateFile)
-		pv.Save()
+		privval.LoadOrGenFilePVWithKeyProvider(privValKeyFile, privValStateFile, keyProvider, kdf)
 		logger.Info(
 			"Generated private validator file",
 			"keyFile", privValKeyFile,
@@ -177,6 +195,28 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	}
 }
 
+// removeAllExcept removes dir, or only its entries but the ones to keep.
+func removeAllExcept(dir string, keep ...string) error {
+	if len(keep) == 0 {
+		return os.RemoveAll(dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if tmstrings.StringInSlice(entry.Name(), keep) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func removeAddrBook(addrBookFile string, logger log.Logger) {
 	if err := os.Remove(addrBookFile); err == nil {
 		logger.Info("Removed existing address book", "file", addrBookFile)
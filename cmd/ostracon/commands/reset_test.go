@@ -49,7 +49,7 @@ func Test_ResetAll(t *testing.T) {
 	pv.LastSignState.Height = 10
 	pv.Save()
 	require.NoError(t, resetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(), logger))
+		config.PrivValidatorStateFile(), nil, "", logger))
 	require.DirExists(t, config.DBDir())
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "block.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "state.db"))
@@ -60,6 +60,24 @@ func Test_ResetAll(t *testing.T) {
 	require.Equal(t, int64(0), pv.LastSignState.Height)
 }
 
+func Test_ResetAllKeepAddrBook(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(config))
+	for _, name := range []string{"state.db", addrBookDBName} {
+		require.NoError(t, os.MkdirAll(filepath.Join(config.DBDir(), name), 0o700))
+	}
+	keepAddrBook = true
+	t.Cleanup(func() { keepAddrBook = false })
+	require.NoError(t, resetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
+		config.PrivValidatorStateFile(), nil, "", logger))
+	require.NoDirExists(t, filepath.Join(config.DBDir(), "state.db"))
+	require.DirExists(t, filepath.Join(config.DBDir(), addrBookDBName))
+	require.FileExists(t, config.PrivValidatorStateFile())
+}
+
 func Test_ResetState(t *testing.T) {
 	config := cfg.TestConfig()
 	dir := t.TempDir()
@@ -28,7 +28,12 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"priv_validator_laddr",
 		config.PrivValidatorListenAddr,
-		"socket address to listen on for connections from external priv_validator process")
+		"socket address to listen on for connections from external priv_validator process"+
+			" (comma separated addresses for a cluster of signers)")
+	cmd.Flags().Int(
+		"priv_validator_threshold",
+		config.PrivValidatorThreshold,
+		"number of signers of the cluster which must agree on each signature (0 for the smallest majority)")
 
 	// node flags
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "fast blockchain syncing")
@@ -40,6 +45,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64("consensus.double_sign_check_height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
+	cmd.Flags().Int64("consensus.double_sign_guard_wait", config.Consensus.DoubleSignGuardWait,
+		"how many blocks to observe the network for before signing anything")
+	cmd.Flags().Bool("consensus.compact_blocks", config.Consensus.CompactBlocks,
+		"relay proposal blocks as compact blocks rebuilt from the mempool")
 
 	// abci flags
 	cmd.Flags().String(
@@ -63,6 +72,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"p2p.laddr",
 		config.P2P.ListenAddress,
 		"node listen address. (0.0.0.0:0 means any interface, any port)")
+	cmd.Flags().String(
+		"p2p.quic_laddr",
+		config.P2P.QUICListenAddress,
+		"node QUIC listen address. (empty means QUIC is disabled)")
 	cmd.Flags().String("p2p.external-address",
 		config.P2P.ExternalAddress, "ip:port address to advertise to peers for them to dial")
 	cmd.Flags().String("p2p.seeds", config.P2P.Seeds, "comma-delimited ID@host:port seed nodes")
@@ -72,7 +85,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "enable/disable seed mode")
+	cmd.Flags().Bool("p2p.census", config.P2P.Census, "keep a census of the nodes crawled in seed mode")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
+	cmd.Flags().String("p2p.topology", config.P2P.Topology,
+		"node topology (\"full\", \"sentry\" or \"validator-behind-sentries\")")
 
 	// consensus flags
 	cmd.Flags().Bool(
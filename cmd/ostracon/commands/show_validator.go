@@ -31,7 +31,7 @@ func showValidator(cmd *cobra.Command, args []string, config *cfg.Config) error
 		if err != nil {
 			return err
 		}
-		pv, err = node.CreateAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, chainID, logger)
+		pv, err = node.CreateAndStartPrivValidatorClient(config, chainID, logger)
 		if err != nil {
 			return err
 		}
@@ -40,7 +40,15 @@ func showValidator(cmd *cobra.Command, args []string, config *cfg.Config) error
 		if !tmos.FileExists(keyFilePath) {
 			return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 		}
-		pv = privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
+		keyProvider, err := privValidatorKeyProvider(config)
+		if err != nil {
+			return err
+		}
+		pvKey, err := privval.LoadFilePVKey(keyFilePath, keyProvider)
+		if err != nil {
+			return err
+		}
+		pv = privval.NewFilePV(pvKey.PrivKey, keyFilePath, config.PrivValidatorStateFile())
 	}
 
 	pubKey, err := pv.GetPubKey()
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/privval"
+)
+
+// VerifySignerLogCmd checks the hash chain of a signer audit log.
+var VerifySignerLogCmd = &cobra.Command{
+	Use:   "verify-signer-log [file]",
+	Short: "Verify the integrity of a signer audit log",
+	Long: `Verify the hash chain of a signer audit log, including its rotated files,
+from its first entry. It fails on the first entry which was altered, removed or
+reordered.
+
+The log defaults to priv_validator_audit_log_file.`,
+	Example: `
+	ostracon verify-signer-log
+	ostracon verify-signer-log /var/log/signer/audit.log
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return verifySignerLog(cmd, args, config)
+	},
+}
+
+func verifySignerLog(cmd *cobra.Command, args []string, config *cfg.Config) error {
+	path := config.PrivValidatorAuditLogFile()
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "" {
+		return errors.New("no signer audit log given and priv_validator_audit_log_file is not set")
+	}
+
+	n, err := privval.VerifySignerAuditLog(path)
+	if err != nil {
+		return fmt.Errorf("signer audit log %s is invalid: %w", path, err)
+	}
+	cmd.Printf("Verified %d entries of %s\n", n, path)
+	return nil
+}
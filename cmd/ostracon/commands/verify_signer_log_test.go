@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/privval"
+)
+
+func TestVerifySignerLog(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+
+	// not configured
+	require.Error(t, verifySignerLog(VerifySignerLogCmd, nil, config))
+
+	config.PrivValidatorAuditLog = "data/signer_audit.log"
+	auditLog, err := privval.OpenSignerAuditLog(config.PrivValidatorAuditLogFile())
+	require.NoError(t, err)
+	require.NoError(t, auditLog.LogPubKey("local", nil))
+	require.NoError(t, auditLog.LogVRFProof("local", []byte("seed"), nil))
+	require.NoError(t, auditLog.Close())
+
+	out := new(bytes.Buffer)
+	VerifySignerLogCmd.SetOut(out)
+	t.Cleanup(func() { VerifySignerLogCmd.SetOut(nil) })
+	require.NoError(t, verifySignerLog(VerifySignerLogCmd, nil, config))
+	assert.Contains(t, out.String(), "Verified 2 entries")
+
+	// the log given as argument
+	other := filepath.Join(dir, "other.log")
+	require.NoError(t, os.WriteFile(other, []byte("{}\n"), 0o600))
+	assert.Error(t, verifySignerLog(VerifySignerLogCmd, []string{other}, config))
+	assert.Error(t, verifySignerLog(VerifySignerLogCmd, []string{filepath.Join(dir, "missing.log")}, config))
+}
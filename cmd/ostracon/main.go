@@ -17,6 +17,7 @@ func main() {
 		cmd.GenValidatorCmd,
 		cmd.ProbeUpnpCmd,
 		cmd.LightCmd,
+		cmd.MonitorCmd,
 		cmd.ReIndexEventCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
@@ -30,6 +31,9 @@ func main() {
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.AddrBookCmd,
+		cmd.EncryptValidatorKeyCmd,
+		cmd.VerifySignerLogCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
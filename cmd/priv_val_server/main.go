@@ -19,6 +19,8 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		passphrasePath   = flag.String("passphrase", "", "file path of the passphrase of an encrypted priv val key file")
+		auditLogPath     = flag.String("audit-log", "", "file path of the audit log of the signed messages")
 
 		logger = log.NewOCLogger(
 			log.NewSyncWriter(os.Stdout),
@@ -34,7 +36,11 @@ func main() {
 		"privStatePath", *privValStatePath,
 	)
 
-	pv := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
+	keyProvider, err := privval.NewKeyProvider(*passphrasePath, "")
+	if err != nil {
+		panic(err)
+	}
+	pv := privval.LoadFilePVWithKeyProvider(*privValKeyPath, *privValStatePath, keyProvider)
 
 	var dialer privval.SocketDialer
 	protocol, address := tmnet.ProtocolAndAddress(*addr)
@@ -52,7 +58,16 @@ func main() {
 	sd := privval.NewSignerDialerEndpoint(logger, dialer)
 	ss := privval.NewSignerServer(sd, *chainID, pv)
 
-	err := ss.Start()
+	var auditLog *privval.SignerAuditLog
+	if *auditLogPath != "" {
+		auditLog, err = privval.OpenSignerAuditLog(*auditLogPath)
+		if err != nil {
+			panic(err)
+		}
+		ss.SetAuditLog(auditLog)
+	}
+
+	err = ss.Start()
 	if err != nil {
 		panic(err)
 	}
@@ -63,6 +78,11 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
+		if auditLog != nil {
+			if err := auditLog.Close(); err != nil {
+				panic(err)
+			}
+		}
 	})
 
 	// Run forever.
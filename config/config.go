@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,6 +35,25 @@ const (
 	MempoolV0 = "v0"
 	// MempoolV1 is prioritized mempool
 	MempoolV1 = "v1"
+
+	// P2P topologies.
+	// Default is full.
+
+	// TopologyFull is a node connecting to any peer
+	TopologyFull = "full"
+	// TopologySentry is a node shielding the validators of its private peers
+	TopologySentry = "sentry"
+	// TopologyValidatorBehindSentries is a validator connecting to its sentries
+	// only
+	TopologyValidatorBehindSentries = "validator-behind-sentries"
+
+	// Address book backends.
+	// Default is db.
+
+	// AddrBookBackendDB is the addrbook database, updated every 10 seconds
+	AddrBookBackendDB = "db"
+	// AddrBookBackendFile is the addr_book_file, rewritten periodically
+	AddrBookBackendFile = "file"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -49,16 +70,18 @@ var (
 	defaultConfigFileName  = "config.toml"
 	defaultGenesisJSONName = "genesis.json"
 
-	defaultPrivValKeyName   = "priv_validator_key.json"
-	defaultPrivValStateName = "priv_validator_state.json"
+	defaultPrivValKeyName     = "priv_validator_key.json"
+	defaultPrivValNextKeyName = "priv_validator_next_key.json"
+	defaultPrivValStateName   = "priv_validator_state.json"
 
 	defaultNodeKeyName  = "node_key.json"
 	defaultAddrBookName = "addrbook.json"
 
-	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
-	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
-	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
-	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
+	defaultConfigFilePath     = filepath.Join(defaultConfigDir, defaultConfigFileName)
+	defaultGenesisJSONPath    = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
+	defaultPrivValKeyPath     = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
+	defaultPrivValNextKeyPath = filepath.Join(defaultConfigDir, defaultPrivValNextKeyName)
+	defaultPrivValStatePath   = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
@@ -172,6 +195,21 @@ type BaseConfig struct { //nolint: maligned
 	// or the name of an ABCI application compiled in with the Ostracon binary
 	ProxyApp string `mapstructure:"proxy_app"`
 
+	// Comma separated TCP or UNIX socket addresses of read replicas of the
+	// ABCI application to send the Query requests of the query connection to,
+	// round-robin between the healthy ones, instead of proxy_app. The other
+	// requests of the query connection, like Info, are still sent to proxy_app.
+	ProxyAppQuery string `mapstructure:"proxy_app_query"`
+
+	// Interval between the health checks of the read replicas of
+	// proxy_app_query
+	ProxyAppQueryHealthCheckInterval time.Duration `mapstructure:"proxy_app_query_health_check_interval"`
+
+	// TCP or UNIX socket address of a process to open the snapshot connection
+	// to instead of proxy_app, e.g. a snapshotting sidecar of the ABCI
+	// application
+	ProxyAppSnapshot string `mapstructure:"proxy_app_snapshot"`
+
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
@@ -240,16 +278,89 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Path to the JSON file containing the next private key of a validator key rotation, if it exists.
+	// It replaces priv_validator_key_file once the validator set uses it.
+	PrivValidatorNextKey string `mapstructure:"priv_validator_next_key_file"`
+
+	// Path to the append-only, hash-chained log of the pubkey requests, the messages signed and the VRF
+	// proofs generated by the private validator of priv_validator_key_file. If empty, they aren't logged.
+	// The log is rotated every 10MB and its oldest files are removed above 1GB.
+	PrivValidatorAuditLog string `mapstructure:"priv_validator_audit_log_file"`
+
+	// Path to a file containing the passphrase which encrypts priv_validator_key_file.
+	// If neither it nor priv_validator_key_passphrase_env is set, the key file isn't encrypted.
+	PrivValidatorKeyPassphrase string `mapstructure:"priv_validator_key_passphrase_file"`
+
+	// Name of an environment variable containing the passphrase which encrypts
+	// priv_validator_key_file
+	PrivValidatorKeyPassphraseEnv string `mapstructure:"priv_validator_key_passphrase_env"`
+
+	// Key derivation function of the passphrase of a new encrypted
+	// priv_validator_key_file: scrypt | argon2id
+	PrivValidatorKeyKDF string `mapstructure:"priv_validator_key_kdf"`
+
 	// TCP or UNIX socket address for Ostracon to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process.
+	// A comma separated list of addresses makes a cluster of signers holding the
+	// same key, of which priv_validator_threshold must agree on each signature.
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Number of signers of the cluster which must agree on each signature, if
+	// priv_validator_laddr lists several addresses. It must be a majority of them.
+	// 0 means the smallest majority.
+	PrivValidatorThreshold int `mapstructure:"priv_validator_threshold"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// If true, reconnect to the ABCI application when a connection fails, e.g.
+	// because the application crashed, instead of stopping the node. The
+	// consensus pauses until the application is back, and replays the blocks the
+	// application lost.
+	ABCIReconnect bool `mapstructure:"abci_reconnect"`
+
+	// Maximum delay between the attempts to reconnect to the ABCI application
+	ABCIReconnectMaxBackoff time.Duration `mapstructure:"abci_reconnect_max_backoff"`
+
+	// If true, deliver the txs of a block not conflicting with each other in
+	// batches the ABCI application may execute concurrently. The conflicts are
+	// found by the keys the application returns to TxAccessSets for each tx.
+	ABCIParallelDeliverTx bool `mapstructure:"abci_parallel_deliver_tx"`
+
+	// If true, start executing a proposed block as soon as it's received,
+	// while voting for it, and commit the result if the block is decided. The
+	// ABCI application must implement AbortBlock, which discards the changes of
+	// a block which isn't decided.
+	ABCIOptimisticExecution bool `mapstructure:"abci_optimistic_execution"`
+
+	// Path to a file to append every request sent to the ABCI application and
+	// its response to, to replay them with "abci-cli replay". If empty, they
+	// aren't recorded.
+	ABCIRecord string `mapstructure:"abci_record_file"`
+
+	// Paths to the certificate and key the node authenticates with to the ABCI
+	// application, and to the certificate of the CA the application's
+	// certificate must be issued by. If they're set, the node connects to the
+	// application over mutual TLS, with both the socket and grpc mechanisms.
+	ABCITLSCert string `mapstructure:"abci_tls_cert_file"`
+	ABCITLSKey  string `mapstructure:"abci_tls_key_file"`
+	ABCITLSCA   string `mapstructure:"abci_tls_ca_file"`
+
+	// Name the ABCI application's certificate must be issued for. If empty,
+	// the host of proxy_app is used.
+	ABCITLSServerName string `mapstructure:"abci_tls_server_name"`
+
+	// Common names of the ABCI application's certificate the node accepts. If
+	// empty, any certificate issued by the CA is accepted.
+	ABCITLSAllowedCNs []string `mapstructure:"abci_tls_allowed_cns"`
+
+	// Maximum size in bytes of a response of the ABCI application. 0 means the
+	// default of the mechanism: 100MB for socket and 4MB for grpc.
+	ABCIMaxMsgSize int `mapstructure:"abci_max_msg_size"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -258,23 +369,31 @@ type BaseConfig struct { //nolint: maligned
 // DefaultBaseConfig returns a default base configuration for an Ostracon node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultPackageLogLevels(),
-		LogFormat:          LogFormatPlain,
-		LogPath:            "",
-		LogMaxAge:          0,
-		LogMaxSize:         100,
-		LogMaxBackups:      0,
-		FastSyncMode:       true,
-		FilterPeers:        false,
-		DBBackend:          DefaultDBBackend,
-		DBPath:             "data",
+		Genesis:                          defaultGenesisJSONPath,
+		PrivValidatorKey:                 defaultPrivValKeyPath,
+		PrivValidatorState:               defaultPrivValStatePath,
+		PrivValidatorNextKey:             defaultPrivValNextKeyPath,
+		PrivValidatorKeyKDF:              "scrypt",
+		PrivValidatorThreshold:           0,
+		NodeKey:                          defaultNodeKeyPath,
+		Moniker:                          defaultMoniker,
+		ProxyApp:                         "tcp://127.0.0.1:26658",
+		ProxyAppQueryHealthCheckInterval: 5 * time.Second,
+		ABCI:                             "socket",
+		ABCIReconnect:                    false,
+		ABCIReconnectMaxBackoff:          10 * time.Second,
+		ABCIParallelDeliverTx:            false,
+		ABCIOptimisticExecution:          false,
+		LogLevel:                         DefaultPackageLogLevels(),
+		LogFormat:                        LogFormatPlain,
+		LogPath:                          "",
+		LogMaxAge:                        0,
+		LogMaxSize:                       100,
+		LogMaxBackups:                    0,
+		FastSyncMode:                     true,
+		FilterPeers:                      false,
+		DBBackend:                        DefaultDBBackend,
+		DBPath:                           "data",
 	}
 }
 
@@ -302,6 +421,71 @@ func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
 }
 
+// PrivValidatorNextKeyFile returns the full path to the priv_validator_next_key.json file
+func (cfg BaseConfig) PrivValidatorNextKeyFile() string {
+	return rootify(cfg.PrivValidatorNextKey, cfg.RootDir)
+}
+
+// PrivValidatorAuditLogFile returns the full path to the audit log of the
+// private validator, if any
+func (cfg BaseConfig) PrivValidatorAuditLogFile() string {
+	if cfg.PrivValidatorAuditLog == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorAuditLog, cfg.RootDir)
+}
+
+// ABCIRecordFile returns the full path to the file recording the ABCI
+// requests and responses, if any
+func (cfg BaseConfig) ABCIRecordFile() string {
+	if cfg.ABCIRecord == "" {
+		return ""
+	}
+	return rootify(cfg.ABCIRecord, cfg.RootDir)
+}
+
+// ABCITLSCertFile returns the full path to the certificate the node
+// authenticates with to the ABCI application, if any
+func (cfg BaseConfig) ABCITLSCertFile() string {
+	if cfg.ABCITLSCert == "" {
+		return ""
+	}
+	return rootify(cfg.ABCITLSCert, cfg.RootDir)
+}
+
+// ABCITLSKeyFile returns the full path to the key of the certificate the node
+// authenticates with to the ABCI application, if any
+func (cfg BaseConfig) ABCITLSKeyFile() string {
+	if cfg.ABCITLSKey == "" {
+		return ""
+	}
+	return rootify(cfg.ABCITLSKey, cfg.RootDir)
+}
+
+// ABCITLSCAFile returns the full path to the certificate of the CA issuing the
+// ABCI application's certificate, if any
+func (cfg BaseConfig) ABCITLSCAFile() string {
+	if cfg.ABCITLSCA == "" {
+		return ""
+	}
+	return rootify(cfg.ABCITLSCA, cfg.RootDir)
+}
+
+// IsABCITLSEnabled returns true if the node connects to the ABCI application
+// over mutual TLS.
+func (cfg BaseConfig) IsABCITLSEnabled() bool {
+	return cfg.ABCITLSCert != "" && cfg.ABCITLSKey != "" && cfg.ABCITLSCA != ""
+}
+
+// PrivValidatorKeyPassphraseFile returns the full path to the file containing
+// the passphrase of the priv_validator_key.json file, if any
+func (cfg BaseConfig) PrivValidatorKeyPassphraseFile() string {
+	if cfg.PrivValidatorKeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorKeyPassphrase, cfg.RootDir)
+}
+
 // PrivValidatorFile returns the full path to the priv_validator_state.json file
 func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
@@ -325,9 +509,75 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.PrivValidatorKeyPassphrase != "" && cfg.PrivValidatorKeyPassphraseEnv != "" {
+		return errors.New("priv_validator_key_passphrase_file and priv_validator_key_passphrase_env can't be both set")
+	}
+	switch cfg.PrivValidatorKeyKDF {
+	case "scrypt", "argon2id":
+	default:
+		return errors.New("unknown priv_validator_key_kdf (must be 'scrypt' or 'argon2id')")
+	}
+	if cfg.ABCIReconnectMaxBackoff < 0 {
+		return errors.New("abci_reconnect_max_backoff can't be negative")
+	}
+	if cfg.ABCIReconnect && cfg.ABCIReconnectMaxBackoff == 0 {
+		return errors.New("abci_reconnect_max_backoff must be positive if abci_reconnect is set")
+	}
+	if !cfg.IsABCITLSEnabled() && (cfg.ABCITLSCert != "" || cfg.ABCITLSKey != "" || cfg.ABCITLSCA != "") {
+		return errors.New("abci_tls_cert_file, abci_tls_key_file and abci_tls_ca_file must be all set or all empty")
+	}
+	if cfg.ProxyAppQueryHealthCheckInterval < 0 {
+		return errors.New("proxy_app_query_health_check_interval can't be negative")
+	}
+	if len(cfg.ProxyAppQueryAddrs()) > 0 && cfg.ProxyAppQueryHealthCheckInterval == 0 {
+		return errors.New("proxy_app_query_health_check_interval must be positive if proxy_app_query is set")
+	}
+	if cfg.ABCIMaxMsgSize < 0 {
+		return errors.New("abci_max_msg_size can't be negative")
+	}
+	if cfg.PrivValidatorThreshold < 0 {
+		return errors.New("priv_validator_threshold can't be negative")
+	}
+	if n := len(cfg.PrivValidatorListenAddrs()); cfg.PrivValidatorThreshold > 0 &&
+		(cfg.PrivValidatorThreshold <= n/2 || cfg.PrivValidatorThreshold > n) {
+		return fmt.Errorf("priv_validator_threshold must be a majority of the %d addresses of priv_validator_laddr", n)
+	}
 	return nil
 }
 
+// PrivValidatorListenAddrs returns the addresses of priv_validator_laddr, more
+// than one for a cluster of signers.
+func (cfg BaseConfig) PrivValidatorListenAddrs() []string {
+	var addrs []string
+	for _, addr := range strings.Split(cfg.PrivValidatorListenAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// ProxyAppQueryAddrs returns the addresses of proxy_app_query, more than one
+// for a pool of read replicas.
+func (cfg BaseConfig) ProxyAppQueryAddrs() []string {
+	var addrs []string
+	for _, addr := range strings.Split(cfg.ProxyAppQuery, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// PrivValidatorClusterThreshold returns the number of signers which must agree
+// on each signature: priv_validator_threshold, or the smallest majority if 0.
+func (cfg BaseConfig) PrivValidatorClusterThreshold() int {
+	if cfg.PrivValidatorThreshold > 0 {
+		return cfg.PrivValidatorThreshold
+	}
+	return len(cfg.PrivValidatorListenAddrs())/2 + 1
+}
+
 // DefaultPackageLogLevels returns a default log level setting so all packages
 // log at "error", while the `state` and `main` packages log at "info"
 func DefaultPackageLogLevels() string {
@@ -599,6 +849,10 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Address to listen for incoming QUIC connections. If empty, QUIC is
+	// disabled, but peers can still be dialed over TCP.
+	QUICListenAddress string `mapstructure:"quic_laddr"`
+
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
@@ -615,6 +869,12 @@ type P2PConfig struct { //nolint: maligned
 	// Path to address book
 	AddrBook string `mapstructure:"addr_book_file"`
 
+	// Storage of the address book:
+	//   1) "db" - the addrbook database of db_dir, updated every 10 seconds, to
+	//   which the addr_book_file is migrated the first time (default)
+	//   2) "file" - the addr_book_file, rewritten every 2 minutes
+	AddrBookBackend string `mapstructure:"addr_book_backend"`
+
 	// Set true for strict address routability rules
 	// Set false for private or local networks
 	AddrBookStrict bool `mapstructure:"addr_book_strict"`
@@ -643,6 +903,16 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Comma separated list of channel IDs and the rates at which packets can be
+	// sent on them, in bytes/second (e.g. "0x30:102400")
+	ChannelSendRates string `mapstructure:"channel_send_rates"`
+
+	// Comma separated list of channel IDs and the rates at which packets can be
+	// received on them, in bytes/second (e.g. "0x30:102400"). The messages
+	// received over the rate are dropped, so only the channels tolerating
+	// message loss accept a rate (see RecvRateChannels).
+	ChannelRecvRates string `mapstructure:"channel_recv_rates"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -652,6 +922,16 @@ type P2PConfig struct { //nolint: maligned
 	// Does not work if the peer-exchange reactor is disabled.
 	SeedMode bool `mapstructure:"seed_mode"`
 
+	// Keep a census of the nodes crawled in seed mode: their version, channels,
+	// latest height and reachability. It's exposed by the net_census RPC.
+	//
+	// Requires the seed mode.
+	Census bool `mapstructure:"census"`
+
+	// How long the census keeps a node which was neither seen nor dialed.
+	// 0 keeps the nodes forever.
+	CensusRetention time.Duration `mapstructure:"census_retention"`
+
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private_peer_ids"`
@@ -659,10 +939,30 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Topology of the node, which drives its dialing policy:
+	//   1) "full" - connect to any peer (default)
+	//   2) "sentry" - shield the validators of private_peer_ids, which are
+	//   always accepted and never gossiped
+	//   3) "validator-behind-sentries" - connect to the sentries of
+	//   persistent_peers only, without PEX, failing over to backup_sentries
+	//   while some of them are down
+	Topology string `mapstructure:"topology"`
+
+	// Comma separated list of sentries to connect to while some of the
+	// persistent peers are down, with the validator-behind-sentries topology
+	BackupSentries string `mapstructure:"backup_sentries"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
 
+	// Offer the Noise_XX handshake for the encrypted connections, which is
+	// used with peers offering it too. Their keys are rotated after
+	// SecretConnRekeyBytes or SecretConnRekeyInterval (0 means no limit).
+	SecretConnNoise         bool          `mapstructure:"secret_conn_noise"`
+	SecretConnRekeyBytes    int64         `mapstructure:"secret_conn_rekey_bytes"`
+	SecretConnRekeyInterval time.Duration `mapstructure:"secret_conn_rekey_interval"`
+
 	// Reactor async receive
 	RecvAsync bool `mapstructure:"recv_async"`
 
@@ -686,9 +986,11 @@ type P2PConfig struct { //nolint: maligned
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
+		QUICListenAddress:            "",
 		ExternalAddress:              "",
 		UPNP:                         false,
 		AddrBook:                     defaultAddrBookPath,
+		AddrBookBackend:              AddrBookBackendDB,
 		AddrBookStrict:               true,
 		MaxNumInboundPeers:           40,
 		MaxNumOutboundPeers:          10,
@@ -699,9 +1001,16 @@ func DefaultP2PConfig() *P2PConfig {
 		RecvRate:                     5120000, // 5 mB/s
 		PexReactor:                   true,
 		SeedMode:                     false,
+		Census:                       false,
+		CensusRetention:              7 * 24 * time.Hour,
 		AllowDuplicateIP:             false,
+		Topology:                     TopologyFull,
+		BackupSentries:               "",
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
+		SecretConnNoise:              false,
+		SecretConnRekeyBytes:         1 << 30, // 1 GB
+		SecretConnRekeyInterval:      10 * time.Minute,
 		RecvAsync:                    true,
 		PexRecvBufSize:               1000,
 		EvidenceRecvBufSize:          1000,
@@ -753,9 +1062,119 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.SecretConnRekeyBytes < 0 {
+		return errors.New("secret_conn_rekey_bytes can't be negative")
+	}
+	if cfg.SecretConnRekeyInterval < 0 {
+		return errors.New("secret_conn_rekey_interval can't be negative")
+	}
+	if cfg.Census && !cfg.SeedMode {
+		return errors.New("census requires seed_mode")
+	}
+	if cfg.CensusRetention < 0 {
+		return errors.New("census_retention can't be negative")
+	}
+	switch cfg.AddrBookBackend {
+	case AddrBookBackendDB, AddrBookBackendFile:
+	default:
+		return fmt.Errorf("unknown addr_book_backend %q, must be %q or %q",
+			cfg.AddrBookBackend, AddrBookBackendDB, AddrBookBackendFile)
+	}
+	switch cfg.Topology {
+	case TopologyFull, TopologySentry, TopologyValidatorBehindSentries:
+	default:
+		return fmt.Errorf("unknown topology %q, must be %q, %q or %q",
+			cfg.Topology, TopologyFull, TopologySentry, TopologyValidatorBehindSentries)
+	}
+	if _, err := ParseChannelRates(cfg.ChannelSendRates); err != nil {
+		return fmt.Errorf("wrong channel_send_rates: %w", err)
+	}
+	recvRates, err := ParseChannelRates(cfg.ChannelRecvRates)
+	if err != nil {
+		return fmt.Errorf("wrong channel_recv_rates: %w", err)
+	}
+	for chID := range recvRates {
+		if !RecvRateChannels[chID] {
+			return fmt.Errorf("wrong channel_recv_rates: channel %#x can't drop messages, "+
+				"only the mempool channel can be rate limited", chID)
+		}
+	}
 	return nil
 }
 
+// TopologyWarnings returns the settings contradicting the topology, which
+// should be logged when starting the node.
+func (cfg *P2PConfig) TopologyWarnings() []string {
+	var warnings []string
+	switch cfg.Topology {
+	case TopologyValidatorBehindSentries:
+		if strings.TrimSpace(cfg.PersistentPeers) == "" {
+			warnings = append(warnings, "no sentry in persistent_peers, the validator won't connect to any peer")
+		}
+		if cfg.PexReactor {
+			warnings = append(warnings, "pex is ignored, the validator doesn't gossip with its sentries")
+		}
+		if cfg.SeedMode {
+			warnings = append(warnings, "seed_mode is ignored, the validator doesn't gossip with its sentries")
+		}
+		if strings.TrimSpace(cfg.Seeds) != "" {
+			warnings = append(warnings, "seeds are ignored, the validator connects to its sentries only")
+		}
+	case TopologySentry:
+		if strings.TrimSpace(cfg.PrivatePeerIDs) == "" {
+			warnings = append(warnings, "no validator in private_peer_ids, the sentry doesn't hide any peer")
+		}
+		if !cfg.PexReactor {
+			warnings = append(warnings, "pex is disabled, the sentry won't discover peers")
+		}
+	}
+	if cfg.Topology != TopologyValidatorBehindSentries && strings.TrimSpace(cfg.BackupSentries) != "" {
+		warnings = append(warnings, fmt.Sprintf("backup_sentries is ignored without the %s topology",
+			TopologyValidatorBehindSentries))
+	}
+	return warnings
+}
+
+// RecvRateChannels are the channels accepting a receive rate, over which the
+// messages are dropped: the mempool channel, since transactions are gossiped
+// again by the other peers. The reactors of the other channels rely on a
+// reliable stream.
+var RecvRateChannels = map[byte]bool{
+	0x30: true,
+}
+
+// ParseChannelRates parses a comma separated list of channel IDs and rates
+// (e.g. "0x30:102400,0x38:51200") into a map from channel IDs to rates.
+func ParseChannelRates(rates string) (map[byte]int64, error) {
+	parsed := map[byte]int64{}
+	for _, item := range strings.Split(rates, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%q is not in the form <channel ID>:<rate>", item)
+		}
+		chID, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel ID %q: %w", parts[0], err)
+		}
+		rate, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q: %w", parts[1], err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate of channel %#x must be positive", chID)
+		}
+		if _, ok := parsed[byte(chID)]; ok {
+			return nil, fmt.Errorf("duplicate channel %#x", chID)
+		}
+		parsed[byte(chID)] = rate
+	}
+	return parsed, nil
+}
+
 // FuzzConnConfig is a FuzzedConnection configuration.
 type FuzzConnConfig struct {
 	Mode         int
@@ -827,6 +1246,15 @@ type MempoolConfig struct {
 	// has existed in the mempool at least TTLNumBlocks number of blocks or if
 	// it's insertion time into the mempool is beyond TTLDuration.
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
+
+	// CheckTxBatchSize, if greater than zero, makes the mempool check the txs
+	// it receives, and recheck its txs, with CheckTxBatch requests of up to
+	// CheckTxBatchSize txs instead of one CheckTx request per tx.
+	CheckTxBatchSize int `mapstructure:"check_tx_batch_size"`
+
+	// CheckTxBatchTimeout is how long the mempool waits for more txs to fill a
+	// batch before sending it to the app anyway.
+	CheckTxBatchTimeout time.Duration `mapstructure:"check_tx_batch_timeout"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Ostracon mempool
@@ -844,6 +1272,9 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxBytes:   1024 * 1024, // 1MB
 		TTLDuration:  0 * time.Second,
 		TTLNumBlocks: 0,
+
+		CheckTxBatchSize:    0,
+		CheckTxBatchTimeout: 5 * time.Millisecond,
 	}
 }
 
@@ -879,6 +1310,12 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.CheckTxBatchSize < 0 {
+		return errors.New("check_tx_batch_size can't be negative")
+	}
+	if cfg.CheckTxBatchTimeout < 0 {
+		return errors.New("check_tx_batch_timeout can't be negative")
+	}
 	return nil
 }
 
@@ -1048,7 +1485,19 @@ type ConsensusConfig struct {
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
+	// Send proposal blocks to peers as compact blocks, the transactions of
+	// which are taken from the mempool, instead of block parts.
+	CompactBlocks bool `mapstructure:"compact_blocks"`
+	// How long to wait for a peer to rebuild a compact block before sending
+	// it the block parts.
+	CompactBlockTimeout time.Duration `mapstructure:"compact_block_timeout"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Number of blocks to observe the network for before signing anything
+	// after a start. No waiting if zero. Requires a private validator which
+	// provides its last sign state.
+	DoubleSignGuardWait int64 `mapstructure:"double_sign_guard_wait"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -1067,7 +1516,10 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		CompactBlocks:               false,
+		CompactBlockTimeout:         1000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		DoubleSignGuardWait:         int64(0),
 	}
 }
 
@@ -1167,9 +1619,15 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer_query_maj23_sleep_duration can't be negative")
 	}
+	if cfg.CompactBlockTimeout < 0 {
+		return errors.New("compact_block_timeout can't be negative")
+	}
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.DoubleSignGuardWait < 0 {
+		return errors.New("double_sign_guard_wait can't be negative")
+	}
 	return nil
 }
 
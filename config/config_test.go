@@ -61,6 +61,57 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = LogFormatPlain
+
+	// tamper with the passphrase of the key file
+	cfg.PrivValidatorKeyPassphrase = "config/passphrase"
+	cfg.PrivValidatorKeyPassphraseEnv = "OC_PASSPHRASE"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorKeyPassphraseEnv = ""
+	cfg.PrivValidatorKeyKDF = "md5"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorKeyKDF = "argon2id"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the abci reconnection
+	cfg.ABCIReconnect = true
+	cfg.ABCIReconnectMaxBackoff = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ABCIReconnectMaxBackoff = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ABCIReconnectMaxBackoff = time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the query replicas
+	cfg.ProxyAppQuery = "tcp://127.0.0.1:26668, tcp://127.0.0.1:26678"
+	assert.Equal(t, []string{"tcp://127.0.0.1:26668", "tcp://127.0.0.1:26678"}, cfg.ProxyAppQueryAddrs())
+	cfg.ProxyAppQueryHealthCheckInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ProxyAppQueryHealthCheckInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ProxyAppQueryHealthCheckInterval = time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the abci tls
+	cfg.ABCITLSCert = "config/abci.crt"
+	cfg.ABCITLSKey = "config/abci.key"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ABCITLSCA = "config/abci-ca.crt"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ABCIMaxMsgSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ABCIMaxMsgSize = 0
+
+	// tamper with the threshold of the signer cluster
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659, tcp://127.0.0.1:26660,tcp://127.0.0.1:26661"
+	assert.Equal(t, 2, cfg.PrivValidatorClusterThreshold())
+	for _, threshold := range []int{-1, 1, 4} {
+		cfg.PrivValidatorThreshold = threshold
+		assert.Error(t, cfg.ValidateBasic(), threshold)
+	}
+	cfg.PrivValidatorThreshold = 3
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, 3, cfg.PrivValidatorClusterThreshold())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -96,6 +147,8 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"SecretConnRekeyBytes",
+		"SecretConnRekeyInterval",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -103,6 +156,70 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Topology = "validator"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Topology = TopologyFull
+
+	cfg.AddrBookBackend = "json"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AddrBookBackend = AddrBookBackendDB
+
+	cfg.Census = true
+	assert.Error(t, cfg.ValidateBasic(), "census without seed mode")
+	cfg.SeedMode = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Census, cfg.SeedMode = false, false
+
+	cfg.CensusRetention = -time.Hour
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CensusRetention = 0
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, rates := range []string{"0x30", "0x30:0", "0x30:-1", "0x100:1", "foo:1", "0x30:1,0x30:2"} {
+		cfg.ChannelSendRates = rates
+		assert.Error(t, cfg.ValidateBasic(), rates)
+		cfg.ChannelSendRates = ""
+		cfg.ChannelRecvRates = rates
+		assert.Error(t, cfg.ValidateBasic(), rates)
+		cfg.ChannelRecvRates = ""
+	}
+	cfg.ChannelRecvRates = "0x30:1024"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ChannelRecvRates = "0x20:1024"
+	assert.Error(t, cfg.ValidateBasic(), "consensus channel can't drop messages")
+	cfg.ChannelRecvRates = ""
+}
+
+func TestP2PConfigTopologyWarnings(t *testing.T) {
+	cfg := TestP2PConfig()
+	assert.Empty(t, cfg.TopologyWarnings())
+	cfg.BackupSentries = "d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:26656"
+	assert.Len(t, cfg.TopologyWarnings(), 1)
+
+	cfg.Topology = TopologyValidatorBehindSentries
+	cfg.PexReactor = true
+	assert.Len(t, cfg.TopologyWarnings(), 2, "no persistent peer and pex")
+	cfg.PersistentPeers = "0491d373a8e0fcf1023aaf18c51d6a1d0d4f31bd@127.0.0.1:26656"
+	cfg.PexReactor = false
+	assert.Empty(t, cfg.TopologyWarnings())
+
+	cfg.Topology = TopologySentry
+	assert.Len(t, cfg.TopologyWarnings(), 3, "no private peer, no pex and backup sentries")
+	cfg.PrivatePeerIDs = "d51fb70907db1c6c2d5237e78379b25cf1a37ab4"
+	cfg.PexReactor = true
+	cfg.BackupSentries = ""
+	assert.Empty(t, cfg.TopologyWarnings())
+}
+
+func TestParseChannelRates(t *testing.T) {
+	rates, err := ParseChannelRates("")
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+
+	rates, err = ParseChannelRates("0x30:102400, 56:512")
+	require.NoError(t, err)
+	assert.Equal(t, map[byte]int64{0x30: 102400, 0x38: 512}, rates)
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -114,6 +231,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"CheckTxBatchSize",
+		"CheckTxBatchTimeout",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -195,6 +314,9 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"CompactBlockTimeout":                  {func(c *ConsensusConfig) { c.CompactBlockTimeout = time.Second }, false},
+		"CompactBlockTimeout negative":         {func(c *ConsensusConfig) { c.CompactBlockTimeout = -1 }, true},
+		"DoubleSignGuardWait negative":         {func(c *ConsensusConfig) { c.DoubleSignGuardWait = -1 }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
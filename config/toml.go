@@ -84,6 +84,19 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # or the name of an ABCI application compiled in with the Ostracon binary
 proxy_app = "{{ .BaseConfig.ProxyApp }}"
 
+# Comma separated TCP or UNIX socket addresses of read replicas of the ABCI
+# application to send the Query requests of the query connection to,
+# round-robin between the healthy ones, instead of proxy_app. The other requests
+# of the query connection, like Info, are still sent to proxy_app.
+proxy_app_query = "{{ .BaseConfig.ProxyAppQuery }}"
+
+# Interval between the health checks of the read replicas of proxy_app_query
+proxy_app_query_health_check_interval = "{{ .BaseConfig.ProxyAppQueryHealthCheckInterval }}"
+
+# TCP or UNIX socket address of a process to open the snapshot connection to
+# instead of proxy_app, e.g. a snapshotting sidecar of the ABCI application
+proxy_app_snapshot = "{{ .BaseConfig.ProxyAppSnapshot }}"
+
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
@@ -154,16 +167,90 @@ priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Path to the JSON file containing the next private key of a validator key rotation, if it exists.
+# It replaces priv_validator_key_file once the validator set uses it.
+priv_validator_next_key_file = "{{ js .BaseConfig.PrivValidatorNextKey }}"
+
+# Path to the append-only, hash-chained log of the pubkey requests, the messages signed and the VRF
+# proofs generated by the private validator of priv_validator_key_file. If empty, they aren't logged.
+# The log is rotated every 10MB and its oldest files are removed above 1GB.
+priv_validator_audit_log_file = "{{ js .BaseConfig.PrivValidatorAuditLog }}"
+
+# Path to a file containing the passphrase which encrypts priv_validator_key_file.
+# If neither it nor priv_validator_key_passphrase_env is set, the key file isn't encrypted.
+priv_validator_key_passphrase_file = "{{ js .BaseConfig.PrivValidatorKeyPassphrase }}"
+
+# Name of an environment variable containing the passphrase which encrypts
+# priv_validator_key_file
+priv_validator_key_passphrase_env = "{{ .BaseConfig.PrivValidatorKeyPassphraseEnv }}"
+
+# Key derivation function of the passphrase of a new encrypted
+# priv_validator_key_file: scrypt | argon2id
+priv_validator_key_kdf = "{{ .BaseConfig.PrivValidatorKeyKDF }}"
+
 # TCP or UNIX socket address for Ostracon to listen on for
-# connections from an external PrivValidator process
+# connections from an external PrivValidator process.
+# A comma separated list of addresses makes a cluster of signers holding the
+# same key, of which priv_validator_threshold must agree on each signature.
+# The cluster saves the last state they signed to priv_validator_state_file.
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Number of signers of the cluster which must agree on each signature, if
+# priv_validator_laddr lists several addresses. It must be a majority of them.
+# 0 means the smallest majority.
+priv_validator_threshold = {{ .BaseConfig.PrivValidatorThreshold }}
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# If true, reconnect to the ABCI application when a connection fails, e.g.
+# because the application crashed, instead of stopping the node. The
+# consensus pauses until the application is back, and replays the blocks the
+# application lost.
+abci_reconnect = {{ .BaseConfig.ABCIReconnect }}
+
+# Maximum delay between the attempts to reconnect to the ABCI application
+abci_reconnect_max_backoff = "{{ .BaseConfig.ABCIReconnectMaxBackoff }}"
+
+# If true, deliver the txs of a block not conflicting with each other in
+# batches the ABCI application may execute concurrently. The conflicts are
+# found by the keys the application returns to TxAccessSets for each tx.
+abci_parallel_deliver_tx = {{ .BaseConfig.ABCIParallelDeliverTx }}
+
+# If true, start executing a proposed block as soon as it's received, while
+# voting for it, and commit the result if the block is decided. The ABCI
+# application must implement AbortBlock, which discards the changes of a block
+# which isn't decided.
+abci_optimistic_execution = {{ .BaseConfig.ABCIOptimisticExecution }}
+
+# Path to a file to append every request sent to the ABCI application and
+# its response to, to replay them with "abci-cli replay". If empty, they
+# aren't recorded.
+abci_record_file = "{{ js .BaseConfig.ABCIRecord }}"
+
+# Paths to the certificate and key the node authenticates with to the ABCI
+# application, and to the certificate of the CA the application's certificate
+# must be issued by. If they're set, the node connects to the application over
+# mutual TLS, with both the socket and grpc mechanisms.
+abci_tls_cert_file = "{{ js .BaseConfig.ABCITLSCert }}"
+abci_tls_key_file = "{{ js .BaseConfig.ABCITLSKey }}"
+abci_tls_ca_file = "{{ js .BaseConfig.ABCITLSCA }}"
+
+# Name the ABCI application's certificate must be issued for. If empty, the
+# host of proxy_app is used.
+abci_tls_server_name = "{{ .BaseConfig.ABCITLSServerName }}"
+
+# Common names of the ABCI application's certificate the node accepts. If
+# empty, any certificate issued by the CA is accepted.
+abci_tls_allowed_cns = [{{ range .BaseConfig.ABCITLSAllowedCNs }}{{ printf "%q, " . }}{{end}}]
+
+# Maximum size in bytes of a response of the ABCI application. 0 means the
+# default of the mechanism: 100MB for socket and 4MB for grpc.
+abci_max_msg_size = {{ .BaseConfig.ABCIMaxMsgSize }}
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -318,6 +405,11 @@ pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Address to listen for incoming QUIC connections (UDP)
+# Peers listening for QUIC are dialed with quic://ID@host:port, e.g. in persistent_peers
+# If empty, QUIC is disabled
+quic_laddr = "{{ .P2P.QUICListenAddress }}"
+
 # Address to advertise to peers for them to dial
 # If empty, will use the same port as the laddr,
 # and will introspect on the listener or use UPnP
@@ -337,6 +429,12 @@ upnp = {{ .P2P.UPNP }}
 # Path to address book
 addr_book_file = "{{ js .P2P.AddrBook }}"
 
+# Storage of the address book:
+#   1) "db" - the addrbook database of db_dir, updated every 10 seconds, to
+#   which the addr_book_file is migrated the first time (default)
+#   2) "file" - the addr_book_file, rewritten every 2 minutes
+addr_book_backend = "{{ .P2P.AddrBookBackend }}"
+
 # Set true for strict address routability rules
 # Set false for private or local networks
 addr_book_strict = {{ .P2P.AddrBookStrict }}
@@ -365,6 +463,17 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Comma separated list of channel IDs and the rates at which packets can be
+# sent on them, in bytes/second. The channels are still limited by send_rate.
+# e.g. "0x30:102400" caps the mempool gossip to 100 kB/s for each peer
+channel_send_rates = "{{ .P2P.ChannelSendRates }}"
+
+# Comma separated list of channel IDs and the rates at which packets can be
+# received on them, in bytes/second. The channels are still limited by recv_rate.
+# The messages received on a channel over its rate are dropped, so only the
+# mempool channel (0x30), whose gossip tolerates losing messages, accepts a rate.
+channel_recv_rates = "{{ .P2P.ChannelRecvRates }}"
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -374,16 +483,48 @@ pex = {{ .P2P.PexReactor }}
 # Does not work if the peer-exchange reactor is disabled.
 seed_mode = {{ .P2P.SeedMode }}
 
+# Keep a census of the nodes crawled in seed mode: their version, channels,
+# latest height and reachability. It's exposed by the net_census RPC.
+#
+# Requires the seed mode.
+census = {{ .P2P.Census }}
+
+# How long the census keeps a node which was neither seen nor dialed.
+# 0 keeps the nodes forever.
+census_retention = "{{ .P2P.CensusRetention }}"
+
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# Topology of the node, which drives its dialing policy:
+#   1) "full" - connect to any peer (default)
+#   2) "sentry" - shield the validators of private_peer_ids, which are
+#   always accepted and never gossiped
+#   3) "validator-behind-sentries" - connect to the sentries of
+#   persistent_peers only, without PEX, failing over to backup_sentries
+#   while some of them are down
+topology = "{{ .P2P.Topology }}"
+
+# Comma separated list of sentries to connect to while some of the
+# persistent peers are down, with the validator-behind-sentries topology
+backup_sentries = "{{ .P2P.BackupSentries }}"
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
 
+# Offer the Noise_XX handshake for the encrypted peer connections
+# It's used only with peers offering it too, otherwise the default handshake is used
+secret_conn_noise = {{ .P2P.SecretConnNoise }}
+
+# With the Noise_XX handshake, the keys of a connection are rotated after
+# the number of bytes or the interval, whichever comes first (0 means no limit)
+secret_conn_rekey_bytes = {{ .P2P.SecretConnRekeyBytes }}
+secret_conn_rekey_interval = "{{ .P2P.SecretConnRekeyInterval }}"
+
 # Sync/async of reactor's receive function
 recv_async = {{ .P2P.RecvAsync }}
 
@@ -450,6 +591,15 @@ ttl-duration = "{{ .Mempool.TTLDuration }}"
 # it's insertion time into the mempool is beyond ttl-duration.
 ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 
+# Maximum number of txs checked by a single CheckTxBatch request, for the
+# mempool to check the txs it receives and recheck its txs in batches.
+# 0 checks each tx with its own CheckTx request.
+check_tx_batch_size = {{ .Mempool.CheckTxBatchSize }}
+
+# How long to wait for more txs to fill a batch before checking the txs
+# received so far. Only used if check_tx_batch_size is greater than 1.
+check_tx_batch_timeout = "{{ .Mempool.CheckTxBatchTimeout }}"
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -527,6 +677,14 @@ timeout_commit = "{{ .Consensus.TimeoutCommit }}"
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double_sign_check_height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# How many blocks to observe the network for before signing anything after a restart
+# When non-zero, the node follows consensus without proposing or voting for
+# {double_sign_guard_wait} blocks. If it sees a proposal or vote signed with its own key,
+# which its private validator does not remember signing, it never signs again.
+# The private validator must provide its last sign state (e.g. the file private validator),
+# or the node fails to start.
+double_sign_guard_wait = {{ .Consensus.DoubleSignGuardWait }}
+
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 
@@ -541,6 +699,15 @@ max_txs = {{ .Consensus.MaxTxs }}
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Relay proposal blocks as compact blocks: the header and the keys of the
+# transactions, which peers rebuild from their mempool, requesting only the
+# transactions they miss. Peers without compact block relay get block parts.
+compact_blocks = {{ .Consensus.CompactBlocks }}
+
+# How long to wait for a peer to rebuild a compact block before falling back
+# to sending it the block parts.
+compact_block_timeout = "{{ .Consensus.CompactBlockTimeout }}"
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -0,0 +1,496 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/libs/bits"
+	"github.com/Finschia/ostracon/libs/log"
+	mempl "github.com/Finschia/ostracon/mempool"
+	"github.com/Finschia/ostracon/p2p"
+	occonsproto "github.com/Finschia/ostracon/proto/ostracon/consensus"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Compact block relay, similar to Bitcoin's BIP-152.
+//
+// Instead of the parts of a complete proposal block, a node sends its peers
+// the block without its transactions, replaced by their keys. The receiver
+// gets the transactions from its mempool, requests the missing ones, rebuilds
+// the block parts and checks them against the PartSetHeader of the proposal
+// before handing them to the consensus state. It then tells the sender
+// whether it could rebuild the block; if not, or if the receiver doesn't
+// answer in time, the sender falls back to sending the block parts.
+//
+// Nodes advertise compact block relay by listing CompactBlockChannel among
+// the channels of their NodeInfo.
+
+// maxCompactBlockMsgSize bounds the messages of CompactBlockChannel, which may
+// carry most of the transactions of a block.
+const maxCompactBlockMsgSize = types.MaxBlockSizeBytes + maxMsgSize
+
+// ReactorCompactBlocks enables compact block relay, rebuilding blocks from the
+// transactions of the given mempool. Peers that didn't rebuild a compact block
+// within timeout get the block parts.
+func ReactorCompactBlocks(mempool mempl.Mempool, timeout time.Duration) ReactorOption {
+	return func(conR *Reactor) {
+		conR.mempool = mempool
+		conR.compactBlockTimeout = timeout
+	}
+}
+
+// acceptsCompactBlocks returns true if the peer advertised
+// CompactBlockChannel.
+func acceptsCompactBlocks(peer p2p.Peer) bool {
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(CompactBlockChannel)
+}
+
+// gossipCompactBlock sends the proposal block of the current round to the
+// peer as a compact block if it supports them and has no part of it yet. It
+// returns true if the block parts must not be sent to the peer, because it is
+// rebuilding the block from a compact block and the timeout hasn't expired.
+func (conR *Reactor) gossipCompactBlock(logger log.Logger, peer p2p.Peer, ps *PeerState,
+	rs *cstypes.RoundState, prs *cstypes.PeerRoundState) bool {
+	if conR.mempool == nil || !acceptsCompactBlocks(peer) {
+		return false
+	}
+	if rs.Height != prs.Height || rs.Round != prs.Round || !rs.ProposalBlockParts.IsComplete() {
+		return false
+	}
+	if ps.waitingForCompactBlock(rs.Height, rs.Round, conR.compactBlockTimeout) {
+		return true
+	}
+	if !prs.ProposalBlockParts.IsEmpty() || !ps.markCompactBlockSent(rs.Height, rs.Round) {
+		return false
+	}
+
+	msg, err := makeCompactBlock(rs.Height, rs.Round, rs.ProposalBlock, rs.ProposalBlockParts.Header())
+	if err != nil {
+		logger.Error("Could not make compact block", "err", err)
+		ps.setCompactBlockResult(rs.Height, rs.Round, false)
+		return false
+	}
+	logger.Debug("Sending compact block", "height", rs.Height, "round", rs.Round, "txs", len(msg.TxKeys))
+	if !p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: CompactBlockChannel,
+		Message:   msg,
+	}, logger) {
+		ps.setCompactBlockResult(rs.Height, rs.Round, false)
+		return false
+	}
+	return true
+}
+
+// receiveCompactBlockMessage handles a message received on
+// CompactBlockChannel.
+func (conR *Reactor) receiveCompactBlockMessage(e p2p.Envelope, ps *PeerState) {
+	if conR.mempool == nil {
+		conR.Logger.Error("Received a compact block message while compact blocks are disabled", "src", e.Src)
+		return
+	}
+	var err error
+	switch msg := e.Message.(type) {
+	case *occonsproto.CompactBlock:
+		err = conR.handleCompactBlock(e.Src, ps, msg)
+	case *occonsproto.CompactBlockTxsRequest:
+		err = conR.handleCompactBlockTxsRequest(e.Src, msg)
+	case *occonsproto.CompactBlockTxs:
+		err = conR.handleCompactBlockTxs(e.Src, ps, msg)
+	case *occonsproto.CompactBlockResult:
+		ps.setCompactBlockResult(msg.Height, msg.Round, msg.Ok)
+	default:
+		conR.Logger.Error(fmt.Sprintf("Unknown message type %T", msg))
+	}
+	if err != nil {
+		conR.Logger.Error("Peer sent us invalid compact block msg", "peer", e.Src, "err", err)
+		conR.Switch.StopPeerForError(e.Src, err)
+	}
+}
+
+func (conR *Reactor) handleCompactBlock(peer p2p.Peer, ps *PeerState, msg *occonsproto.CompactBlock) error {
+	if err := validateCompactBlock(msg); err != nil {
+		return err
+	}
+	if conR.WaitSync() {
+		conR.sendCompactBlockResult(peer, msg.Height, msg.Round, false)
+		return nil
+	}
+
+	// we may already have the block, from another peer
+	rs := conR.getRoundState()
+	header, _ := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+	if rs.Height == msg.Height && rs.Round == msg.Round && rs.ProposalBlockParts.HasHeader(*header) &&
+		rs.ProposalBlockParts.IsComplete() {
+		ps.setHasAllProposalBlockParts(msg.Height, msg.Round)
+		conR.sendCompactBlockResult(peer, msg.Height, msg.Round, true)
+		return nil
+	}
+
+	txs := make([][]byte, len(msg.TxKeys))
+	var missing []uint32
+	for i, key := range msg.TxKeys {
+		var txKey types.TxKey
+		copy(txKey[:], key)
+		if tx, ok := conR.mempool.GetTxByKey(txKey); ok {
+			txs[i] = tx
+		} else {
+			missing = append(missing, uint32(i))
+		}
+	}
+	if len(missing) == 0 {
+		conR.rebuildCompactBlock(peer, ps, msg, txs)
+		return nil
+	}
+
+	conR.Metrics.CompactBlockMissingTxs.Add(float64(len(missing)))
+	ps.setReceivedCompactBlock(msg, txs)
+	if !p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: CompactBlockChannel,
+		Message: &occonsproto.CompactBlockTxsRequest{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Indexes: missing,
+		},
+	}, conR.Logger) {
+		ps.clearReceivedCompactBlock()
+	}
+	return nil
+}
+
+func (conR *Reactor) handleCompactBlockTxsRequest(peer p2p.Peer, msg *occonsproto.CompactBlockTxsRequest) error {
+	res := &occonsproto.CompactBlockTxs{Height: msg.Height, Round: msg.Round}
+
+	rs := conR.getRoundState()
+	// we moved on since we sent the compact block: let the peer fall back
+	if rs.Height == msg.Height && rs.Round == msg.Round &&
+		rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete() {
+		txs := rs.ProposalBlock.Txs
+		for _, i := range msg.Indexes {
+			if int(i) >= len(txs) {
+				return fmt.Errorf("requested tx #%d of a block of %d txs", i, len(txs))
+			}
+			res.Indexes = append(res.Indexes, i)
+			res.Txs = append(res.Txs, txs[i])
+		}
+	}
+
+	p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: CompactBlockChannel,
+		Message:   res,
+	}, conR.Logger)
+	return nil
+}
+
+func (conR *Reactor) handleCompactBlockTxs(peer p2p.Peer, ps *PeerState, msg *occonsproto.CompactBlockTxs) error {
+	if len(msg.Indexes) != len(msg.Txs) {
+		return fmt.Errorf("got %d txs for %d indexes", len(msg.Txs), len(msg.Indexes))
+	}
+	cb, txs := ps.receivedCompactBlock(msg.Height, msg.Round)
+	if cb == nil {
+		return nil
+	}
+	ps.clearReceivedCompactBlock()
+
+	for i, idx := range msg.Indexes {
+		if int(idx) >= len(txs) || txs[idx] != nil {
+			return fmt.Errorf("got unrequested tx #%d", idx)
+		}
+		if key := types.Tx(msg.Txs[i]).Key(); string(key[:]) != string(cb.TxKeys[idx]) {
+			return fmt.Errorf("tx #%d does not match its key", idx)
+		}
+		txs[idx] = msg.Txs[i]
+	}
+	for _, tx := range txs {
+		if tx == nil {
+			// the peer no longer has the block
+			conR.Metrics.CompactBlocks.With("result", "fallback").Add(1)
+			conR.sendCompactBlockResult(peer, cb.Height, cb.Round, false)
+			return nil
+		}
+	}
+	conR.rebuildCompactBlock(peer, ps, cb, txs)
+	return nil
+}
+
+// rebuildCompactBlock rebuilds the block parts of a compact block and hands
+// them to the consensus state, or tells the peer to send the parts if they
+// don't match the PartSetHeader of the compact block.
+func (conR *Reactor) rebuildCompactBlock(peer p2p.Peer, ps *PeerState, msg *occonsproto.CompactBlock, txs [][]byte) {
+	parts, err := rebuildBlockParts(msg, txs)
+	if err != nil {
+		conR.Logger.Info("Could not rebuild compact block", "peer", peer, "height", msg.Height,
+			"round", msg.Round, "err", err)
+		conR.Metrics.CompactBlocks.With("result", "fallback").Add(1)
+		conR.sendCompactBlockResult(peer, msg.Height, msg.Round, false)
+		return
+	}
+	ps.setRebuiltCompactBlock(msg.Height, msg.Round, parts)
+	conR.deliverCompactBlock(peer, ps, conR.getRoundState())
+}
+
+// deliverCompactBlock hands the block parts rebuilt from a compact block to
+// the consensus state once it received the matching proposal. It doesn't
+// block if the queue of the consensus state is full, the remaining parts are
+// handed on the next call.
+func (conR *Reactor) deliverCompactBlock(peer p2p.Peer, ps *PeerState, rs *cstypes.RoundState) {
+	height, round, parts, next := ps.rebuiltCompactBlock()
+	if parts == nil {
+		return
+	}
+
+	switch {
+	case rs.Height > height || (rs.Height == height && rs.Round > round):
+		// too late
+		ps.clearReceivedCompactBlock()
+		return
+	case rs.Height < height || rs.Round < round || rs.Proposal == nil:
+		// wait for the proposal
+		return
+	case !rs.Proposal.BlockID.PartSetHeader.Equals(parts.Header()):
+		ps.clearReceivedCompactBlock()
+		conR.Metrics.CompactBlocks.With("result", "fallback").Add(1)
+		conR.sendCompactBlockResult(peer, height, round, false)
+		return
+	}
+
+	if rs.ProposalBlockParts == nil || !rs.ProposalBlockParts.IsComplete() {
+		for ; next < int(parts.Total()); next++ {
+			select {
+			case conR.conS.peerMsgQueue <- msgInfo{&BlockPartMessage{height, round, parts.GetPart(next)}, peer.ID()}:
+			default:
+				ps.setDeliveredCompactBlockParts(next)
+				return
+			}
+		}
+	}
+	ps.clearReceivedCompactBlock()
+	ps.setHasAllProposalBlockParts(height, round)
+	conR.Metrics.CompactBlocks.With("result", "rebuilt").Add(1)
+	conR.sendCompactBlockResult(peer, height, round, true)
+}
+
+func (conR *Reactor) sendCompactBlockResult(peer p2p.Peer, height int64, round int32, ok bool) {
+	p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: CompactBlockChannel,
+		Message:   &occonsproto.CompactBlockResult{Height: height, Round: round, Ok: ok},
+	}, conR.Logger)
+}
+
+//-----------------------------------------------------------------------------
+
+// makeCompactBlock returns the compact block of a proposal block.
+func makeCompactBlock(height int64, round int32, block *types.Block,
+	header types.PartSetHeader) (*occonsproto.CompactBlock, error) {
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		key := tx.Key()
+		keys[i] = key[:]
+	}
+	pb.Data.Txs = nil
+	return &occonsproto.CompactBlock{
+		Height:        height,
+		Round:         round,
+		PartSetHeader: header.ToProto(),
+		Block:         pb,
+		TxKeys:        keys,
+	}, nil
+}
+
+// rebuildBlockParts returns the parts of a compact block with the given
+// transactions, which must match the PartSetHeader of the compact block.
+func rebuildBlockParts(msg *occonsproto.CompactBlock, txs [][]byte) (*types.PartSet, error) {
+	pb := *msg.Block
+	pb.Data.Txs = txs
+	bz, err := proto.Marshal(&pb)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > types.MaxBlockSizeBytes {
+		return nil, fmt.Errorf("block too big: %d bytes, max: %d", len(bz), types.MaxBlockSizeBytes)
+	}
+	header, err := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+	if err != nil {
+		return nil, err
+	}
+	parts := types.NewPartSetFromData(bz, types.BlockPartSizeBytes)
+	if !parts.HasHeader(*header) {
+		return nil, errors.New("block parts do not match the part set header")
+	}
+	return parts, nil
+}
+
+func validateCompactBlock(msg *occonsproto.CompactBlock) error {
+	if msg.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if msg.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if _, err := types.PartSetHeaderFromProto(&msg.PartSetHeader); err != nil {
+		return fmt.Errorf("wrong PartSetHeader: %w", err)
+	}
+	if msg.Block == nil {
+		return errors.New("nil Block")
+	}
+	if len(msg.Block.Data.Txs) > 0 {
+		return errors.New("compact block with txs")
+	}
+	for i, key := range msg.TxKeys {
+		if len(key) != types.TxKeySize {
+			return fmt.Errorf("wrong size of tx key #%d: %d", i, len(key))
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// compactBlockSent tracks the compact block sent to a peer.
+type compactBlockSent struct {
+	height int64
+	round  int32
+	sentAt time.Time
+	// done is set once the peer rebuilt the block or asked for its parts
+	done bool
+}
+
+// compactBlockReceived tracks the compact block received from a peer, until
+// the block parts rebuilt from it are handed to the consensus state.
+type compactBlockReceived struct {
+	msg *occonsproto.CompactBlock
+	// transactions collected so far, nil if missing
+	txs   [][]byte
+	parts *types.PartSet
+	// index of the next rebuilt part to hand to the consensus state
+	next int
+}
+
+// markCompactBlockSent records that a compact block is sent to the peer for
+// the given height and round, and returns false if one was sent already.
+func (ps *PeerState) markCompactBlockSent(height int64, round int32) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactSent.height == height && ps.compactSent.round == round {
+		return false
+	}
+	ps.compactSent = compactBlockSent{height: height, round: round, sentAt: time.Now()}
+	return true
+}
+
+// waitingForCompactBlock returns true if the peer is rebuilding the block of
+// the given height and round from a compact block we sent less than timeout
+// ago.
+func (ps *PeerState) waitingForCompactBlock(height int64, round int32, timeout time.Duration) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	cs := ps.compactSent
+	return cs.height == height && cs.round == round && !cs.done && time.Since(cs.sentAt) < timeout
+}
+
+// setCompactBlockResult records whether the peer could rebuild the compact
+// block we sent.
+func (ps *PeerState) setCompactBlockResult(height int64, round int32, ok bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactSent.height != height || ps.compactSent.round != round {
+		return
+	}
+	ps.compactSent.done = true
+	if ok {
+		ps.setHasAllProposalBlockPartsLocked(height, round)
+	}
+}
+
+// setHasAllProposalBlockParts sets every part of the proposal block as known
+// for the peer.
+func (ps *PeerState) setHasAllProposalBlockParts(height int64, round int32) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.setHasAllProposalBlockPartsLocked(height, round)
+}
+
+func (ps *PeerState) setHasAllProposalBlockPartsLocked(height int64, round int32) {
+	if ps.PRS.Height != height || ps.PRS.Round != round || ps.PRS.ProposalBlockParts == nil {
+		return
+	}
+	parts := bits.NewBitArray(ps.PRS.ProposalBlockParts.Size())
+	for i := 0; i < parts.Size(); i++ {
+		parts.SetIndex(i, true)
+	}
+	ps.PRS.ProposalBlockParts = parts
+}
+
+func (ps *PeerState) setReceivedCompactBlock(msg *occonsproto.CompactBlock, txs [][]byte) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactReceived = compactBlockReceived{msg: msg, txs: txs}
+}
+
+// receivedCompactBlock returns the compact block received from the peer for
+// the given height and round that is waiting for transactions.
+func (ps *PeerState) receivedCompactBlock(height int64, round int32) (*occonsproto.CompactBlock, [][]byte) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	cb := ps.compactReceived
+	if cb.msg == nil || cb.parts != nil || cb.msg.Height != height || cb.msg.Round != round {
+		return nil, nil
+	}
+	return cb.msg, cb.txs
+}
+
+func (ps *PeerState) setRebuiltCompactBlock(height int64, round int32, parts *types.PartSet) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactReceived = compactBlockReceived{
+		msg:   &occonsproto.CompactBlock{Height: height, Round: round},
+		parts: parts,
+	}
+}
+
+// rebuiltCompactBlock returns the block parts rebuilt from a compact block
+// received from the peer, if any, and the index of the first part not handed
+// to the consensus state yet.
+func (ps *PeerState) rebuiltCompactBlock() (int64, int32, *types.PartSet, int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	cb := ps.compactReceived
+	if cb.parts == nil {
+		return 0, 0, nil, 0
+	}
+	return cb.msg.Height, cb.msg.Round, cb.parts, cb.next
+}
+
+// setDeliveredCompactBlockParts records that the rebuilt parts before next
+// were handed to the consensus state.
+func (ps *PeerState) setDeliveredCompactBlockParts(next int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactReceived.parts != nil {
+		ps.compactReceived.next = next
+	}
+}
+
+func (ps *PeerState) clearReceivedCompactBlock() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactReceived = compactBlockReceived{}
+}
@@ -0,0 +1,271 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/libs/bits"
+	"github.com/Finschia/ostracon/libs/log"
+	mempl "github.com/Finschia/ostracon/mempool"
+	"github.com/Finschia/ostracon/p2p"
+	p2pmock "github.com/Finschia/ostracon/p2p/mock"
+	occonsproto "github.com/Finschia/ostracon/proto/ostracon/consensus"
+	"github.com/Finschia/ostracon/types"
+)
+
+func makeTestCompactBlock(t *testing.T, txs types.Txs) (*types.Block, *occonsproto.CompactBlock) {
+	state, _ := randGenesisState(1, false, 10)
+	block, parts := state.MakeBlock(1, txs, nil, nil, state.Validators.Validators[0].Address, 0, nil)
+	msg, err := makeCompactBlock(1, 0, block, parts.Header())
+	require.NoError(t, err)
+	return block, msg
+}
+
+func TestCompactBlockRebuild(t *testing.T) {
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	block, msg := makeTestCompactBlock(t, txs)
+	require.NoError(t, validateCompactBlock(msg))
+	assert.Empty(t, msg.Block.Data.Txs)
+	require.Len(t, msg.TxKeys, len(txs))
+
+	parts, err := rebuildBlockParts(msg, [][]byte{txs[0], txs[1], txs[2]})
+	require.NoError(t, err)
+	assert.True(t, parts.HasHeader(block.MakePartSet(types.BlockPartSizeBytes).Header()))
+
+	// the compact block is left untouched
+	assert.Empty(t, msg.Block.Data.Txs)
+
+	// other transactions don't match the part set header
+	_, err = rebuildBlockParts(msg, [][]byte{txs[0], txs[2], txs[1]})
+	assert.Error(t, err)
+}
+
+func TestValidateCompactBlock(t *testing.T) {
+	testCases := []struct {
+		name     string
+		malleate func(*occonsproto.CompactBlock)
+	}{
+		{"negative height", func(msg *occonsproto.CompactBlock) { msg.Height = -1 }},
+		{"negative round", func(msg *occonsproto.CompactBlock) { msg.Round = -1 }},
+		{"invalid part set header", func(msg *occonsproto.CompactBlock) { msg.PartSetHeader.Hash = []byte{1} }},
+		{"nil block", func(msg *occonsproto.CompactBlock) { msg.Block = nil }},
+		{"with txs", func(msg *occonsproto.CompactBlock) { msg.Block.Data.Txs = [][]byte{{1}} }},
+		{"invalid tx key", func(msg *occonsproto.CompactBlock) { msg.TxKeys[0] = []byte{1} }},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, msg := makeTestCompactBlock(t, types.Txs{types.Tx("a=1")})
+			tc.malleate(msg)
+			assert.Error(t, validateCompactBlock(msg))
+		})
+	}
+}
+
+// labelCounter is a Counter that sums the values added by label values.
+type labelCounter struct {
+	mtx    *sync.Mutex
+	counts map[string]float64
+	lvs    string
+}
+
+var _ metrics.Counter = labelCounter{}
+
+func newLabelCounter() labelCounter {
+	return labelCounter{mtx: &sync.Mutex{}, counts: make(map[string]float64)}
+}
+
+func (c labelCounter) With(labelValues ...string) metrics.Counter {
+	return labelCounter{mtx: c.mtx, counts: c.counts, lvs: strings.Join(labelValues, ",")}
+}
+
+func (c labelCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.counts[c.lvs] += delta
+}
+
+func (c labelCounter) Value(labelValues ...string) float64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.counts[strings.Join(labelValues, ",")]
+}
+
+func TestReactorCompactBlocks(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+
+	// the last node misses the transactions and has to request them
+	for i := 0; i < N-1; i++ {
+		for j := 0; j < 10; j++ {
+			tx := make([]byte, 8)
+			binary.BigEndian.PutUint64(tx, uint64(j))
+			require.NoError(t, assertMempool(css[i].txNotifier).CheckTxSync(tx, nil, mempl.TxInfo{}))
+		}
+	}
+
+	compactBlocks := make([]labelCounter, N)
+	missingTxs := make([]labelCounter, N)
+	reactors, blocksSubs, eventBuses := startConsensusNetWithOptions(t, css, N, func(i int) []ReactorOption {
+		metrics := NopMetrics()
+		compactBlocks[i], missingTxs[i] = newLabelCounter(), newLabelCounter()
+		metrics.CompactBlocks, metrics.CompactBlockMissingTxs = compactBlocks[i], missingTxs[i]
+		return []ReactorOption{ReactorMetrics(metrics), ReactorCompactBlocks(assertMempool(css[i].txNotifier), css[i].config.CompactBlockTimeout)}
+	})
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// wait till everyone commits a block with the transactions
+	timeoutWaitGroup(t, N, func(j int) {
+		for {
+			msg := <-blocksSubs[j].Out()
+			if len(msg.Data().(types.EventDataNewBlock).Block.Txs) > 0 {
+				return
+			}
+		}
+	}, css)
+
+	rebuilt := 0.0
+	for i := 0; i < N; i++ {
+		rebuilt += compactBlocks[i].Value("result", "rebuilt")
+		assert.Zero(t, compactBlocks[i].Value("result", "fallback"))
+	}
+	assert.Positive(t, rebuilt)
+	assert.Positive(t, missingTxs[N-1].Value())
+}
+
+// compactBlockTestPeer is a peer advertising compact blocks that records the
+// messages sent to it.
+type compactBlockTestPeer struct {
+	*p2pmock.Peer
+
+	mtx  sync.Mutex
+	sent []p2p.Envelope
+}
+
+func newCompactBlockTestPeer() *compactBlockTestPeer {
+	return &compactBlockTestPeer{Peer: p2pmock.NewPeer(nil)}
+}
+
+func (p *compactBlockTestPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.Channels = []byte{CompactBlockChannel}
+	return ni
+}
+
+func (p *compactBlockTestPeer) SendEnvelope(e p2p.Envelope) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.sent = append(p.sent, e)
+	return true
+}
+
+func (p *compactBlockTestPeer) sentMessages() []proto.Message {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	msgs := make([]proto.Message, len(p.sent))
+	for i, e := range p.sent {
+		msgs[i] = e.Message
+	}
+	return msgs
+}
+
+// makeTestProposalBlock returns a proposal block of several parts.
+func makeTestProposalBlock(t *testing.T) (*types.Block, *types.PartSet) {
+	state, _ := randGenesisState(1, false, 10)
+	txs := make(types.Txs, 4)
+	for i := range txs {
+		txs[i] = make(types.Tx, types.BlockPartSizeBytes/2)
+		binary.BigEndian.PutUint64(txs[i], uint64(i))
+	}
+	block, parts := state.MakeBlock(1, txs, nil, nil, state.Validators.Validators[0].Address, 0, nil)
+	require.Greater(t, parts.Total(), uint32(1))
+	return block, parts
+}
+
+func TestReactorCompactBlockTimeout(t *testing.T) {
+	cs, _ := randState(1)
+	conR := NewReactor(cs, false, false, 1000, ReactorCompactBlocks(emptyMempool{}, 100*time.Millisecond))
+
+	block, parts := makeTestProposalBlock(t)
+	rs := &cstypes.RoundState{Height: 1, Round: 0, ProposalBlock: block, ProposalBlockParts: parts}
+	peerA, peerB := newCompactBlockTestPeer(), newCompactBlockTestPeer()
+	psA, psB := NewPeerState(peerA), NewPeerState(peerB)
+	for _, ps := range []*PeerState{psA, psB} {
+		ps.PRS.Height, ps.PRS.Round = 1, 0
+		ps.PRS.ProposalBlockParts = bits.NewBitArray(int(parts.Total()))
+	}
+
+	// both peers get the compact block instead of the parts
+	assert.True(t, conR.gossipCompactBlock(conR.Logger, peerA, psA, rs, psA.GetRoundState()))
+	assert.True(t, conR.gossipCompactBlock(conR.Logger, peerB, psB, rs, psB.GetRoundState()))
+	for _, peer := range []*compactBlockTestPeer{peerA, peerB} {
+		msgs := peer.sentMessages()
+		require.Len(t, msgs, 1)
+		assert.IsType(t, &occonsproto.CompactBlock{}, msgs[0])
+	}
+
+	// the first peer rebuilds the block
+	conR.receiveCompactBlockMessage(p2p.Envelope{
+		Src:       peerA,
+		ChannelID: CompactBlockChannel,
+		Message:   &occonsproto.CompactBlockResult{Height: 1, Round: 0, Ok: true},
+	}, psA)
+	assert.True(t, psA.GetRoundState().ProposalBlockParts.IsFull())
+
+	// the second one doesn't answer and gets the parts after the timeout
+	assert.True(t, conR.gossipCompactBlock(conR.Logger, peerB, psB, rs, psB.GetRoundState()))
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, conR.gossipCompactBlock(conR.Logger, peerB, psB, rs, psB.GetRoundState()))
+	assert.True(t, psB.GetRoundState().ProposalBlockParts.IsEmpty())
+	assert.Len(t, peerB.sentMessages(), 1)
+}
+
+func TestReactorDeliverCompactBlockDoesNotBlock(t *testing.T) {
+	cs, _ := randState(1)
+	cs.peerMsgQueue = make(chan msgInfo, 1)
+	conR := NewReactor(cs, false, false, 1000, ReactorCompactBlocks(emptyMempool{}, time.Second))
+
+	_, parts := makeTestProposalBlock(t)
+	blockID := types.BlockID{Hash: []byte("hash"), PartSetHeader: parts.Header()}
+	rs := &cstypes.RoundState{
+		Height:             1,
+		Round:              0,
+		Proposal:           types.NewProposal(1, 0, -1, blockID),
+		ProposalBlockParts: types.NewPartSetFromHeader(parts.Header()),
+	}
+	peer := newCompactBlockTestPeer()
+	ps := NewPeerState(peer)
+	ps.PRS.Height, ps.PRS.Round = 1, 0
+	ps.PRS.ProposalBlockParts = bits.NewBitArray(int(parts.Total()))
+	ps.setRebuiltCompactBlock(1, 0, parts)
+
+	// the parts are handed one by one while the queue is full
+	for i := 0; i < int(parts.Total()); i++ {
+		conR.deliverCompactBlock(peer, ps, rs)
+		require.Len(t, cs.peerMsgQueue, 1)
+		mi := <-cs.peerMsgQueue
+		assert.Equal(t, peer.ID(), mi.PeerID)
+		assert.Equal(t, uint32(i), mi.Msg.(*BlockPartMessage).Part.Index)
+		if i < int(parts.Total())-1 {
+			assert.Empty(t, peer.sentMessages())
+		}
+	}
+	conR.deliverCompactBlock(peer, ps, rs)
+
+	_, _, rebuilt, _ := ps.rebuiltCompactBlock()
+	assert.Nil(t, rebuilt)
+	assert.True(t, ps.GetRoundState().ProposalBlockParts.IsFull())
+	assert.Equal(t, []proto.Message{&occonsproto.CompactBlockResult{Height: 1, Round: 0, Ok: true}},
+		peer.sentMessages())
+	assert.Empty(t, cs.peerMsgQueue)
+}
@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Block parts are compressed per part, on the wire only: the part set, its
+// merkle proofs and therefore the BlockID keep hashing the uncompressed bytes,
+// which is what receivers verify after decompressing a part.
+//
+// Nodes advertise they can decompress block parts by listing
+// CompressedDataChannel among the channels of their NodeInfo. Block parts
+// are sent uncompressed on DataChannel to peers that don't.
+
+// acceptsCompressedBlockParts returns true if the peer advertised
+// CompressedDataChannel.
+func acceptsCompressedBlockParts(peer p2p.Peer) bool {
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(CompressedDataChannel)
+}
+
+// compressBlockPart returns a copy of msg with the part bytes compressed, or
+// false if compressing them doesn't make them any smaller.
+func compressBlockPart(msg *tmcons.BlockPart) (*tmcons.BlockPart, bool) {
+	compressed := snappy.Encode(nil, msg.Part.Bytes)
+	if len(compressed) >= len(msg.Part.Bytes) {
+		return nil, false
+	}
+	cmsg := *msg
+	cmsg.Part.Bytes = compressed
+	return &cmsg, true
+}
+
+// decompressBlockPart decompresses in place the part bytes of a block part
+// received on CompressedDataChannel.
+func decompressBlockPart(msg *tmcons.BlockPart) error {
+	n, err := snappy.DecodedLen(msg.Part.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid compressed block part: %w", err)
+	}
+	// check the size before allocating anything
+	if n > int(types.BlockPartSizeBytes) {
+		return fmt.Errorf("compressed block part too big: %d bytes, max: %d", n, types.BlockPartSizeBytes)
+	}
+	bz, err := snappy.Decode(nil, msg.Part.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid compressed block part: %w", err)
+	}
+	msg.Part.Bytes = bz
+	return nil
+}
+
+// decompressMessage decompresses a message received on CompressedDataChannel,
+// on which only block parts are sent.
+func decompressMessage(msg *tmcons.Message) error {
+	bp := msg.GetBlockPart()
+	if bp == nil {
+		return errors.New("only block parts can be sent on the compressed data channel")
+	}
+	return decompressBlockPart(bp)
+}
+
+// sendBlockPart sends a block part to the peer, compressed if the peer
+// supports it, and returns true if it was queued.
+func sendBlockPart(peer p2p.Peer, msg *tmcons.BlockPart, logger log.Logger) bool {
+	if acceptsCompressedBlockParts(peer) {
+		if cmsg, ok := compressBlockPart(msg); ok {
+			return p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+				ChannelID: CompressedDataChannel,
+				Message:   cmsg,
+			}, logger)
+		}
+	}
+	return p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: DataChannel,
+		Message:   msg,
+	}, logger)
+}
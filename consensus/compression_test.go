@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+
+	p2pmock "github.com/Finschia/ostracon/p2p/mock"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestCompressBlockPart(t *testing.T) {
+	txs := bytes.Repeat([]byte(`{"key":"value"}`), 1000)
+	partSet := types.NewPartSetFromData(txs, types.BlockPartSizeBytes)
+	part, err := partSet.GetPart(0).ToProto()
+	require.NoError(t, err)
+	msg := &tmcons.BlockPart{Height: 1, Round: 0, Part: *part}
+
+	cmsg, ok := compressBlockPart(msg)
+	require.True(t, ok)
+	assert.Less(t, len(cmsg.Part.Bytes), len(msg.Part.Bytes))
+	// the original message is left untouched
+	assert.Equal(t, txs, msg.Part.Bytes)
+
+	require.NoError(t, decompressMessage(cmsg.Wrap().(*tmcons.Message)))
+	assert.Equal(t, msg, cmsg)
+
+	// the decompressed part still matches the part set header
+	bp, err := MsgFromProto(cmsg.Wrap().(*tmcons.Message))
+	require.NoError(t, err)
+	added, err := types.NewPartSetFromHeader(partSet.Header()).AddPart(bp.(*BlockPartMessage).Part)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestCompressBlockPartIncompressible(t *testing.T) {
+	msg := &tmcons.BlockPart{}
+	msg.Part.Bytes = []byte{0x01}
+	_, ok := compressBlockPart(msg)
+	assert.False(t, ok)
+}
+
+func TestDecompressBlockPartInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		bytes []byte
+	}{
+		{"not snappy", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"too big", snappy.Encode(nil, make([]byte, types.BlockPartSizeBytes+1))},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &tmcons.BlockPart{}
+			msg.Part.Bytes = tc.bytes
+			assert.Error(t, decompressBlockPart(msg))
+		})
+	}
+
+	// only block parts are sent on the compressed channel
+	msg := (&tmcons.HasVote{Height: 1}).Wrap().(*tmcons.Message)
+	assert.Error(t, decompressMessage(msg))
+}
+
+func TestAcceptsCompressedBlockParts(t *testing.T) {
+	// mock peers advertise no channel
+	assert.False(t, acceptsCompressedBlockParts(p2pmock.NewPeer(nil)))
+}
@@ -17,7 +17,9 @@ import (
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	"github.com/Finschia/ostracon/libs/log"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
+	mempl "github.com/Finschia/ostracon/mempool"
 	"github.com/Finschia/ostracon/p2p"
+	occonsproto "github.com/Finschia/ostracon/proto/ostracon/consensus"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 	tmtime "github.com/Finschia/ostracon/types/time"
@@ -29,6 +31,12 @@ const (
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
 
+	// CompressedDataChannel carries block parts with compressed bytes, see
+	// compression.go.
+	CompressedDataChannel = byte(0x24)
+	// CompactBlockChannel carries compact blocks, see compact_block.go.
+	CompactBlockChannel = byte(0x25)
+
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
 	blocksToContributeToBecomeGoodPeer = 10000
@@ -48,6 +56,12 @@ type Reactor struct {
 	eventBus *types.EventBus
 	rs       *cstypes.RoundState
 
+	// mempool to rebuild compact blocks from, nil if they are disabled
+	mempool mempl.Mempool
+	// how long to wait for a peer to rebuild a compact block before sending
+	// it the block parts
+	compactBlockTimeout time.Duration
+
 	Metrics *Metrics
 }
 
@@ -148,7 +162,7 @@ conR:
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
 			Priority:            6,
@@ -165,6 +179,14 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &tmcons.Message{},
 		},
+		{
+			ID:                  CompressedDataChannel,
+			Priority:            10,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmcons.Message{},
+		},
 		{
 			ID:                  VoteChannel,
 			Priority:            7,
@@ -182,6 +204,17 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			MessageType:         &tmcons.Message{},
 		},
 	}
+	if conR.mempool != nil {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  CompactBlockChannel,
+			Priority:            10,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxCompactBlockMsgSize,
+			MessageType:         &occonsproto.Message{},
+		})
+	}
+	return channels
 }
 
 // InitPeer implements Reactor by creating a state for the peer.
@@ -238,10 +271,26 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		conR.Logger.Debug("Receive", "src", e.Src, "chId", e.ChannelID)
 		return
 	}
+	if e.ChannelID == CompactBlockChannel {
+		ps, ok := e.Src.Get(types.PeerStateKey).(*PeerState)
+		if !ok {
+			panic(fmt.Sprintf("Peer %v has no state", e.Src))
+		}
+		conR.receiveCompactBlockMessage(e, ps)
+		return
+	}
+
 	m := e.Message
 	if wm, ok := m.(p2p.Wrapper); ok {
 		m = wm.Wrap()
 	}
+	if e.ChannelID == CompressedDataChannel {
+		if err := decompressMessage(m.(*tmcons.Message)); err != nil {
+			conR.Logger.Error("Error decompressing message", "src", e.Src, "chId", e.ChannelID, "err", err)
+			conR.Switch.StopPeerForError(e.Src, err)
+			return
+		}
+	}
 	msg, err := MsgFromProto(m.(*tmcons.Message))
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
@@ -322,7 +371,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
 
-	case DataChannel:
+	case DataChannel, CompressedDataChannel:
 		if conR.WaitSync() {
 			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
 			return
@@ -400,7 +449,10 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 }
 
 func (conR *Reactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
-	msg := &tmcons.Message{}
+	var msg p2p.Unwrapper = &tmcons.Message{}
+	if chID == CompactBlockChannel {
+		msg = &occonsproto.Message{}
+	}
 	err := proto.Unmarshal(msgBytes, msg)
 	if err != nil {
 		panic(err)
@@ -575,8 +627,12 @@ OUTER_LOOP:
 		rs := conR.getRoundState()
 		prs := ps.GetRoundState()
 
+		// Hand the block rebuilt from a compact block to the consensus state?
+		conR.deliverCompactBlock(peer, ps, rs)
+
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) &&
+			!conR.gossipCompactBlock(logger, peer, ps, rs, prs) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
@@ -584,13 +640,10 @@ OUTER_LOOP:
 					panic(err)
 				}
 				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
-				if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
-					ChannelID: DataChannel,
-					Message: &tmcons.BlockPart{
-						Height: rs.Height, // This tells peer that this part applies to us.
-						Round:  rs.Round,  // This tells peer that this part applies to us.
-						Part:   *parts,
-					},
+				if sendBlockPart(peer, &tmcons.BlockPart{
+					Height: rs.Height, // This tells peer that this part applies to us.
+					Round:  rs.Round,  // This tells peer that this part applies to us.
+					Part:   *parts,
 				}, logger) {
 					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 				}
@@ -702,13 +755,10 @@ func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundSt
 			logger.Error("Could not convert part to proto", "index", index, "error", err)
 			return
 		}
-		if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
-			ChannelID: DataChannel,
-			Message: &tmcons.BlockPart{
-				Height: prs.Height, // Not our height, so it doesn't matter.
-				Round:  prs.Round,  // Not our height, so it doesn't matter.
-				Part:   *pp,
-			},
+		if sendBlockPart(peer, &tmcons.BlockPart{
+			Height: prs.Height, // Not our height, so it doesn't matter.
+			Round:  prs.Round,  // Not our height, so it doesn't matter.
+			Part:   *pp,
 		}, logger) {
 			ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 		} else {
@@ -1039,6 +1089,10 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// compact block relay, see compact_block.go
+	compactSent     compactBlockSent
+	compactReceived compactBlockReceived
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -52,6 +52,14 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	[]*Reactor,
 	[]types.Subscription,
 	[]*types.EventBus,
+) {
+	return startConsensusNetWithOptions(t, css, n, func(int) []ReactorOption { return nil })
+}
+
+func startConsensusNetWithOptions(t *testing.T, css []*State, n int, options func(i int) []ReactorOption) (
+	[]*Reactor,
+	[]types.Subscription,
+	[]*types.EventBus,
 ) {
 	reactors := make([]*Reactor, n)
 	blocksSubs := make([]types.Subscription, 0)
@@ -59,7 +67,7 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	for i := 0; i < n; i++ {
 		/*logger, err := tmflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true, true, 1000) // so we dont start the consensus states
+		reactors[i] = NewReactor(css[i], true, true, 1000, options(i)...) // so we dont start the consensus states
 		reactors[i].SetLogger(css[i].Logger)
 
 		// eventBus is already started with the cs
@@ -280,6 +280,93 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// Resync redoes the handshake with an application which restarted while the
+// node was running, and replays the blocks it lost. The application is synced
+// up to the last saved state only: the block the node was applying when the
+// application failed is applied again by the consensus.
+func (h *Handshaker) Resync(proxyApp proxy.AppConns) error {
+	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("error calling Info: %v", err)
+	}
+	appBlockHeight := res.LastBlockHeight
+	if appBlockHeight < 0 {
+		return fmt.Errorf("got a negative last block height (%d) from the app", appBlockHeight)
+	}
+	appHash := res.LastBlockAppHash
+
+	state, err := h.stateStore.Load()
+	if err != nil {
+		return err
+	}
+	if state.IsEmpty() {
+		state = h.initialState
+	}
+	stateBlockHeight := state.LastBlockHeight
+	storeBlockBase := h.store.Base()
+	h.logger.Info("ABCI Resync App Info",
+		"appHeight", appBlockHeight,
+		"appHash", appHash,
+		"stateHeight", stateBlockHeight,
+	)
+
+	switch {
+	case appBlockHeight > stateBlockHeight:
+		// the app committed a block the node didn't save the state of: only a
+		// handshake on restart can replay it
+		return sm.ErrAppBlockHeightTooHigh{CoreHeight: stateBlockHeight, AppHeight: appBlockHeight}
+
+	case appBlockHeight == 0 && stateBlockHeight > 0 && state.InitialHeight < storeBlockBase:
+		return sm.ErrAppBlockHeightTooLow{AppHeight: appBlockHeight, StoreBase: storeBlockBase}
+
+	case appBlockHeight > 0 && appBlockHeight < storeBlockBase-1:
+		return sm.ErrAppBlockHeightTooLow{AppHeight: appBlockHeight, StoreBase: storeBlockBase}
+	}
+
+	if appBlockHeight == 0 {
+		res, err := proxyApp.Consensus().InitChainSync(h.initChainRequest())
+		if err != nil {
+			return err
+		}
+		appHash = res.AppHash
+		if stateBlockHeight == 0 && len(res.AppHash) == 0 {
+			appHash = state.AppHash
+		}
+	}
+
+	if appBlockHeight < stateBlockHeight {
+		appHash, err = h.replayBlocks(state, proxyApp, appBlockHeight, stateBlockHeight, false)
+		if err != nil {
+			return fmt.Errorf("error on replay: %v", err)
+		}
+	} else {
+		assertAppHashEqualsOneFromState(appHash, state)
+	}
+
+	h.logger.Info("Completed ABCI Resync - Ostracon and App are synced",
+		"appHeight", stateBlockHeight, "appHash", appHash)
+	return nil
+}
+
+// initChainRequest returns the InitChain request of the genesis.
+func (h *Handshaker) initChainRequest() abci.RequestInitChain {
+	validators := make([]*types.Validator, len(h.genDoc.Validators))
+	for i, val := range h.genDoc.Validators {
+		validators[i] = types.NewValidator(val.PubKey, val.Power)
+	}
+	validatorSet := types.NewValidatorSet(validators)
+	nextVals := types.OC2PB.ValidatorUpdates(validatorSet)
+	csParams := types.OC2PB.ConsensusParams(h.genDoc.ConsensusParams)
+	return abci.RequestInitChain{
+		Time:            h.genDoc.GenesisTime,
+		ChainId:         h.genDoc.ChainID,
+		InitialHeight:   h.genDoc.InitialHeight,
+		ConsensusParams: csParams,
+		Validators:      nextVals,
+		AppStateBytes:   h.genDoc.AppState,
+	}
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -303,22 +390,7 @@ func (h *Handshaker) ReplayBlocks(
 
 	// If appBlockHeight == 0 it means that we are at genesis and hence should send InitChain.
 	if appBlockHeight == 0 {
-		validators := make([]*types.Validator, len(h.genDoc.Validators))
-		for i, val := range h.genDoc.Validators {
-			validators[i] = types.NewValidator(val.PubKey, val.Power)
-		}
-		validatorSet := types.NewValidatorSet(validators)
-		nextVals := types.OC2PB.ValidatorUpdates(validatorSet)
-		csParams := types.OC2PB.ConsensusParams(h.genDoc.ConsensusParams)
-		req := abci.RequestInitChain{
-			Time:            h.genDoc.GenesisTime,
-			ChainId:         h.genDoc.ChainID,
-			InitialHeight:   h.genDoc.InitialHeight,
-			ConsensusParams: csParams,
-			Validators:      nextVals,
-			AppStateBytes:   h.genDoc.AppState,
-		}
-		res, err := proxyApp.Consensus().InitChainSync(req)
+		res, err := proxyApp.Consensus().InitChainSync(h.initChainRequest())
 		if err != nil {
 			return nil, err
 		}
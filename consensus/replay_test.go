@@ -16,7 +16,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
@@ -30,6 +29,7 @@ import (
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	mempl "github.com/Finschia/ostracon/mempool"
 	"github.com/Finschia/ostracon/privval"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	"github.com/Finschia/ostracon/proxy"
 	sm "github.com/Finschia/ostracon/state"
@@ -71,7 +71,11 @@ func startNewStateAndWaitForBlock(t *testing.T, i int, consensusReplayConfig *cf
 	blockDB dbm.DB, stateStore sm.Store) {
 	logger := log.TestingLogger().With("attr", "make block", "i", i)
 	state, _ := stateStore.LoadFromDBOrGenesisFile(consensusReplayConfig.GenesisFile())
-	privValidator := loadPrivValidator(consensusReplayConfig)
+	// keep the last sign state of the crashed run, as a restarted node would
+	privValidator := privval.LoadFilePV(
+		consensusReplayConfig.PrivValidatorKeyFile(),
+		consensusReplayConfig.PrivValidatorStateFile(),
+	)
 	cs := newStateWithConfigAndBlockStoreWithLoggers(
 		consensusReplayConfig,
 		state,
@@ -616,14 +620,14 @@ func TestMockProxyApp(t *testing.T) {
 	txIndex := 0
 
 	assert.NotPanics(t, func() {
-		abciResWithEmptyDeliverTx := new(tmstate.ABCIResponses)
+		abciResWithEmptyDeliverTx := new(ocstate.ABCIResponses)
 		abciResWithEmptyDeliverTx.DeliverTxs = make([]*abci.ResponseDeliverTx, 0)
 		abciResWithEmptyDeliverTx.DeliverTxs = append(abciResWithEmptyDeliverTx.DeliverTxs, &abci.ResponseDeliverTx{})
 
 		// called when saveABCIResponses:
 		bytes, err := proto.Marshal(abciResWithEmptyDeliverTx)
 		require.NoError(t, err)
-		loadedAbciRes := new(tmstate.ABCIResponses)
+		loadedAbciRes := new(ocstate.ABCIResponses)
 
 		// this also happens sm.LoadABCIResponses
 		err = proto.Unmarshal(bytes, loadedAbciRes)
@@ -631,7 +635,7 @@ func TestMockProxyApp(t *testing.T) {
 
 		mock := newMockProxyApp([]byte("mock_hash"), loadedAbciRes)
 
-		abciRes := new(tmstate.ABCIResponses)
+		abciRes := new(ocstate.ABCIResponses)
 		abciRes.DeliverTxs = make([]*abci.ResponseDeliverTx, len(loadedAbciRes.DeliverTxs))
 		// Execute transactions and get hash.
 		proxyCb := func(req *ocabci.Request, res *ocabci.Response) {
@@ -1325,3 +1329,84 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		Validators: ica.vals,
 	}
 }
+
+func TestHandshakerResync(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	defer os.RemoveAll(config.RootDir)
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, version.AppProtocol)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	genesisState := state
+	// the app hashes of the 3 blocks are 0x01, 0x02, 0x03
+	store.chain = makeBlocks(3, &state, privVal)
+	require.NoError(t, stateStore.Save(state))
+
+	resync := func(app *resyncApp) error {
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+		require.NoError(t, proxyApp.Start())
+		t.Cleanup(func() {
+			if err := proxyApp.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		return NewHandshaker(stateStore, genesisState, store, genDoc).Resync(proxyApp)
+	}
+
+	// the restarted app lost all its blocks: the blocks are replayed
+	app := &resyncApp{}
+	require.NoError(t, resync(app))
+	assert.True(t, app.initChain)
+	assert.EqualValues(t, 3, app.height)
+
+	// the restarted app lost its last block
+	app = &resyncApp{height: 2}
+	require.NoError(t, resync(app))
+	assert.False(t, app.initChain)
+	assert.EqualValues(t, 3, app.height)
+
+	// the app is synced
+	app = &resyncApp{height: 3}
+	require.NoError(t, resync(app))
+	assert.EqualValues(t, 3, app.height)
+
+	// the app committed a block the state wasn't saved with
+	err = resync(&resyncApp{height: 4})
+	assert.ErrorAs(t, err, &sm.ErrAppBlockHeightTooHigh{})
+
+	// the app hash differs
+	assert.Panics(t, func() {
+		_ = resync(&resyncApp{height: 3, wrongHash: true})
+	})
+}
+
+// resyncApp commits the blocks with their height as app hash
+type resyncApp struct {
+	ocabci.BaseApplication
+	height    byte
+	initChain bool
+	wrongHash bool
+}
+
+func (app *resyncApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	hash := []byte{app.height}
+	if app.wrongHash {
+		hash = tmrand.Bytes(8)
+	}
+	return abci.ResponseInfo{LastBlockHeight: int64(app.height), LastBlockAppHash: hash}
+}
+
+func (app *resyncApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	app.initChain = true
+	return abci.ResponseInitChain{}
+}
+
+func (app *resyncApp) Commit() abci.ResponseCommit {
+	app.height++
+	return abci.ResponseCommit{Data: []byte{app.height}}
+}
@@ -0,0 +1,97 @@
+package simulation
+
+import (
+	"bytes"
+
+	"github.com/Finschia/ostracon/consensus"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Behaviour decides which messages a node actually sends to a peer in place
+// of a message produced by its consensus state.
+type Behaviour interface {
+	Outbound(node *Node, to int, msg consensus.Message) []consensus.Message
+}
+
+// Honest sends every message unchanged.
+type Honest struct{}
+
+var _ Behaviour = Honest{}
+
+// Outbound implements Behaviour.
+func (Honest) Outbound(_ *Node, _ int, msg consensus.Message) []consensus.Message {
+	return []consensus.Message{msg}
+}
+
+// Equivocate signs, for every vote cast by the node, a second vote of the
+// same height, round and type for a different block. Both votes are sent to
+// every peer, the conflicting one first to peers with an even index so that
+// the honest nodes see different votes first.
+type Equivocate struct{}
+
+var _ Behaviour = Equivocate{}
+
+// Outbound implements Behaviour.
+func (Equivocate) Outbound(node *Node, to int, msg consensus.Message) []consensus.Message {
+	voteMsg, ok := msg.(*consensus.VoteMessage)
+	if !ok || !bytes.Equal(voteMsg.Vote.ValidatorAddress, node.address) {
+		// only our own votes can be signed again
+		return []consensus.Message{msg}
+	}
+	conflicting, err := node.signConflictingVote(voteMsg.Vote)
+	if err != nil {
+		return []consensus.Message{msg}
+	}
+	if to%2 == 0 {
+		return []consensus.Message{&consensus.VoteMessage{Vote: conflicting}, msg}
+	}
+	return []consensus.Message{msg, &consensus.VoteMessage{Vote: conflicting}}
+}
+
+// Withhold keeps the selected kinds of messages from the given peers, or from
+// every peer if Peers is empty. Messages it withholds are not recorded as
+// drops in the trace since they are never sent.
+type Withhold struct {
+	Proposals bool // proposals and block parts
+	Votes     bool
+	Peers     []int
+}
+
+var _ Behaviour = Withhold{}
+
+// Outbound implements Behaviour.
+func (w Withhold) Outbound(_ *Node, to int, msg consensus.Message) []consensus.Message {
+	if len(w.Peers) > 0 && !containsInt(w.Peers, to) {
+		return []consensus.Message{msg}
+	}
+	switch msg.(type) {
+	case *consensus.ProposalMessage, *consensus.BlockPartMessage:
+		if w.Proposals {
+			return nil
+		}
+	case *consensus.VoteMessage:
+		if w.Votes {
+			return nil
+		}
+	}
+	return []consensus.Message{msg}
+}
+
+// conflictingBlockID returns the block ID equivocating votes are cast for.
+func conflictingBlockID(vote *types.Vote) types.BlockID {
+	hash := tmhash.Sum(append([]byte("equivocation"), vote.BlockID.Hash...))
+	return types.BlockID{
+		Hash:          hash,
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: hash},
+	}
+}
+
+func containsInt(xs []int, x int) bool {
+	for _, y := range xs {
+		if x == y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package simulation
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Clock is a virtual clock. Time only advances when the next scheduled event
+// is run, and events scheduled for the same instant run in the order in which
+// they were scheduled.
+type Clock struct {
+	now   time.Duration
+	seq   uint64
+	queue eventQueue
+}
+
+// NewClock returns a clock set to zero with no scheduled events.
+func NewClock() *Clock {
+	return &Clock{}
+}
+
+// Now returns the virtual time elapsed since the start of the simulation.
+func (c *Clock) Now() time.Duration {
+	return c.now
+}
+
+// Schedule runs fn after the given delay. A non-positive delay schedules fn
+// at the current time, after all the events already scheduled for it.
+func (c *Clock) Schedule(after time.Duration, fn func()) {
+	if after < 0 {
+		after = 0
+	}
+	c.seq++
+	heap.Push(&c.queue, &event{at: c.now + after, seq: c.seq, fn: fn})
+}
+
+// Pending returns the number of scheduled events.
+func (c *Clock) Pending() int {
+	return c.queue.Len()
+}
+
+// Step advances the clock to the next event and runs it. It returns false if
+// no event is scheduled.
+func (c *Clock) Step() bool {
+	if c.queue.Len() == 0 {
+		return false
+	}
+	ev := heap.Pop(&c.queue).(*event)
+	c.now = ev.at
+	ev.fn()
+	return true
+}
+
+//-----------------------------------------------------------------------------
+
+type event struct {
+	at  time.Duration
+	seq uint64
+	fn  func()
+}
+
+// eventQueue implements heap.Interface ordered by (at, seq).
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at == q[j].at {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at < q[j].at
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	ev := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return ev
+}
@@ -0,0 +1,35 @@
+/*
+Package simulation runs several consensus.State instances deterministically
+over a virtual clock and an in-memory network.
+
+Each simulated node owns a real consensus.State, block store, state store and
+kvstore application, but its receive and timeout routines are never started.
+Instead the State is driven by a consensus.Stepper: every message delivery and
+every expired timeout is an event on a single virtual Clock, and events are
+executed one at a time in (time, sequence) order. Nothing depends on the wall
+clock or on goroutine scheduling, so a run is fully determined by its Config,
+and in particular by Config.Seed.
+
+# Network
+
+The Network delays every message by a seeded random duration and may drop it
+with a fixed probability. Nodes can be split into partitions at any virtual
+time and healed later (see Simulation.At). Since dropped or partitioned
+messages are never retransmitted by the State itself, nodes periodically
+re-gossip the messages they know for the height of a stalled peer, which plays
+the role of the gossip routines of the consensus reactor.
+
+# Byzantine behaviours
+
+A Behaviour rewrites the messages a node sends to each of its peers. Honest
+nodes forward messages unchanged; Equivocate signs a conflicting vote for
+every vote it casts and Withhold keeps proposals and/or votes from some peers.
+
+# Traces
+
+Every delivery, drop, timeout and commit is recorded in a Trace. Traces do not
+contain hashes or timestamps, which depend on the wall clock used to sign
+votes, so two runs with the same Config produce identical traces and Replay
+can be used to reproduce a failing run from its seed.
+*/
+package simulation
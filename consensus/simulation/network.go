@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+)
+
+// NetworkConfig configures the links of the in-memory network.
+type NetworkConfig struct {
+	// Every message is delayed by a duration drawn uniformly from
+	// [MinDelay, MaxDelay].
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// Probability in [0, 1] that a message is dropped.
+	DropRate float64
+}
+
+// DefaultNetworkConfig returns a network with small delays and no drops.
+func DefaultNetworkConfig() NetworkConfig {
+	return NetworkConfig{
+		MinDelay: 1 * time.Millisecond,
+		MaxDelay: 10 * time.Millisecond,
+		DropRate: 0,
+	}
+}
+
+// Network decides, from a seeded source of randomness, whether and when a
+// message sent from one node reaches another.
+type Network struct {
+	config NetworkConfig
+	rng    *rand.Rand
+	size   int
+
+	// group of every node; nil when the network is not partitioned.
+	groups []int
+
+	// arrival time of the last message sent on every link, by sender and
+	// receiver, so that links deliver messages in order like a TCP stream
+	arrivals [][]time.Duration
+}
+
+func newNetwork(config NetworkConfig, rng *rand.Rand, size int) *Network {
+	arrivals := make([][]time.Duration, size)
+	for i := range arrivals {
+		arrivals[i] = make([]time.Duration, size)
+	}
+	return &Network{
+		config:   config,
+		rng:      rng,
+		size:     size,
+		arrivals: arrivals,
+	}
+}
+
+// Partition splits the network so that only nodes of the same group can
+// communicate. Nodes not listed in any group are isolated.
+func (n *Network) Partition(groups ...[]int) {
+	n.groups = make([]int, n.size)
+	for i := range n.groups {
+		n.groups[i] = -1 - i
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			n.groups[i] = g
+		}
+	}
+}
+
+// Heal removes any partition.
+func (n *Network) Heal() {
+	n.groups = nil
+}
+
+// Connected returns true if a message from node from can reach node to.
+func (n *Network) Connected(from, to int) bool {
+	if n.groups == nil {
+		return true
+	}
+	return n.groups[from] == n.groups[to]
+}
+
+// route returns the arrival time of a message sent at the given time between
+// two connected nodes, or false if the message is dropped. Randomness is
+// always consumed in the same order, so that the outcome only depends on the
+// seed and on the sequence of calls.
+func (n *Network) route(from, to int, now time.Duration) (time.Duration, bool) {
+	drop := n.rng.Float64() < n.config.DropRate
+	delay := n.config.MinDelay
+	if spread := n.config.MaxDelay - n.config.MinDelay; spread > 0 {
+		delay += time.Duration(n.rng.Int63n(int64(spread) + 1))
+	}
+	if drop {
+		return 0, false
+	}
+	arrival := now + delay
+	if last := n.arrivals[from][to]; arrival < last {
+		arrival = last
+	}
+	n.arrivals[from][to] = arrival
+	return arrival, true
+}
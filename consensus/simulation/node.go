@@ -0,0 +1,195 @@
+package simulation
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abcicli "github.com/Finschia/ostracon/abci/client"
+	"github.com/Finschia/ostracon/abci/example/kvstore"
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/consensus"
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/libs/log"
+	mempl "github.com/Finschia/ostracon/mempool/mock"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/proxy"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/store"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Commit records a block committed by a node.
+type Commit struct {
+	Height   int64
+	Round    int32
+	Hash     []byte
+	Proposer int // index of the proposing node
+}
+
+// Node is a simulated validator.
+type Node struct {
+	Index         int
+	ID            p2p.ID
+	PrivValidator types.PrivValidator
+
+	address    crypto.Address
+	chainID    string
+	stepper    *consensus.Stepper
+	blockStore *store.BlockStore
+	eventBus   *types.EventBus
+	evpool     *evidenceRecorder
+	behaviour  Behaviour
+
+	// messages known by the node, by height, re-gossiped to stalled peers
+	known     map[int64][]consensus.Message
+	knownKeys map[string]struct{}
+
+	commits []Commit
+}
+
+func newNode(
+	index int,
+	config *cfg.ConsensusConfig,
+	state sm.State,
+	pv types.PrivValidator,
+	behaviour Behaviour,
+	schedule func(consensus.Timeout),
+	logger log.Logger,
+) (*Node, error) {
+	pubKey, err := pv.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	db := dbm.NewMemDB()
+	blockStore := store.NewBlockStore(db)
+	stateStore := sm.NewStore(db, sm.StoreOptions{DiscardABCIResponses: false})
+	if err := stateStore.Save(state); err != nil {
+		return nil, err
+	}
+
+	appConn := abcicli.NewLocalClient(nil, kvstore.NewApplication())
+	if err := appConn.Start(); err != nil {
+		return nil, err
+	}
+
+	evpool := &evidenceRecorder{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxy.NewAppConnConsensus(appConn), mempl.Mempool{}, evpool)
+	cs := consensus.NewState(config, state.Copy(), blockExec, blockStore, mempl.Mempool{}, evpool)
+	cs.SetLogger(logger)
+	cs.SetPrivValidator(pv)
+
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger)
+	if err := eventBus.Start(); err != nil {
+		return nil, err
+	}
+	cs.SetEventBus(eventBus)
+
+	return &Node{
+		Index:         index,
+		ID:            p2p.PubKeyToID(pubKey),
+		PrivValidator: pv,
+		address:       pubKey.Address(),
+		chainID:       state.ChainID,
+		stepper:       consensus.NewStepper(cs, schedule),
+		blockStore:    blockStore,
+		eventBus:      eventBus,
+		evpool:        evpool,
+		behaviour:     behaviour,
+		known:         make(map[int64][]consensus.Message),
+		knownKeys:     make(map[string]struct{}),
+	}, nil
+}
+
+// State returns the consensus state of the node.
+func (n *Node) State() *consensus.State {
+	return n.stepper.State()
+}
+
+// Height returns the height the node is currently trying to decide.
+func (n *Node) Height() int64 {
+	return n.State().GetRoundState().Height
+}
+
+// Commits returns the blocks committed by the node, in order.
+func (n *Node) Commits() []Commit {
+	return n.commits
+}
+
+// ConflictingVotes returns the pairs of conflicting votes the node reported
+// to its evidence pool.
+func (n *Node) ConflictingVotes() [][2]*types.Vote {
+	return n.evpool.reported
+}
+
+// remember stores a message to be re-gossiped later and returns false if the
+// node already knew it.
+func (n *Node) remember(msg consensus.Message) bool {
+	var (
+		height int64
+		key    string
+	)
+	switch msg := msg.(type) {
+	case *consensus.ProposalMessage:
+		height = msg.Proposal.Height
+		key = fmt.Sprintf("P/%d/%d/%X", msg.Proposal.Height, msg.Proposal.Round, msg.Proposal.BlockID.Hash)
+	case *consensus.BlockPartMessage:
+		height = msg.Height
+		key = fmt.Sprintf("B/%d/%d/%d/%X", msg.Height, msg.Round, msg.Part.Index, msg.Part.Proof.LeafHash)
+	case *consensus.VoteMessage:
+		height = msg.Vote.Height
+		key = fmt.Sprintf("V/%d/%d/%d/%d/%X",
+			msg.Vote.Height, msg.Vote.Round, msg.Vote.Type, msg.Vote.ValidatorIndex, msg.Vote.BlockID.Hash)
+	default:
+		return false
+	}
+	if _, ok := n.knownKeys[key]; ok {
+		return false
+	}
+	n.knownKeys[key] = struct{}{}
+	n.known[height] = append(n.known[height], msg)
+	return true
+}
+
+// prune forgets the messages of heights below the given one.
+func (n *Node) prune(height int64) {
+	for h := range n.known {
+		if h < height {
+			delete(n.known, h)
+		}
+	}
+}
+
+// signConflictingVote returns a copy of the vote for a different block, signed
+// by the node.
+func (n *Node) signConflictingVote(vote *types.Vote) (*types.Vote, error) {
+	conflicting := vote.Copy()
+	conflicting.BlockID = conflictingBlockID(vote)
+	v := conflicting.ToProto()
+	if err := n.PrivValidator.SignVote(n.chainID, v); err != nil {
+		return nil, err
+	}
+	conflicting.Signature = v.Signature
+	return conflicting, nil
+}
+
+func (n *Node) stop() {
+	if err := n.eventBus.Stop(); err != nil {
+		n.State().Logger.Error("failed to stop event bus", "err", err)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// evidenceRecorder is an empty evidence pool recording the conflicting votes
+// reported by the consensus state.
+type evidenceRecorder struct {
+	sm.EmptyEvidencePool
+	reported [][2]*types.Vote
+}
+
+func (evr *evidenceRecorder) ReportConflictingVotes(voteA, voteB *types.Vote) {
+	evr.reported = append(evr.reported, [2]*types.Vote{voteA, voteB})
+}
@@ -0,0 +1,413 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/consensus"
+	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/libs/log"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+// ChainID is the chain ID of simulated networks.
+const ChainID = "simulation"
+
+// genesisTime is fixed so that the genesis hash, which seeds the VRF based
+// proposer election, only depends on the validator keys.
+var genesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Partition isolates groups of nodes from each other between Start and End.
+// An End of zero means the partition is never healed.
+type Partition struct {
+	Start  time.Duration
+	End    time.Duration
+	Groups [][]int
+}
+
+// Config configures a simulation. Two simulations with the same Config
+// produce the same Trace.
+type Config struct {
+	// Seed of the validator keys and of the network randomness.
+	Seed int64
+
+	// Number of validators, all with the same voting power.
+	Validators int
+
+	Consensus *cfg.ConsensusConfig
+	Network   NetworkConfig
+
+	// Interval at which stalled nodes are sent the messages their peers know
+	// for their current height.
+	GossipInterval time.Duration
+
+	// Behaviours of byzantine nodes, by node index. Other nodes are Honest.
+	Behaviours map[int]Behaviour
+
+	Partitions []Partition
+
+	Logger log.Logger
+}
+
+// DefaultConfig returns the configuration of a 4 validator network using the
+// consensus timeouts of the test configuration.
+func DefaultConfig() Config {
+	return Config{
+		Seed:           1,
+		Validators:     4,
+		Consensus:      cfg.TestConsensusConfig(),
+		Network:        DefaultNetworkConfig(),
+		GossipInterval: 50 * time.Millisecond,
+		Logger:         log.NewNopLogger(),
+	}
+}
+
+// progress is the position of a node in the consensus protocol.
+type progress struct {
+	height int64
+	round  int32
+	step   cstypes.RoundStepType
+}
+
+// Simulation is a set of nodes running consensus over a virtual clock and an
+// in-memory network.
+type Simulation struct {
+	config  Config
+	clock   *Clock
+	network *Network
+	nodes   []*Node
+
+	// node index by validator address
+	indexes map[string]int
+
+	// last progress observed by the gossip routine, by node index
+	progress []progress
+
+	// hash of the first block committed at each height
+	decided map[int64][]byte
+
+	trace Trace
+	err   error
+}
+
+// New creates the nodes of a simulation. Call Start to schedule the first
+// round of every node.
+func New(config Config) (*Simulation, error) {
+	if config.Validators < 1 {
+		return nil, errors.New("at least one validator is required")
+	}
+	if config.GossipInterval <= 0 {
+		return nil, errors.New("gossip interval must be positive")
+	}
+	if config.Logger == nil {
+		config.Logger = log.NewNopLogger()
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed)) //nolint:gosec
+	s := &Simulation{
+		config:   config,
+		clock:    NewClock(),
+		network:  newNetwork(config.Network, rng, config.Validators),
+		indexes:  make(map[string]int, config.Validators),
+		progress: make([]progress, config.Validators),
+		decided:  make(map[int64][]byte),
+	}
+
+	privVals := make([]types.PrivValidator, config.Validators)
+	genVals := make([]types.GenesisValidator, config.Validators)
+	for i := range privVals {
+		secret := []byte(fmt.Sprintf("simulation/%d/%d", config.Seed, i))
+		pv := types.NewMockPVWithParams(ed25519.GenPrivKeyFromSecret(secret), false, false)
+		pubKey, err := pv.GetPubKey()
+		if err != nil {
+			return nil, err
+		}
+		privVals[i] = pv
+		genVals[i] = types.GenesisValidator{PubKey: pubKey, Power: 10}
+		s.indexes[string(pubKey.Address())] = i
+	}
+
+	genDoc := &types.GenesisDoc{
+		GenesisTime:   genesisTime,
+		ChainID:       ChainID,
+		InitialHeight: 1,
+		Validators:    genVals,
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pv := range privVals {
+		behaviour, ok := config.Behaviours[i]
+		if !ok {
+			behaviour = Honest{}
+		}
+		node, err := newNode(i, config.Consensus, state, pv, behaviour, s.timeoutScheduler(i),
+			config.Logger.With("node", i))
+		if err != nil {
+			return nil, err
+		}
+		s.nodes = append(s.nodes, node)
+	}
+
+	return s, nil
+}
+
+// Start schedules the first round of every node, the configured partitions
+// and the gossip routine.
+func (s *Simulation) Start() {
+	for _, node := range s.nodes {
+		node.stepper.Start()
+	}
+	for _, p := range s.config.Partitions {
+		groups := p.Groups
+		s.At(p.Start, func() { s.network.Partition(groups...) })
+		if p.End > 0 {
+			s.At(p.End, s.network.Heal)
+		}
+	}
+	s.clock.Schedule(s.config.GossipInterval, s.gossip)
+}
+
+// Stop releases the resources of the nodes.
+func (s *Simulation) Stop() {
+	for _, node := range s.nodes {
+		node.stop()
+	}
+}
+
+// Now returns the current virtual time.
+func (s *Simulation) Now() time.Duration {
+	return s.clock.Now()
+}
+
+// Nodes returns the simulated nodes, by index.
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Network returns the simulated network, e.g. to partition it from a function
+// scheduled with At.
+func (s *Simulation) Network() *Network {
+	return s.network
+}
+
+// Trace returns the events recorded so far.
+func (s *Simulation) Trace() Trace {
+	return s.trace
+}
+
+// At runs fn at the given virtual time, or immediately after the pending
+// events of the current time if it already passed.
+func (s *Simulation) At(at time.Duration, fn func()) {
+	s.clock.Schedule(at-s.clock.Now(), fn)
+}
+
+// RunUntil runs events until cond returns true. It returns an error if the
+// condition is not met within the given virtual duration or if two nodes
+// committed different blocks at the same height.
+func (s *Simulation) RunUntil(cond func() bool, limit time.Duration) error {
+	deadline := s.clock.Now() + limit
+	for !cond() {
+		if s.err != nil {
+			return s.err
+		}
+		if s.clock.Now() > deadline {
+			return fmt.Errorf("condition not met after %v", limit)
+		}
+		if !s.clock.Step() {
+			return errors.New("no more events to run")
+		}
+	}
+	return s.err
+}
+
+// RunUntilHeight runs events until every node committed the given height.
+func (s *Simulation) RunUntilHeight(height int64, limit time.Duration) error {
+	return s.RunUntil(func() bool {
+		for _, node := range s.nodes {
+			if int64(len(node.commits)) < height {
+				return false
+			}
+		}
+		return true
+	}, limit)
+}
+
+// RunFor runs events for the given virtual duration.
+func (s *Simulation) RunFor(d time.Duration) error {
+	end := s.clock.Now() + d
+	s.At(end, func() {})
+	return s.RunUntil(func() bool { return s.clock.Now() >= end }, d)
+}
+
+// Replay runs a new simulation with the given config until it recorded as many
+// events as the expected trace, and returns an error describing the first
+// event that differs.
+func Replay(config Config, expected Trace) error {
+	s, err := New(config)
+	if err != nil {
+		return err
+	}
+	s.Start()
+	defer s.Stop()
+
+	for len(s.trace) < len(expected) && s.clock.Step() {
+	}
+
+	got := s.trace
+	if len(got) > len(expected) {
+		got = got[:len(expected)]
+	}
+	if i := expected.Diverge(got); i >= 0 {
+		if i >= len(got) {
+			return fmt.Errorf("replay stopped after %d of %d events", len(got), len(expected))
+		}
+		return fmt.Errorf("replay diverged at event %d: expected %q, got %q", i, expected[i], got[i])
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+func (s *Simulation) record(kind EventKind, from, to int, value string) {
+	s.trace = append(s.trace, Event{
+		Time:  s.clock.Now(),
+		Kind:  kind,
+		From:  from,
+		To:    to,
+		Value: value,
+	})
+}
+
+// timeoutScheduler returns the callback the stepper of a node reports its
+// timeouts to.
+func (s *Simulation) timeoutScheduler(index int) func(consensus.Timeout) {
+	return func(t consensus.Timeout) {
+		node := s.nodes[index]
+		d := t.Duration
+		if t.Step == cstypes.RoundStepNewHeight {
+			// the State computes this one from the wall clock
+			d = s.config.Consensus.TimeoutCommit
+		}
+		s.clock.Schedule(d, func() {
+			s.record(EventTimeout, -1, node.Index, fmt.Sprintf("%d/%d %v", t.Height, t.Round, t.Step))
+			s.handleOutput(node, node.stepper.Fire(t))
+		})
+	}
+}
+
+// handleOutput broadcasts the messages produced by a node and records the
+// blocks it committed.
+func (s *Simulation) handleOutput(node *Node, msgs []consensus.Message) {
+	for _, msg := range msgs {
+		node.remember(msg)
+		for _, peer := range s.nodes {
+			if peer != node {
+				s.send(node, peer, msg)
+			}
+		}
+	}
+	s.checkCommits(node)
+}
+
+func (s *Simulation) send(from, to *Node, msg consensus.Message) {
+	for _, m := range from.behaviour.Outbound(from, to.Index, msg) {
+		if !s.network.Connected(from.Index, to.Index) {
+			s.record(EventDrop, from.Index, to.Index, describe(m))
+			continue
+		}
+		arrival, ok := s.network.route(from.Index, to.Index, s.clock.Now())
+		if !ok {
+			s.record(EventDrop, from.Index, to.Index, describe(m))
+			continue
+		}
+		m := m
+		s.At(arrival, func() { s.deliver(from, to, m) })
+	}
+}
+
+func (s *Simulation) deliver(from, to *Node, msg consensus.Message) {
+	s.record(EventDeliver, from.Index, to.Index, describe(msg))
+
+	// nodes must not share messages, so go through the wire encoding
+	pb, err := consensus.MsgToProto(msg)
+	if err != nil {
+		panic(err)
+	}
+	msg, err = consensus.MsgFromProto(pb)
+	if err != nil {
+		panic(err)
+	}
+
+	to.remember(msg)
+	s.handleOutput(to, to.stepper.Deliver(msg, from.ID))
+}
+
+func (s *Simulation) checkCommits(node *Node) {
+	lastHeight := node.State().GetLastHeight()
+	for height := int64(len(node.commits)) + 1; height <= lastHeight; height++ {
+		meta := node.blockStore.LoadBlockMeta(height)
+		seen := node.blockStore.LoadSeenCommit(height)
+		if meta == nil || seen == nil {
+			panic(fmt.Sprintf("node %d committed height %d but the block is not stored", node.Index, height))
+		}
+		commit := Commit{
+			Height:   height,
+			Round:    seen.Round,
+			Hash:     meta.BlockID.Hash,
+			Proposer: s.indexes[string(meta.Header.ProposerAddress)],
+		}
+		node.commits = append(node.commits, commit)
+		s.record(EventCommit, -1, node.Index,
+			fmt.Sprintf("%d/%d proposer:%d", commit.Height, commit.Round, commit.Proposer))
+
+		if decided, ok := s.decided[height]; !ok {
+			s.decided[height] = commit.Hash
+		} else if !bytes.Equal(decided, commit.Hash) && s.err == nil {
+			s.err = fmt.Errorf("safety violation: node %d committed %X at height %d, %X was committed before",
+				node.Index, commit.Hash, height, decided)
+		}
+	}
+}
+
+// gossip sends to every node that made no progress since the previous call
+// the messages its peers know for its current height, and reschedules itself.
+func (s *Simulation) gossip() {
+	minHeight := int64(-1)
+	for _, to := range s.nodes {
+		rs := to.State().GetRoundState()
+		p := progress{rs.Height, rs.Round, rs.Step}
+		if minHeight < 0 || p.height < minHeight {
+			minHeight = p.height
+		}
+		stalled := p == s.progress[to.Index]
+		s.progress[to.Index] = p
+		if !stalled {
+			continue
+		}
+		for _, from := range s.nodes {
+			if from == to {
+				continue
+			}
+			for _, msg := range from.known[p.height] {
+				s.send(from, to, msg)
+			}
+		}
+	}
+
+	for _, node := range s.nodes {
+		node.prune(minHeight)
+	}
+	s.clock.Schedule(s.config.GossipInterval, s.gossip)
+}
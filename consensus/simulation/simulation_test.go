@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runSimulation(t *testing.T, config Config, height int64) *Simulation {
+	s, err := New(config)
+	require.NoError(t, err)
+	s.Start()
+	t.Cleanup(s.Stop)
+	require.NoError(t, s.RunUntilHeight(height, time.Minute))
+	return s
+}
+
+func TestSimulationLiveness(t *testing.T) {
+	config := DefaultConfig()
+	config.Network.DropRate = 0.1
+	s := runSimulation(t, config, 5)
+
+	for _, node := range s.Nodes() {
+		assert.GreaterOrEqual(t, len(node.Commits()), 5)
+	}
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	config := DefaultConfig()
+	config.Seed = 42
+	config.Network.DropRate = 0.2
+
+	s1 := runSimulation(t, config, 4)
+	s2 := runSimulation(t, config, 4)
+	require.Equal(t, s1.Trace().Hash(), s2.Trace().Hash())
+	require.NoError(t, Replay(config, s1.Trace()))
+
+	config.Seed = 43
+	s3 := runSimulation(t, config, 4)
+	require.NotEqual(t, s1.Trace().Hash(), s3.Trace().Hash())
+	require.Error(t, Replay(config, s1.Trace()))
+}
+
+func TestSimulationProposerRotation(t *testing.T) {
+	s := runSimulation(t, DefaultConfig(), 10)
+
+	proposers := make(map[int]struct{})
+	expected := s.Nodes()[0].Commits()
+	for _, c := range expected[:10] {
+		proposers[c.Proposer] = struct{}{}
+	}
+	// the VRF election should not pick the same proposer ten times in a row
+	assert.Greater(t, len(proposers), 1)
+
+	for _, node := range s.Nodes()[1:] {
+		for i, c := range node.Commits()[:10] {
+			assert.Equal(t, expected[i].Proposer, c.Proposer)
+			assert.Equal(t, expected[i].Hash, c.Hash)
+		}
+	}
+}
+
+func TestSimulationPartition(t *testing.T) {
+	config := DefaultConfig()
+	config.Partitions = []Partition{
+		// one node out of four: the others keep committing
+		{Start: 0, End: 2 * time.Second, Groups: [][]int{{0}, {1, 2, 3}}},
+	}
+	s, err := New(config)
+	require.NoError(t, err)
+	s.Start()
+	defer s.Stop()
+
+	require.NoError(t, s.RunUntil(func() bool {
+		return len(s.Nodes()[1].Commits()) >= 3
+	}, time.Second))
+	assert.Empty(t, s.Nodes()[0].Commits())
+
+	// after the partition is healed, node 0 catches up
+	require.NoError(t, s.RunFor(2*time.Second))
+	require.NoError(t, s.RunUntilHeight(int64(len(s.Nodes()[1].Commits())), time.Minute))
+
+	// no group holds +2/3 of the voting power: nobody commits
+	s.Network().Partition([]int{0, 1}, []int{2, 3})
+	require.NoError(t, s.RunFor(time.Second))
+	height := int64(0)
+	for _, node := range s.Nodes() {
+		if node.Height() > height {
+			height = node.Height()
+		}
+	}
+	require.NoError(t, s.RunFor(5*time.Second))
+	for _, node := range s.Nodes() {
+		assert.LessOrEqual(t, node.Height(), height+1)
+	}
+
+	s.Network().Heal()
+	require.NoError(t, s.RunUntilHeight(height+2, time.Minute))
+}
+
+func TestSimulationEquivocation(t *testing.T) {
+	config := DefaultConfig()
+	config.Behaviours = map[int]Behaviour{3: Equivocate{}}
+	s := runSimulation(t, config, 5)
+
+	for _, node := range s.Nodes()[:3] {
+		assert.NotEmpty(t, node.ConflictingVotes())
+	}
+}
+
+func TestSimulationWithholding(t *testing.T) {
+	config := DefaultConfig()
+	config.Behaviours = map[int]Behaviour{
+		0: Withhold{Proposals: true, Votes: true},
+	}
+	s := runSimulation(t, config, 5)
+
+	// rounds proposed by node 0 fail, but the others decide without its votes
+	for _, c := range s.Nodes()[1].Commits() {
+		assert.NotEqual(t, 0, c.Proposer)
+	}
+}
+
+func TestTraceDiverge(t *testing.T) {
+	a := Trace{{Kind: EventDeliver}, {Kind: EventCommit}}
+	assert.Equal(t, -1, a.Diverge(Trace{{Kind: EventDeliver}, {Kind: EventCommit}}))
+	assert.Equal(t, 1, a.Diverge(Trace{{Kind: EventDeliver}, {Kind: EventDrop}}))
+	assert.Equal(t, 1, a.Diverge(Trace{{Kind: EventDeliver}}))
+}
@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Finschia/ostracon/consensus"
+)
+
+// EventKind is the kind of a trace event.
+type EventKind string
+
+const (
+	EventDeliver EventKind = "deliver"
+	EventDrop    EventKind = "drop"
+	EventTimeout EventKind = "timeout"
+	EventCommit  EventKind = "commit"
+)
+
+// Event is an entry of a Trace. From is -1 for timeouts and commits.
+type Event struct {
+	Time  time.Duration
+	Kind  EventKind
+	From  int
+	To    int
+	Value string
+}
+
+// String returns a one line representation of the event.
+func (e Event) String() string {
+	return fmt.Sprintf("%v %s %d->%d %s", e.Time, e.Kind, e.From, e.To, e.Value)
+}
+
+// Trace is the ordered list of events of a simulation run.
+type Trace []Event
+
+// String returns the events, one per line.
+func (t Trace) String() string {
+	var sb strings.Builder
+	for _, e := range t {
+		sb.WriteString(e.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Hash returns a digest of the trace. Two runs with the same Config have
+// the same trace hash.
+func (t Trace) Hash() []byte {
+	h := sha256.New()
+	for _, e := range t {
+		h.Write([]byte(e.String()))
+		h.Write([]byte{'\n'})
+	}
+	return h.Sum(nil)
+}
+
+// Diverge returns the index of the first event that differs between the two
+// traces, or -1 if they are identical.
+func (t Trace) Diverge(other Trace) int {
+	for i := 0; i < len(t) && i < len(other); i++ {
+		if t[i] != other[i] {
+			return i
+		}
+	}
+	if len(t) != len(other) {
+		if len(t) < len(other) {
+			return len(t)
+		}
+		return len(other)
+	}
+	return -1
+}
+
+// describe returns a deterministic description of a consensus message. Hashes
+// and signatures are left out because they depend on vote timestamps.
+func describe(msg consensus.Message) string {
+	switch msg := msg.(type) {
+	case *consensus.ProposalMessage:
+		return fmt.Sprintf("Proposal{%d/%d pol:%d}", msg.Proposal.Height, msg.Proposal.Round, msg.Proposal.POLRound)
+	case *consensus.BlockPartMessage:
+		return fmt.Sprintf("BlockPart{%d/%d #%d}", msg.Height, msg.Round, msg.Part.Index)
+	case *consensus.VoteMessage:
+		target := "block"
+		if msg.Vote.BlockID.IsZero() {
+			target = "nil"
+		}
+		return fmt.Sprintf("Vote{%d/%d %v val:%d %s}",
+			msg.Vote.Height, msg.Vote.Round, msg.Vote.Type, msg.Vote.ValidatorIndex, target)
+	default:
+		return fmt.Sprintf("%T", msg)
+	}
+}
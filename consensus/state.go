@@ -38,6 +38,10 @@ var (
 	ErrInvalidProposalPOLRound    = errors.New("error invalid proposal POL round")
 	ErrAddingVote                 = errors.New("error adding vote")
 	ErrSignatureFoundInPastBlocks = errors.New("found signature from the same key")
+	ErrSignatureFoundInWAL        = errors.New("found message signed by the same key in the WAL after the last sign state")
+	ErrSignatureFoundInNetwork    = errors.New("found message signed by the same key in the network after the last sign state")
+	ErrNoLastSignState            = errors.New("private validator does not provide its last sign state, " +
+		"which double_sign_guard_wait requires")
 
 	errPubKeyIsNotSet = errors.New("pubkey is not set. Look for \"Can't get private validator pubkey\" errors")
 )
@@ -118,6 +122,8 @@ type txNotifier interface {
 type evidencePool interface {
 	// reports conflicting votes to the evidence pool to be processed into evidence
 	ReportConflictingVotes(voteA, voteB *types.Vote)
+	// reports conflicting proposals to the evidence pool to be processed into evidence
+	ReportConflictingProposals(proposalA, proposalB *types.Proposal, proposerAddr types.Address)
 }
 
 // State handles execution of the consensus algorithm.
@@ -151,6 +157,11 @@ type State struct {
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
+	// height from which we sign proposals and votes (see DoubleSignGuardWait)
+	signingStartHeight int64
+	// set when a peer sent us a message signed with our key that our
+	// privValidator does not remember signing; we never sign again
+	foreignSignatureFound bool
 
 	// state changes may be triggered by: msgs from peers,
 	// msgs from ourself, or by timeouts
@@ -358,6 +369,11 @@ func (cs *State) OnStart() error {
 		}
 	}
 
+	// Double Signing Risk Reduction: observe the network before signing, from
+	// the height we start at, which is later than the one of NewState after
+	// fast sync or state sync.
+	cs.signingStartHeight = cs.Height + cs.config.DoubleSignGuardWait
+
 	// we need the timeoutRoutine for replay so
 	// we don't block on the tick chan.
 	// NOTE: we will get a build up of garbage go routines
@@ -427,6 +443,9 @@ func (cs *State) OnStart() error {
 	if err := cs.checkDoubleSigningRisk(cs.Height); err != nil {
 		return err
 	}
+	if cs.signingStartHeight > cs.Height {
+		cs.Logger.Info("observing the network before signing", "until_height", cs.signingStartHeight)
+	}
 
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
@@ -763,6 +782,12 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		// priv_val that haven't hit the WAL, but its ok because
 		// priv_val tracks LastSig
 
+		// the block executed optimistically won't be decided once consensus
+		// stopped, whether the node stops or leaves it for blocksync or statesync
+		if err := cs.blockExec.AbortOptimisticBlock(); err != nil {
+			cs.Logger.Error("failed to abort the block executed optimistically", "err", err)
+		}
+
 		// close wal now that we're done writing to it
 		if err := cs.wal.Stop(); err != nil {
 			cs.Logger.Error("failed trying to stop WAL", "error", err)
@@ -1137,6 +1162,11 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
+	if !cs.signingAllowed() {
+		logger.Debug("propose step; signing is disabled by the double signing guard")
+		return
+	}
+
 	if cs.privValidatorPubKey == nil {
 		// If this node is a validator & proposer in the current round, it will
 		// miss the opportunity to create a block.
@@ -1144,7 +1174,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
-	address := cs.privValidatorPubKey.Address()
+	address := validatorAddress(cs.Validators, cs.privValidatorPubKey)
 
 	// if not a validator, we're done
 	if !cs.Validators.HasAddress(address) {
@@ -1262,7 +1292,7 @@ func (cs *State) createProposalBlock(round int32) (block *types.Block, blockPart
 		return
 	}
 
-	proposerAddr := cs.privValidatorPubKey.Address()
+	proposerAddr := validatorAddress(cs.Validators, cs.privValidatorPubKey)
 
 	message := cs.state.MakeHashMessage(round)
 
@@ -1724,6 +1754,10 @@ func (cs *State) finalizeCommit(height int64) {
 		&cs.stepTimes.CommitStepTimes,
 	)
 	if err != nil {
+		if _, ok := err.(sm.ErrAbortBlock); ok {
+			// the app may keep the changes of another block, no block can be applied anymore
+			panic(fmt.Sprintf("failed to apply block; error %v", err))
+		}
 		logger.Error("failed to apply block", "err", err)
 		return
 	}
@@ -1811,7 +1845,7 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 				// Metrics won't be updated, but it's not critical.
 				cs.Logger.Error(fmt.Sprintf("recordMetrics: %v", errPubKeyIsNotSet))
 			} else {
-				address = cs.privValidatorPubKey.Address()
+				address = validatorAddress(cs.LastValidators, cs.privValidatorPubKey)
 			}
 		}
 
@@ -1821,7 +1855,7 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 				// Metrics won't be updated, but it's not critical.
 				cs.Logger.Error("Error on retrieval of pubkey", "err", err)
 			} else {
-				address = pubkey.Address()
+				address = validatorAddress(cs.LastValidators, pubkey)
 			}
 		}
 
@@ -1901,8 +1935,11 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 
 func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	// Already have one
-	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
+		if proposal.Height == cs.Proposal.Height && proposal.Round == cs.Proposal.Round &&
+			!proposal.BlockID.Equals(cs.Proposal.BlockID) {
+			cs.reportConflictingProposal(proposal)
+		}
 		return nil
 	}
 
@@ -1933,6 +1970,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	proposal.Signature = p.Signature
 	cs.Proposal = proposal
+	cs.detectForeignSignature(proposer.PubKey, proposal.Height, proposal.Round, signStepPropose)
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1944,6 +1982,22 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	return nil
 }
 
+// reportConflictingProposal reports the proposal to the evidence pool along with cs.Proposal
+// if it's signed by the proposer of the height and round of cs.Proposal.
+func (cs *State) reportConflictingProposal(proposal *types.Proposal) {
+	proposer := cs.Validators.SelectProposer(cs.state.LastProofHash, proposal.Height, proposal.Round)
+	if !proposer.PubKey.VerifySignature(
+		types.ProposalSignBytes(cs.state.ChainID, proposal.ToProto()), proposal.Signature,
+	) {
+		cs.Logger.Debug("ignoring conflicting proposal with an invalid signature", "proposal", proposal)
+		return
+	}
+
+	cs.Logger.Info("found conflicting proposals", "proposal_a", cs.Proposal, "proposal_b", proposal,
+		"proposer", proposer.Address)
+	cs.evpool.ReportConflictingProposals(cs.Proposal, proposal, proposer.Address)
+}
+
 // NOTE: block is not necessarily valid.
 // Asynchronously triggers either enterPrevote (before we timeout of propose) or tryFinalizeCommit,
 // once we have the full block.
@@ -2031,6 +2085,14 @@ func (cs *State) handleCompleteProposal(blockHeight int64) {
 		// procedure at this point.
 	}
 
+	if cs.ProposalBlock != nil && cs.Step < cstypes.RoundStepCommit {
+		// Start executing the block while voting for it, if enabled
+		cs.blockExec.ExecuteBlockOptimistically(cs.state, types.BlockID{
+			Hash:          cs.ProposalBlock.Hash(),
+			PartSetHeader: cs.ProposalBlockParts.Header(),
+		}, cs.ProposalBlock)
+	}
+
 	if cs.Step <= cstypes.RoundStepPropose && cs.isProposalComplete() {
 		// Move onto the next step
 		cs.enterPrevote(blockHeight, cs.Round)
@@ -2046,6 +2108,9 @@ func (cs *State) handleCompleteProposal(blockHeight int64) {
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
 func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	added, err := cs.addVote(vote, peerID)
+	if added {
+		cs.detectForeignVote(vote)
+	}
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
 		// But if it's a conflicting sig, add it to the cs.evpool.
@@ -2056,13 +2121,14 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 				return false, errPubKeyIsNotSet
 			}
 
-			if bytes.Equal(vote.ValidatorAddress, cs.privValidatorPubKey.Address()) {
+			if bytes.Equal(vote.ValidatorAddress, validatorAddress(cs.Validators, cs.privValidatorPubKey)) {
 				cs.Logger.Error(
 					"found conflicting vote from ourselves; did you unsafe_reset a validator?",
 					"height", vote.Height,
 					"round", vote.Round,
 					"type", vote.Type,
 				)
+				cs.detectForeignVote(vote)
 
 				return added, err
 			}
@@ -2283,7 +2349,7 @@ func (cs *State) signVote(
 		return nil, errPubKeyIsNotSet
 	}
 
-	addr := cs.privValidatorPubKey.Address()
+	addr := validatorAddress(cs.Validators, cs.privValidatorPubKey)
 	valIdx, _ := cs.Validators.GetByAddress(addr)
 
 	vote := &types.Vote{
@@ -2330,6 +2396,11 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 		return nil
 	}
 
+	if !cs.signingAllowed() {
+		cs.Logger.Debug("signing is disabled by the double signing guard", "height", cs.Height, "round", cs.Round)
+		return nil
+	}
+
 	if cs.privValidatorPubKey == nil {
 		// Vote won't be signed, but it's not critical.
 		cs.Logger.Error(fmt.Sprintf("signAddVote: %v", errPubKeyIsNotSet))
@@ -2337,7 +2408,7 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 	}
 
 	// If the node not in the validator set, do nothing.
-	if !cs.Validators.HasAddress(cs.privValidatorPubKey.Address()) {
+	if _, val := cs.Validators.GetByPubKey(cs.privValidatorPubKey); val == nil {
 		return nil
 	}
 
@@ -2361,6 +2432,10 @@ func (cs *State) updatePrivValidatorPubKey() error {
 		return nil
 	}
 
+	if err := cs.rotatePrivValidatorKey(); err != nil {
+		return err
+	}
+
 	pubKey, err := cs.privValidator.GetPubKey()
 	if err != nil {
 		return err
@@ -2369,10 +2444,32 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	return nil
 }
 
+// rotatePrivValidatorKey switches the private validator to its next key once
+// the validator set uses it, i.e. from the second height after the app
+// returned the key rotation. The old key signed the height before.
+func (cs *State) rotatePrivValidatorKey() error {
+	rotator, ok := cs.privValidator.(types.KeyRotatingPrivValidator)
+	if !ok || cs.Validators == nil {
+		return nil
+	}
+	nextPubKey, err := rotator.NextPubKey()
+	if err != nil || nextPubKey == nil {
+		return err
+	}
+	if _, val := cs.Validators.GetByPubKey(nextPubKey); val == nil {
+		return nil
+	}
+	if err := rotator.RotateKey(); err != nil {
+		return fmt.Errorf("failed to rotate the private validator key: %w", err)
+	}
+	cs.Logger.Info("rotated the private validator key", "height", cs.Height, "pubKey", nextPubKey)
+	return nil
+}
+
 // look back to check existence of the node's consensus votes before joining consensus
 func (cs *State) checkDoubleSigningRisk(height int64) error {
 	if cs.privValidator != nil && cs.privValidatorPubKey != nil && cs.config.DoubleSignCheckHeight > 0 && height > 0 {
-		valAddr := cs.privValidatorPubKey.Address()
+		valAddr := validatorAddress(cs.Validators, cs.privValidatorPubKey)
 		doubleSignCheckHeight := cs.config.DoubleSignCheckHeight
 		if doubleSignCheckHeight > height {
 			doubleSignCheckHeight = height
@@ -2394,6 +2491,189 @@ func (cs *State) checkDoubleSigningRisk(height int64) error {
 	return nil
 }
 
+// steps of the height/round/step (HRS) of a signed message, as recorded by
+// the private validator
+const (
+	signStepPropose   int8 = 1
+	signStepPrevote   int8 = 2
+	signStepPrecommit int8 = 3
+)
+
+// lastSignStateProvider is implemented by private validators which persist the
+// HRS of the last message they signed, such as privval.FilePV.
+type lastSignStateProvider interface {
+	LastSignedHRS() (height int64, round int32, step int8)
+}
+
+func voteSignStep(voteType tmproto.SignedMsgType) int8 {
+	if voteType == tmproto.PrecommitType {
+		return signStepPrecommit
+	}
+	return signStepPrevote
+}
+
+// isAfterHRS returns true if the first HRS is strictly after the second one.
+func isAfterHRS(height int64, round int32, step int8, height2 int64, round2 int32, step2 int8) bool {
+	if height != height2 {
+		return height > height2
+	}
+	if round != round2 {
+		return round > round2
+	}
+	return step > step2
+}
+
+// signingAllowed returns false while we observe the network after a start,
+// or for good once a message signed with our key by someone else was found.
+func (cs *State) signingAllowed() bool {
+	return !cs.foreignSignatureFound && cs.Height >= cs.signingStartHeight
+}
+
+// validatorAddress returns the address of the validator with pubKey in vals,
+// which it keeps across the rotations of its key, or the address of pubKey if
+// it isn't a validator of vals.
+func validatorAddress(vals *types.ValidatorSet, pubKey crypto.PubKey) types.Address {
+	if vals != nil {
+		if _, val := vals.GetByPubKey(pubKey); val != nil {
+			return val.Address
+		}
+	}
+	return pubKey.Address()
+}
+
+// CheckLastSignState checks the last sign state of the private validator
+// against the WAL, before the node starts and the catchup replay of the WAL
+// may sign. It fails if the private validator doesn't provide its last sign
+// state while DoubleSignGuardWait is set, as the network can't be checked
+// against it either.
+func (cs *State) CheckLastSignState() error {
+	if cs.privValidator == nil || cs.privValidatorPubKey == nil {
+		return nil
+	}
+	lssProvider, ok := cs.privValidator.(lastSignStateProvider)
+	if !ok {
+		if cs.config.DoubleSignGuardWait > 0 {
+			return ErrNoLastSignState
+		}
+		cs.Logger.Info("private validator does not provide its last sign state; " +
+			"not checking the WAL and the network for messages signed with our key")
+		return nil
+	}
+
+	wal := cs.wal
+	if _, ok := wal.(nilWAL); ok {
+		var err error
+		wal, err = cs.OpenWAL(cs.config.WalFile())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := wal.Stop(); err != nil {
+				cs.Logger.Error("failed to stop WAL", "err", err)
+			}
+		}()
+	}
+	return cs.checkWALDoubleSigningRisk(wal, lssProvider)
+}
+
+// checkWALDoubleSigningRisk looks for our own proposals and votes in the WAL
+// with an HRS after the last sign state of the private validator. They can
+// only be there if the state of the private validator was restored from an
+// old backup, in which case the private validator would sign them again.
+func (cs *State) checkWALDoubleSigningRisk(wal WAL, lssProvider lastSignStateProvider) error {
+	lssHeight, lssRound, lssStep := lssProvider.LastSignedHRS()
+
+	endHeight := lssHeight - 1
+	if endHeight < 0 {
+		endHeight = 0
+	}
+	gr, found, err := wal.SearchForEndHeight(endHeight, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if !found {
+		cs.Logger.Debug("WAL does not contain the last signed height; skipping double signing check",
+			"height", lssHeight)
+		return nil
+	}
+	defer gr.Close()
+
+	valAddr := validatorAddress(cs.Validators, cs.privValidatorPubKey)
+	dec := NewWALDecoder(gr)
+	for {
+		msg, err := dec.Decode()
+		switch {
+		case err == io.EOF:
+			return nil
+		case IsDataCorruptionError(err):
+			// the catchup replay takes care of corrupted WALs
+			return nil
+		case err != nil:
+			return err
+		}
+
+		mi, ok := msg.Msg.(msgInfo)
+		if !ok || mi.PeerID != "" {
+			continue
+		}
+
+		var (
+			height int64
+			round  int32
+			step   int8
+		)
+		switch m := mi.Msg.(type) {
+		case *ProposalMessage:
+			height, round, step = m.Proposal.Height, m.Proposal.Round, signStepPropose
+		case *VoteMessage:
+			if !bytes.Equal(m.Vote.ValidatorAddress, valAddr) {
+				continue
+			}
+			height, round, step = m.Vote.Height, m.Vote.Round, voteSignStep(m.Vote.Type)
+		default:
+			continue
+		}
+
+		if isAfterHRS(height, round, step, lssHeight, lssRound, lssStep) {
+			cs.Logger.Error("found a message signed by us in the WAL after the last sign state",
+				"height", height, "round", round, "step", step,
+				"last_sign_height", lssHeight, "last_sign_round", lssRound, "last_sign_step", lssStep)
+			return ErrSignatureFoundInWAL
+		}
+	}
+}
+
+// detectForeignSignature disables signing for good if a verified proposal or
+// vote signed with our key has an HRS after the last sign state of our private
+// validator: either another node uses our key or our private validator forgot
+// what it signed.
+func (cs *State) detectForeignSignature(pubKey crypto.PubKey, height int64, round int32, step int8) {
+	if cs.foreignSignatureFound || cs.privValidatorPubKey == nil || !pubKey.Equals(cs.privValidatorPubKey) {
+		return
+	}
+	lssProvider, ok := cs.privValidator.(lastSignStateProvider)
+	if !ok {
+		return
+	}
+	lssHeight, lssRound, lssStep := lssProvider.LastSignedHRS()
+	if !isAfterHRS(height, round, step, lssHeight, lssRound, lssStep) {
+		return
+	}
+
+	cs.foreignSignatureFound = true
+	cs.Logger.Error("received a message signed with our key that we did not sign; refusing to sign from now on",
+		"height", height, "round", round, "step", step,
+		"last_sign_height", lssHeight, "last_sign_round", lssRound, "last_sign_step", lssStep,
+		"err", ErrSignatureFoundInNetwork)
+}
+
+// detectForeignVote runs detectForeignSignature on a verified vote.
+func (cs *State) detectForeignVote(vote *types.Vote) {
+	if _, val := cs.Validators.GetByAddress(vote.ValidatorAddress); val != nil {
+		cs.detectForeignSignature(val.PubKey, vote.Height, vote.Round, voteSignStep(vote.Type))
+	}
+}
+
 func (cs *State) calculatePrevoteMessageDelayMetrics() {
 	if cs.Proposal == nil {
 		return
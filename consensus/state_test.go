@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -18,11 +20,14 @@ import (
 	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/abci/types/mocks"
 	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/libs/log"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	p2pmock "github.com/Finschia/ostracon/p2p/mock"
+	"github.com/Finschia/ostracon/privval"
+	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -2099,7 +2104,7 @@ func TestPruneBlocks(t *testing.T) {
 	// Based behaviour is counter.Application
 	mockApp := &mocks.Application{}
 	mockApp.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
-	mockApp.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	mockApp.On("EndBlock", mock.Anything).Return(ocabci.ResponseEndBlock{})
 	mockApp.On("BeginRecheckTx", mock.Anything).Return(ocabci.ResponseBeginRecheckTx{Code: ocabci.CodeTypeOK})
 	mockApp.On("EndRecheckTx", mock.Anything).Return(ocabci.ResponseEndRecheckTx{Code: ocabci.CodeTypeOK})
 	// Mocking behaviour to response `RetainHeight` for pruneBlocks
@@ -2125,3 +2130,209 @@ func TestPruneBlocks(t *testing.T) {
 	// Wait for new round so next validator is set.
 	ensureNewRound(newRoundCh, height+1, 0)
 }
+
+// lastSignStatePV reports a fixed last sign state, like privval.FilePV does.
+type lastSignStatePV struct {
+	types.PrivValidator
+	height int64
+	round  int32
+	step   int8
+}
+
+func (pv lastSignStatePV) LastSignedHRS() (int64, int32, int8) {
+	return pv.height, pv.round, pv.step
+}
+
+func TestStateDoubleSignGuardWait(t *testing.T) {
+	state, privVals := randGenesisState(1, false, 10)
+	thisConfig := ResetConfig("consensus_double_sign_guard_wait_test")
+	defer os.RemoveAll(thisConfig.RootDir)
+	thisConfig.Consensus.DoubleSignGuardWait = 1
+
+	cs1 := newStateWithConfig(thisConfig, state, privVals[0], counter.NewApplication(true))
+	height, round := cs1.Height, cs1.Round
+
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+
+	// the height to observe is counted from the start, not from NewState
+	require.NoError(t, cs1.Start())
+	defer cs1.Stop() //nolint:errcheck // ignore for tests
+	ensureNewRound(newRoundCh, height, round)
+	cs1.mtx.RLock()
+	assert.Equal(t, height+1, cs1.signingStartHeight)
+	cs1.mtx.RUnlock()
+
+	// we are the only validator, but we neither propose nor vote
+	ensureNoNewEventOnChannel(proposalCh)
+	ensureNoNewEventOnChannel(voteCh)
+}
+
+func TestStateDoubleSignGuardWaitForeignSignature(t *testing.T) {
+	cs1, vss := randState(4)
+	height := cs1.Height
+	vss[0].Height = height
+	cs1.signingStartHeight = height + 1
+
+	prevote := signVote(vss[0], tmproto.PrevoteType, nil, types.PartSetHeader{})
+
+	// a vote signed with our key after our last sign state while we observe
+	// the network keeps us from signing after the observation
+	cs1.SetPrivValidator(lastSignStatePV{vss[0].PrivValidator, 0, 0, 0})
+	cs1.handleMsg(msgInfo{&VoteMessage{prevote}, "peer"})
+	cs1.signingStartHeight = height
+	assert.False(t, cs1.signingAllowed())
+}
+
+func TestStateDetectForeignSignature(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+	vss[0].Height = height
+
+	prevote := signVote(vss[0], tmproto.PrevoteType, nil, types.PartSetHeader{})
+
+	// a vote we remember signing
+	cs1.SetPrivValidator(lastSignStatePV{vss[0].PrivValidator, height, round, 2})
+	cs1.handleMsg(msgInfo{&VoteMessage{prevote}, "peer"})
+	assert.True(t, cs1.signingAllowed())
+
+	// a vote with our address but not our signature
+	forged := signVote(vss[1], tmproto.PrecommitType, nil, types.PartSetHeader{})
+	forged.ValidatorAddress = prevote.ValidatorAddress
+	forged.ValidatorIndex = prevote.ValidatorIndex
+	cs1.handleMsg(msgInfo{&VoteMessage{forged}, "peer"})
+	assert.True(t, cs1.signingAllowed())
+
+	// a vote signed with our key after our last sign state
+	precommit := signVote(vss[0], tmproto.PrecommitType, nil, types.PartSetHeader{})
+	cs1.SetPrivValidator(lastSignStatePV{vss[0].PrivValidator, height, round, 2})
+	cs1.handleMsg(msgInfo{&VoteMessage{precommit}, "peer"})
+	assert.False(t, cs1.signingAllowed())
+	assert.Nil(t, cs1.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{}))
+}
+
+// proposalRecorder records the conflicting proposals reported by the consensus state.
+type proposalRecorder struct {
+	sm.EmptyEvidencePool
+	reported [][2]*types.Proposal
+	proposer types.Address
+}
+
+func (pr *proposalRecorder) ReportConflictingProposals(proposalA, proposalB *types.Proposal,
+	proposerAddr types.Address) {
+	pr.reported = append(pr.reported, [2]*types.Proposal{proposalA, proposalB})
+	pr.proposer = proposerAddr
+}
+
+func TestStateDetectDuplicateProposal(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+	evpool := &proposalRecorder{}
+	cs1.evpool = evpool
+
+	proposer := cs1.Validators.SelectProposer(cs1.state.LastProofHash, height, round)
+	var proposerStub, otherStub *validatorStub
+	for _, vs := range vss {
+		pubKey, err := vs.GetPubKey()
+		require.NoError(t, err)
+		if bytes.Equal(pubKey.Address(), proposer.Address) {
+			proposerStub = vs
+		} else {
+			otherStub = vs
+		}
+	}
+	require.NotNil(t, proposerStub)
+
+	signProposal := func(vs *validatorStub) *types.Proposal {
+		blockID := types.BlockID{
+			Hash:          tmrand.Bytes(tmhash.Size),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+		}
+		proposal := types.NewProposal(height, round, -1, blockID)
+		p := proposal.ToProto()
+		require.NoError(t, vs.SignProposal(config.ChainID(), p))
+		proposal.Signature = p.Signature
+		return proposal
+	}
+
+	proposal := signProposal(proposerStub)
+	cs1.handleMsg(msgInfo{&ProposalMessage{proposal}, "peer"})
+	require.Equal(t, proposal, cs1.Proposal)
+
+	// the same proposal again isn't a misbehavior
+	cs1.handleMsg(msgInfo{&ProposalMessage{proposal}, "peer"})
+	assert.Empty(t, evpool.reported)
+
+	// a conflicting proposal not signed by the proposer is ignored
+	cs1.handleMsg(msgInfo{&ProposalMessage{signProposal(otherStub)}, "peer"})
+	assert.Empty(t, evpool.reported)
+
+	// a conflicting proposal signed by the proposer is reported
+	conflicting := signProposal(proposerStub)
+	cs1.handleMsg(msgInfo{&ProposalMessage{conflicting}, "peer"})
+	require.Len(t, evpool.reported, 1)
+	assert.Equal(t, [2]*types.Proposal{proposal, conflicting}, evpool.reported[0])
+	assert.Equal(t, proposer.Address, evpool.proposer)
+	assert.Equal(t, proposal, cs1.Proposal)
+}
+
+func TestStateCheckWALDoubleSigningRisk(t *testing.T) {
+	cs1, vss := randState(1)
+	vss[0].Height = cs1.Height
+
+	wal, err := NewWAL(filepath.Join(t.TempDir(), "wal"))
+	require.NoError(t, err)
+	require.NoError(t, wal.Start())
+	defer func() {
+		require.NoError(t, wal.Stop())
+	}()
+	cs1.wal = wal
+
+	precommit := signVote(vss[0], tmproto.PrecommitType, nil, types.PartSetHeader{})
+	require.NoError(t, wal.WriteSync(msgInfo{&VoteMessage{precommit}, ""}))
+
+	// the state of the private validator was restored from an old backup
+	cs1.SetPrivValidator(lastSignStatePV{vss[0].PrivValidator, 0, 0, 0})
+	assert.ErrorIs(t, cs1.CheckLastSignState(), ErrSignatureFoundInWAL)
+
+	cs1.SetPrivValidator(lastSignStatePV{vss[0].PrivValidator, precommit.Height, precommit.Round, 3})
+	assert.NoError(t, cs1.CheckLastSignState())
+
+	// without the last sign state, nothing can be checked
+	cs1.SetPrivValidator(vss[0].PrivValidator)
+	assert.NoError(t, cs1.CheckLastSignState())
+	cs1.config.DoubleSignGuardWait = 1
+	assert.ErrorIs(t, cs1.CheckLastSignState(), ErrNoLastSignState)
+}
+
+// the private validator switches to its next key once the validator set uses it
+func TestStateRotatePrivValidatorKey(t *testing.T) {
+	cs1, vss := randState(1)
+	mockPV, ok := vss[0].PrivValidator.(types.MockPV)
+	require.True(t, ok)
+
+	dir := t.TempDir()
+	pv := privval.NewFilePV(ed25519.GenPrivKey(), filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+	pv.Save()
+	nextKeyFile := filepath.Join(dir, "next_key.json")
+	pv.SetNextKey(privval.NewFilePV(ed25519.GenPrivKey(), nextKeyFile, "").Key)
+
+	// the next key isn't in the validator set yet
+	cs1.SetPrivValidator(pv)
+	assert.Equal(t, pv.Key.PubKey, cs1.privValidatorPubKey)
+
+	next := privval.NewFilePV(mockPV.PrivKey, nextKeyFile, "")
+	next.Key.Save()
+	pv.SetNextKey(next.Key)
+	cs1.SetPrivValidator(pv)
+	assert.Equal(t, mockPV.PrivKey.PubKey(), cs1.privValidatorPubKey)
+	nextPubKey, err := pv.NextPubKey()
+	require.NoError(t, err)
+	assert.Nil(t, nextPubKey)
+	assert.NoFileExists(t, nextKeyFile)
+
+	// persisted
+	loaded := privval.LoadFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+	assert.Equal(t, mockPV.PrivKey, loaded.Key.PrivKey)
+}
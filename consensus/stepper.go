@@ -0,0 +1,110 @@
+package consensus
+
+import (
+	"time"
+
+	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+)
+
+// Timeout is a timeout requested by the consensus state machine. It is the
+// exported counterpart of the timeoutInfo scheduled on a TimeoutTicker.
+type Timeout struct {
+	Duration time.Duration
+	Height   int64
+	Round    int32
+	Step     cstypes.RoundStepType
+}
+
+// Stepper drives a State synchronously, without starting its receive and
+// timeout routines. Every input is processed to completion before the call
+// returns, together with all the internal messages (our own proposal, block
+// parts and votes) it produced, so the caller fully controls the order in
+// which messages and timeouts are observed. It is meant for deterministic
+// simulations of the consensus protocol.
+//
+// A Stepper is not safe for concurrent use and the State it drives must not
+// be started.
+type Stepper struct {
+	cs *State
+}
+
+// NewStepper returns a Stepper for the given State. Timeouts scheduled by the
+// State are handed to schedule instead of a timer; it is up to the caller to
+// pass them back to Fire once they expire.
+func NewStepper(cs *State, schedule func(Timeout)) *Stepper {
+	cs.SetTimeoutTicker(&stepperTicker{schedule: schedule})
+	return &Stepper{cs: cs}
+}
+
+// State returns the State driven by the stepper.
+func (s *Stepper) State() *State {
+	return s.cs
+}
+
+// Start schedules the first round, as State.OnStart does.
+func (s *Stepper) Start() {
+	s.cs.scheduleRound0(s.cs.GetRoundState())
+}
+
+// Deliver processes a message received from the given peer and returns the
+// messages the State produced in response.
+func (s *Stepper) Deliver(msg Message, peerID p2p.ID) []Message {
+	s.cs.handleMsg(msgInfo{msg, peerID})
+	return s.drain()
+}
+
+// Fire processes an expired timeout and returns the messages the State
+// produced in response.
+func (s *Stepper) Fire(t Timeout) []Message {
+	s.cs.handleTimeout(timeoutInfo{t.Duration, t.Height, t.Round, t.Step}, s.cs.RoundState)
+	return s.drain()
+}
+
+// drain processes the internal message queue until it is empty and returns
+// the processed messages in order.
+func (s *Stepper) drain() []Message {
+	var msgs []Message
+	for {
+		s.drainStats()
+		select {
+		case mi := <-s.cs.internalMsgQueue:
+			s.cs.handleMsg(mi)
+			msgs = append(msgs, mi.Msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+// drainStats discards the statistics normally consumed by the reactor, so that
+// handleMsg never blocks on a full statsMsgQueue.
+func (s *Stepper) drainStats() {
+	for {
+		select {
+		case <-s.cs.statsMsgQueue:
+		default:
+			return
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// stepperTicker is a TimeoutTicker that reports scheduled timeouts to a
+// callback and never fires on its own.
+type stepperTicker struct {
+	schedule func(Timeout)
+}
+
+var _ TimeoutTicker = (*stepperTicker)(nil)
+
+func (t *stepperTicker) Start() error             { return nil }
+func (t *stepperTicker) Stop() error              { return nil }
+func (t *stepperTicker) Chan() <-chan timeoutInfo { return nil }
+func (t *stepperTicker) SetLogger(log.Logger)     {}
+
+func (t *stepperTicker) ScheduleTimeout(ti timeoutInfo) {
+	t.schedule(Timeout{ti.Duration, ti.Height, ti.Round, ti.Step})
+}
@@ -10,20 +10,32 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/libs/clist"
 	"github.com/Finschia/ostracon/libs/log"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
+	"github.com/Finschia/ostracon/version"
 )
 
 const (
 	baseKeyCommitted = byte(0x00)
 	baseKeyPending   = byte(0x01)
+	// height of the evidence by hash, to find its committed or pending key
+	baseKeyHeight = byte(0x02)
+	// height of the block committing the evidence by hash
+	baseKeyCommittedHeight = byte(0x03)
 )
 
+// set once the evidence stored before baseKeyHeight was indexed
+var keyHeightIndexed = []byte{0x04}
+
+// ErrCommittedHeightUnknown is returned for the evidence committed before the
+// height of its block was recorded.
+var ErrCommittedHeightUnknown = errors.New("the height of the block committing the evidence is unknown")
+
 // Pool maintains a pool of valid evidence to be broadcasted and committed
 type Pool struct {
 	logger log.Logger
@@ -44,6 +56,8 @@ type Pool struct {
 	// before being flushed to the pool. This prevents broadcasting and proposing of
 	// evidence before the height with which the evidence happened is finished.
 	consensusBuffer []duplicateVoteSet
+	// the same for the conflicting proposals
+	proposalBuffer []duplicateProposalSet
 
 	pruningHeight int64
 	pruningTime   time.Time
@@ -66,6 +80,11 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		evidenceStore:   evidenceDB,
 		evidenceList:    clist.New(),
 		consensusBuffer: make([]duplicateVoteSet, 0),
+		proposalBuffer:  make([]duplicateProposalSet, 0),
+	}
+
+	if err := pool.indexHeights(); err != nil {
+		return nil, err
 	}
 
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
@@ -121,7 +140,7 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	evpool.updateState(state)
 
 	// move committed evidence out from the pending pool and into the committed pool
-	evpool.markEvidenceAsCommitted(ev)
+	evpool.markEvidenceAsCommitted(ev, state.LastBlockHeight)
 
 	// prune pending evidence when it has expired. This also updates when the next evidence will expire
 	if evpool.Size() > 0 && state.LastBlockHeight > evpool.pruningHeight &&
@@ -185,6 +204,21 @@ func (evpool *Pool) ReportConflictingVotes(voteA, voteB *types.Vote) {
 	})
 }
 
+// ReportConflictingProposals takes two conflicting proposals of the proposer of proposerAddr
+// and forms duplicate proposal evidence, adding it eventually to the evidence pool in the
+// same way as ReportConflictingVotes.
+//
+// Proposals are not verified.
+func (evpool *Pool) ReportConflictingProposals(proposalA, proposalB *types.Proposal, proposerAddr types.Address) {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	evpool.proposalBuffer = append(evpool.proposalBuffer, duplicateProposalSet{
+		ProposalA:    proposalA,
+		ProposalB:    proposalB,
+		ProposerAddr: proposerAddr,
+	})
+}
+
 // CheckEvidence takes an array of evidence from a block and verifies all the evidence there.
 // If it has already verified the evidence then it jumps to the next one. It ensures that no
 // evidence has already been committed or is being proposed twice. It also adds any
@@ -260,6 +294,53 @@ func (evpool *Pool) Close() error {
 	return evpool.evidenceStore.Close()
 }
 
+// PendingEvidenceByHash returns the pending evidence of hash, or nil if there is none.
+func (evpool *Pool) PendingEvidenceByHash(hash []byte) (types.Evidence, error) {
+	height, err := evpool.evidenceHeight(hash)
+	if err != nil || height == 0 {
+		return nil, err
+	}
+	evBytes, err := evpool.evidenceStore.Get(append([]byte{baseKeyPending}, keySuffixOf(height, hash)...))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	if evBytes == nil {
+		return nil, nil
+	}
+	return bytesToEv(evBytes)
+}
+
+// CommittedEvidenceHeight returns the height of the block committing the evidence of hash,
+// or 0 if it isn't committed. It returns ErrCommittedHeightUnknown for the evidence committed
+// before this height was recorded.
+func (evpool *Pool) CommittedEvidenceHeight(hash []byte) (int64, error) {
+	height, err := evpool.evidenceHeight(hash)
+	if err != nil || height == 0 {
+		return 0, err
+	}
+	ok, err := evpool.evidenceStore.Has(append([]byte{baseKeyCommitted}, keySuffixOf(height, hash)...))
+	if err != nil {
+		return 0, fmt.Errorf("database error: %v", err)
+	}
+	if !ok {
+		return 0, nil
+	}
+	committedHeight, err := evpool.getInt64(keyCommittedHeight(hash))
+	if err != nil {
+		return 0, err
+	}
+	if committedHeight == 0 {
+		return 0, ErrCommittedHeightUnknown
+	}
+	return committedHeight, nil
+}
+
+// IsExpired checks whether the evidence is older than allowed by the evidence consensus
+// parameters, in which case it can no longer be committed.
+func (evpool *Pool) IsExpired(ev types.Evidence) bool {
+	return evpool.isExpired(ev.Height(), ev.Time())
+}
+
 // IsExpired checks whether evidence or a polc is expired by checking whether a height and time is older
 // than set by the evidence consensus parameters
 func (evpool *Pool) isExpired(height int64, time time.Time) bool {
@@ -309,6 +390,9 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 	if err != nil {
 		return fmt.Errorf("can't persist evidence: %w", err)
 	}
+	if err := evpool.setInt64(keyHeight(ev.Hash()), ev.Height()); err != nil {
+		return fmt.Errorf("can't index evidence: %w", err)
+	}
 	atomic.AddUint32(&evpool.evidenceSize, 1)
 	return nil
 }
@@ -323,9 +407,9 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	}
 }
 
-// markEvidenceAsCommitted processes all the evidence in the block, marking it as
+// markEvidenceAsCommitted processes all the evidence in the block of height, marking it as
 // committed and removing it from the pending database.
-func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
+func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height int64) {
 	blockEvidenceMap := make(map[string]struct{}, len(evidence))
 	for _, ev := range evidence {
 		if evpool.isPending(ev) {
@@ -347,6 +431,12 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 		if err := evpool.evidenceStore.Set(key, evBytes); err != nil {
 			evpool.logger.Error("Unable to save committed evidence", "err", err, "key(height/hash)", key)
 		}
+		if err := evpool.setInt64(keyHeight(ev.Hash()), ev.Height()); err != nil {
+			evpool.logger.Error("Unable to index committed evidence", "err", err, "key(height/hash)", key)
+		}
+		if err := evpool.setInt64(keyCommittedHeight(ev.Hash()), height); err != nil {
+			evpool.logger.Error("Unable to save the height of committed evidence", "err", err, "key(height/hash)", key)
+		}
 	}
 
 	// remove committed evidence from the clist
@@ -355,6 +445,72 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 	}
 }
 
+// evidenceHeight returns the height of the evidence of hash, or 0 if it isn't known.
+func (evpool *Pool) evidenceHeight(hash []byte) (int64, error) {
+	return evpool.getInt64(keyHeight(hash))
+}
+
+// indexHeights indexes the height of the evidence stored before it was indexed,
+// once. The height of the block committing this evidence remains unknown.
+func (evpool *Pool) indexHeights() error {
+	ok, err := evpool.evidenceStore.Has(keyHeightIndexed)
+	if err != nil {
+		return fmt.Errorf("database error: %v", err)
+	}
+	if ok {
+		return nil
+	}
+	for _, prefixKey := range []byte{baseKeyCommitted, baseKeyPending} {
+		iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{prefixKey})
+		if err != nil {
+			return fmt.Errorf("database error: %v", err)
+		}
+		for ; iter.Valid(); iter.Next() {
+			var (
+				height int64
+				hash   []byte
+			)
+			if _, err := fmt.Sscanf(string(iter.Key()[1:]), "%016X/%X", &height, &hash); err != nil {
+				iter.Close()
+				return fmt.Errorf("invalid evidence key %X: %w", iter.Key(), err)
+			}
+			if err := evpool.setInt64(keyHeight(hash), height); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		if err := iter.Error(); err != nil {
+			iter.Close()
+			return err
+		}
+		iter.Close()
+	}
+	return evpool.evidenceStore.Set(keyHeightIndexed, []byte{})
+}
+
+func (evpool *Pool) getInt64(key []byte) (int64, error) {
+	bz, err := evpool.evidenceStore.Get(key)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %v", err)
+	}
+	if bz == nil {
+		return 0, nil
+	}
+	var v gogotypes.Int64Value
+	if err := proto.Unmarshal(bz, &v); err != nil {
+		return 0, err
+	}
+	return v.Value, nil
+}
+
+func (evpool *Pool) setInt64(key []byte, value int64) error {
+	bz, err := proto.Marshal(&gogotypes.Int64Value{Value: value})
+	if err != nil {
+		return err
+	}
+	return evpool.evidenceStore.Set(key, bz)
+}
+
 // listEvidence retrieves lists evidence from oldest to newest within maxBytes.
 // If maxBytes is -1, there's no cap on the size of returned evidence.
 func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Evidence, int64, error) {
@@ -362,7 +518,7 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 		evSize    int64
 		totalSize int64
 		evidence  []types.Evidence
-		evList    tmproto.EvidenceList // used for calculating the bytes size
+		evList    ocproto.EvidenceList // used for calculating the bytes size
 	)
 
 	iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{prefixKey})
@@ -371,7 +527,7 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 	}
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		var evpb tmproto.Evidence
+		var evpb ocproto.Evidence
 		err := evpb.Unmarshal(iter.Value())
 		if err != nil {
 			return evidence, totalSize, err
@@ -452,9 +608,9 @@ func (evpool *Pool) updateState(state sm.State) {
 	evpool.state = state
 }
 
-// processConsensusBuffer converts all the duplicate votes witnessed from consensus
-// into DuplicateVoteEvidence. It sets the evidence timestamp to the block height
-// from the most recently committed block.
+// processConsensusBuffer converts all the duplicate votes and proposals witnessed from
+// consensus into DuplicateVoteEvidence and DuplicateProposalEvidence. It sets the evidence
+// timestamp to the block height from the most recently committed block.
 // Evidence is then added to the pool so as to be ready to be broadcasted and proposed.
 func (evpool *Pool) processConsensusBuffer(state sm.State) {
 	evpool.mtx.Lock()
@@ -463,69 +619,106 @@ func (evpool *Pool) processConsensusBuffer(state sm.State) {
 
 		// Check the height of the conflicting votes and fetch the corresponding time and validator set
 		// to produce the valid evidence
-		var dve *types.DuplicateVoteEvidence
-		switch {
-		case voteSet.VoteA.Height == state.LastBlockHeight:
-			dve = types.NewDuplicateVoteEvidence(
-				voteSet.VoteA,
-				voteSet.VoteB,
-				state.LastBlockTime,
-				state.LastValidators,
-			)
-
-		case voteSet.VoteA.Height < state.LastBlockHeight:
-			valSet, err := evpool.stateDB.LoadValidators(voteSet.VoteA.Height)
-			if err != nil {
-				evpool.logger.Error("failed to load validator set for conflicting votes", "height",
-					voteSet.VoteA.Height, "err", err,
-				)
-				continue
-			}
-			blockMeta := evpool.blockStore.LoadBlockMeta(voteSet.VoteA.Height)
-			if blockMeta == nil {
-				evpool.logger.Error("failed to load block time for conflicting votes", "height", voteSet.VoteA.Height)
-				continue
-			}
-			dve = types.NewDuplicateVoteEvidence(
-				voteSet.VoteA,
-				voteSet.VoteB,
-				blockMeta.Header.Time,
-				valSet,
-			)
-
-		default:
-			// evidence pool shouldn't expect to get votes from consensus of a height that is above the current
-			// state. If this error is seen then perhaps consider keeping the votes in the buffer and retry
-			// in following heights
-			evpool.logger.Error("inbound duplicate votes from consensus are of a greater height than current state",
-				"duplicate vote height", voteSet.VoteA.Height,
-				"state.LastBlockHeight", state.LastBlockHeight)
+		blockTime, valSet, ok := evpool.consensusEvidenceContext(state, voteSet.VoteA.Height, "votes")
+		if !ok {
 			continue
 		}
-
-		// check if we already have this evidence
-		if evpool.isPending(dve) {
-			evpool.logger.Debug("evidence already pending; ignoring", "evidence", dve)
+		dve := types.NewDuplicateVoteEvidence(
+			voteSet.VoteA,
+			voteSet.VoteB,
+			blockTime,
+			valSet,
+		)
+		evpool.addConsensusEvidence(dve)
+	}
+	for _, proposalSet := range evpool.proposalBuffer {
+		// the blocks of the older protocols can't include the evidence
+		if state.Version.Consensus.Block < version.BlockProtocolDuplicateProposalEvidence {
+			evpool.logger.Info("ignoring conflicting proposals not supported by the block protocol",
+				"height", proposalSet.ProposalA.Height, "proposer", proposalSet.ProposerAddr,
+				"block_protocol", state.Version.Consensus.Block)
 			continue
 		}
-
-		// check that the evidence is not already committed on chain
-		if evpool.isCommitted(dve) {
-			evpool.logger.Debug("evidence already committed; ignoring", "evidence", dve)
+		blockTime, valSet, ok := evpool.consensusEvidenceContext(state, proposalSet.ProposalA.Height, "proposals")
+		if !ok {
 			continue
 		}
-
-		if err := evpool.addPendingEvidence(dve); err != nil {
-			evpool.logger.Error("failed to flush evidence from consensus buffer to pending list: %w", err)
+		dpe := types.NewDuplicateProposalEvidence(
+			proposalSet.ProposalA,
+			proposalSet.ProposalB,
+			proposalSet.ProposerAddr,
+			blockTime,
+			valSet,
+		)
+		if dpe == nil {
+			evpool.logger.Error("proposer of conflicting proposals is not a validator",
+				"height", proposalSet.ProposalA.Height, "proposer", proposalSet.ProposerAddr)
 			continue
 		}
-
-		evpool.evidenceList.PushBack(dve)
-
-		evpool.logger.Info("verified new evidence of byzantine behavior", "evidence", dve)
+		evpool.addConsensusEvidence(dpe)
 	}
 	// reset consensus buffer
 	evpool.consensusBuffer = make([]duplicateVoteSet, 0)
+	evpool.proposalBuffer = make([]duplicateProposalSet, 0)
+}
+
+// consensusEvidenceContext returns the block time and the validator set of the height of
+// the conflicting messages of kind reported by consensus.
+func (evpool *Pool) consensusEvidenceContext(state sm.State, height int64,
+	kind string) (time.Time, *types.ValidatorSet, bool) {
+	switch {
+	case height == state.LastBlockHeight:
+		return state.LastBlockTime, state.LastValidators, true
+
+	case height < state.LastBlockHeight:
+		valSet, err := evpool.stateDB.LoadValidators(height)
+		if err != nil {
+			evpool.logger.Error("failed to load validator set for conflicting "+kind, "height",
+				height, "err", err,
+			)
+			return time.Time{}, nil, false
+		}
+		blockMeta := evpool.blockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			evpool.logger.Error("failed to load block time for conflicting "+kind, "height", height)
+			return time.Time{}, nil, false
+		}
+		return blockMeta.Header.Time, valSet, true
+
+	default:
+		// evidence pool shouldn't expect to get votes from consensus of a height that is above the current
+		// state. If this error is seen then perhaps consider keeping the votes in the buffer and retry
+		// in following heights
+		evpool.logger.Error("inbound duplicate "+kind+" from consensus are of a greater height than current state",
+			"duplicate "+kind+" height", height,
+			"state.LastBlockHeight", state.LastBlockHeight)
+		return time.Time{}, nil, false
+	}
+}
+
+// addConsensusEvidence adds the evidence formed from consensus to the pending evidence,
+// unless it's already pending or committed.
+func (evpool *Pool) addConsensusEvidence(ev types.Evidence) {
+	// check if we already have this evidence
+	if evpool.isPending(ev) {
+		evpool.logger.Debug("evidence already pending; ignoring", "evidence", ev)
+		return
+	}
+
+	// check that the evidence is not already committed on chain
+	if evpool.isCommitted(ev) {
+		evpool.logger.Debug("evidence already committed; ignoring", "evidence", ev)
+		return
+	}
+
+	if err := evpool.addPendingEvidence(ev); err != nil {
+		evpool.logger.Error("failed to flush evidence from consensus buffer to pending list: %w", err)
+		return
+	}
+
+	evpool.evidenceList.PushBack(ev)
+
+	evpool.logger.Info("verified new evidence of byzantine behavior", "evidence", ev)
 }
 
 type duplicateVoteSet struct {
@@ -533,8 +726,14 @@ type duplicateVoteSet struct {
 	VoteB *types.Vote
 }
 
+type duplicateProposalSet struct {
+	ProposalA    *types.Proposal
+	ProposalB    *types.Proposal
+	ProposerAddr types.Address
+}
+
 func bytesToEv(evBytes []byte) (types.Evidence, error) {
-	var evpb tmproto.Evidence
+	var evpb ocproto.Evidence
 	err := evpb.Unmarshal(evBytes)
 	if err != nil {
 		return &types.DuplicateVoteEvidence{}, err
@@ -561,5 +760,17 @@ func keyPending(evidence types.Evidence) []byte {
 }
 
 func keySuffix(evidence types.Evidence) []byte {
-	return []byte(fmt.Sprintf("%s/%X", bE(evidence.Height()), evidence.Hash()))
+	return keySuffixOf(evidence.Height(), evidence.Hash())
+}
+
+func keySuffixOf(height int64, hash []byte) []byte {
+	return []byte(fmt.Sprintf("%s/%X", bE(height), hash))
+}
+
+func keyHeight(hash []byte) []byte {
+	return append([]byte{baseKeyHeight}, hash...)
+}
+
+func keyCommittedHeight(hash []byte) []byte {
+	return append([]byte{baseKeyCommittedHeight}, hash...)
 }
@@ -83,6 +83,13 @@ func TestEvidencePoolBasic(t *testing.T) {
 	next := pool.EvidenceFront()
 	assert.Equal(t, ev, next.Value.(types.Evidence))
 
+	pending, err := pool.PendingEvidenceByHash(ev.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, ev, pending)
+	pending, err = pool.PendingEvidenceByHash([]byte("unknown"))
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+
 	const evidenceBytes int64 = 372
 	evs, size = pool.PendingEvidence(evidenceBytes)
 	assert.Equal(t, 1, len(evs))
@@ -180,6 +187,38 @@ func TestReportConflictingVotes(t *testing.T) {
 	require.NotNil(t, next)
 }
 
+func TestReportConflictingProposals(t *testing.T) {
+	var height int64 = 10
+
+	pool, pv := defaultTestPool(height)
+	val := types.NewValidator(pv.PrivKey.PubKey(), 10)
+	ev := types.NewMockDuplicateProposalEvidenceWithValidator(height+1, defaultEvidenceTime, pv, evidenceChainID)
+
+	pool.ReportConflictingProposals(ev.ProposalA, ev.ProposalB, ev.ValidatorAddress)
+
+	// shouldn't be able to submit the same evidence twice
+	pool.ReportConflictingProposals(ev.ProposalB, ev.ProposalA, ev.ValidatorAddress)
+
+	// evidence from consensus should not be added immediately but reside in the consensus buffer
+	evList, evSize := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	require.Empty(t, evList)
+	require.Zero(t, evSize)
+
+	// move to next height and update state and evidence pool
+	state := pool.State()
+	state.LastBlockHeight++
+	state.LastBlockTime = ev.Time()
+	state.LastValidators = types.NewValidatorSet([]*types.Validator{val})
+	pool.Update(state, []types.Evidence{})
+
+	// should be able to retrieve evidence from pool
+	evList, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
+	require.Equal(t, []types.Evidence{ev}, evList)
+
+	next := pool.EvidenceFront()
+	require.NotNil(t, next)
+}
+
 func TestEvidencePoolUpdate(t *testing.T) {
 	height := int64(21)
 	pool, val := defaultTestPool(height)
@@ -211,6 +250,16 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	if assert.Error(t, err) {
 		assert.Equal(t, "evidence was already committed", err.(*types.ErrInvalidEvidence).Reason.Error())
 	}
+
+	// c) The committed evidence is recorded with the height of the committing block
+	committedHeight, err := pool.CommittedEvidenceHeight(ev.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, state.LastBlockHeight, committedHeight)
+	committedHeight, err = pool.CommittedEvidenceHeight(prunedEv.Hash())
+	require.NoError(t, err)
+	assert.Zero(t, committedHeight)
+	assert.False(t, pool.IsExpired(ev))
+	assert.True(t, pool.IsExpired(prunedEv))
 }
 
 func TestVerifyPendingEvidencePasses(t *testing.T) {
@@ -346,12 +395,58 @@ func TestRecoverPendingEvidence(t *testing.T) {
 
 }
 
+func TestIndexLegacyEvidence(t *testing.T) {
+	height := int64(10)
+	val := types.NewMockPV()
+	evidenceDB := dbm.NewMemDB()
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(dbm.NewMemDB(), state, val.PrivKey)
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+	pendingEv := types.NewMockDuplicateVoteEvidenceWithValidator(height,
+		defaultEvidenceTime.Add(10*time.Minute), val, evidenceChainID)
+	committedEv := types.NewMockDuplicateVoteEvidenceWithValidator(height-1,
+		defaultEvidenceTime.Add(9*time.Minute), val, evidenceChainID)
+	require.NoError(t, pool.AddEvidence(pendingEv))
+	require.NoError(t, pool.AddEvidence(committedEv))
+	state.LastBlockHeight++
+	pool.Update(state, types.EvidenceList{committedEv})
+
+	// drop the index, as in the db of the pool before it was indexed
+	for _, key := range [][]byte{
+		append([]byte{0x02}, pendingEv.Hash()...),
+		append([]byte{0x02}, committedEv.Hash()...),
+		append([]byte{0x03}, committedEv.Hash()...),
+		{0x04},
+	} {
+		require.NoError(t, evidenceDB.Delete(key))
+	}
+
+	newPool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	ev, err := newPool.PendingEvidenceByHash(pendingEv.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pendingEv, ev)
+	ev, err = newPool.PendingEvidenceByHash(committedEv.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	_, err = newPool.CommittedEvidenceHeight(committedEv.Hash())
+	assert.ErrorIs(t, err, evidence.ErrCommittedHeightUnknown)
+	committedHeight, err := newPool.CommittedEvidenceHeight(pendingEv.Hash())
+	require.NoError(t, err)
+	assert.Zero(t, committedHeight)
+}
+
 func initializeStateFromValidatorSet(valSet *types.ValidatorSet, height int64) sm.Store {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
 		DiscardABCIResponses: false,
 	})
 	state := sm.State{
+		Version:                     sm.InitStateVersion,
 		ChainID:                     evidenceChainID,
 		InitialHeight:               1,
 		LastBlockHeight:             height,
@@ -5,11 +5,11 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	clist "github.com/Finschia/ostracon/libs/clist"
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -57,7 +57,7 @@ func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			ID:                  EvidenceChannel,
 			Priority:            6,
 			RecvMessageCapacity: maxMsgSize,
-			MessageType:         &tmproto.EvidenceList{},
+			MessageType:         &ocproto.EvidenceList{},
 		},
 	}
 }
@@ -94,7 +94,7 @@ func (evR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 }
 
 func (evR *Reactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
-	msg := &tmproto.EvidenceList{}
+	msg := &ocproto.EvidenceList{}
 	err := proto.Unmarshal(msgBytes, msg)
 	if err != nil {
 		panic(err)
@@ -230,8 +230,8 @@ type PeerState interface {
 
 // encodemsg takes a array of evidence
 // returns the byte encoding of the List Message
-func evidenceListToProto(evis []types.Evidence) (*tmproto.EvidenceList, error) {
-	evi := make([]tmproto.Evidence, len(evis))
+func evidenceListToProto(evis []types.Evidence) (*ocproto.EvidenceList, error) {
+	evi := make([]ocproto.Evidence, len(evis))
 	for i := 0; i < len(evis); i++ {
 		ev, err := types.EvidenceToProto(evis[i])
 		if err != nil {
@@ -239,14 +239,14 @@ func evidenceListToProto(evis []types.Evidence) (*tmproto.EvidenceList, error) {
 		}
 		evi[i] = *ev
 	}
-	epl := tmproto.EvidenceList{
+	epl := ocproto.EvidenceList{
 		Evidence: evi,
 	}
 	return &epl, nil
 }
 
 func evidenceListFromProto(m proto.Message) ([]types.Evidence, error) {
-	lm := m.(*tmproto.EvidenceList)
+	lm := m.(*ocproto.EvidenceList)
 
 	evis := make([]types.Evidence, len(lm.Evidence))
 	for i := 0; i < len(lm.Evidence); i++ {
@@ -25,6 +25,7 @@ import (
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
 	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 )
@@ -376,6 +377,29 @@ func exampleVote(t byte) *types.Vote {
 		ValidatorIndex:   56789,
 	}
 }
+func exampleProposal(blockHash string) *types.Proposal {
+	var stamp, err = time.Parse(types.TimeFormat, "2017-12-25T03:00:01.234Z")
+	if err != nil {
+		panic(err)
+	}
+
+	return &types.Proposal{
+		Type:      tmproto.ProposalType,
+		Height:    3,
+		Round:     2,
+		POLRound:  -1,
+		Timestamp: stamp,
+		BlockID: types.BlockID{
+			Hash: tmhash.Sum([]byte(blockHash)),
+			PartSetHeader: types.PartSetHeader{
+				Total: 1000000,
+				Hash:  tmhash.Sum([]byte("blockID_part_set_header_hash")),
+			},
+		},
+		Signature: []byte("signature"),
+	}
+}
+
 func TestLegacyReactorReceiveBasic(t *testing.T) {
 	config := cfg.TestConfig()
 	N := 1
@@ -395,7 +419,7 @@ func TestLegacyReactorReceiveBasic(t *testing.T) {
 
 	reactor.InitPeer(peer)
 	reactor.AddPeer(peer)
-	e := &tmproto.EvidenceList{}
+	e := &ocproto.EvidenceList{}
 	msg, err := proto.Marshal(e)
 	assert.NoError(t, err)
 
@@ -421,25 +445,34 @@ func TestEvidenceVectors(t *testing.T) {
 		valSet,
 	)
 
+	duplp := types.NewDuplicateProposalEvidence(
+		exampleProposal("blockID_hash"),
+		exampleProposal("blockID_hash2"),
+		val.Address,
+		defaultEvidenceTime,
+		valSet,
+	)
+
 	testCases := []struct {
 		testName     string
 		evidenceList []types.Evidence
 		expBytes     string
 	}{
 		{"DuplicateVoteEvidence", []types.Evidence{dupl}, "0a85020a82020a79080210031802224a0a208b01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a2a0b08b1d381d20510809dca6f32146af1f4111082efb388211bc72c55bcd61e9ac3d538d5bb031279080110031802224a0a208b01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a2a0b08b1d381d20510809dca6f32146af1f4111082efb388211bc72c55bcd61e9ac3d538d5bb03180a200a2a060880dbaae105"},
+		{"DuplicateProposalEvidence", []types.Evidence{duplp}, "0a9402c23e90020a7508201003180220ffffffffffffffffff012a4a0a2068814d91eb60b6bdbddac7728b8fff9a6ca66107a717a1ebfc4d26d98d77c769122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a320b08b1d381d20510809dca6f3a097369676e6174757265127508201003180220ffffffffffffffffff012a4a0a208b01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a320b08b1d381d20510809dca6f3a097369676e6174757265180a200a2a060880dbaae10532146af1f4111082efb388211bc72c55bcd61e9ac3d5"},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 
-		evi := make([]tmproto.Evidence, len(tc.evidenceList))
+		evi := make([]ocproto.Evidence, len(tc.evidenceList))
 		for i := 0; i < len(tc.evidenceList); i++ {
 			ev, err := types.EvidenceToProto(tc.evidenceList[i])
 			require.NoError(t, err, tc.testName)
 			evi[i] = *ev
 		}
 
-		epl := tmproto.EvidenceList{
+		epl := ocproto.EvidenceList{
 			Evidence: evi,
 		}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Finschia/ostracon/light"
 	"github.com/Finschia/ostracon/types"
+	"github.com/Finschia/ostracon/version"
 )
 
 // verify verifies the evidence fully by checking:
@@ -56,6 +57,17 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 		return VerifyDuplicateVote(ev, state.ChainID, valSet)
 
+	case *types.DuplicateProposalEvidence:
+		if state.Version.Consensus.Block < version.BlockProtocolDuplicateProposalEvidence {
+			return fmt.Errorf("duplicate proposal evidence is not supported by block protocol %d",
+				state.Version.Consensus.Block)
+		}
+		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		return VerifyDuplicateProposal(ev, state.ChainID, valSet)
+
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
 		if err != nil {
@@ -191,12 +203,9 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 		)
 	}
 
-	// pubkey must match address (this should already be true, sanity check)
-	addr := e.VoteA.ValidatorAddress
-	if !bytes.Equal(pubKey.Address(), addr) {
-		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
-			addr, pubKey, pubKey.Address())
-	}
+	// NOTE: the pubkey doesn't have to match the address, which the validator
+	// keeps across the rotations of its key. pubKey is the key of the validator
+	// at the height of the evidence.
 
 	// validator voting power and total voting power must match
 	if val.VotingPower != e.ValidatorPower {
@@ -221,6 +230,57 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	return nil
 }
 
+// VerifyDuplicateProposal verifies DuplicateProposalEvidence against the state of full node. This
+// involves the following checks:
+//   - the proposer is in the validator set at the height of the evidence
+//   - the height and round of the proposals must be the same
+//   - the block ID's must be different
+//   - The signatures must both be valid
+func VerifyDuplicateProposal(e *types.DuplicateProposalEvidence, chainID string, valSet *types.ValidatorSet) error {
+	_, val := valSet.GetByAddress(e.ValidatorAddress)
+	if val == nil {
+		return fmt.Errorf("address %X was not a validator at height %d", e.ValidatorAddress, e.Height())
+	}
+	pubKey := val.PubKey
+
+	// H/R must be the same
+	if e.ProposalA.Height != e.ProposalB.Height || e.ProposalA.Round != e.ProposalB.Round {
+		return fmt.Errorf("h/r does not match: %d/%d vs %d/%d",
+			e.ProposalA.Height, e.ProposalA.Round,
+			e.ProposalB.Height, e.ProposalB.Round)
+	}
+
+	// BlockIDs must be different
+	if e.ProposalA.BlockID.Equals(e.ProposalB.BlockID) {
+		return fmt.Errorf(
+			"block IDs are the same (%v) - not a real duplicate proposal",
+			e.ProposalA.BlockID,
+		)
+	}
+
+	// validator voting power and total voting power must match
+	if val.VotingPower != e.ValidatorPower {
+		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
+			e.ValidatorPower, val.VotingPower)
+	}
+	if valSet.TotalVotingPower() != e.TotalVotingPower {
+		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+			e.TotalVotingPower, valSet.TotalVotingPower())
+	}
+
+	pa := e.ProposalA.ToProto()
+	pb := e.ProposalB.ToProto()
+	// Signatures must be valid
+	if !pubKey.VerifySignature(types.ProposalSignBytes(chainID, pa), e.ProposalA.Signature) {
+		return fmt.Errorf("verifying ProposalA: %w", types.ErrVoteInvalidSignature)
+	}
+	if !pubKey.VerifySignature(types.ProposalSignBytes(chainID, pb), e.ProposalB.Signature) {
+		return fmt.Errorf("verifying ProposalB: %w", types.ErrVoteInvalidSignature)
+	}
+
+	return nil
+}
+
 // validateABCIEvidence validates the ABCI component of the light client attack
 // evidence i.e voting power and byzantine validators
 func validateABCIEvidence(
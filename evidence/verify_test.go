@@ -508,6 +508,169 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyDuplicateVoteEvidenceKeyRotation(t *testing.T) {
+	const chainID = "mychain"
+	val := types.NewMockPV()
+	oldPubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	newVal := types.NewMockPV()
+	newPubKey, err := newVal.GetPubKey()
+	require.NoError(t, err)
+
+	// the key of the validator is rotated from height 11
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1)})
+	rotatedValSet := valSet.Copy()
+	require.NoError(t, rotatedValSet.RotateKeys([]types.ValidatorKeyRotation{
+		{OldPubKey: oldPubKey, NewPubKey: newPubKey},
+	}))
+	state := sm.State{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Minute),
+		LastBlockHeight: 12,
+		Validators:      rotatedValSet,
+		NextValidators:  rotatedValSet,
+		LastValidators:  rotatedValSet,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	stateStore.On("LoadValidators", int64(11)).Return(rotatedValSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
+	blockStore.On("LoadBlockMeta", int64(11)).Return(
+		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime.Add(1 * time.Minute)}})
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	// the evidence against the old key before the rotation is verified against the
+	// validator set of its height
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(10, defaultEvidenceTime, val, chainID)
+	ev.ValidatorPower = 1
+	ev.TotalVotingPower = 1
+	assert.NoError(t, pool.CheckEvidence(types.EvidenceList{ev}))
+	assert.Equal(t, oldPubKey.Address(), types.Address(ev.ABCI()[0].Validator.Address))
+
+	// the old key can't sign for the validator after the rotation
+	ev = types.NewMockDuplicateVoteEvidenceWithValidator(11, defaultEvidenceTime.Add(1*time.Minute), val, chainID)
+	ev.ValidatorPower = 1
+	ev.TotalVotingPower = 1
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{ev}))
+
+	// the evidence against the new key reports the validator with the address it kept
+	ev = types.NewMockDuplicateVoteEvidenceWithValidator(11, defaultEvidenceTime.Add(1*time.Minute), newVal, chainID)
+	ev.VoteA.ValidatorAddress = oldPubKey.Address()
+	ev.VoteB.ValidatorAddress = oldPubKey.Address()
+	ev.ValidatorPower = 1
+	ev.TotalVotingPower = 1
+	assert.NoError(t, pool.CheckEvidence(types.EvidenceList{ev}))
+	assert.Equal(t, oldPubKey.Address(), types.Address(ev.ABCI()[0].Validator.Address))
+}
+
+func TestVerifyDuplicateProposalEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1)})
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+
+	const chainID = "mychain"
+
+	proposal1 := makeProposal(t, val, chainID, 10, 2, blockID, defaultEvidenceTime)
+	cases := []struct {
+		proposal2 *types.Proposal
+		valid     bool
+	}{
+		{makeProposal(t, val, chainID, 10, 2, blockID2, defaultEvidenceTime), true},
+		{makeProposal(t, val, chainID, 10, 2, blockID, defaultEvidenceTime), false},     // same block id
+		{makeProposal(t, val, "mychain2", 10, 2, blockID2, defaultEvidenceTime), false}, // wrong chain id
+		{makeProposal(t, val, chainID, 11, 2, blockID2, defaultEvidenceTime), false},    // wrong height
+		{makeProposal(t, val, chainID, 10, 3, blockID2, defaultEvidenceTime), false},    // wrong round
+		{makeProposal(t, val2, chainID, 10, 2, blockID2, defaultEvidenceTime), false},   // signed by wrong key
+	}
+	for _, c := range cases {
+		ev := &types.DuplicateProposalEvidence{
+			ProposalA:        proposal1,
+			ProposalB:        c.proposal2,
+			ValidatorAddress: pubKey.Address(),
+			ValidatorPower:   1,
+			TotalVotingPower: 1,
+			Timestamp:        defaultEvidenceTime,
+		}
+		if c.valid {
+			assert.Nil(t, evidence.VerifyDuplicateProposal(ev, chainID, valSet), "evidence should be valid")
+		} else {
+			assert.NotNil(t, evidence.VerifyDuplicateProposal(ev, chainID, valSet), "evidence should be invalid")
+		}
+	}
+
+	// the proposer must be a validator
+	pubKey2, err := val2.GetPubKey()
+	require.NoError(t, err)
+	ev := &types.DuplicateProposalEvidence{
+		ProposalA:        makeProposal(t, val2, chainID, 10, 2, blockID, defaultEvidenceTime),
+		ProposalB:        makeProposal(t, val2, chainID, 10, 2, blockID2, defaultEvidenceTime),
+		ValidatorAddress: pubKey2.Address(),
+		ValidatorPower:   1,
+		TotalVotingPower: 1,
+		Timestamp:        defaultEvidenceTime,
+	}
+	assert.Error(t, evidence.VerifyDuplicateProposal(ev, chainID, valSet))
+
+	// create good evidence and correct validator power
+	goodEv := types.NewMockDuplicateProposalEvidenceWithValidator(10, defaultEvidenceTime, val, chainID)
+	goodEv.ValidatorPower = 1
+	goodEv.TotalVotingPower = 1
+	badEv := types.NewMockDuplicateProposalEvidenceWithValidator(10, defaultEvidenceTime, val, chainID)
+	badTimeEv := types.NewMockDuplicateProposalEvidenceWithValidator(10, defaultEvidenceTime.Add(1*time.Minute),
+		val, chainID)
+	badTimeEv.ValidatorPower = 1
+	badTimeEv.TotalVotingPower = 1
+	state := sm.State{
+		Version:         sm.InitStateVersion,
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	evList := types.EvidenceList{goodEv}
+	err = pool.CheckEvidence(evList)
+	assert.NoError(t, err)
+
+	// the evidence is not accepted before the block protocol supporting it
+	oldState := state
+	oldState.Version.Consensus.Block = version.BlockProtocolDuplicateProposalEvidence - 1
+	oldStateStore := &smmocks.Store{}
+	oldStateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	oldStateStore.On("Load").Return(oldState, nil)
+	oldPool, err := evidence.NewPool(dbm.NewMemDB(), oldStateStore, blockStore)
+	require.NoError(t, err)
+	assert.Error(t, oldPool.CheckEvidence(evList))
+
+	// evidence with a different validator power should fail
+	evList = types.EvidenceList{badEv}
+	err = pool.CheckEvidence(evList)
+	assert.Error(t, err)
+
+	// evidence with a different timestamp should fail
+	evList = types.EvidenceList{badTimeEv}
+	err = pool.CheckEvidence(evList)
+	assert.Error(t, err)
+}
+
 func makeLunaticEvidence(
 	t *testing.T,
 	height, commonHeight int64,
@@ -616,6 +779,19 @@ func makeVote(
 	return v
 }
 
+func makeProposal(
+	t *testing.T, val types.PrivValidator, chainID string, height int64, round int32,
+	blockID types.BlockID, time time.Time) *types.Proposal {
+	p := types.NewProposal(height, round, -1, blockID)
+	p.Timestamp = time
+
+	ppb := p.ToProto()
+	err := val.SignProposal(chainID, ppb)
+	require.NoError(t, err)
+	p.Signature = ppb.Signature
+	return p
+}
+
 func makeHeaderRandom(height int64) *types.Header {
 	return &types.Header{
 		Version:            tmversion.Consensus{Block: version.BlockProtocol, App: version.AppProtocol},
@@ -0,0 +1,81 @@
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// NewClientTLSConfig returns the config of a client of mutual TLS, presenting
+// the certificate of certFile and keyFile. The server's certificate must be
+// issued by a CA of caFile for serverName, and its common name must be one of
+// allowedCNs, unless allowedCNs is empty.
+func NewClientTLSConfig(certFile, keyFile, caFile, serverName string, allowedCNs []string) (*tls.Config, error) {
+	cert, pool, err := loadTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates:     []tls.Certificate{cert},
+		RootCAs:          pool,
+		ServerName:       serverName,
+		MinVersion:       tls.VersionTLS13,
+		VerifyConnection: verifyCommonName(allowedCNs),
+	}, nil
+}
+
+// NewServerTLSConfig returns the config of a server of mutual TLS, presenting
+// the certificate of certFile and keyFile. The clients' certificates must be
+// issued by a CA of caFile, and their common name must be one of allowedCNs,
+// unless allowedCNs is empty.
+func NewServerTLSConfig(certFile, keyFile, caFile string, allowedCNs []string) (*tls.Config, error) {
+	cert, pool, err := loadTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates:     []tls.Certificate{cert},
+		ClientCAs:        pool,
+		ClientAuth:       tls.RequireAndVerifyClientCert,
+		MinVersion:       tls.VersionTLS13,
+		VerifyConnection: verifyCommonName(allowedCNs),
+	}, nil
+}
+
+func loadTLSFiles(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load the certificate: %w", err)
+	}
+	bz, err := os.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read the CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificate found in %s", caFile)
+	}
+	return cert, pool, nil
+}
+
+// verifyCommonName returns a function rejecting the connections whose peer's
+// certificate has a common name not in allowedCNs, if it isn't empty.
+func verifyCommonName(allowedCNs []string) func(tls.ConnectionState) error {
+	if len(allowedCNs) == 0 {
+		return nil
+	}
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("no peer certificate")
+		}
+		cn := cs.PeerCertificates[0].Subject.CommonName
+		for _, allowed := range allowedCNs {
+			if cn == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("common name %q of the peer certificate isn't allowed", cn)
+	}
+}
@@ -155,6 +155,9 @@ type Client struct {
 	pruningSize uint16
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
+	// Handles the evidence of the attacks instead of sending it to the opposite
+	// provider, see Monitor
+	evidenceHandler func(ctx context.Context, ev *types.LightClientAttackEvidence, accused provider.Provider)
 
 	quit chan struct{}
 
@@ -1033,6 +1036,30 @@ func (c *Client) removeWitnesses(indexes []int) error {
 	return nil
 }
 
+// dropProviders removes the providers, e.g. accused of an attack. If the
+// primary is one of them, it's replaced by the first witness left; if there is
+// none, nothing is removed and ErrNoWitnesses is returned.
+func (c *Client) dropProviders(dropped map[provider.Provider]struct{}) error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	witnesses := make([]provider.Provider, 0, len(c.witnesses))
+	for _, w := range c.witnesses {
+		if _, ok := dropped[w]; !ok {
+			witnesses = append(witnesses, w)
+		}
+	}
+	if _, ok := dropped[c.primary]; ok {
+		if len(witnesses) == 0 {
+			return ErrNoWitnesses
+		}
+		c.logger.Info("Replacing the dropped primary", "primary", c.primary, "new", witnesses[0])
+		c.primary, witnesses = witnesses[0], witnesses[1:]
+	}
+	c.witnesses = witnesses
+	return nil
+}
+
 type witnessResponse struct {
 	lb           *types.LightBlock
 	witnessIndex int
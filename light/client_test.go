@@ -1161,3 +1161,64 @@ func TestClientHandlesContexts(t *testing.T) {
 	require.True(t, errors.Is(err, context.Canceled))
 
 }
+
+func TestClient_KeyRotation(t *testing.T) {
+	// the validator holding most of the voting power rotates its key at height 1,
+	// and signs with the new key from height 2
+	oldKeys := genPrivKeys(4)
+	newKeys := append(genPrivKeys(1), oldKeys[1:]...)
+	vals := make([]*types.Validator, len(oldKeys))
+	for i, k := range oldKeys {
+		vals[i] = types.NewValidator(k.PubKey(), 10)
+	}
+	vals[0].VotingPower = 70
+	oldVals := types.NewValidatorSet(vals)
+	newVals := oldVals.Copy()
+	require.NoError(t, newVals.RotateKeys([]types.ValidatorKeyRotation{
+		{OldPubKey: oldKeys[0].PubKey(), NewPubKey: newKeys[0].PubKey()},
+	}))
+	// the validator keeps its address, which the validator set commits to
+	_, rotated := newVals.GetByPubKey(newKeys[0].PubKey())
+	require.NotNil(t, rotated)
+	assert.Equal(t, oldKeys[0].PubKey().Address(), rotated.Address)
+	assert.NotEqual(t, oldVals.Hash(), newVals.Hash())
+
+	rh1 := oldKeys.GenSignedHeader(chainID, 1, bTime, nil, oldVals, newVals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(oldKeys))
+	rh2 := newKeys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, newVals, newVals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys), types.BlockID{Hash: rh1.Hash()})
+	rh3 := newKeys.GenSignedHeaderLastBlockID(chainID, 3, bTime.Add(1*time.Hour), nil, newVals, newVals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys), types.BlockID{Hash: rh2.Hash()})
+
+	// the adjacent header at the transition height is verified with the new keys
+	err := light.VerifyAdjacent(rh1, rh2, newVals, trustPeriod, bTime.Add(2*time.Hour), 10*time.Second)
+	require.NoError(t, err)
+
+	// the signature with the new key doesn't count for the trusted validator,
+	// without failing the verification
+	err = light.VerifyNonAdjacent(rh1, oldVals, rh3, newVals, trustPeriod, bTime.Add(2*time.Hour),
+		10*time.Second, light.DefaultTrustLevel)
+	assert.IsType(t, light.ErrNewValSetCantBeTrusted{}, err)
+
+	node := mockp.New(
+		chainID,
+		map[int64]*types.SignedHeader{1: rh1, 2: rh2, 3: rh3},
+		map[int64]*types.ValidatorSet{1: oldVals, 2: newVals, 3: newVals, 4: newVals},
+	)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{Period: trustPeriod, Height: 1, Hash: rh1.Hash()},
+		node,
+		[]provider.Provider{node},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.SkippingVerification(light.DefaultTrustLevel),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	// so the client bisects down to the adjacent headers, which commit to the rotation
+	l, err := c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, newVals.Hash(), l.ValidatorSet.Hash())
+}
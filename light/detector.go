@@ -212,6 +212,17 @@ func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEv
 	}
 }
 
+// reportEvidence sends the evidence against the accused provider to the receiver, or
+// passes it to the evidence handler of the client if there is one.
+func (c *Client) reportEvidence(ctx context.Context, ev *types.LightClientAttackEvidence,
+	accused, receiver provider.Provider) {
+	if c.evidenceHandler != nil {
+		c.evidenceHandler(ctx, ev, accused)
+		return
+	}
+	c.sendEvidence(ctx, ev, receiver)
+}
+
 // handleConflictingHeaders handles the primary style of attack, which is where a primary and witness have
 // two headers of the same height but with different hashes
 func (c *Client) handleConflictingHeaders(
@@ -240,7 +251,7 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstPrimary := newLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
 	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary by witness", "ev", evidenceAgainstPrimary,
 		"primary", c.primary, "witness", supportingWitness)
-	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
+	c.reportEvidence(ctx, evidenceAgainstPrimary, c.primary, supportingWitness)
 
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
@@ -268,7 +279,7 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstWitness := newLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
-	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	c.reportEvidence(ctx, evidenceAgainstWitness, supportingWitness, c.primary)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
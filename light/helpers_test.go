@@ -113,7 +113,7 @@ func (pkz privKeys) signHeaderByRate(header *types.Header, valSet *types.Validat
 	until := int64(float64(valSet.TotalVotingPower()) * rate)
 	sum := int64(0)
 	for i := 0; i < len(pkz); i++ {
-		_, val := valSet.GetByAddress(pkz[i].PubKey().Address())
+		_, val := valSet.GetByPubKey(pkz[i].PubKey())
 		if val == nil {
 			continue
 		}
@@ -132,13 +132,12 @@ func (pkz privKeys) signHeaderByRate(header *types.Header, valSet *types.Validat
 func makeVote(header *types.Header, valset *types.ValidatorSet,
 	key crypto.PrivKey, blockID types.BlockID) *types.Vote {
 
-	addr := key.PubKey().Address()
-	idx, _ := valset.GetByAddress(addr)
+	idx, val := valset.GetByPubKey(key.PubKey())
 	if idx < 0 {
-		panic(fmt.Sprintf("address %X is not contained in ValSet: %+v", addr, valset))
+		panic(fmt.Sprintf("pubkey %v is not contained in ValSet: %+v", key.PubKey(), valset))
 	}
 	vote := &types.Vote{
-		ValidatorAddress: addr,
+		ValidatorAddress: val.Address,
 		ValidatorIndex:   idx,
 		Height:           header.Height,
 		Round:            1,
@@ -0,0 +1,81 @@
+package light
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Latest height verified by the monitor.
+	MonitorHeight metrics.Gauge
+	// Number of light client attacks detected, by accused provider.
+	MonitorAttacks metrics.Counter
+	// Number of evidence reported, by receiving provider.
+	MonitorEvidenceReported metrics.Counter
+	// Number of evidence failed to be reported, by receiving provider.
+	MonitorEvidenceReportFailures metrics.Counter
+	// Number of headers failed to be verified.
+	MonitorVerificationFailures metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		MonitorHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "monitor_height",
+			Help:      "Latest height verified by the monitor.",
+		}, labels).With(labelsAndValues...),
+		MonitorAttacks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "monitor_attacks",
+			Help:      "Number of light client attacks detected, by accused provider.",
+		}, append(labels, "accused")).With(labelsAndValues...),
+		MonitorEvidenceReported: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "monitor_evidence_reported",
+			Help:      "Number of evidence reported, by receiving provider.",
+		}, append(labels, "provider")).With(labelsAndValues...),
+		MonitorEvidenceReportFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "monitor_evidence_report_failures",
+			Help:      "Number of evidence failed to be reported, by receiving provider.",
+		}, append(labels, "provider")).With(labelsAndValues...),
+		MonitorVerificationFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "monitor_verification_failures",
+			Help:      "Number of headers failed to be verified.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		MonitorHeight:                 discard.NewGauge(),
+		MonitorAttacks:                discard.NewCounter(),
+		MonitorEvidenceReported:       discard.NewCounter(),
+		MonitorEvidenceReportFailures: discard.NewCounter(),
+		MonitorVerificationFailures:   discard.NewCounter(),
+	}
+}
@@ -0,0 +1,194 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Finschia/ostracon/libs/service"
+	"github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/types"
+)
+
+// number of hashes of the reported evidence kept to not report it twice
+const maxReportedEvidence = 1000
+
+// Monitor follows a chain with a light client, verifying every height from
+// the primary and cross-checking it with the witnesses. The evidence of the
+// detected attacks is reported to all the providers accused by none of it,
+// instead of only to the opposite one. The accused providers are then dropped,
+// the primary being replaced by an honest witness, so the monitor keeps
+// following the chain.
+//
+// The client shouldn't be used by anything else while the monitor runs.
+type Monitor struct {
+	service.BaseService
+
+	client   *Client
+	interval time.Duration
+	metrics  *Metrics
+
+	// accessed by the monitor routine only:
+	// evidence of the attack being detected, and the providers it accuses
+	pending []*types.LightClientAttackEvidence
+	accused map[provider.Provider]struct{}
+	// hashes of the evidence already reported, oldest first in reportedOrder
+	reported      map[string]struct{}
+	reportedOrder []string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMonitor returns a monitor of the chain of the client, polling the primary
+// for new heights every interval.
+func NewMonitor(c *Client, interval time.Duration, metrics *Metrics) *Monitor {
+	m := &Monitor{
+		client:   c,
+		interval: interval,
+		metrics:  metrics,
+		accused:  make(map[provider.Provider]struct{}),
+		reported: make(map[string]struct{}),
+	}
+	m.BaseService = *service.NewBaseService(nil, "Monitor", m)
+	c.evidenceHandler = m.handleEvidence
+	return m
+}
+
+// OnStart implements service.Service.
+func (m *Monitor) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go m.monitorRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (m *Monitor) OnStop() {
+	m.cancel()
+	<-m.done
+}
+
+func (m *Monitor) monitorRoutine(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.followHeaders(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// followHeaders verifies the heights from the last trusted one to the latest
+// one of the primary, one by one, so that the divergence of any of them is
+// detected. It stops at the first height failing to be verified, which is
+// retried the next time.
+func (m *Monitor) followHeaders(ctx context.Context) {
+	latest, err := m.client.lightBlockFromPrimary(ctx, 0)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.Logger.Error("Failed to get the latest light block", "err", err)
+			m.metrics.MonitorVerificationFailures.Add(1)
+		}
+		return
+	}
+	lastHeight, err := m.client.LastTrustedHeight()
+	if err != nil {
+		m.Logger.Error("Failed to get the last trusted height", "err", err)
+		return
+	}
+
+	for height := lastHeight + 1; height <= latest.Height; height++ {
+		if _, err := m.client.VerifyLightBlockAtHeight(ctx, height, time.Now()); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, ErrLightClientAttack) {
+				m.Logger.Error("Halted on an attack", "height", height)
+			} else {
+				m.Logger.Error("Failed to verify the header", "height", height, "err", err)
+			}
+			m.metrics.MonitorVerificationFailures.Add(1)
+			m.handleAttack(ctx)
+			return
+		}
+		m.Logger.Debug("Verified the header", "height", height)
+		m.metrics.MonitorHeight.Set(float64(height))
+	}
+}
+
+// handleEvidence alerts of the attack and records its evidence, once, to be
+// reported when the detection is over. It's called by the client while
+// detecting the divergence, with the providers locked.
+func (m *Monitor) handleEvidence(ctx context.Context, ev *types.LightClientAttackEvidence,
+	accused provider.Provider) {
+	m.accused[accused] = struct{}{}
+	hash := string(ev.Hash())
+	if _, ok := m.reported[hash]; ok {
+		return
+	}
+	m.addReported(hash)
+
+	m.Logger.Error("LIGHT CLIENT ATTACK DETECTED",
+		"accused", accused,
+		"height", ev.ConflictingBlock.Height,
+		"commonHeight", ev.CommonHeight,
+		"byzantineValidators", len(ev.ByzantineValidators),
+		"evidence", ev.Hash())
+	m.metrics.MonitorAttacks.With("accused", fmt.Sprint(accused)).Add(1)
+	m.pending = append(m.pending, ev)
+}
+
+// handleAttack reports the evidence of the detected attack, if any, to the
+// providers accused by none of it, and drops the accused providers.
+func (m *Monitor) handleAttack(ctx context.Context) {
+	if len(m.accused) == 0 {
+		return
+	}
+	defer func() {
+		m.pending = nil
+		m.accused = make(map[provider.Provider]struct{})
+	}()
+
+	var honest []provider.Provider
+	for _, p := range append([]provider.Provider{m.client.Primary()}, m.client.Witnesses()...) {
+		if _, ok := m.accused[p]; !ok {
+			honest = append(honest, p)
+		}
+	}
+	for _, ev := range m.pending {
+		for _, p := range honest {
+			if err := p.ReportEvidence(ctx, ev); err != nil {
+				m.Logger.Error("Failed to report evidence", "provider", p, "evidence", ev.Hash(), "err", err)
+				m.metrics.MonitorEvidenceReportFailures.With("provider", fmt.Sprint(p)).Add(1)
+				continue
+			}
+			m.Logger.Info("Reported evidence", "provider", p, "evidence", ev.Hash())
+			m.metrics.MonitorEvidenceReported.With("provider", fmt.Sprint(p)).Add(1)
+		}
+	}
+
+	if err := m.client.dropProviders(m.accused); err != nil {
+		m.Logger.Error("Failed to drop the accused providers", "err", err)
+		return
+	}
+	m.Logger.Info("Dropped the accused providers", "primary", m.client.Primary(),
+		"witnesses", len(m.client.Witnesses()))
+}
+
+// addReported records the hash of reported evidence, forgetting the oldest
+// one past maxReportedEvidence.
+func (m *Monitor) addReported(hash string) {
+	m.reported[hash] = struct{}{}
+	m.reportedOrder = append(m.reportedOrder, hash)
+	if len(m.reportedOrder) > maxReportedEvidence {
+		delete(m.reported, m.reportedOrder[0])
+		m.reportedOrder = m.reportedOrder[1:]
+	}
+}
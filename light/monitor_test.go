@@ -0,0 +1,127 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/light"
+	"github.com/Finschia/ostracon/light/provider"
+	mockp "github.com/Finschia/ostracon/light/provider/mock"
+	dbs "github.com/Finschia/ostracon/light/store/db"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestMonitor(t *testing.T) {
+	// primary performs a lunatic attack, detected at the divergence height
+	var (
+		latestHeight      = int64(10)
+		valSize           = 5
+		divergenceHeight  = int64(5)
+		startTime         = time.Now().Add(-time.Hour)
+		primaryHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		primaryValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+
+	witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, startTime)
+	witness := mockp.New(chainID, witnessHeaders, witnessValidators)
+	otherWitness := mockp.New(chainID, witnessHeaders, witnessValidators)
+	thirdWitness := mockp.New(chainID, witnessHeaders, witnessValidators)
+
+	for height := int64(1); height < divergenceHeight; height++ {
+		primaryHeaders[height] = witnessHeaders[height]
+		primaryValidators[height] = witnessValidators[height]
+	}
+	curKeys := chainKeys[divergenceHeight]
+	forgedKeys := curKeys.ChangeKeys(3) // we change 3 out of the 5 validators (still 2/5 remain)
+	forgedVals := forgedKeys.ToValidators(2, 0)
+	header, vals, _ := genMockNodeWithKey(chainID, divergenceHeight, nil,
+		curKeys, forgedKeys,
+		nil, forgedVals, primaryHeaders[divergenceHeight-1],
+		startTime.Add(time.Duration(divergenceHeight)*time.Minute),
+		0, len(curKeys),
+		0, nil)
+	primaryHeaders[divergenceHeight] = header
+	primaryValidators[divergenceHeight] = vals
+	for height := divergenceHeight + 1; height <= latestHeight; height++ {
+		header, vals, _ := genMockNodeWithKey(chainID, height, nil,
+			forgedKeys, forgedKeys,
+			forgedVals, forgedVals, primaryHeaders[height-1],
+			startTime.Add(time.Duration(height)*time.Minute),
+			0, len(forgedKeys),
+			0, nil)
+		primaryHeaders[height] = header
+		primaryValidators[height] = vals
+	}
+	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   primaryHeaders[1].Hash(),
+		},
+		primary,
+		[]provider.Provider{witness, otherWitness, thirdWitness},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+	)
+	require.NoError(t, err)
+
+	metrics := light.NopMetrics()
+	height := generic.NewGauge("height")
+	failures := generic.NewCounter("failures")
+	metrics.MonitorHeight = height
+	metrics.MonitorVerificationFailures = failures
+
+	m := light.NewMonitor(c, 10*time.Millisecond, metrics)
+	m.SetLogger(log.TestingLogger())
+	require.NoError(t, m.Start())
+	// halted on the attack, then the chain of the witnesses is followed
+	assert.Eventually(t, func() bool { return height.Value() == float64(latestHeight) },
+		5*time.Second, 10*time.Millisecond)
+	require.NoError(t, m.Stop())
+	assert.EqualValues(t, 1, failures.Value())
+
+	// the accused primary is replaced by a witness, and the accused witness is dropped
+	assert.NotEqual(t, primary, c.Primary())
+	assert.Len(t, c.Witnesses(), 1)
+
+	// the evidence is reported to the providers accused by none of it
+	evAgainstPrimary := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: primaryHeaders[divergenceHeight],
+			ValidatorSet: primaryValidators[divergenceHeight],
+		},
+		CommonHeight: divergenceHeight - 1,
+	}
+	assert.False(t, primary.HasEvidence(evAgainstPrimary))
+
+	evAgainstWitness := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: witnessHeaders[divergenceHeight],
+			ValidatorSet: witnessValidators[divergenceHeight],
+		},
+		CommonHeight: divergenceHeight - 1,
+	}
+	assert.False(t, primary.HasEvidence(evAgainstWitness))
+
+	// the witnesses have the same blocks, any of them can be the accused one
+	honest := 0
+	for _, w := range []*mockp.Mock{witness, otherWitness, thirdWitness} {
+		assert.Equal(t, w.HasEvidence(evAgainstPrimary), w.HasEvidence(evAgainstWitness))
+		if w.HasEvidence(evAgainstPrimary) {
+			honest++
+		}
+	}
+	assert.Equal(t, 2, honest)
+}
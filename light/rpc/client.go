@@ -261,6 +261,10 @@ func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
 
+func (c *Client) NetCensus(ctx context.Context) (*ctypes.ResultNetCensus, error) {
+	return c.next.NetCensus(ctx)
+}
+
 // BlockchainInfo calls rpcclient#BlockchainInfo and then verifies every header
 // returned.
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -535,6 +539,18 @@ func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*cty
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+func (c *Client) PendingEvidence(ctx context.Context) (*ctypes.ResultPendingEvidence, error) {
+	return c.next.PendingEvidence(ctx)
+}
+
+func (c *Client) CommittedEvidence(ctx context.Context, height *int64) (*ctypes.ResultCommittedEvidence, error) {
+	return c.next.CommittedEvidence(ctx, height)
+}
+
+func (c *Client) EvidenceByHash(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return c.next.EvidenceByHash(ctx, hash)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
@@ -39,6 +39,14 @@ type Mempool interface {
 	// from the mempool.
 	RemoveTxByKey(txKey types.TxKey) error
 
+	// GetTxByKey returns a transaction of the mempool, identified by its key.
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+
+	// GetTxAccessSet returns the access set the app declared for a transaction
+	// of the mempool, identified by its key, when it last checked it, if it
+	// declared one.
+	GetTxAccessSet(txKey types.TxKey) (TxAccessSet, bool)
+
 	// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes
 	// bytes total with the condition that the total gasWanted must be less than
 	// maxGas.
@@ -22,7 +22,11 @@ func (Mempool) CheckTxSync(_ types.Tx, _ func(*ocabci.Response), _ mempool.TxInf
 }
 func (Mempool) CheckTxAsync(_ types.Tx, _ mempool.TxInfo, _ func(error), _ func(*ocabci.Response)) {
 }
+func (Mempool) GetTxAccessSet(txKey types.TxKey) (mempool.TxAccessSet, bool) {
+	return mempool.TxAccessSet{}, false
+}
 func (Mempool) RemoveTxByKey(txKey types.TxKey) error            { return nil }
+func (Mempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool)    { return nil, false }
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs          { return types.Txs{} }
 func (Mempool) ReapMaxBytesMaxGasMaxTxs(_, _, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs                       { return types.Txs{} }
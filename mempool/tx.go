@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/p2p"
 )
 
@@ -15,3 +16,21 @@ type TxInfo struct {
 	// SenderP2PID is the actual p2p.ID of the sender, used e.g. for logging.
 	SenderP2PID p2p.ID
 }
+
+// TxAccessSet are the keys of the app state a tx reads and writes, as declared
+// by the app in CheckTx. The blocks are delivered in batches by the access sets
+// the app returns to TxAccessSets instead, as they don't depend on the mempool
+// of the node.
+type TxAccessSet struct {
+	ReadKeys  [][]byte
+	WriteKeys [][]byte
+}
+
+// NewTxAccessSet returns the access set declared by the CheckTx response, and
+// false if it declares no keys.
+func NewTxAccessSet(res *ocabci.ResponseCheckTx) (TxAccessSet, bool) {
+	if len(res.ReadKeys) == 0 && len(res.WriteKeys) == 0 {
+		return TxAccessSet{}, false
+	}
+	return TxAccessSet{ReadKeys: res.ReadKeys, WriteKeys: res.WriteKeys}, true
+}
@@ -250,11 +250,46 @@ func (mem *CListMempool) CheckTxAsync(
 }
 
 func (mem *CListMempool) checkTxAsyncReactor() {
+	if mem.config.CheckTxBatchSize > 0 {
+		mem.checkTxBatchReactor()
+		return
+	}
 	for req := range mem.chReqCheckTx {
 		mem.checkTxAsync(req.tx, req.txInfo, req.prepareCb, req.checkTxCb)
 	}
 }
 
+// checkTxBatchReactor checks the requested txs in batches of up to
+// CheckTxBatchSize txs. A batch is checked once it's full, or once
+// CheckTxBatchTimeout elapsed since its first tx was requested.
+func (mem *CListMempool) checkTxBatchReactor() {
+	size := mem.config.CheckTxBatchSize
+	timer := time.NewTimer(0)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	batch := make([]*requestCheckTxAsync, 0, size)
+	for {
+		select {
+		case req := <-mem.chReqCheckTx:
+			batch = append(batch, req)
+			if len(batch) < size {
+				if len(batch) == 1 {
+					timer.Reset(mem.config.CheckTxBatchTimeout)
+				}
+				continue
+			}
+			if size > 1 && !timer.Stop() {
+				<-timer.C
+			}
+		case <-timer.C:
+		}
+		mem.checkTxBatchAsync(batch)
+		batch = make([]*requestCheckTxAsync, 0, size)
+	}
+}
+
 // It blocks if we're waiting on Update() or Reap().
 func (mem *CListMempool) checkTxAsync(
 	tx types.Tx,
@@ -290,6 +325,62 @@ func (mem *CListMempool) checkTxAsync(
 	})
 }
 
+// checkTxBatchAsync is like checkTxAsync, but checks the txs of batch with a
+// single CheckTxBatch request.
+// It blocks if we're waiting on Update() or Reap().
+func (mem *CListMempool) checkTxBatchAsync(batch []*requestCheckTxAsync) {
+	mem.updateMtx.RLock()
+	defer func() {
+		if r := recover(); r != nil {
+			mem.updateMtx.RUnlock()
+			panic(r)
+		}
+	}()
+
+	prepared := make([]*requestCheckTxAsync, 0, len(batch))
+	txs := make([]*abci.RequestCheckTx, 0, len(batch))
+	for _, req := range batch {
+		err := mem.prepareCheckTx(req.tx, req.txInfo)
+		if req.prepareCb != nil {
+			req.prepareCb(err)
+		}
+		if err == nil {
+			prepared = append(prepared, req)
+			txs = append(txs, &abci.RequestCheckTx{Tx: req.tx})
+		}
+	}
+	if len(prepared) == 0 {
+		mem.updateMtx.RUnlock()
+		return
+	}
+
+	// CONTRACT: `app.CheckTxBatch()` should check whether `GasWanted` is valid (0 <= GasWanted <= block.masGas)
+	mem.proxyAppConn.CheckTxBatchAsync(ocabci.RequestCheckTxBatch{Txs: txs}, func(res *ocabci.Response) {
+		responses := res.GetCheckTxBatch().GetResponses()
+		if len(responses) != len(prepared) {
+			mem.logger.Error("wrong number of responses to CheckTxBatch",
+				"expected", len(prepared), "got", len(responses))
+		}
+		for i, req := range prepared {
+			var txRes *ocabci.Response
+			if i < len(responses) && responses[i] != nil {
+				txRes = ocabci.ToResponseCheckTx(*responses[i])
+			} else {
+				txRes = ocabci.ToResponseCheckTx(ocabci.ResponseCheckTx{
+					Code:         codeTypeNoResponse,
+					MempoolError: "no response to the tx in the CheckTxBatch response",
+				})
+			}
+			mem.reqResCb(req.tx, req.txInfo.SenderID, req.txInfo.SenderP2PID, txRes, req.checkTxCb)
+		}
+		mem.updateMtx.RUnlock()
+	})
+}
+
+// codeTypeNoResponse rejects the txs of a CheckTxBatch request the app didn't
+// respond to.
+const codeTypeNoResponse uint32 = 1
+
 // CONTRACT: `caller` should held `mem.updateMtx.RLock()`
 func (mem *CListMempool) prepareCheckTx(tx types.Tx, txInfo mempool.TxInfo) error {
 	// For keeping the consistency between `mem.txs` and `mem.txsMap`
@@ -358,6 +449,11 @@ func (mem *CListMempool) prepareCheckTx(tx types.Tx, txInfo mempool.TxInfo) erro
 // When rechecking, we don't need the peerID, so the recheck callback happens
 // here.
 func (mem *CListMempool) globalCb(req *ocabci.Request, res *ocabci.Response) {
+	if batchReq := req.GetCheckTxBatch(); batchReq != nil {
+		mem.resCbRecheckBatch(batchReq, res.GetCheckTxBatch())
+		return
+	}
+
 	checkTxReq := req.GetCheckTx()
 	if checkTxReq == nil {
 		return
@@ -372,6 +468,27 @@ func (mem *CListMempool) globalCb(req *ocabci.Request, res *ocabci.Response) {
 	}
 }
 
+// resCbRecheckBatch handles the responses to the rechecked txs of a
+// CheckTxBatch request like globalCb does for a CheckTx request.
+func (mem *CListMempool) resCbRecheckBatch(req *ocabci.RequestCheckTxBatch, res *ocabci.ResponseCheckTxBatch) {
+	responses := res.GetResponses()
+	for i, tx := range req.Txs {
+		if tx.Type != abci.CheckTxType_Recheck {
+			continue
+		}
+		if i >= len(responses) || responses[i] == nil {
+			mem.logger.Error("no response to the rechecked tx in the CheckTxBatch response",
+				"tx", types.Tx(tx.Tx).Hash())
+			continue
+		}
+		mem.metrics.RecheckTimes.Add(1)
+		mem.resCbRecheck(ocabci.ToRequestCheckTx(*tx), ocabci.ToResponseCheckTx(*responses[i]))
+	}
+
+	// update metrics
+	mem.metrics.Size.Set(float64(mem.Size()))
+}
+
 // Request specific callback that should be set on individual reqRes objects
 // to incorporate local information when processing the response.
 // This allows us to track the peer that sent us this tx, so we can avoid sending it back to them.
@@ -435,6 +552,24 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return errors.New("invalid transaction found")
 }
 
+// GetTxByKey returns the transaction with the given key, if it is in the
+// mempool.
+func (mem *CListMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	if e, ok := mem.txsMap.Load(txKey); ok {
+		return e.(*clist.CElement).Value.(*mempoolTx).tx, true
+	}
+	return nil, false
+}
+
+// GetTxAccessSet returns the access set declared for the transaction with the
+// given key, if it is in the mempool and the app declared one.
+func (mem *CListMempool) GetTxAccessSet(txKey types.TxKey) (mempool.TxAccessSet, bool) {
+	if e, ok := mem.txsMap.Load(txKey); ok {
+		return e.(*clist.CElement).Value.(*mempoolTx).getAccessSet()
+	}
+	return mempool.TxAccessSet{}, false
+}
+
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
 		memSize  = mem.Size()
@@ -502,6 +637,7 @@ func (mem *CListMempool) resCbFirstTime(
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
 			}
+			memTx.setAccessSet(r.CheckTx)
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Debug(
@@ -549,18 +685,19 @@ func (mem *CListMempool) resCbRecheck(req *ocabci.Request, res *ocabci.Response)
 			return
 		}
 
+		celem := e.(*clist.CElement)
 		var postCheckErr error
 		if r.CheckTx.Code == ocabci.CodeTypeOK {
-			if mem.postCheck == nil {
-				return
+			if mem.postCheck != nil {
+				postCheckErr = mem.postCheck(tx, r.CheckTx)
 			}
-			postCheckErr = mem.postCheck(tx, r.CheckTx)
 			if postCheckErr == nil {
+				// the keys the tx reads and writes may change with the state
+				celem.Value.(*mempoolTx).setAccessSet(r.CheckTx)
 				return
 			}
 			r.CheckTx.MempoolError = postCheckErr.Error()
 		}
-		celem := e.(*clist.CElement)
 		// Tx became invalidated due to newly committed block.
 		mem.logger.Debug("tx is no longer valid",
 			"tx", types.Tx(tx).Hash(),
@@ -782,21 +919,37 @@ func (mem *CListMempool) recheckTxs() {
 	}
 
 	wg := sync.WaitGroup{}
+	done := func(res *ocabci.Response) {
+		wg.Done()
+	}
 
-	// Push txs to proxyAppConn
+	// Push txs to proxyAppConn, in batches of up to CheckTxBatchSize txs if
+	// it's set
 	// NOTE: globalCb may be called concurrently.
+	batchSize := mem.config.CheckTxBatchSize
+	batch := make([]*abci.RequestCheckTx, 0, batchSize)
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		wg.Add(1)
-
 		memTx := e.Value.(*mempoolTx)
 		req := abci.RequestCheckTx{
 			Tx:   memTx.tx,
 			Type: abci.CheckTxType_Recheck,
 		}
 
-		mem.proxyAppConn.CheckTxAsync(req, func(res *ocabci.Response) {
-			wg.Done()
-		})
+		if batchSize == 0 {
+			wg.Add(1)
+			mem.proxyAppConn.CheckTxAsync(req, done)
+			continue
+		}
+		batch = append(batch, &req)
+		if len(batch) == batchSize {
+			wg.Add(1)
+			mem.proxyAppConn.CheckTxBatchAsync(ocabci.RequestCheckTxBatch{Txs: batch}, done)
+			batch = make([]*abci.RequestCheckTx, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		wg.Add(1)
+		mem.proxyAppConn.CheckTxBatchAsync(ocabci.RequestCheckTxBatch{Txs: batch}, done)
 	}
 
 	mem.proxyAppConn.FlushAsync(func(res *ocabci.Response) {})
@@ -811,6 +964,12 @@ type mempoolTx struct {
 	gasWanted int64    // amount of gas this tx states it will require
 	tx        types.Tx //
 
+	// keys the app declared the tx reads and writes in the last check, if it
+	// declared any
+	accessSetMtx sync.RWMutex
+	accessSet    mempool.TxAccessSet
+	hasAccessSet bool
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
@@ -820,3 +979,17 @@ type mempoolTx struct {
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// setAccessSet sets the access set declared by the CheckTx response.
+func (memTx *mempoolTx) setAccessSet(res *ocabci.ResponseCheckTx) {
+	memTx.accessSetMtx.Lock()
+	defer memTx.accessSetMtx.Unlock()
+	memTx.accessSet, memTx.hasAccessSet = mempool.NewTxAccessSet(res)
+}
+
+// getAccessSet returns the access set declared for this transaction, if any.
+func (memTx *mempoolTx) getAccessSet() (mempool.TxAccessSet, bool) {
+	memTx.accessSetMtx.RLock()
+	defer memTx.accessSetMtx.RUnlock()
+	return memTx.accessSet, memTx.hasAccessSet
+}
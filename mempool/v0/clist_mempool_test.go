@@ -783,3 +783,103 @@ func TestTxMempoolPostCheckError(t *testing.T) {
 		})
 	}
 }
+
+func TestMempoolCheckTxBatch(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", tmrand.Str(6))
+	app := kvstore.NewApplication()
+	_, server := newRemoteApp(t, sockPath, app)
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.CheckTxBatchSize = 4
+	cfg.Mempool.CheckTxBatchTimeout = 10 * time.Millisecond
+	mp, cleanup := newMempoolWithAppAndConfig(proxy.NewRemoteClientCreator(sockPath, "socket", true), cfg)
+	defer cleanup()
+
+	// 10 txs are checked in 2 full batches, and one sent on timeout
+	txs := make(types.Txs, 10)
+	wg := sync.WaitGroup{}
+	for i := range txs {
+		txs[i] = []byte(fmt.Sprintf("key%d=value", i))
+		wg.Add(1)
+		mp.CheckTxAsync(txs[i], mempool.TxInfo{}, func(err error) {
+			require.NoError(t, err)
+		}, func(res *ocabci.Response) {
+			require.Equal(t, ocabci.CodeTypeOK, res.GetCheckTx().Code)
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	require.Equal(t, len(txs), mp.Size())
+
+	// a tx checked again is rejected before being sent to the app
+	wg.Add(1)
+	mp.CheckTxAsync(txs[0], mempool.TxInfo{}, func(err error) {
+		require.Equal(t, mempool.ErrTxInMap, err)
+		wg.Done()
+	}, nil)
+	wg.Wait()
+
+	// the txs left are rechecked in batches too
+	rejected := txs[5]
+	mp.Lock()
+	err := mp.Update(newTestBlock(1, txs[:3]), abciResponses(3, ocabci.CodeTypeOK), nil,
+		func(tx types.Tx, _ *ocabci.ResponseCheckTx) error {
+			if tx.Key() == rejected.Key() {
+				return errors.New("rejected")
+			}
+			return nil
+		})
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, len(txs)-4, mp.Size())
+	_, ok := mp.GetTxByKey(rejected.Key())
+	require.False(t, ok)
+}
+
+// recheckKeysApp declares that the rechecked txs read the key "recheck" too.
+type recheckKeysApp struct {
+	*kvstore.Application
+}
+
+func (app recheckKeysApp) CheckTxSync(req abci.RequestCheckTx) ocabci.ResponseCheckTx {
+	res := app.Application.CheckTxSync(req)
+	if req.Type == abci.CheckTxType_Recheck {
+		res.ReadKeys = [][]byte{[]byte("recheck")}
+	}
+	return res
+}
+
+func (app recheckKeysApp) CheckTxAsync(req abci.RequestCheckTx, callback ocabci.CheckTxCallback) {
+	callback(app.CheckTxSync(req))
+}
+
+func TestMempoolRecheckUpdatesTxAccessSet(t *testing.T) {
+	app := recheckKeysApp{kvstore.NewApplication()}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=1")}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTxSync(tx, nil, mempool.TxInfo{}))
+	}
+	set, ok := mp.GetTxAccessSet(txs[1].Key())
+	require.True(t, ok)
+	assert.Equal(t, mempool.TxAccessSet{WriteKeys: [][]byte{[]byte("b")}}, set)
+
+	mp.Lock()
+	err := mp.Update(newTestBlock(1, txs[:1]), abciResponses(1, ocabci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+
+	set, ok = mp.GetTxAccessSet(txs[1].Key())
+	require.True(t, ok)
+	assert.Equal(t, mempool.TxAccessSet{
+		ReadKeys:  [][]byte{[]byte("recheck")},
+		WriteKeys: [][]byte{[]byte("b")},
+	}, set)
+}
@@ -144,6 +144,7 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			Priority:            5,
 			RecvMessageCapacity: batchMsg.Size(),
 			MessageType:         &protomem.Message{},
+			DropsOverRecvRate:   true,
 		},
 	}
 }
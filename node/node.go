@@ -1,12 +1,12 @@
 package node
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -16,6 +16,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tm-db"
 
+	abcicli "github.com/Finschia/ostracon/abci/client"
 	bcv0 "github.com/Finschia/ostracon/blockchain/v0"
 	bcv1 "github.com/Finschia/ostracon/blockchain/v1"
 	bcv2 "github.com/Finschia/ostracon/blockchain/v2"
@@ -25,6 +26,8 @@ import (
 	"github.com/Finschia/ostracon/evidence"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	"github.com/Finschia/ostracon/libs/log"
+	tmnet "github.com/Finschia/ostracon/libs/net"
+	tmos "github.com/Finschia/ostracon/libs/os"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
 	"github.com/Finschia/ostracon/libs/service"
 	"github.com/Finschia/ostracon/light"
@@ -33,11 +36,15 @@ import (
 
 	//mempoolv1 "github.com/Finschia/ostracon/mempool/v1"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/banlist"
+	"github.com/Finschia/ostracon/p2p/census"
 	"github.com/Finschia/ostracon/p2p/pex"
 	"github.com/Finschia/ostracon/privval"
 	"github.com/Finschia/ostracon/proxy"
 	rpccore "github.com/Finschia/ostracon/rpc/core"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
 	grpccore "github.com/Finschia/ostracon/rpc/grpc"
+	rpcclient "github.com/Finschia/ostracon/rpc/jsonrpc/client"
 	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/state/indexer"
@@ -103,11 +110,23 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
-	pv := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	keyProvider, err := privval.NewKeyProvider(config.PrivValidatorKeyPassphraseFile(), config.PrivValidatorKeyPassphraseEnv)
+	if err != nil {
+		return nil, err
+	}
+	pv := privval.LoadOrGenFilePVWithKeyProvider(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(),
+		keyProvider, config.PrivValidatorKeyKDF)
+	if err := loadPrivValidatorNextKey(config, pv, keyProvider); err != nil {
+		return nil, err
+	}
+	clientCreator, err := abciClientCreator(config)
+	if err != nil {
+		return nil, err
+	}
 	return NewNode(config,
 		pv,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		clientCreator,
 		DefaultGenesisDocProviderFunc(config),
 		DefaultDBProvider,
 		DefaultMetricsProvider(config.Instrumentation),
@@ -125,15 +144,29 @@ func NewOstraconNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 
 	var privKey types.PrivValidator
 	if config.PrivValidatorListenAddr == "" {
-		privKey = privval.LoadFilePV(
+		keyProvider, err := privval.NewKeyProvider(config.PrivValidatorKeyPassphraseFile(),
+			config.PrivValidatorKeyPassphraseEnv)
+		if err != nil {
+			return nil, err
+		}
+		pv := privval.LoadFilePVWithKeyProvider(
 			config.PrivValidatorKeyFile(),
-			config.PrivValidatorStateFile())
+			config.PrivValidatorStateFile(),
+			keyProvider)
+		if err := loadPrivValidatorNextKey(config, pv, keyProvider); err != nil {
+			return nil, err
+		}
+		privKey = pv
+	}
+	clientCreator, err := abciClientCreator(config)
+	if err != nil {
+		return nil, err
 	}
 	return NewNode(
 		config,
 		privKey,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		clientCreator,
 		DefaultGenesisDocProviderFunc(config),
 		DefaultDBProvider,
 		DefaultMetricsProvider(config.Instrumentation),
@@ -200,6 +233,11 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 						if err != nil {
 							n.Logger.Debug("AddChannel failed", "err", err)
 						}
+						if n.quicTransport != nil {
+							if err := n.quicTransport.AddChannel(chDesc.ID); err != nil {
+								n.Logger.Debug("AddChannel failed", "err", err)
+							}
+						}
 					}
 				}
 				n.nodeInfo = ni
@@ -223,12 +261,14 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
-	sw          *p2p.Switch  // p2p connections
-	addrBook    pex.AddrBook // known peers
-	nodeInfo    p2p.NodeInfo
-	nodeKey     *p2p.NodeKey // our node privkey
-	isListening bool
+	transport     *p2p.MultiplexTransport
+	quicTransport *p2p.QUICTransport // nil if p2p.quic_laddr is not set
+	sw            *p2p.Switch        // p2p connections
+	addrBook      pex.AddrBook       // known peers
+	census        *census.Census     // nil if p2p.census is disabled
+	nodeInfo      p2p.NodeInfo
+	nodeKey       *p2p.NodeKey // our node privkey
+	isListening   bool
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
@@ -246,6 +286,8 @@ type Node struct {
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
+	abciRecorder      *abcicli.Recorder       // records the ABCI requests and responses, if set
+	signerAuditLog    *privval.SignerAuditLog // records the requests served by the private validator, if set
 	rpcListeners      []net.Listener          // rpc servers
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
@@ -278,6 +320,28 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
+// createAndStartResilientProxyAppConns returns connections to the ABCI app
+// reconnecting when they fail, which then resync the app by redoing the
+// handshake.
+func createAndStartResilientProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	maxBackoff time.Duration,
+	stateStore sm.Store,
+	state sm.State,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+	logger log.Logger,
+) (proxy.AppConns, error) {
+	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
+	handshaker.SetLogger(logger.With("module", "consensus"))
+	proxyApp := proxy.NewResilientAppConns(clientCreator, maxBackoff, handshaker.Resync)
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
+	}
+	return proxyApp, nil
+}
+
 func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
@@ -371,12 +435,11 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 		)
 	}
 
-	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
-	if state.Validators.HasAddress(addr) {
-		consensusLogger.Info("This node is a validator", "addr", addr, "pubKey", pubKey)
+	if _, val := state.Validators.GetByPubKey(pubKey); val != nil {
+		consensusLogger.Info("This node is a validator", "addr", val.Address, "pubKey", pubKey)
 	} else {
-		consensusLogger.Info("This node is not a validator", "addr", addr, "pubKey", pubKey)
+		consensusLogger.Info("This node is not a validator", "addr", pubKey.Address(), "pubKey", pubKey)
 	}
 }
 
@@ -384,8 +447,8 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	if state.Validators.Size() > 1 {
 		return false
 	}
-	addr, _ := state.Validators.GetByIndex(0)
-	return bytes.Equal(pubKey.Address(), addr)
+	_, val := state.Validators.GetByIndex(0)
+	return val.PubKey.Equals(pubKey)
 }
 
 func createMempoolAndMempoolReactor(
@@ -519,8 +582,12 @@ func createConsensusReactor(config *cfg.Config,
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
+	options := []cs.ReactorOption{cs.ReactorMetrics(csMetrics)}
+	if config.Consensus.CompactBlocks {
+		options = append(options, cs.ReactorCompactBlocks(mempool, config.Consensus.CompactBlockTimeout))
+	}
 	consensusReactor := cs.NewReactor(consensusState, waitSync, config.P2P.RecvAsync, config.P2P.ConsensusRecvBufSize,
-		cs.ReactorMetrics(csMetrics))
+		options...)
 	consensusReactor.SetLogger(consensusLogger)
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
@@ -538,8 +605,105 @@ func createTransport(
 	[]p2p.PeerFilterFunc,
 ) {
 	var (
-		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
+		mConnConfig              = p2p.MConnConfig(config.P2P)
+		transport                = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
+		connFilters, peerFilters = createFilters(config, proxyApp)
+	)
+
+	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
+	p2p.MultiplexTransportSecretConnConfig(p2p.SecretConnConfig(config.P2P))(transport)
+
+	// Limit the number of incoming connections.
+	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
+
+	return transport, peerFilters
+}
+
+// createQUICTransport returns the QUIC transport if p2p.quic_laddr is set, or
+// nil otherwise.
+func createQUICTransport(
+	config *cfg.Config,
+	nodeInfo p2p.NodeInfo,
+	nodeKey *p2p.NodeKey,
+	proxyApp proxy.AppConns,
+	banList *banlist.BanList,
+) (*p2p.QUICTransport, error) {
+	if config.P2P.QUICListenAddress == "" {
+		return nil, nil
+	}
+	connFilters, _ := createFilters(config, proxyApp)
+	// Limit the number of incoming connections like the TCP transport.
+	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	return p2p.NewQUICTransport(
+		nodeInfo,
+		*nodeKey,
+		p2p.MConnConfig(config.P2P),
+		p2p.QUICTransportConnFilters(connFilters...),
+		p2p.QUICTransportBanList(banList),
+		p2p.QUICTransportMaxIncomingConnections(max),
+	)
+}
+
+func createBanList(config *cfg.Config, dbProvider DBProvider) (*banlist.BanList, error) {
+	banListDB, err := dbProvider(&DBContext{"banlist", config})
+	if err != nil {
+		return nil, err
+	}
+	return banlist.NewBanList(banListDB)
+}
+
+func createCensus(config *cfg.Config, dbProvider DBProvider, p2pMetrics *p2p.Metrics) (*census.Census, error) {
+	if !config.P2P.Census {
+		return nil, nil
+	}
+	censusDB, err := dbProvider(&DBContext{"census", config})
+	if err != nil {
+		return nil, err
+	}
+	return census.NewCensus(censusDB, probeStatus, config.P2P.CensusRetention, p2pMetrics)
+}
+
+// probeStatus returns the latest block height of a node by calling the status
+// RPC it advertises. Only the port of the advertised address is used: the RPC
+// is always called on the IP the node was connected from, so that a node can't
+// make the prober request any other host.
+func probeStatus(ctx context.Context, nodeInfo p2p.DefaultNodeInfo, ip net.IP) (int64, error) {
+	if nodeInfo.Other.RPCAddress == "" {
+		return 0, errors.New("no RPC address")
+	}
+	if ip == nil {
+		return 0, errors.New("no remote IP")
+	}
+	u, err := url.Parse(nodeInfo.Other.RPCAddress)
+	if err != nil {
+		return 0, err
+	}
+	_, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return 0, err
+	}
+	host := ip.String()
+
+	client, err := rpcclient.New("http://" + net.JoinHostPort(host, port))
+	if err != nil {
+		return 0, err
+	}
+	status := new(ctypes.ResultStatus)
+	if _, err := client.Call(ctx, "status", map[string]interface{}{}, status); err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func createFilters(
+	config *cfg.Config,
+	proxyApp proxy.AppConns,
+) (
+	[]p2p.ConnFilterFunc,
+	[]p2p.PeerFilterFunc,
+) {
+	var (
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
@@ -588,17 +752,13 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-
-	// Limit the number of incoming connections.
-	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
-	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
-
-	return transport, peerFilters
+	return connFilters, peerFilters
 }
 
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
+	quicTransport *p2p.QUICTransport,
+	banList *banlist.BanList,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
 	mempoolReactor p2p.Reactor,
@@ -610,11 +770,18 @@ func createSwitch(config *cfg.Config,
 	nodeKey *p2p.NodeKey,
 	p2pLogger log.Logger,
 ) *p2p.Switch {
+	options := []p2p.SwitchOption{
+		p2p.WithMetrics(p2pMetrics),
+		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchBanList(banList),
+	}
+	if quicTransport != nil {
+		options = append(options, p2p.SwitchQUICTransport(quicTransport))
+	}
 	sw := p2p.NewSwitch(
 		config.P2P,
 		transport,
-		p2p.WithMetrics(p2pMetrics),
-		p2p.SwitchPeerFilters(peerFilters...),
+		options...,
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -630,11 +797,22 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
-func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
+func createAddrBookAndSetOnSwitch(config *cfg.Config, dbProvider DBProvider, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey *p2p.NodeKey,
 ) (pex.AddrBook, error) {
-	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
-	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
+	var addrBook pex.AddrBook
+	switch config.P2P.AddrBookBackend {
+	case cfg.AddrBookBackendDB:
+		addrBookDB, err := dbProvider(&DBContext{"addrbook", config})
+		if err != nil {
+			return nil, err
+		}
+		addrBook = pex.NewDBAddrBook(addrBookDB, config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+		addrBook.SetLogger(p2pLogger.With("book", "addrbook.db"))
+	default:
+		addrBook = pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+		addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
+	}
 
 	// Add ourselves to addrbook to prevent dialing ourselves
 	if config.P2P.ExternalAddress != "" {
@@ -658,7 +836,7 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 }
 
 func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
-	sw *p2p.Switch, logger log.Logger,
+	sw *p2p.Switch, nodeCensus *census.Census, logger log.Logger,
 ) *pex.Reactor {
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook,
@@ -674,6 +852,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
 			RecvBufSize:                  config.P2P.PexRecvBufSize,
+			Census:                       nodeCensus,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
@@ -769,8 +948,22 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	abciRecorder, err := createABCIRecorder(config)
+	if err != nil {
+		return nil, err
+	}
+	if abciRecorder != nil {
+		clientCreator = proxy.NewRecordingClientCreator(clientCreator, abciRecorder)
+	}
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	var proxyApp proxy.AppConns
+	if config.ABCIReconnect {
+		proxyApp, err = createAndStartResilientProxyAppConns(clientCreator, config.ABCIReconnectMaxBackoff,
+			stateStore, state, blockStore, genDoc, logger)
+	} else {
+		proxyApp, err = createAndStartProxyAppConns(clientCreator, logger)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -794,12 +987,25 @@ func NewNode(config *cfg.Config,
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = CreateAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+		privValidator, err = CreateAndStartPrivValidatorClient(config, genDoc.ChainID, logger)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
 	}
 
+	// The external signing process keeps its own audit log.
+	var signerAuditLog *privval.SignerAuditLog
+	if config.PrivValidatorListenAddr == "" && privValidator != nil {
+		signerAuditLog, err = createSignerAuditLog(config)
+		if err != nil {
+			return nil, err
+		}
+		if signerAuditLog != nil {
+			privValidator = privval.NewAuditedPrivValidator(privValidator, signerAuditLog,
+				privval.SignerAuditRequesterLocal)
+		}
+	}
+
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
@@ -847,13 +1053,20 @@ func NewNode(config *cfg.Config,
 	}
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{sm.BlockExecutorWithMetrics(smMetrics)}
+	if config.ABCIParallelDeliverTx {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithParallelDeliverTx())
+	}
+	if config.ABCIOptimisticExecution {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithOptimisticExecution())
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
@@ -874,6 +1087,12 @@ func NewNode(config *cfg.Config,
 		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
 	)
 
+	// Refuse to start with a private validator which may sign again what it
+	// signed before its state was restored from a backup.
+	if err := consensusState.CheckLastSignState(); err != nil {
+		return nil, fmt.Errorf("failed to check the last sign state of the private validator: %w", err)
+	}
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -891,13 +1110,28 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Setup the list of the peers to allow or deny, enforced by the transports
+	// and the switch.
+	banList, err := createBanList(config, dbProvider)
+	if err != nil {
+		return nil, fmt.Errorf("could not create ban list: %w", err)
+	}
+
 	// Setup Transport.
 	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	p2p.MultiplexTransportBanList(banList)(transport)
+	quicTransport, err := createQUICTransport(config, nodeInfo, nodeKey, proxyApp, banList)
+	if err != nil {
+		return nil, fmt.Errorf("could not create QUIC transport: %w", err)
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	for _, warning := range config.P2P.TopologyWarnings() {
+		p2pLogger.Error("Misconfigured topology", "topology", config.P2P.Topology, "warning", warning)
+	}
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, quicTransport, banList, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -911,7 +1145,21 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	switch config.P2P.Topology {
+	case cfg.TopologyValidatorBehindSentries:
+		err = sw.AddBackupSentries(splitAndTrimEmpty(config.P2P.BackupSentries, ",", " "))
+		if err != nil {
+			return nil, fmt.Errorf("could not add sentries from backup_sentries field: %w", err)
+		}
+	case cfg.TopologySentry:
+		// the validators behind the sentry are connected whatever the limits
+		err = sw.AddUnconditionalPeerIDs(splitAndTrimEmpty(config.P2P.PrivatePeerIDs, ",", " "))
+		if err != nil {
+			return nil, fmt.Errorf("could not add peer ids from private_peer_ids field: %w", err)
+		}
+	}
+
+	addrBook, err := createAddrBookAndSetOnSwitch(config, dbProvider, sw, p2pLogger, nodeKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
@@ -928,9 +1176,14 @@ func NewNode(config *cfg.Config,
 	//
 	// If PEX is on, it should handle dialing the seeds. Otherwise the switch does it.
 	// Note we currently use the addrBook regardless at least for AddOurAddress
+	nodeCensus, err := createCensus(config, dbProvider, p2pMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("could not create census: %w", err)
+	}
+
 	var pexReactor *pex.Reactor
-	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+	if isPEXEnabled(config.P2P) {
+		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, nodeCensus, logger)
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -946,11 +1199,13 @@ func NewNode(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
+		transport:     transport,
+		quicTransport: quicTransport,
+		sw:            sw,
+		addrBook:      addrBook,
+		census:        nodeCensus,
+		nodeInfo:      nodeInfo,
+		nodeKey:       nodeKey,
 
 		stateStore:       stateStore,
 		blockStore:       blockStore,
@@ -969,6 +1224,8 @@ func NewNode(config *cfg.Config,
 		indexerService:   indexerService,
 		blockIndexer:     blockIndexer,
 		eventBus:         eventBus,
+		abciRecorder:     abciRecorder,
+		signerAuditLog:   signerAuditLog,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -1014,6 +1271,15 @@ func (n *Node) OnStart() error {
 	if err := n.transport.Listen(*addr); err != nil {
 		return err
 	}
+	if n.quicTransport != nil {
+		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.QUICListenAddress))
+		if err != nil {
+			return err
+		}
+		if err := n.quicTransport.Listen(*addr); err != nil {
+			return err
+		}
+	}
 
 	n.isListening = true
 
@@ -1067,6 +1333,11 @@ func (n *Node) OnStop() {
 	if err := n.transport.Close(); err != nil {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
+	if n.quicTransport != nil {
+		if err := n.quicTransport.Close(); err != nil {
+			n.Logger.Error("Error closing QUIC transport", "err", err)
+		}
+	}
 
 	n.isListening = false
 
@@ -1100,6 +1371,16 @@ func (n *Node) OnStop() {
 			n.Logger.Error("problem closing statestore", "err", err)
 		}
 	}
+	if n.abciRecorder != nil {
+		if err := n.abciRecorder.Close(); err != nil {
+			n.Logger.Error("problem closing ABCI record file", "err", err)
+		}
+	}
+	if n.signerAuditLog != nil {
+		if err := n.signerAuditLog.Close(); err != nil {
+			n.Logger.Error("problem closing signer audit log", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -1117,6 +1398,7 @@ func (n *Node) ConfigureRPC() error {
 		EvidencePool:   n.evidencePool,
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
+		Census:         n.census,
 		P2PTransport:   n,
 
 		PubKey:           pubKey,
@@ -1413,7 +1695,7 @@ func makeNodeInfo(
 		},
 	}
 
-	if config.P2P.PexReactor {
+	if isPEXEnabled(config.P2P) {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
@@ -1553,6 +1835,153 @@ func CreateAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+// loadPrivValidatorNextKey sets the next key of a pending validator key
+// rotation of pv if priv_validator_next_key_file exists. The consensus
+// switches to it once the validator set uses it.
+func loadPrivValidatorNextKey(config *cfg.Config, pv *privval.FilePV, keyProvider privval.KeyProvider) error {
+	nextKeyFile := config.PrivValidatorNextKeyFile()
+	if !tmos.FileExists(nextKeyFile) {
+		return nil
+	}
+	nextKey, err := privval.LoadFilePVKey(nextKeyFile, keyProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load the next private validator key: %w", err)
+	}
+	pv.SetNextKey(nextKey)
+	return nil
+}
+
+// abciClientCreator returns the creator of the clients of the ABCI
+// application, routing the query and snapshot connections to proxy_app_query
+// and proxy_app_snapshot if they're set.
+func abciClientCreator(config *cfg.Config) (proxy.ClientCreator, error) {
+	opts, err := abciClientOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	primary := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir(), opts...)
+	if config.ProxyAppQuery == "" && config.ProxyAppSnapshot == "" {
+		return primary, nil
+	}
+
+	var query, snapshot proxy.ClientCreator
+	if addrs := config.ProxyAppQueryAddrs(); len(addrs) > 0 {
+		replicas := make([]proxy.ClientCreator, len(addrs))
+		for i, addr := range addrs {
+			replicas[i] = proxy.NewRemoteClientCreator(addr, config.ABCI, true, opts...)
+		}
+		query = proxy.NewPoolClientCreator(replicas, config.ProxyAppQueryHealthCheckInterval)
+	}
+	if config.ProxyAppSnapshot != "" {
+		snapshot = proxy.NewRemoteClientCreator(config.ProxyAppSnapshot, config.ABCI, false, opts...)
+	}
+	return proxy.NewRoutingClientCreator(primary, query, snapshot), nil
+}
+
+// abciClientOptions returns the options of the clients of a remote ABCI
+// application: mutual TLS if abci_tls_cert_file, abci_tls_key_file and
+// abci_tls_ca_file are set, and abci_max_msg_size.
+func abciClientOptions(config *cfg.Config) ([]abcicli.ClientOption, error) {
+	opts := []abcicli.ClientOption{abcicli.WithMaxMessageSize(config.ABCIMaxMsgSize)}
+	if !config.IsABCITLSEnabled() {
+		return opts, nil
+	}
+	tlsConfig, err := tmnet.NewClientTLSConfig(config.ABCITLSCertFile(), config.ABCITLSKeyFile(),
+		config.ABCITLSCAFile(), config.ABCITLSServerName, config.ABCITLSAllowedCNs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the ABCI TLS config: %w", err)
+	}
+	return append(opts, abcicli.WithTLS(tlsConfig)), nil
+}
+
+// createABCIRecorder returns a recorder of the ABCI requests and responses
+// appending to abci_record_file, or nil if it isn't set.
+func createABCIRecorder(config *cfg.Config) (*abcicli.Recorder, error) {
+	recordFile := config.ABCIRecordFile()
+	if recordFile == "" {
+		return nil, nil
+	}
+	recorder, err := abcicli.NewRecorder(recordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the ABCI record file: %w", err)
+	}
+	return recorder, nil
+}
+
+// createSignerAuditLog returns the audit log of the requests served by the
+// private validator at priv_validator_audit_log_file, or nil if it isn't set.
+func createSignerAuditLog(config *cfg.Config) (*privval.SignerAuditLog, error) {
+	auditLogFile := config.PrivValidatorAuditLogFile()
+	if auditLogFile == "" {
+		return nil, nil
+	}
+	auditLog, err := privval.OpenSignerAuditLog(auditLogFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the private validator audit log: %w", err)
+	}
+	return auditLog, nil
+}
+
+// CreateAndStartPrivValidatorClient returns a client of the external signing
+// process listened for on priv_validator_laddr, or of the cluster of signers
+// if it lists several addresses.
+func CreateAndStartPrivValidatorClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	listenAddrs := config.PrivValidatorListenAddrs()
+	if len(listenAddrs) > 1 {
+		return CreateAndStartPrivValidatorCluster(listenAddrs, config.PrivValidatorClusterThreshold(),
+			config.PrivValidatorStateFile(), chainID, logger)
+	}
+	return CreateAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, chainID, logger)
+}
+
+// CreateAndStartPrivValidatorCluster listens for the signers of a cluster on
+// each address, and returns a client requiring threshold of them to agree on
+// each signature. The unavailable signers are skipped instead of retried. The
+// last sign state of the cluster is saved to stateFilePath.
+func CreateAndStartPrivValidatorCluster(
+	listenAddrs []string,
+	threshold int,
+	stateFilePath string,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	signers := make([]types.PrivValidator, 0, len(listenAddrs))
+	for _, listenAddr := range listenAddrs {
+		pve, err := privval.NewSignerListener(listenAddr, logger.With("signer", listenAddr))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		pvsc, err := privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		signers = append(signers, pvsc)
+	}
+
+	const timeout = 5 * time.Second
+	cluster, err := privval.NewSignerCluster(signers, threshold, timeout, stateFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// try to get a pubkey from the cluster first time
+	_, err = cluster.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+	return cluster, nil
+}
+
+// isPEXEnabled returns true if the node gossips peers, which a validator
+// behind sentries never does.
+func isPEXEnabled(config *cfg.P2PConfig) bool {
+	return config.PexReactor && config.Topology != cfg.TopologyValidatorBehindSentries
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 	"syscall"
@@ -34,6 +35,9 @@ import (
 	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
 	"github.com/Finschia/ostracon/privval"
 	"github.com/Finschia/ostracon/proxy"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/store"
 	"github.com/Finschia/ostracon/types"
@@ -693,3 +697,29 @@ func NewInvalidNode(config *cfg.Config,
 
 	return n, nil
 }
+
+func TestProbeStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"status": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
+			return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 42}}, nil
+		}, ""),
+	}, log.TestingLogger())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go rpcserver.Serve(l, mux, log.TestingLogger(), rpcserver.DefaultConfig()) //nolint:errcheck // ignore for tests
+	t.Cleanup(func() { l.Close() })
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+
+	// the advertised host is replaced by the IP of the peer, whatever it is
+	for _, host := range []string{"0.0.0.0", "10.0.0.5", "internal.example.com"} {
+		nodeInfo := p2p.DefaultNodeInfo{Other: p2p.DefaultNodeInfoOther{RPCAddress: "tcp://" + host + ":" + port}}
+		height, err := probeStatus(context.Background(), nodeInfo, net.IP{127, 0, 0, 1})
+		require.NoError(t, err, host)
+		assert.EqualValues(t, 42, height, host)
+	}
+
+	_, err = probeStatus(context.Background(), p2p.DefaultNodeInfo{}, net.IP{127, 0, 0, 1})
+	assert.Error(t, err, "no RPC address")
+}
@@ -0,0 +1,320 @@
+package banlist
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+)
+
+var banListKey = []byte("banList")
+
+// node IDs are the hex encoding of 20 bytes (see p2p.ID)
+const idByteLength = 20
+
+// Policy tells if the peers matching a rule are allowed or denied.
+type Policy string
+
+const (
+	// PolicyAllow allows the matching peers.
+	PolicyAllow Policy = "allow"
+	// PolicyDeny denies the matching peers.
+	PolicyDeny Policy = "deny"
+)
+
+// ValidateBasic returns an error if the policy is unknown.
+func (p Policy) ValidateBasic() error {
+	switch p {
+	case PolicyAllow, PolicyDeny:
+		return nil
+	default:
+		return fmt.Errorf("unknown policy %q, must be %q or %q", p, PolicyAllow, PolicyDeny)
+	}
+}
+
+// Rule allows or denies the peers matching its target, which is a node ID, an
+// IP address or a CIDR (e.g. "10.0.0.0/8").
+type Rule struct {
+	Target  string    `json:"target"`
+	Policy  Policy    `json:"policy"`
+	Reason  string    `json:"reason,omitempty"`
+	Created time.Time `json:"created"`
+	// the rule doesn't apply anymore after Expires, unless it's zero
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// ValidateBasic returns an error if the target or the policy is malformed.
+func (r Rule) ValidateBasic() error {
+	if _, _, err := parseTarget(r.Target); err != nil {
+		return err
+	}
+	return r.Policy.ValidateBasic()
+}
+
+// Expired returns true if the rule doesn't apply anymore at t.
+func (r Rule) Expired(t time.Time) bool {
+	return !r.Expires.IsZero() && !t.Before(r.Expires)
+}
+
+// ErrDenied is returned when a peer is denied.
+type ErrDenied struct {
+	// the deny rule matching the peer, or nil if it was denied by default
+	Rule *Rule
+}
+
+func (e ErrDenied) Error() string {
+	if e.Rule == nil {
+		return "denied by the default policy"
+	}
+	if e.Rule.Reason != "" {
+		return fmt.Sprintf("denied by the rule of %s: %s", e.Rule.Target, e.Rule.Reason)
+	}
+	return fmt.Sprintf("denied by the rule of %s", e.Rule.Target)
+}
+
+// BanList is a list of rules allowing or denying peers, with a default policy
+// for the peers matching none of them. It's saved to the DB on every change.
+//
+// A deny rule takes precedence over an allow rule, so that a peer can be
+// banned out of an allowed IP range.
+type BanList struct {
+	mtx           tmsync.RWMutex
+	db            dbm.DB
+	defaultPolicy Policy
+	rules         map[string]Rule // by Target
+}
+
+// banListJSON is how the BanList is saved to the DB.
+type banListJSON struct {
+	DefaultPolicy Policy `json:"default_policy"`
+	Rules         []Rule `json:"rules"`
+}
+
+// NewBanList returns the BanList saved in the DB, or an empty one allowing
+// all the peers by default.
+func NewBanList(db dbm.DB) (*BanList, error) {
+	bl := &BanList{
+		db:            db,
+		defaultPolicy: PolicyAllow,
+		rules:         make(map[string]Rule),
+	}
+	bz, err := db.Get(banListKey)
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return bl, nil
+	}
+
+	var blJSON banListJSON
+	if err := json.Unmarshal(bz, &blJSON); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the ban list: %w", err)
+	}
+	if err := blJSON.DefaultPolicy.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	bl.defaultPolicy = blJSON.DefaultPolicy
+	for _, rule := range blJSON.Rules {
+		if err := rule.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid rule of %s: %w", rule.Target, err)
+		}
+		bl.rules[rule.Target] = rule
+	}
+	return bl, nil
+}
+
+// DefaultPolicy returns the policy of the peers matching no rule.
+func (bl *BanList) DefaultPolicy() Policy {
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+	return bl.defaultPolicy
+}
+
+// SetDefaultPolicy sets the policy of the peers matching no rule. With
+// PolicyDeny, only the peers matching an allow rule are allowed.
+func (bl *BanList) SetDefaultPolicy(policy Policy) error {
+	if err := policy.ValidateBasic(); err != nil {
+		return err
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	prev := bl.defaultPolicy
+	bl.defaultPolicy = policy
+	if err := bl.save(); err != nil {
+		bl.defaultPolicy = prev
+		return err
+	}
+	return nil
+}
+
+// SetRule adds the rule, replacing the one of the same target if any.
+func (bl *BanList) SetRule(rule Rule) error {
+	if err := rule.ValidateBasic(); err != nil {
+		return err
+	}
+	rule.Target = normalizeTarget(rule.Target)
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	prev, hadPrev := bl.rules[rule.Target]
+	bl.rules[rule.Target] = rule
+	if err := bl.save(); err != nil {
+		if hadPrev {
+			bl.rules[rule.Target] = prev
+		} else {
+			delete(bl.rules, rule.Target)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveRule removes the rule of the target, and returns false if there
+// wasn't any.
+func (bl *BanList) RemoveRule(target string) (bool, error) {
+	target = normalizeTarget(target)
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	prev, ok := bl.rules[target]
+	if !ok {
+		return false, nil
+	}
+	delete(bl.rules, target)
+	if err := bl.save(); err != nil {
+		bl.rules[target] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// Rules returns the rules which haven't expired, sorted by target. The
+// expired ones are removed.
+func (bl *BanList) Rules() []Rule {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	now := time.Now()
+	rules := make([]Rule, 0, len(bl.rules))
+	expired := false
+	for target, rule := range bl.rules {
+		if rule.Expired(now) {
+			delete(bl.rules, target)
+			expired = true
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if expired {
+		// failing to save only leaves the expired rules in the DB
+		_ = bl.save()
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Target < rules[j].Target })
+	return rules
+}
+
+// Check returns ErrDenied if the peer with the node ID and the IPs is denied.
+//
+// An empty ID means it's not known yet (e.g. when filtering an incoming
+// connection), in which case the peer is denied only by the rules of its IPs,
+// not by the default policy.
+func (bl *BanList) Check(id string, ips []net.IP) error {
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+
+	var (
+		now     = time.Now()
+		allowed = false
+	)
+	for _, rule := range bl.rules {
+		if rule.Expired(now) || !rule.matches(id, ips) {
+			continue
+		}
+		if rule.Policy == PolicyDeny {
+			rule := rule
+			return ErrDenied{Rule: &rule}
+		}
+		allowed = true
+	}
+	if !allowed && id != "" && bl.defaultPolicy == PolicyDeny {
+		return ErrDenied{}
+	}
+	return nil
+}
+
+func (r Rule) matches(id string, ips []net.IP) bool {
+	ruleID, ipNet, err := parseTarget(r.Target)
+	if err != nil {
+		// rejected by ValidateBasic
+		return false
+	}
+	if ipNet == nil {
+		return id != "" && id == ruleID
+	}
+	for _, ip := range ips {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// CONTRACT: bl.mtx is locked.
+func (bl *BanList) save() error {
+	blJSON := banListJSON{
+		DefaultPolicy: bl.defaultPolicy,
+		Rules:         make([]Rule, 0, len(bl.rules)),
+	}
+	for _, rule := range bl.rules {
+		blJSON.Rules = append(blJSON.Rules, rule)
+	}
+	sort.Slice(blJSON.Rules, func(i, j int) bool { return blJSON.Rules[i].Target < blJSON.Rules[j].Target })
+	bz, err := json.Marshal(blJSON)
+	if err != nil {
+		return err
+	}
+	return bl.db.SetSync(banListKey, bz)
+}
+
+// parseTarget returns either the node ID or the network of the target.
+func parseTarget(target string) (id string, ipNet *net.IPNet, err error) {
+	target = normalizeTarget(target)
+	if target == "" {
+		return "", nil, errors.New("empty target")
+	}
+	if strings.Contains(target, "/") {
+		_, ipNet, err := net.ParseCIDR(target)
+		if err != nil {
+			return "", nil, err
+		}
+		return "", ipNet, nil
+	}
+	if ip := net.ParseIP(target); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return "", &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	bz, err := hex.DecodeString(target)
+	if err != nil {
+		return "", nil, fmt.Errorf("target %q is neither a node ID, an IP address nor a CIDR", target)
+	}
+	if len(bz) != idByteLength {
+		return "", nil, fmt.Errorf("invalid node ID length %d, must be %d bytes", len(bz), idByteLength)
+	}
+	return target, nil, nil
+}
+
+func normalizeTarget(target string) string {
+	return strings.ToLower(strings.TrimSpace(target))
+}
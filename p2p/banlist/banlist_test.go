@@ -0,0 +1,112 @@
+package banlist
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	testID      = "f9baeaa15fedf5e1ef7448dd60f46c01f1a9e9c4"
+	otherTestID = "0491d373a8e0fcf1023aaf18c51d6a1d0d4f31bd"
+)
+
+func TestRuleValidateBasic(t *testing.T) {
+	testCases := []struct {
+		target string
+		policy Policy
+		valid  bool
+	}{
+		{testID, PolicyDeny, true},
+		{"F9BAEAA15FEDF5E1EF7448DD60F46C01F1A9E9C4", PolicyAllow, true},
+		{"127.0.0.1", PolicyDeny, true},
+		{"::1", PolicyDeny, true},
+		{"10.0.0.0/8", PolicyDeny, true},
+		{"fd00::/8", PolicyAllow, true},
+		{"", PolicyDeny, false},
+		{"f9baeaa1", PolicyDeny, false},
+		{"not-a-target", PolicyDeny, false},
+		{"10.0.0.0/33", PolicyDeny, false},
+		{testID, Policy("ban"), false},
+	}
+	for _, tc := range testCases {
+		err := Rule{Target: tc.target, Policy: tc.policy}.ValidateBasic()
+		if tc.valid {
+			assert.NoError(t, err, tc.target)
+		} else {
+			assert.Error(t, err, tc.target)
+		}
+	}
+}
+
+func TestBanListCheck(t *testing.T) {
+	bl, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	ip := net.ParseIP("10.1.2.3")
+	otherIP := net.ParseIP("192.168.0.1")
+	assert.NoError(t, bl.Check(testID, []net.IP{ip}))
+
+	// by node ID
+	require.NoError(t, bl.SetRule(Rule{Target: testID, Policy: PolicyDeny, Reason: "spam"}))
+	err = bl.Check(testID, []net.IP{otherIP})
+	require.Error(t, err)
+	assert.Equal(t, testID, err.(ErrDenied).Rule.Target)
+	assert.Contains(t, err.Error(), "spam")
+	assert.NoError(t, bl.Check("", []net.IP{otherIP}), "the ID isn't known yet")
+	assert.NoError(t, bl.Check(otherTestID, []net.IP{otherIP}))
+
+	// by CIDR, a deny rule taking precedence over an allow rule
+	require.NoError(t, bl.SetRule(Rule{Target: "10.0.0.0/8", Policy: PolicyDeny}))
+	require.NoError(t, bl.SetRule(Rule{Target: otherTestID, Policy: PolicyAllow}))
+	assert.Error(t, bl.Check("", []net.IP{ip}))
+	assert.Error(t, bl.Check(otherTestID, []net.IP{ip}))
+	assert.NoError(t, bl.Check(otherTestID, []net.IP{otherIP}))
+
+	// by default
+	removed, err := bl.RemoveRule("10.0.0.0/8")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	require.NoError(t, bl.SetDefaultPolicy(PolicyDeny))
+	assert.NoError(t, bl.Check(otherTestID, []net.IP{ip}), "allowed by its rule")
+	assert.Equal(t, ErrDenied{}, bl.Check("0000000000000000000000000000000000000000", []net.IP{ip}))
+	assert.NoError(t, bl.Check("", []net.IP{ip}), "the ID isn't known yet")
+
+	// expired rules don't apply
+	require.NoError(t, bl.SetDefaultPolicy(PolicyAllow))
+	require.NoError(t, bl.SetRule(Rule{Target: "192.168.0.1", Policy: PolicyDeny, Expires: time.Now().Add(-time.Second)}))
+	assert.NoError(t, bl.Check("", []net.IP{otherIP}))
+}
+
+func TestBanListPersistence(t *testing.T) {
+	db := dbm.NewMemDB()
+	bl, err := NewBanList(db)
+	require.NoError(t, err)
+	require.NoError(t, bl.SetDefaultPolicy(PolicyDeny))
+	require.NoError(t, bl.SetRule(Rule{Target: "10.0.0.0/8", Policy: PolicyAllow}))
+	require.NoError(t, bl.SetRule(Rule{Target: " " + testID + " ", Policy: PolicyDeny, Reason: "spam"}))
+	require.NoError(t, bl.SetRule(Rule{Target: "127.0.0.1", Policy: PolicyDeny, Expires: time.Now().Add(-time.Second)}))
+	assert.Error(t, bl.SetRule(Rule{Target: "foo", Policy: PolicyDeny}))
+
+	removed, err := bl.RemoveRule("127.0.0.2")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	bl, err = NewBanList(db)
+	require.NoError(t, err)
+	assert.Equal(t, PolicyDeny, bl.DefaultPolicy())
+	rules := bl.Rules()
+	require.Len(t, rules, 2, "the expired rule is removed")
+	assert.Equal(t, "10.0.0.0/8", rules[0].Target)
+	assert.Equal(t, PolicyAllow, rules[0].Policy)
+	assert.Equal(t, testID, rules[1].Target)
+	assert.Equal(t, "spam", rules[1].Reason)
+
+	bl, err = NewBanList(db)
+	require.NoError(t, err)
+	assert.Len(t, bl.Rules(), 2)
+}
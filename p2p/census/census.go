@@ -0,0 +1,331 @@
+package census
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmbytes "github.com/Finschia/ostracon/libs/bytes"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/p2p"
+)
+
+const (
+	// number of reachability probes kept by node
+	maxProbes = 10
+
+	// how long the status of a node may be probed
+	probeTimeout = 5 * time.Second
+
+	// number of statuses probed at the same time
+	maxConcurrentProbes = 16
+
+	// how often the nodes past the retention are pruned
+	pruneInterval = time.Hour
+)
+
+// prefix of the nodes, by ID
+var nodeKeyPrefix = []byte("node/")
+
+func nodeKey(id p2p.ID) []byte {
+	return append(append([]byte{}, nodeKeyPrefix...), id...)
+}
+
+// StatusProber returns the latest block height of a node, e.g. by calling the
+// status RPC advertised in its NodeInfo. ip is the address it was connected
+// from; as NodeInfo is untrusted, the prober should only request this IP.
+type StatusProber func(ctx context.Context, nodeInfo p2p.DefaultNodeInfo, ip net.IP) (int64, error)
+
+// Probe is an attempt to connect to a node.
+type Probe struct {
+	Time      time.Time `json:"time"`
+	Reachable bool      `json:"reachable"`
+}
+
+// Node is a node discovered while crawling the network.
+type Node struct {
+	ID              p2p.ID              `json:"id"`
+	ListenAddr      string              `json:"listen_addr"`
+	Network         string              `json:"network"`
+	Version         string              `json:"version"`
+	ProtocolVersion p2p.ProtocolVersion `json:"protocol_version"`
+	Moniker         string              `json:"moniker"`
+	Channels        tmbytes.HexBytes    `json:"channels"`
+	RPCAddress      string              `json:"rpc_address"`
+	// as of LatestHeightTime, zero if the status was never probed
+	LatestHeight     int64     `json:"latest_height"`
+	LatestHeightTime time.Time `json:"latest_height_time"`
+	FirstSeen        time.Time `json:"first_seen"`
+	LastSeen         time.Time `json:"last_seen"`
+	// most recent probes first
+	Probes []Probe `json:"probes"`
+}
+
+// Reachable returns true if the node could be connected to the last time it
+// was dialed.
+func (n Node) Reachable() bool {
+	return len(n.Probes) > 0 && n.Probes[0].Reachable
+}
+
+// lastActive returns when the node was last seen or dialed.
+func (n Node) lastActive() time.Time {
+	last := n.FirstSeen
+	if n.LastSeen.After(last) {
+		last = n.LastSeen
+	}
+	if len(n.Probes) > 0 && n.Probes[0].Time.After(last) {
+		last = n.Probes[0].Time
+	}
+	return last
+}
+
+func (n *Node) addProbe(probe Probe) {
+	n.Probes = append([]Probe{probe}, n.Probes...)
+	if len(n.Probes) > maxProbes {
+		n.Probes = n.Probes[:maxProbes]
+	}
+}
+
+// Census keeps track of the nodes discovered by a seed node crawling the
+// network: their NodeInfo, their latest height and whether they can be
+// dialed. It's saved to the DB on every change, and the nodes neither seen nor
+// dialed for the retention are pruned.
+type Census struct {
+	mtx       tmsync.RWMutex
+	db        dbm.DB
+	nodes     map[p2p.ID]*Node
+	prober    StatusProber
+	probes    chan struct{} // semaphore of the running probes
+	retention time.Duration // 0 if the nodes are kept forever
+	lastPrune time.Time
+	metrics   *p2p.Metrics
+
+	// versions labelling the metrics, to reset the ones without nodes anymore
+	versions map[string]struct{}
+}
+
+// NewCensus returns the census saved in the DB, pruning the nodes past the
+// retention (0 keeps them forever). The prober is optional, the heights aren't
+// probed without it.
+func NewCensus(
+	db dbm.DB,
+	prober StatusProber,
+	retention time.Duration,
+	metrics *p2p.Metrics,
+) (*Census, error) {
+	c := &Census{
+		db:        db,
+		nodes:     make(map[p2p.ID]*Node),
+		prober:    prober,
+		probes:    make(chan struct{}, maxConcurrentProbes),
+		retention: retention,
+		metrics:   metrics,
+		versions:  make(map[string]struct{}),
+	}
+
+	iter, err := dbm.IteratePrefix(db, nodeKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		node := new(Node)
+		if err := json.Unmarshal(iter.Value(), node); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node %s: %w", iter.Key()[len(nodeKeyPrefix):], err)
+		}
+		c.nodes[node.ID] = node
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	if err := c.prune(time.Now()); err != nil {
+		return nil, err
+	}
+	c.updateMetrics()
+	return c, nil
+}
+
+// RecordPeer records the NodeInfo of a connected peer, probing its latest
+// height. An outbound peer is recorded as reachable. It blocks while probing,
+// so it should be called in a goroutine. At most maxConcurrentProbes peers are
+// probed at the same time: if they are all busy, the peer is recorded without
+// probing it, keeping the height of its last probe.
+func (c *Census) RecordPeer(peer p2p.Peer) error {
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("unexpected NodeInfo type %T", peer.NodeInfo())
+	}
+
+	var (
+		probed   bool
+		height   int64
+		probeErr error
+	)
+	if c.prober != nil {
+		select {
+		case c.probes <- struct{}{}:
+			ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+			height, probeErr = c.prober(ctx, nodeInfo, peer.RemoteIP())
+			cancel()
+			<-c.probes
+			probed = true
+		default:
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	node := c.getOrCreate(nodeInfo.ID(), now)
+	node.ListenAddr = nodeInfo.ListenAddr
+	node.Network = nodeInfo.Network
+	node.Version = nodeInfo.Version
+	node.ProtocolVersion = nodeInfo.ProtocolVersion
+	node.Moniker = nodeInfo.Moniker
+	node.Channels = nodeInfo.Channels
+	node.RPCAddress = nodeInfo.Other.RPCAddress
+	node.LastSeen = now
+	if probed && probeErr == nil {
+		node.LatestHeight = height
+		node.LatestHeightTime = now
+	}
+	if peer.IsOutbound() {
+		node.addProbe(Probe{Time: now, Reachable: true})
+	}
+
+	if err := c.save(node, now); err != nil {
+		return err
+	}
+	if probeErr != nil {
+		return fmt.Errorf("failed to probe the status of %v: %w", nodeInfo.ID(), probeErr)
+	}
+	return nil
+}
+
+// RecordUnreachable records that the address could not be dialed.
+func (c *Census) RecordUnreachable(addr *p2p.NetAddress) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	node := c.getOrCreate(addr.ID, now)
+	if node.ListenAddr == "" {
+		node.ListenAddr = addr.DialString()
+	}
+	node.addProbe(Probe{Time: now, Reachable: false})
+	return c.save(node, now)
+}
+
+// Nodes returns the nodes of the census, sorted by ID.
+func (c *Census) Nodes() []Node {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	nodes := make([]Node, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		n := *node
+		n.Probes = append([]Probe{}, node.Probes...)
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// CONTRACT: c.mtx is locked.
+func (c *Census) getOrCreate(id p2p.ID, now time.Time) *Node {
+	node, ok := c.nodes[id]
+	if !ok {
+		node = &Node{ID: id, FirstSeen: now}
+		c.nodes[id] = node
+	}
+	return node
+}
+
+// CONTRACT: c.mtx is locked.
+func (c *Census) save(node *Node, now time.Time) error {
+	bz, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	if err := c.db.Set(nodeKey(node.ID), bz); err != nil {
+		return err
+	}
+	if now.Sub(c.lastPrune) >= pruneInterval {
+		if err := c.prune(now); err != nil {
+			return err
+		}
+	}
+	c.updateMetrics()
+	return nil
+}
+
+// prune deletes the nodes neither seen nor dialed for the retention.
+//
+// CONTRACT: c.mtx is locked.
+func (c *Census) prune(now time.Time) error {
+	c.lastPrune = now
+	if c.retention == 0 {
+		return nil
+	}
+
+	var pruned []p2p.ID
+	batch := c.db.NewBatch()
+	defer batch.Close()
+	for id, node := range c.nodes {
+		if now.Sub(node.lastActive()) > c.retention {
+			if err := batch.Delete(nodeKey(id)); err != nil {
+				return err
+			}
+			pruned = append(pruned, id)
+		}
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for _, id := range pruned {
+		delete(c.nodes, id)
+	}
+	return nil
+}
+
+// CONTRACT: c.mtx is locked.
+func (c *Census) updateMetrics() {
+	var (
+		byVersion = make(map[string]int)
+		reachable = 0
+		maxHeight int64
+	)
+	for _, node := range c.nodes {
+		if node.Version != "" {
+			byVersion[node.Version]++
+		}
+		if node.Reachable() {
+			reachable++
+		}
+		if node.LatestHeight > maxHeight {
+			maxHeight = node.LatestHeight
+		}
+	}
+
+	for version := range c.versions {
+		if _, ok := byVersion[version]; !ok {
+			c.metrics.CensusNodes.With("version", version).Set(0)
+		}
+	}
+	for version, n := range byVersion {
+		c.metrics.CensusNodes.With("version", version).Set(float64(n))
+		c.versions[version] = struct{}{}
+	}
+	c.metrics.CensusReachableNodes.Set(float64(reachable))
+	c.metrics.CensusLatestHeight.Set(float64(maxHeight))
+}
@@ -0,0 +1,201 @@
+package census
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/mock"
+)
+
+// testPeer is a mock peer advertising a version and an RPC address
+type testPeer struct {
+	*mock.Peer
+	version string
+}
+
+func newTestPeer(version string, outbound bool) testPeer {
+	p := mock.NewPeer(net.IP{127, 0, 0, 1})
+	p.Outbound = outbound
+	return testPeer{Peer: p, version: version}
+}
+
+func (p testPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.Network = "testing"
+	ni.Version = p.version
+	ni.Moniker = "moniker-" + p.version
+	ni.Channels = []byte{0x20, 0x30}
+	ni.Other.RPCAddress = "tcp://0.0.0.0:26657"
+	return ni
+}
+
+func TestCensusRecordPeer(t *testing.T) {
+	var probedIP net.IP
+	prober := func(ctx context.Context, nodeInfo p2p.DefaultNodeInfo, ip net.IP) (int64, error) {
+		probedIP = ip
+		if nodeInfo.Version == "0.1.0" {
+			return 0, errors.New("connection refused")
+		}
+		return 42, nil
+	}
+	c, err := NewCensus(dbm.NewMemDB(), prober, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	outbound := newTestPeer("0.2.0", true)
+	require.NoError(t, c.RecordPeer(outbound))
+	assert.Equal(t, net.IP{127, 0, 0, 1}, probedIP)
+	inbound := newTestPeer("0.1.0", false)
+	assert.Error(t, c.RecordPeer(inbound), "the probe failed")
+
+	nodes := c.Nodes()
+	require.Len(t, nodes, 2)
+	byID := map[p2p.ID]Node{nodes[0].ID: nodes[0], nodes[1].ID: nodes[1]}
+
+	node := byID[outbound.ID()]
+	assert.Equal(t, "0.2.0", node.Version)
+	assert.Equal(t, "testing", node.Network)
+	assert.Equal(t, "moniker-0.2.0", node.Moniker)
+	assert.Equal(t, "tcp://0.0.0.0:26657", node.RPCAddress)
+	assert.EqualValues(t, []byte{0x20, 0x30}, node.Channels)
+	assert.EqualValues(t, 42, node.LatestHeight)
+	assert.False(t, node.FirstSeen.IsZero())
+	assert.True(t, node.Reachable())
+
+	node = byID[inbound.ID()]
+	assert.Equal(t, "0.1.0", node.Version)
+	assert.Zero(t, node.LatestHeight)
+	assert.True(t, node.LatestHeightTime.IsZero())
+	assert.Empty(t, node.Probes, "an inbound peer isn't dialed")
+	assert.False(t, node.Reachable())
+}
+
+func TestCensusReachability(t *testing.T) {
+	c, err := NewCensus(dbm.NewMemDB(), nil, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	peer := newTestPeer("0.2.0", true)
+	addr := peer.SocketAddr()
+	require.NoError(t, c.RecordUnreachable(addr))
+	nodes := c.Nodes()
+	require.Len(t, nodes, 1)
+	assert.Equal(t, addr.DialString(), nodes[0].ListenAddr)
+	assert.Empty(t, nodes[0].Version)
+	assert.False(t, nodes[0].Reachable())
+
+	require.NoError(t, c.RecordPeer(peer))
+	nodes = c.Nodes()
+	assert.True(t, nodes[0].Reachable())
+	assert.Len(t, nodes[0].Probes, 2)
+
+	for i := 0; i < maxProbes; i++ {
+		require.NoError(t, c.RecordUnreachable(addr))
+	}
+	nodes = c.Nodes()
+	assert.Len(t, nodes[0].Probes, maxProbes)
+	assert.False(t, nodes[0].Reachable())
+	assert.Equal(t, "0.2.0", nodes[0].Version, "the NodeInfo is kept")
+}
+
+func TestCensusPersistence(t *testing.T) {
+	db := dbm.NewMemDB()
+	c, err := NewCensus(db, nil, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+	peers := []testPeer{newTestPeer("0.1.0", true), newTestPeer("0.2.0", false)}
+	for _, peer := range peers {
+		require.NoError(t, c.RecordPeer(peer))
+	}
+	nodes := c.Nodes()
+
+	c, err = NewCensus(db, nil, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+	restored := c.Nodes()
+	require.Len(t, restored, 2)
+	for i := range nodes {
+		assert.Equal(t, nodes[i].ID, restored[i].ID)
+		assert.Equal(t, nodes[i].Version, restored[i].Version)
+		assert.Equal(t, nodes[i].Reachable(), restored[i].Reachable())
+		assert.True(t, nodes[i].FirstSeen.Equal(restored[i].FirstSeen))
+	}
+}
+
+func TestCensusPrune(t *testing.T) {
+	db := dbm.NewMemDB()
+	c, err := NewCensus(db, nil, time.Hour, p2p.NopMetrics())
+	require.NoError(t, err)
+	stale, fresh := newTestPeer("0.1.0", true), newTestPeer("0.2.0", true)
+	require.NoError(t, c.RecordPeer(stale))
+	require.NoError(t, c.RecordPeer(fresh))
+
+	// the stale node was last seen and dialed two hours ago
+	node := c.nodes[stale.ID()]
+	node.FirstSeen = node.FirstSeen.Add(-2 * time.Hour)
+	node.LastSeen = node.LastSeen.Add(-2 * time.Hour)
+	node.Probes[0].Time = node.Probes[0].Time.Add(-2 * time.Hour)
+	require.NoError(t, c.save(node, time.Now()))
+
+	// pruned when the census is loaded
+	c, err = NewCensus(db, nil, time.Hour, p2p.NopMetrics())
+	require.NoError(t, err)
+	nodes := c.Nodes()
+	require.Len(t, nodes, 1)
+	assert.Equal(t, fresh.ID(), nodes[0].ID)
+	has, err := db.Has(nodeKey(stale.ID()))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// and on the first change after the prune interval
+	require.NoError(t, c.RecordPeer(stale))
+	require.NoError(t, c.RecordPeer(fresh))
+	assert.Len(t, c.Nodes(), 2)
+	require.NoError(t, c.RecordUnreachable(stale.SocketAddr()))
+	assert.Len(t, c.Nodes(), 2, "not pruned before the prune interval")
+	c.lastPrune = c.lastPrune.Add(-pruneInterval)
+	future := time.Now().Add(90 * time.Minute)
+	c.mtx.Lock()
+	require.NoError(t, c.save(c.nodes[fresh.ID()], future))
+	c.mtx.Unlock()
+	assert.Empty(t, c.Nodes())
+}
+
+func TestCensusBoundedProbes(t *testing.T) {
+	var (
+		probing = make(chan struct{})
+		release = make(chan struct{})
+	)
+	prober := func(ctx context.Context, nodeInfo p2p.DefaultNodeInfo, ip net.IP) (int64, error) {
+		probing <- struct{}{}
+		<-release
+		return 42, nil
+	}
+	c, err := NewCensus(dbm.NewMemDB(), prober, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	// fill all the probe slots
+	errs := make(chan error, maxConcurrentProbes)
+	for i := 0; i < maxConcurrentProbes; i++ {
+		go func() { errs <- c.RecordPeer(newTestPeer("0.2.0", true)) }()
+		<-probing
+	}
+
+	// the next peer is recorded without being probed
+	peer := newTestPeer("0.2.0", true)
+	require.NoError(t, c.RecordPeer(peer))
+	nodes := c.Nodes()
+	require.Len(t, nodes, 1)
+	assert.Equal(t, peer.ID(), nodes[0].ID)
+	assert.Zero(t, nodes[0].LatestHeight)
+
+	close(release)
+	for i := 0; i < maxConcurrentProbes; i++ {
+		require.NoError(t, <-errs)
+	}
+	assert.Len(t, c.Nodes(), maxConcurrentProbes+1)
+}
@@ -33,6 +33,9 @@ const (
 	minWriteBufferSize = 65536
 	updateStats        = 2 * time.Second
 
+	// retry to send the packets of rate limited channels after this interval
+	throttleRetryInterval = 100 * time.Millisecond
+
 	// some of these defaults are written in the user config
 	// flushThrottle, sendRate, recvRate
 	// TODO: remove values present in config
@@ -106,8 +109,9 @@ type MConnection struct {
 	// are safe to call concurrently.
 	stopMtx tmsync.Mutex
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // resume sending when rate limited channels can send again.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
@@ -139,6 +143,11 @@ type MConnConfig struct {
 
 	// Action method of reactor's receive function
 	RecvAsync bool `mapstructure:"recv_async"`
+
+	// Rates at which packets can be sent and received on given channels, in
+	// bytes/second. Channels not listed are only limited by SendRate/RecvRate.
+	ChannelSendRates map[byte]int64 `mapstructure:"channel_send_rates"`
+	ChannelRecvRates map[byte]int64 `mapstructure:"channel_recv_rates"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -228,6 +237,7 @@ func (c *MConnection) OnStart() error {
 		return err
 	}
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", throttleRetryInterval)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.pongTimeoutCh = make(chan bool, 1)
 	c.chStatsTimer = time.NewTicker(updateStats)
@@ -262,6 +272,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 
 	c.BaseService.OnStop()
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -488,6 +499,16 @@ FOR_LOOP:
 				default:
 				}
 			}
+		case <-c.throttleTimer.Ch:
+			// Send the PacketMsgs of rate limited channels
+			eof := c.sendSomePacketMsgs()
+			if !eof {
+				// Keep sendRoutine awake.
+				select {
+				case c.send <- struct{}{}:
+				default:
+				}
+			}
 		}
 
 		if !c.IsRunning() {
@@ -528,11 +549,17 @@ func (c *MConnection) sendPacketMsg() bool {
 	// The chosen channel will be the one whose recentlySent/priority is the least.
 	var leastRatio float32 = math.MaxFloat32
 	var leastChannel *Channel
+	var throttled bool
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		// If the channel exceeds its rate, skip it until the next sample
+		if !channel.canSendPacketMsg() {
+			throttled = true
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
 		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
 		if ratio < leastRatio {
@@ -543,6 +570,9 @@ func (c *MConnection) sendPacketMsg() bool {
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if throttled {
+			c.throttleTimer.Set()
+		}
 		return true
 	}
 	// c.Logger.Info("Found a msgPacket to send")
@@ -640,6 +670,12 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 
+			// Drop the messages of the channel over its receive rate, not to
+			// delay the packets of the other channels behind it.
+			if channel.updateRecvStats(_n, *pkt.PacketMsg) {
+				continue
+			}
+
 			msgBytes, err := channel.recvPacketMsg(*pkt.PacketMsg)
 			if err != nil {
 				if c.IsRunning() {
@@ -702,6 +738,16 @@ type ChannelStatus struct {
 	SendQueueSize     int
 	Priority          int
 	RecentlySent      int64
+
+	// Added by Ostracon
+	SendBytes int64 // total bytes of the packets sent
+	RecvBytes int64 // total bytes of the packets received
+	SendMsgs  int64 // total number of the messages sent
+	RecvMsgs  int64 // total number of the messages received
+	SendRate  int64 // rate limit of sending in bytes/second, 0 if unlimited
+	RecvRate  int64 // rate limit of receiving in bytes/second, 0 if unlimited
+
+	RecvDroppedMsgs int64 // total number of the messages dropped over RecvRate
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -717,6 +763,13 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			SendBytes:         atomic.LoadInt64(&channel.bytesSent),
+			RecvBytes:         atomic.LoadInt64(&channel.bytesRecv),
+			SendMsgs:          atomic.LoadInt64(&channel.msgsSent),
+			RecvMsgs:          atomic.LoadInt64(&channel.msgsRecv),
+			SendRate:          channel.sendRate,
+			RecvRate:          channel.recvRate,
+			RecvDroppedMsgs:   atomic.LoadInt64(&channel.msgsRecvDropped),
 		}
 	}
 	return status
@@ -731,6 +784,11 @@ type ChannelDescriptor struct {
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
 	MessageType         proto.Message
+
+	// DropsOverRecvRate opts the channel into a receive rate: the messages
+	// received over it are dropped, so it is only for the channels whose
+	// reactors tolerate losing messages, such as the mempool gossip.
+	DropsOverRecvRate bool
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -758,6 +816,19 @@ type Channel struct {
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	// Added by Ostracon
+	bytesSent   int64 // atomic.
+	bytesRecv   int64 // atomic.
+	msgsSent    int64 // atomic.
+	msgsRecv    int64 // atomic.
+	sendRate    int64 // 0 if unlimited
+	recvRate    int64 // 0 if unlimited
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
+	msgsRecvDropped int64 // atomic.
+	dropping        bool  // whether the packets of the message being received are dropped
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -768,11 +839,19 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	if desc.Priority <= 0 {
 		panic("Channel default priority must be a positive integer")
 	}
+	var recvRate int64
+	if desc.DropsOverRecvRate {
+		recvRate = conn.config.ChannelRecvRates[desc.ID]
+	}
 	return &Channel{
 		conn:                    conn,
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendRate:                conn.config.ChannelSendRates[desc.ID],
+		recvRate:                recvRate,
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -841,6 +920,7 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 		packet.EOF = true
 		ch.sending = nil
 		atomic.AddInt32(&ch.sendQueueSize, -1) // decrement sendQueueSize
+		atomic.AddInt64(&ch.msgsSent, 1)
 	} else {
 		packet.EOF = false
 		ch.sending = ch.sending[tmmath.MinInt(maxSize, len(ch.sending)):]
@@ -854,9 +934,38 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	atomic.AddInt64(&ch.bytesSent, int64(n))
+	ch.sendMonitor.Update(n)
 	return
 }
 
+// Returns true if the channel is under its send rate in the current sample.
+// Not goroutine-safe
+func (ch *Channel) canSendPacketMsg() bool {
+	return ch.sendMonitor.Limit(ch.conn._maxPacketMsgSize, ch.sendRate, false) > 0
+}
+
+// Accounts n bytes of a PacketMsg received and returns true if it must be
+// dropped. A message starting while the channel is over its receive rate is
+// dropped as a whole, without blocking.
+// Not goroutine-safe
+func (ch *Channel) updateRecvStats(n int, packet tmp2p.PacketMsg) (drop bool) {
+	atomic.AddInt64(&ch.bytesRecv, int64(n))
+	ch.recvMonitor.Update(n)
+	if len(ch.recving) == 0 && !ch.dropping && ch.recvRate > 0 {
+		ch.dropping = ch.recvMonitor.Limit(ch.conn._maxPacketMsgSize, ch.recvRate, false) == 0
+	}
+	if !ch.dropping {
+		return false
+	}
+	if packet.EOF {
+		ch.dropping = false
+		atomic.AddInt64(&ch.msgsRecvDropped, 1)
+		ch.Logger.Debug("Dropped a message over the receive rate", "conn", ch.conn, "chID", ch.desc.ID)
+	}
+	return true
+}
+
 // Handles incoming PacketMsgs. It returns a message bytes if message is
 // complete. NOTE message bytes may change on next call to recvPacketMsg.
 // Not goroutine-safe
@@ -869,6 +978,7 @@ func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	ch.recving = append(ch.recving, packet.Data...)
 	if packet.EOF {
 		msgBytes := ch.recving
+		atomic.AddInt64(&ch.msgsRecv, 1)
 
 		// clear the slice without re-allocating.
 		// http://stackoverflow.com/questions/16971741/how-do-you-clear-a-slice-in-go
@@ -164,6 +164,126 @@ func TestMConnectionStatus(t *testing.T) {
 	assert.Zero(t, status.Channels[0].SendQueueSize)
 }
 
+func TestMConnectionChannelRates(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	type received struct {
+		chID byte
+		at   time.Time
+	}
+	receivedCh := make(chan received, 100)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- received{chID, time.Now()}
+	}
+	onError := func(r interface{}) {}
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 100},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 100},
+	}
+
+	// 0x02 can send about two packets per sample of 100ms
+	cfg := DefaultMConnConfig()
+	cfg.ChannelSendRates = map[byte]int64{0x02: 20000}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, func(byte, []byte) {}, onError, cfg)
+	mconn1.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	defer mconn1.Stop() // nolint:errcheck // ignore for tests
+
+	mconn2 := NewMConnectionWithConfig(server, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconn2.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn2.Start())
+	defer mconn2.Stop() // nolint:errcheck // ignore for tests
+
+	start := time.Now()
+	numMsgs := 10
+	for i := 0; i < numMsgs; i++ {
+		require.True(t, mconn1.Send(0x02, make([]byte, 1000)))
+	}
+	require.True(t, mconn1.Send(0x01, []byte("unlimited")))
+
+	var lastLimited, unlimited time.Time
+	for i := 0; i < numMsgs+1; i++ {
+		select {
+		case r := <-receivedCh:
+			if r.chID == 0x01 {
+				unlimited = r.at
+			} else {
+				lastLimited = r.at
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Did not receive all the messages in 5s")
+		}
+	}
+	// the messages of the limited channel are spread over several samples
+	// without delaying the unlimited channel
+	assert.True(t, lastLimited.Sub(start) >= 300*time.Millisecond, lastLimited.Sub(start))
+	assert.True(t, unlimited.Before(lastLimited))
+
+	status := mconn1.Status()
+	assert.EqualValues(t, 1, status.Channels[0].SendMsgs)
+	assert.EqualValues(t, numMsgs, status.Channels[1].SendMsgs)
+	assert.Greater(t, status.Channels[1].SendBytes, int64(numMsgs*1000))
+	assert.Zero(t, status.Channels[0].SendRate)
+	assert.EqualValues(t, 20000, status.Channels[1].SendRate)
+
+	status = mconn2.Status()
+	assert.EqualValues(t, 1, status.Channels[0].RecvMsgs)
+	assert.EqualValues(t, numMsgs, status.Channels[1].RecvMsgs)
+	assert.Equal(t, mconn1.Status().Channels[1].SendBytes, status.Channels[1].RecvBytes)
+}
+
+func TestMConnectionChannelRecvRate(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	onReceive := func(chID byte, msgBytes []byte) {}
+	onError := func(r interface{}) {}
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 100, DropsOverRecvRate: true},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 100},
+		{ID: 0x03, Priority: 1, SendQueueCapacity: 100},
+	}
+
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconn1.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	defer mconn1.Stop() // nolint:errcheck // ignore for tests
+
+	// 0x01 can receive about two packets per sample of 100ms, while the rate
+	// of 0x03 is ignored since it doesn't opt into dropping messages
+	cfg := DefaultMConnConfig()
+	cfg.ChannelRecvRates = map[byte]int64{0x01: 20000, 0x03: 20000}
+	mconn2 := NewMConnectionWithConfig(server, chDescs, onReceive, onError, cfg)
+	mconn2.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn2.Start())
+	defer mconn2.Stop() // nolint:errcheck // ignore for tests
+
+	numMsgs := 10
+	for i := 0; i < numMsgs; i++ {
+		require.True(t, mconn1.Send(0x01, make([]byte, 1000)))
+		require.True(t, mconn1.Send(0x03, make([]byte, 1000)))
+	}
+	require.True(t, mconn1.Send(0x02, []byte("unlimited")))
+
+	// the messages of the limited channel over its rate are dropped without
+	// delaying the unlimited channel
+	assert.Eventually(t, func() bool {
+		status := mconn2.Status()
+		return status.Channels[0].RecvMsgs+status.Channels[0].RecvDroppedMsgs == int64(numMsgs) &&
+			status.Channels[1].RecvMsgs == 1 &&
+			status.Channels[2].RecvMsgs == int64(numMsgs)
+	}, 5*time.Second, 10*time.Millisecond)
+	status := mconn2.Status()
+	assert.Positive(t, status.Channels[0].RecvDroppedMsgs)
+	assert.Equal(t, mconn1.Status().Channels[0].SendBytes, status.Channels[0].RecvBytes)
+	assert.EqualValues(t, 1, status.Channels[1].RecvMsgs)
+	assert.Zero(t, status.Channels[1].RecvDroppedMsgs)
+	assert.Zero(t, status.Channels[2].RecvDroppedMsgs)
+}
+
 func TestMConnectionPongTimeoutResultsInError(t *testing.T) {
 	server, client := net.Pipe()
 	defer server.Close()
@@ -38,6 +38,22 @@ const (
 	aeadSizeOverhead = 16 // overhead of poly 1305 authentication tag
 	aeadKeySize      = chacha20poly1305.KeySize
 	aeadNonceSize    = chacha20poly1305.NonceSize
+
+	// rekeyFlag is set in the length of the last frame sealed with a key,
+	// after which the sender and the receiver rotate the key.
+	rekeyFlag = uint32(1) << 31
+)
+
+const (
+	// HandshakeSTS is the original handshake of SecretConnection, used unless
+	// both peers enable Noise_XX.
+	HandshakeSTS = "sts"
+	// HandshakeNoiseXX is the Noise_XX_25519_ChaChaPoly_SHA256 handshake,
+	// which also rotates the keys of the connection periodically.
+	HandshakeNoiseXX = "noise_xx"
+
+	// versions of the handshake offered after the ephemeral public key
+	handshakeVersionNoiseXX = byte(0x01)
 )
 
 var (
@@ -63,8 +79,9 @@ var (
 type SecretConnection struct {
 
 	// immutable
-	recvAead cipher.AEAD
-	sendAead cipher.AEAD
+	handshake     string
+	rekeyBytes    int64
+	rekeyInterval time.Duration
 
 	remPubKey crypto.PubKey
 	conn      io.ReadWriteCloser
@@ -79,9 +96,39 @@ type SecretConnection struct {
 	recvMtx    tmsync.Mutex
 	recvBuffer []byte
 	recvNonce  *[aeadNonceSize]byte
+	recvAead   cipher.AEAD
+	recvKey    *[aeadKeySize]byte // nil if the keys are never rotated
+
+	sendMtx     tmsync.Mutex
+	sendNonce   *[aeadNonceSize]byte
+	sendAead    cipher.AEAD
+	sendKey     *[aeadKeySize]byte // nil if the keys are never rotated
+	sendBytes   int64              // sent with sendKey
+	sendKeyTime time.Time          // when sendKey started to be used
+}
 
-	sendMtx   tmsync.Mutex
-	sendNonce *[aeadNonceSize]byte
+// SecretConnectionConfig configures the handshake and the key rotation of a
+// SecretConnection.
+type SecretConnectionConfig struct {
+	// Offer the Noise_XX handshake, used if the peer offers it too. Otherwise,
+	// the STS handshake is used.
+	Noise bool
+
+	// Once the Noise_XX handshake is done, each peer rotates the key it sends
+	// with after RekeyBytes bytes or RekeyInterval, whichever comes first.
+	// Zero disables the limit.
+	RekeyBytes    int64
+	RekeyInterval time.Duration
+}
+
+// DefaultSecretConnectionConfig returns the default config, which keeps the
+// STS handshake.
+func DefaultSecretConnectionConfig() SecretConnectionConfig {
+	return SecretConnectionConfig{
+		Noise:         false,
+		RekeyBytes:    1 << 30, // 1GB
+		RekeyInterval: 10 * time.Minute,
+	}
 }
 
 // MakeSecretConnection performs handshake and returns a new authenticated
@@ -90,9 +137,27 @@ type SecretConnection struct {
 // Caller should call conn.Close()
 // See docs/sts-final.pdf for more information.
 func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*SecretConnection, error) {
+	return MakeSecretConnectionWithConfig(conn, locPrivKey, DefaultSecretConnectionConfig())
+}
+
+// MakeSecretConnectionWithConfig is like MakeSecretConnection, but negotiates
+// the Noise_XX handshake if both peers enable it in the config.
+//
+// The handshakes are negotiated with the first message of the STS handshake:
+// the versions a peer offers are appended to its ephemeral public key, which
+// older nodes ignore and answer without offering any.
+func MakeSecretConnectionWithConfig(
+	conn io.ReadWriteCloser,
+	locPrivKey crypto.PrivKey,
+	config SecretConnectionConfig,
+) (*SecretConnection, error) {
 	var (
-		locPubKey = locPrivKey.PubKey()
+		locPubKey   = locPrivKey.PubKey()
+		locVersions []byte
 	)
+	if config.Noise {
+		locVersions = append(locVersions, handshakeVersionNoiseXX)
+	}
 
 	// Generate ephemeral keys for perfect forward secrecy.
 	locEphPub, locEphPriv := genEphKeys()
@@ -100,7 +165,7 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	// Write local ephemeral pubkey and receive one too.
 	// NOTE: every 32-byte string is accepted as a Curve25519 public key (see
 	// DJB's Curve25519 paper: http://cr.yp.to/ecdh/curve25519-20060209.pdf)
-	remEphPub, err := shareEphPubKey(conn, locEphPub)
+	remEphPub, remVersions, err := shareEphPubKey(conn, locEphPub, locVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +173,19 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	// Sort by lexical order.
 	loEphPub, hiEphPub := sort32(locEphPub, remEphPub)
 
+	if bytes.IndexByte(locVersions, handshakeVersionNoiseXX) >= 0 &&
+		bytes.IndexByte(remVersions, handshakeVersionNoiseXX) >= 0 {
+		// The lesser ephemeral public key initiates, and both offers are bound
+		// to the handshake so that they can't be tampered with.
+		initiator := bytes.Equal(locEphPub[:], loEphPub[:])
+		loOffer, hiOffer := append(locEphPub[:], locVersions...), append(remEphPub[:], remVersions...)
+		if !initiator {
+			loOffer, hiOffer = hiOffer, loOffer
+		}
+		prologue := append(loOffer, hiOffer...)
+		return makeNoiseSecretConnection(conn, locPrivKey, initiator, prologue, config)
+	}
+
 	transcript := merlin.NewTranscript("TENDERMINT_SECRET_CONNECTION_TRANSCRIPT_HASH")
 
 	transcript.AppendMessage(labelEphemeralLowerPublicKey, loEphPub[:])
@@ -136,23 +214,11 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 
 	copy(challenge[:], challengeSlice[0:challengeSize])
 
-	sendAead, err := chacha20poly1305.New(sendSecret[:])
-	if err != nil {
-		return nil, errors.New("invalid send SecretConnection Key")
-	}
-	recvAead, err := chacha20poly1305.New(recvSecret[:])
+	sc, err := newSecretConnection(conn, recvSecret, sendSecret)
 	if err != nil {
-		return nil, errors.New("invalid receive SecretConnection Key")
-	}
-
-	sc := &SecretConnection{
-		conn:       conn,
-		recvBuffer: nil,
-		recvNonce:  new([aeadNonceSize]byte),
-		sendNonce:  new([aeadNonceSize]byte),
-		recvAead:   recvAead,
-		sendAead:   sendAead,
+		return nil, err
 	}
+	sc.handshake = HandshakeSTS
 
 	// Sign the challenge bytes for authentication.
 	locSignature, err := signChallenge(&challenge, locPrivKey)
@@ -179,11 +245,40 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	return sc, nil
 }
 
+func newSecretConnection(
+	conn io.ReadWriteCloser,
+	recvSecret, sendSecret *[aeadKeySize]byte,
+) (*SecretConnection, error) {
+	sendAead, err := chacha20poly1305.New(sendSecret[:])
+	if err != nil {
+		return nil, errors.New("invalid send SecretConnection Key")
+	}
+	recvAead, err := chacha20poly1305.New(recvSecret[:])
+	if err != nil {
+		return nil, errors.New("invalid receive SecretConnection Key")
+	}
+
+	return &SecretConnection{
+		conn:       conn,
+		recvBuffer: nil,
+		recvNonce:  new([aeadNonceSize]byte),
+		sendNonce:  new([aeadNonceSize]byte),
+		recvAead:   recvAead,
+		sendAead:   sendAead,
+	}, nil
+}
+
 // RemotePubKey returns authenticated remote pubkey
 func (sc *SecretConnection) RemotePubKey() crypto.PubKey {
 	return sc.remPubKey
 }
 
+// Handshake returns the handshake negotiated with the peer, HandshakeSTS or
+// HandshakeNoiseXX.
+func (sc *SecretConnection) Handshake() string {
+	return sc.handshake
+}
+
 // Writes encrypted frames of `totalFrameSize + aeadSizeOverhead`.
 // CONTRACT: data smaller than dataMaxSize is written atomically.
 func (sc *SecretConnection) Write(data []byte) (n int, err error) {
@@ -206,8 +301,12 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 				chunk = data
 				data = nil
 			}
-			chunkLength := len(chunk)
-			binary.LittleEndian.PutUint32(frame, uint32(chunkLength))
+			chunkLength := uint32(len(chunk))
+			rekey := sc.sendRekeyDue()
+			if rekey {
+				chunkLength |= rekeyFlag
+			}
+			binary.LittleEndian.PutUint32(frame, chunkLength)
 			copy(frame[dataLenSize:], chunk)
 
 			// encrypt the frame
@@ -215,6 +314,14 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 			incrNonce(sc.sendNonce)
 			// end encryption
 
+			// rotate the key once the frame telling the peer is sealed
+			if rekey {
+				if err := sc.rekeySend(); err != nil {
+					return err
+				}
+			}
+			sc.sendBytes += int64(len(chunk))
+
 			_, err = sc.conn.Write(sealedFrame)
 			if err != nil {
 				return err
@@ -262,6 +369,13 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	// copy checkLength worth into data,
 	// set recvBuffer to the rest.
 	var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
+	if chunkLength&rekeyFlag != 0 && sc.recvKey != nil {
+		// the peer rotated its key after this frame
+		chunkLength &^= rekeyFlag
+		if err := sc.rekeyRecv(); err != nil {
+			return 0, err
+		}
+	}
 	if chunkLength > dataMaxSize {
 		return 0, errors.New("chunkLength is greater than dataMaxSize")
 	}
@@ -286,6 +400,54 @@ func (sc *SecretConnection) SetWriteDeadline(t time.Time) error {
 	return sc.conn.(net.Conn).SetWriteDeadline(t)
 }
 
+// sendRekeyDue returns true if the send key has to be rotated.
+// CONTRACT: sendMtx is held.
+func (sc *SecretConnection) sendRekeyDue() bool {
+	if sc.sendKey == nil {
+		return false
+	}
+	return (sc.rekeyBytes > 0 && sc.sendBytes >= sc.rekeyBytes) ||
+		(sc.rekeyInterval > 0 && time.Since(sc.sendKeyTime) >= sc.rekeyInterval)
+}
+
+// CONTRACT: sendMtx is held.
+func (sc *SecretConnection) rekeySend() (err error) {
+	sc.sendAead, err = rekey(sc.sendKey)
+	if err != nil {
+		return err
+	}
+	sc.sendNonce = new([aeadNonceSize]byte)
+	sc.sendBytes = 0
+	sc.sendKeyTime = time.Now()
+	return nil
+}
+
+// CONTRACT: recvMtx is held.
+func (sc *SecretConnection) rekeyRecv() (err error) {
+	sc.recvAead, err = rekey(sc.recvKey)
+	if err != nil {
+		return err
+	}
+	sc.recvNonce = new([aeadNonceSize]byte)
+	return nil
+}
+
+// rekey replaces the key with the next one, derived like the REKEY function of
+// the Noise protocol, and returns the AEAD of the new key. The previous keys
+// can't be recovered from it, so that a leaked key doesn't reveal the
+// traffic sent before.
+func rekey(key *[aeadKeySize]byte) (cipher.AEAD, error) {
+	aead, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+	var nonce [aeadNonceSize]byte
+	binary.LittleEndian.PutUint64(nonce[4:], math.MaxUint64)
+	sealed := aead.Seal(nil, nonce[:], make([]byte, aeadKeySize), nil)
+	copy(key[:], sealed[:aeadKeySize])
+	return chacha20poly1305.New(key[:])
+}
+
 func genEphKeys() (ephPub, ephPriv *[32]byte) {
 	var err error
 	// TODO: Probably not a problem but ask Tony: different from the rust implementation (uses x25519-dalek),
@@ -298,13 +460,19 @@ func genEphKeys() (ephPub, ephPriv *[32]byte) {
 	return
 }
 
-func shareEphPubKey(conn io.ReadWriter, locEphPub *[32]byte) (remEphPub *[32]byte, err error) {
+// shareEphPubKey exchanges the ephemeral public keys, followed by the versions
+// of the handshake each peer offers.
+func shareEphPubKey(
+	conn io.ReadWriter,
+	locEphPub *[32]byte,
+	locVersions []byte,
+) (remEphPub *[32]byte, remVersions []byte, err error) {
 
 	// Send our pubkey and receive theirs in tandem.
 	var trs, _ = async.Parallel(
 		func(_ int) (val interface{}, abort bool, err error) {
 			lc := *locEphPub
-			_, err = protoio.NewDelimitedWriter(conn).WriteMsg(&gogotypes.BytesValue{Value: lc[:]})
+			_, err = protoio.NewDelimitedWriter(conn).WriteMsg(&gogotypes.BytesValue{Value: append(lc[:], locVersions...)})
 			if err != nil {
 				return nil, true, err // abort
 			}
@@ -317,9 +485,7 @@ func shareEphPubKey(conn io.ReadWriter, locEphPub *[32]byte) (remEphPub *[32]byt
 				return nil, true, err // abort
 			}
 
-			var _remEphPub [32]byte
-			copy(_remEphPub[:], bytes.Value)
-			return _remEphPub, false, nil
+			return bytes.Value, false, nil
 		},
 	)
 
@@ -330,8 +496,13 @@ func shareEphPubKey(conn io.ReadWriter, locEphPub *[32]byte) (remEphPub *[32]byt
 	}
 
 	// Otherwise:
-	var _remEphPub = trs.FirstValue().([32]byte)
-	return &_remEphPub, nil
+	var _remEphPub [32]byte
+	var value = trs.FirstValue().([]byte)
+	copy(_remEphPub[:], value)
+	if len(value) > len(_remEphPub) {
+		remVersions = value[len(_remEphPub):]
+	}
+	return &_remEphPub, remVersions, nil
 }
 
 func deriveSecrets(
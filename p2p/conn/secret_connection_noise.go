@@ -0,0 +1,182 @@
+package conn
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/flynn/noise"
+	gogotypes "github.com/gogo/protobuf/types"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
+	"github.com/Finschia/ostracon/libs/protoio"
+)
+
+var (
+	noiseCipherSuite = noise.NewCipherSuite(noise.DH25519, noise.CipherChaChaPoly, noise.HashSHA256)
+
+	// prefix of the static key of the Noise_XX handshake signed by the node key
+	labelNoiseStaticKey = []byte("OSTRACON_SECRET_CONNECTION_NOISE_STATIC_KEY")
+)
+
+// makeNoiseSecretConnection performs the Noise_XX_25519_ChaChaPoly_SHA256
+// handshake:
+//
+//	-> e
+//	<- e, ee, s, es
+//	-> s, se
+//
+// The static keys are generated for each connection, and the peers
+// authenticate them in the payloads of the last two messages with a signature
+// of their node keys.
+func makeNoiseSecretConnection(
+	conn io.ReadWriteCloser,
+	locPrivKey crypto.PrivKey,
+	initiator bool,
+	prologue []byte,
+	config SecretConnectionConfig,
+) (*SecretConnection, error) {
+	staticKey, err := noiseCipherSuite.GenerateKeypair(crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	hs, err := noise.NewHandshakeState(noise.Config{
+		CipherSuite:   noiseCipherSuite,
+		Random:        crand.Reader,
+		Pattern:       noise.HandshakeXX,
+		Initiator:     initiator,
+		Prologue:      prologue,
+		StaticKeypair: staticKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locPayload, err := noiseAuthPayload(locPrivKey, staticKey.Public)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		remPayload []byte
+		cs1, cs2   *noise.CipherState
+	)
+	if initiator {
+		if _, _, err = writeNoiseMessage(conn, hs, nil); err != nil {
+			return nil, err
+		}
+		if remPayload, _, _, err = readNoiseMessage(conn, hs); err != nil {
+			return nil, err
+		}
+		if cs1, cs2, err = writeNoiseMessage(conn, hs, locPayload); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, _, _, err = readNoiseMessage(conn, hs); err != nil {
+			return nil, err
+		}
+		if _, _, err = writeNoiseMessage(conn, hs, locPayload); err != nil {
+			return nil, err
+		}
+		if remPayload, cs1, cs2, err = readNoiseMessage(conn, hs); err != nil {
+			return nil, err
+		}
+	}
+	if cs1 == nil || cs2 == nil {
+		return nil, errors.New("noise handshake didn't complete")
+	}
+
+	remPubKey, err := verifyNoiseAuthPayload(remPayload, hs.PeerStatic())
+	if err != nil {
+		return nil, err
+	}
+
+	// The first cipher state is the one of the initiator.
+	recvSecret, sendSecret := cs2.UnsafeKey(), cs1.UnsafeKey()
+	if !initiator {
+		recvSecret, sendSecret = sendSecret, recvSecret
+	}
+	sc, err := newSecretConnection(conn, &recvSecret, &sendSecret)
+	if err != nil {
+		return nil, err
+	}
+	sc.handshake = HandshakeNoiseXX
+	sc.remPubKey = remPubKey
+	sc.rekeyBytes = config.RekeyBytes
+	sc.rekeyInterval = config.RekeyInterval
+	sc.recvKey = &recvSecret
+	sc.sendKey = &sendSecret
+	sc.sendKeyTime = time.Now()
+	return sc, nil
+}
+
+func writeNoiseMessage(
+	conn io.Writer,
+	hs *noise.HandshakeState,
+	payload []byte,
+) (cs1, cs2 *noise.CipherState, err error) {
+	msg, cs1, cs2, err := hs.WriteMessage(nil, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = protoio.NewDelimitedWriter(conn).WriteMsg(&gogotypes.BytesValue{Value: msg})
+	return cs1, cs2, err
+}
+
+func readNoiseMessage(
+	conn io.Reader,
+	hs *noise.HandshakeState,
+) (payload []byte, cs1, cs2 *noise.CipherState, err error) {
+	var msg gogotypes.BytesValue
+	if _, err = protoio.NewDelimitedReader(conn, 1024*1024).ReadMsg(&msg); err != nil {
+		return nil, nil, nil, err
+	}
+	payload, cs1, cs2, err = hs.ReadMessage(nil, msg.Value)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("noise handshake failed: %w", err)
+	}
+	return payload, cs1, cs2, nil
+}
+
+// noiseAuthPayload returns the node pubkey and its signature of the static key.
+func noiseAuthPayload(locPrivKey crypto.PrivKey, staticPubKey []byte) ([]byte, error) {
+	sig, err := locPrivKey.Sign(noiseStaticKeySignBytes(staticPubKey))
+	if err != nil {
+		return nil, err
+	}
+	pbpk, err := cryptoenc.PubKeyToProto(locPrivKey.PubKey())
+	if err != nil {
+		return nil, err
+	}
+	msg := tmp2p.AuthSigMessage{PubKey: pbpk, Sig: sig}
+	return msg.Marshal()
+}
+
+// verifyNoiseAuthPayload returns the node pubkey of the peer if it signed the
+// static key of the peer.
+func verifyNoiseAuthPayload(payload, remStaticPubKey []byte) (crypto.PubKey, error) {
+	var pba tmp2p.AuthSigMessage
+	if err := pba.Unmarshal(payload); err != nil {
+		return nil, err
+	}
+	remPubKey, err := cryptoenc.PubKeyFromProto(&pba.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := remPubKey.(ed25519.PubKey); !ok {
+		return nil, fmt.Errorf("expected ed25519 pubkey, got %T", remPubKey)
+	}
+	if !remPubKey.VerifySignature(noiseStaticKeySignBytes(remStaticPubKey), pba.Sig) {
+		return nil, errors.New("static key verification failed")
+	}
+	return remPubKey, nil
+}
+
+func noiseStaticKeySignBytes(staticPubKey []byte) []byte {
+	return append(append([]byte{}, labelNoiseStaticKey...), staticPubKey...)
+}
@@ -13,7 +13,9 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/flynn/noise"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -259,6 +261,121 @@ func TestDeriveSecretsAndChallengeGolden(t *testing.T) {
 	}
 }
 
+func TestSecretConnectionNegotiateHandshake(t *testing.T) {
+	noiseConfig := DefaultSecretConnectionConfig()
+	noiseConfig.Noise = true
+
+	testCases := []struct {
+		name      string
+		fooConfig SecretConnectionConfig
+		barConfig SecretConnectionConfig
+		expected  string
+	}{
+		{"default", DefaultSecretConnectionConfig(), DefaultSecretConnectionConfig(), HandshakeSTS},
+		{"noise and default", noiseConfig, DefaultSecretConnectionConfig(), HandshakeSTS},
+		{"default and noise", DefaultSecretConnectionConfig(), noiseConfig, HandshakeSTS},
+		{"noise", noiseConfig, noiseConfig, HandshakeNoiseXX},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fooSecConn, barSecConn := makeSecretConnPairWithConfig(t, tc.fooConfig, tc.barConfig)
+			defer fooSecConn.Close()
+			defer barSecConn.Close()
+			assert.Equal(t, tc.expected, fooSecConn.Handshake())
+			assert.Equal(t, tc.expected, barSecConn.Handshake())
+
+			go func() {
+				_, err := fooSecConn.Write([]byte("hello"))
+				assert.NoError(t, err)
+			}()
+			buf := make([]byte, dataMaxSize)
+			n, err := barSecConn.Read(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(buf[:n]))
+		})
+	}
+}
+
+func TestSecretConnectionRekey(t *testing.T) {
+	config := DefaultSecretConnectionConfig()
+	config.Noise = true
+	config.RekeyBytes = 3 * dataMaxSize
+	fooSecConn, barSecConn := makeSecretConnPairWithConfig(t, config, config)
+	defer fooSecConn.Close()
+	defer barSecConn.Close()
+	fooSendKey := *fooSecConn.sendKey
+	assert.Equal(t, fooSendKey, *barSecConn.recvKey)
+
+	data := tmrand.Bytes(10 * dataMaxSize)
+	go func() {
+		_, err := fooSecConn.Write(data)
+		assert.NoError(t, err)
+	}()
+	read := make([]byte, len(data))
+	_, err := io.ReadFull(barSecConn, read)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	// the keys have been rotated in both peers
+	assert.NotEqual(t, fooSendKey, *fooSecConn.sendKey)
+	assert.Equal(t, *fooSecConn.sendKey, *barSecConn.recvKey)
+
+	// and after some time
+	fooSecConn.sendMtx.Lock()
+	fooSecConn.rekeyBytes = 0
+	fooSecConn.rekeyInterval = time.Millisecond
+	fooSecConn.sendMtx.Unlock()
+	fooSendKey = *fooSecConn.sendKey
+	time.Sleep(2 * time.Millisecond)
+	go func() {
+		_, err := fooSecConn.Write([]byte("hello"))
+		assert.NoError(t, err)
+	}()
+	read = make([]byte, dataMaxSize)
+	n, err := barSecConn.Read(read)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(read[:n]))
+	assert.NotEqual(t, fooSendKey, *fooSecConn.sendKey)
+	assert.Equal(t, *fooSecConn.sendKey, *barSecConn.recvKey)
+}
+
+func TestRekeyGolden(t *testing.T) {
+	goldenFilepath := filepath.Join("testdata", t.Name()+".golden")
+	if *update {
+		t.Logf("Updating golden test vector file %s", goldenFilepath)
+		data := createRekeyGoldenTestVectors(t)
+		err := tmos.WriteFile(goldenFilepath, []byte(data), 0644)
+		require.NoError(t, err)
+	}
+	f, err := os.Open(goldenFilepath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		params := strings.Split(line, ",")
+		keyVector, err := hex.DecodeString(params[0])
+		require.Nil(t, err)
+		expectedKey, err := hex.DecodeString(params[1])
+		require.Nil(t, err)
+
+		key := new([aeadKeySize]byte)
+		copy(key[:], keyVector)
+		_, err = rekey(key)
+		require.NoError(t, err)
+		require.Equal(t, expectedKey, key[:], "Keys aren't equal")
+
+		// same as the REKEY function of the Noise protocol
+		cs := noise.UnsafeNewCipherState(noiseCipherSuite, [aeadKeySize]byte(keyVector), 0)
+		cs.Rekey()
+		noiseKey := cs.UnsafeKey()
+		require.Equal(t, expectedKey, noiseKey[:], "Keys aren't equal to Noise")
+	}
+}
+
 func TestNilPubkey(t *testing.T) {
 	var fooConn, barConn = makeKVStoreConnPair()
 	defer fooConn.Close()
@@ -326,6 +443,22 @@ func createGoldenTestVectors(t *testing.T) string {
 	return data
 }
 
+// Creates the data for the test vector file of rekey.
+// The file format is:
+// Hex(key), Hex(next key)
+func createRekeyGoldenTestVectors(t *testing.T) string {
+	data := ""
+	for i := 0; i < 32; i++ {
+		key := new([aeadKeySize]byte)
+		copy(key[:], tmrand.Bytes(aeadKeySize))
+		data += hex.EncodeToString(key[:]) + ","
+		_, err := rekey(key)
+		require.NoError(t, err)
+		data += hex.EncodeToString(key[:]) + "\n"
+	}
+	return data
+}
+
 // Each returned ReadWriteCloser is akin to a net.Connection
 func makeKVStoreConnPair() (fooConn, barConn kvstoreConn) {
 	barReader, fooWriter := io.Pipe()
@@ -334,6 +467,13 @@ func makeKVStoreConnPair() (fooConn, barConn kvstoreConn) {
 }
 
 func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection) {
+	return makeSecretConnPairWithConfig(tb, DefaultSecretConnectionConfig(), DefaultSecretConnectionConfig())
+}
+
+func makeSecretConnPairWithConfig(
+	tb testing.TB,
+	fooConfig, barConfig SecretConnectionConfig,
+) (fooSecConn, barSecConn *SecretConnection) {
 	var (
 		fooConn, barConn = makeKVStoreConnPair()
 		fooPrvKey        = ed25519.GenPrivKey()
@@ -345,7 +485,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 	// Make connections from both sides in parallel.
 	var trs, ok = async.Parallel(
 		func(_ int) (val interface{}, abort bool, err error) {
-			fooSecConn, err = MakeSecretConnection(fooConn, fooPrvKey)
+			fooSecConn, err = MakeSecretConnectionWithConfig(fooConn, fooPrvKey, fooConfig)
 			if err != nil {
 				tb.Errorf("failed to establish SecretConnection for foo: %v", err)
 				return nil, true, err
@@ -360,7 +500,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 			return nil, false, nil
 		},
 		func(_ int) (val interface{}, abort bool, err error) {
-			barSecConn, err = MakeSecretConnection(barConn, barPrvKey)
+			barSecConn, err = MakeSecretConnectionWithConfig(barConn, barPrvKey, barConfig)
 			if barSecConn == nil {
 				tb.Errorf("failed to establish SecretConnection for bar: %v", err)
 				return nil, true, err
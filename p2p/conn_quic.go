@@ -0,0 +1,282 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/quic-go/quic-go"
+
+	flow "github.com/Finschia/ostracon/libs/flowrate"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/libs/service"
+	tmconn "github.com/Finschia/ostracon/p2p/conn"
+)
+
+// quicStreamConn is a net.Conn over a stream of a QUIC connection.
+type quicStreamConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = (*quicStreamConn)(nil)
+
+func (c *quicStreamConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicStreamConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close closes both directions of the stream, leaving the connection open.
+func (c *quicStreamConn) Close() error {
+	c.Stream.CancelRead(0)
+	return c.Stream.Close()
+}
+
+// quicRateLimiter limits the transfers of all the streams of a connection
+// together, so that the rates of a peer don't grow with its channels.
+type quicRateLimiter struct {
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	sendRate    int64 // 0 if unlimited
+	recvRate    int64 // 0 if unlimited
+}
+
+func newQUICRateLimiter(sendRate, recvRate int64) *quicRateLimiter {
+	return &quicRateLimiter{
+		sendMonitor: flow.New(0, 0),
+		recvMonitor: flow.New(0, 0),
+		sendRate:    sendRate,
+		recvRate:    recvRate,
+	}
+}
+
+// rateLimitedStreamConn is a stream whose transfers are limited by the
+// quicRateLimiter of its connection.
+type rateLimitedStreamConn struct {
+	net.Conn
+	limiter *quicRateLimiter
+}
+
+// Read blocks until the receive rate of the connection allows reading.
+func (c *rateLimitedStreamConn) Read(p []byte) (int, error) {
+	p = p[:c.limiter.recvMonitor.Limit(len(p), c.limiter.recvRate, true)]
+	n, err := c.Conn.Read(p)
+	c.limiter.recvMonitor.Update(n)
+	return n, err
+}
+
+// Write blocks until the send rate of the connection allows writing all of p.
+func (c *rateLimitedStreamConn) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		chunk := p[written:]
+		chunk = chunk[:c.limiter.sendMonitor.Limit(len(chunk), c.limiter.sendRate, true)]
+		n, err := c.Conn.Write(chunk)
+		c.limiter.sendMonitor.Update(n)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// quicConn is a QUIC connection to a peer. As a net.Conn, it's the stream used
+// for the handshake, and closing it closes the whole connection.
+type quicConn struct {
+	*quicStreamConn
+
+	// stream of each channel both nodes have
+	streams map[byte]*quicStreamConn
+}
+
+func newQUICConn(conn quic.Connection) *quicConn {
+	return &quicConn{
+		quicStreamConn: &quicStreamConn{conn: conn},
+		streams:        map[byte]*quicStreamConn{},
+	}
+}
+
+// Close closes the connection and all its streams.
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+//-----------------------------------------------------------------------------
+
+// streamMConnection runs a MConnection with a single channel on each stream of
+// a QUIC connection, so that a burst on a channel (e.g. block parts) doesn't
+// delay the messages of the others (e.g. votes).
+//
+// The send and receive rates of the MConnConfig apply to the whole connection,
+// shared by the streams.
+type streamMConnection struct {
+	service.BaseService
+
+	conn       *quicConn
+	mconns     []*tmconn.MConnection
+	mconnsByCh map[byte]*tmconn.MConnection
+}
+
+var _ mConnection = (*streamMConnection)(nil)
+
+func createStreamMConnection(
+	conn *quicConn,
+	p *peer,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+	chDescs []*tmconn.ChannelDescriptor,
+	onPeerError func(Peer, interface{}),
+	config tmconn.MConnConfig,
+) *streamMConnection {
+	smc := &streamMConnection{
+		conn:       conn,
+		mconnsByCh: make(map[byte]*tmconn.MConnection, len(chDescs)),
+	}
+	limiter := newQUICRateLimiter(config.SendRate, config.RecvRate)
+	streamConfig := config
+	streamConfig.SendRate, streamConfig.RecvRate = 0, 0
+	for _, chDesc := range chDescs {
+		stream, ok := conn.streams[chDesc.ID]
+		if !ok {
+			// the peer doesn't have the channel
+			continue
+		}
+		mconn := createMConnection(
+			&rateLimitedStreamConn{Conn: stream, limiter: limiter},
+			p,
+			reactorsByCh,
+			msgTypeByChID,
+			[]*tmconn.ChannelDescriptor{chDesc},
+			onPeerError,
+			streamConfig,
+		)
+		smc.mconns = append(smc.mconns, mconn)
+		smc.mconnsByCh[chDesc.ID] = mconn
+	}
+	smc.BaseService = *service.NewBaseService(nil, "StreamMConnection", smc)
+	return smc
+}
+
+// SetLogger implements BaseService.
+func (smc *streamMConnection) SetLogger(l log.Logger) {
+	smc.BaseService.SetLogger(l)
+	for _, mconn := range smc.mconns {
+		mconn.SetLogger(l)
+	}
+}
+
+// OnStart implements BaseService.
+func (smc *streamMConnection) OnStart() error {
+	for _, mconn := range smc.mconns {
+		if err := mconn.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlushStop flushes the streams like MConnection.FlushStop and closes the
+// connection.
+func (smc *streamMConnection) FlushStop() {
+	for _, mconn := range smc.mconns {
+		mconn.FlushStop()
+	}
+	_ = smc.conn.Close()
+}
+
+// OnStop implements BaseService.
+func (smc *streamMConnection) OnStop() {
+	for _, mconn := range smc.mconns {
+		if err := mconn.Stop(); err != nil {
+			smc.Logger.Debug("Error while stopping stream", "err", err)
+		}
+	}
+	_ = smc.conn.Close()
+}
+
+func (smc *streamMConnection) String() string {
+	return fmt.Sprintf("StreamMConn{%v}", smc.conn.RemoteAddr())
+}
+
+// Send queues a message on the stream of the channel like MConnection.Send.
+func (smc *streamMConnection) Send(chID byte, msgBytes []byte) bool {
+	mconn, ok := smc.mconnsByCh[chID]
+	if !ok {
+		smc.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	return mconn.Send(chID, msgBytes)
+}
+
+// TrySend queues a message on the stream of the channel like
+// MConnection.TrySend.
+func (smc *streamMConnection) TrySend(chID byte, msgBytes []byte) bool {
+	mconn, ok := smc.mconnsByCh[chID]
+	if !ok {
+		smc.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	return mconn.TrySend(chID, msgBytes)
+}
+
+// CanSend returns true if the stream of the channel can queue a message.
+func (smc *streamMConnection) CanSend(chID byte) bool {
+	mconn, ok := smc.mconnsByCh[chID]
+	if !ok {
+		smc.Logger.Error(fmt.Sprintf("Unknown channel %X", chID))
+		return false
+	}
+	return mconn.CanSend(chID)
+}
+
+// Status returns the channels of all the streams, and the sums of their
+// transfers.
+func (smc *streamMConnection) Status() tmconn.ConnectionStatus {
+	var status tmconn.ConnectionStatus
+	for i, mconn := range smc.mconns {
+		mstatus := mconn.Status()
+		if i == 0 {
+			status.Duration = mstatus.Duration
+			status.SendMonitor = mstatus.SendMonitor
+			status.RecvMonitor = mstatus.RecvMonitor
+		} else {
+			status.SendMonitor = addFlowStatus(status.SendMonitor, mstatus.SendMonitor)
+			status.RecvMonitor = addFlowStatus(status.RecvMonitor, mstatus.RecvMonitor)
+		}
+		status.Channels = append(status.Channels, mstatus.Channels...)
+	}
+	return status
+}
+
+// addFlowStatus adds up the transfers of two monitors.
+func addFlowStatus(a, b flow.Status) flow.Status {
+	sum := flow.Status{
+		Start:    a.Start,
+		Bytes:    a.Bytes + b.Bytes,
+		Samples:  a.Samples + b.Samples,
+		InstRate: a.InstRate + b.InstRate,
+		CurRate:  a.CurRate + b.CurRate,
+		AvgRate:  a.AvgRate + b.AvgRate,
+		PeakRate: a.PeakRate + b.PeakRate,
+		BytesRem: a.BytesRem + b.BytesRem,
+		Duration: a.Duration,
+		Idle:     a.Idle,
+		Active:   a.Active || b.Active,
+	}
+	if b.Start.Before(sum.Start) {
+		sum.Start = b.Start
+	}
+	if b.Duration > sum.Duration {
+		sum.Duration = b.Duration
+	}
+	if b.Idle < sum.Idle {
+		sum.Idle = b.Idle
+	}
+	return sum
+}
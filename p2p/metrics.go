@@ -47,6 +47,18 @@ type Metrics struct {
 	NumAbandonedPeerMsgs metrics.Counter
 	// Number of pooled peer messages
 	NumPooledPeerMsgs metrics.Gauge
+	// Number of messages received from a given peer.
+	PeerReceiveMsgsTotal metrics.Counter
+	// Number of messages sent to a given peer.
+	PeerSendMsgsTotal metrics.Counter
+	// Number of connected sentries, primary or backup.
+	SentryPeers metrics.Gauge
+	// Number of nodes in the census of a crawling seed, by version.
+	CensusNodes metrics.Gauge
+	// Number of nodes in the census reachable the last time they were dialed.
+	CensusReachableNodes metrics.Gauge
+	// Highest latest block height probed among the nodes in the census.
+	CensusLatestHeight metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -114,6 +126,42 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "num_pooled_peer_msgs",
 			Help:      "Number of peer messages pooled currently",
 		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerReceiveMsgsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_msgs_total",
+			Help:      "Number of messages received from a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerSendMsgsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_msgs_total",
+			Help:      "Number of messages sent to a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		SentryPeers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sentry_peers",
+			Help:      "Number of connected sentries, primary or backup.",
+		}, append(labels, "backup")).With(labelsAndValues...),
+		CensusNodes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "census_nodes",
+			Help:      "Number of nodes in the census of a crawling seed, by version.",
+		}, append(labels, "version")).With(labelsAndValues...),
+		CensusReachableNodes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "census_reachable_nodes",
+			Help:      "Number of nodes in the census reachable the last time they were dialed.",
+		}, labels).With(labelsAndValues...),
+		CensusLatestHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "census_latest_height",
+			Help:      "Highest latest block height probed among the nodes in the census.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -130,6 +178,12 @@ func NopMetrics() *Metrics {
 		// Added by Ostracon
 		NumAbandonedPeerMsgs: discard.NewCounter(),
 		NumPooledPeerMsgs:    discard.NewGauge(),
+		PeerReceiveMsgsTotal: discard.NewCounter(),
+		PeerSendMsgsTotal:    discard.NewCounter(),
+		SentryPeers:          discard.NewGauge(),
+		CensusNodes:          discard.NewGauge(),
+		CensusReachableNodes: discard.NewGauge(),
+		CensusLatestHeight:   discard.NewGauge(),
 	}
 }
 
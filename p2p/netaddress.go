@@ -20,12 +20,21 @@ import (
 // EmptyNetAddress defines the string representation of an empty NetAddress
 const EmptyNetAddress = "<nil-NetAddress>"
 
+// ProtocolQUIC is the protocol of the addresses to be dialed by the
+// QUICTransport. Addresses without it are dialed over TCP.
+const ProtocolQUIC = "quic"
+
 // NetAddress defines information about a peer on the network
 // including its ID, IP address, and port.
 type NetAddress struct {
 	ID   ID     `json:"id"`
 	IP   net.IP `json:"ip"`
 	Port uint16 `json:"port"`
+
+	// Added by Ostracon
+	// Protocol is ProtocolQUIC for QUIC addresses and empty for TCP ones. It's
+	// not gossiped by PEX.
+	Protocol string `json:"protocol,omitempty"`
 }
 
 // IDAddressString returns id@hostPort. It strips the leading
@@ -36,11 +45,17 @@ func IDAddressString(id ID, protocolHostPort string) string {
 }
 
 // NewNetAddress returns a new NetAddress using the provided TCP
-// address. When testing, other net.Addr (except TCP) will result in
-// using 0.0.0.0:0. When normal run, other net.Addr (except TCP) will
-// panic. Panics if ID is invalid.
+// address, or UDP address of a QUIC connection. When testing, other
+// net.Addr (except TCP and UDP) will result in using 0.0.0.0:0. When normal
+// run, other net.Addr (except TCP and UDP) will panic. Panics if ID is invalid.
 // TODO: socks proxies?
 func NewNetAddress(id ID, addr net.Addr) *NetAddress {
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		na := NewNetAddress(id, &net.TCPAddr{IP: udpAddr.IP, Port: udpAddr.Port})
+		na.Protocol = ProtocolQUIC
+		return na
+	}
+
 	tcpAddr, ok := addr.(*net.TCPAddr)
 	if !ok {
 		if flag.Lookup("test.v") == nil { // normal run
@@ -64,7 +79,7 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 }
 
 // NewNetAddressString returns a new NetAddress using the provided address in
-// the form of "ID@IP:Port".
+// the form of "ID@IP:Port", optionally prefixed by "quic://" for QUIC addresses.
 // Also resolves the host if host is not an IP.
 // Errors are of type ErrNetAddressXxx where Xxx is in (NoID, Invalid, Lookup)
 func NewNetAddressString(addr string) (*NetAddress, error) {
@@ -108,6 +123,9 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 
 	na := NewNetAddressIPPort(ip, uint16(port))
 	na.ID = id
+	if strings.HasPrefix(addr, ProtocolQUIC+"://") {
+		na.Protocol = ProtocolQUIC
+	}
 	return na, nil
 }
 
@@ -207,7 +225,8 @@ func (na *NetAddress) Same(other interface{}) bool {
 	return false
 }
 
-// String representation: <ID>@<IP>:<PORT>
+// String representation: <ID>@<IP>:<PORT>, prefixed by the protocol if it's
+// not TCP.
 func (na *NetAddress) String() string {
 	if na == nil {
 		return EmptyNetAddress
@@ -217,6 +236,9 @@ func (na *NetAddress) String() string {
 	if na.ID != "" {
 		addrStr = IDAddressString(na.ID, addrStr)
 	}
+	if na.Protocol != "" {
+		addrStr = na.Protocol + "://" + addrStr
+	}
 
 	return addrStr
 }
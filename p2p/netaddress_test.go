@@ -43,8 +43,11 @@ func TestNewNetAddress(t *testing.T) {
 	assert.Equal(t, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080", addr.String())
 
 	assert.NotPanics(t, func() {
-		NewNetAddress("", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8000})
-	}, "Calling NewNetAddress with UDPAddr should not panic in testing")
+		NewNetAddress("", &net.UnixAddr{Name: "/tmp/sock", Net: "unix"})
+	}, "Calling NewNetAddress with UnixAddr should not panic in testing")
+
+	addr = NewNetAddress("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8000})
+	assert.Equal(t, "quic://deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8000", addr.String())
 }
 
 func TestNewNetAddressString(t *testing.T) {
@@ -76,6 +79,12 @@ func TestNewNetAddressString(t *testing.T) {
 			"deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080",
 			true,
 		},
+		{
+			"quic input",
+			"quic://deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080",
+			"quic://deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080",
+			true,
+		},
 		{"malformed tcp input", "tcp//deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080", "", false},
 		{"malformed udp input", "udp//deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080", "", false},
 
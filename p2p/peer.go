@@ -164,6 +164,20 @@ func (pc peerConn) RemoteIP() net.IP {
 	return pc.ip
 }
 
+// mConnection is the multiplex connection of a peer: a MConnection over TCP or
+// a streamMConnection over QUIC.
+type mConnection interface {
+	service.Service
+	FlushStop()
+
+	Send(byte, []byte) bool
+	TrySend(byte, []byte) bool
+	CanSend(byte) bool
+	Status() tmconn.ConnectionStatus
+}
+
+var _ mConnection = (*tmconn.MConnection)(nil)
+
 // peer implements Peer.
 //
 // Before using a peer, you will need to perform a handshake on connection.
@@ -172,7 +186,7 @@ type peer struct {
 
 	// raw peerConn and the multiplex connection
 	peerConn
-	mconn *tmconn.MConnection
+	mconn mConnection
 
 	// peer's node info and the channel it knows about
 	// channels = nodeInfo.Channels
@@ -214,15 +228,27 @@ func newPeer(
 		mlc:           mlc,
 	}
 
-	p.mconn = createMConnection(
-		pc.conn,
-		p,
-		reactorsByCh,
-		msgTypeByChID,
-		chDescs,
-		onPeerError,
-		mConfig,
-	)
+	if qc, ok := pc.conn.(*quicConn); ok {
+		p.mconn = createStreamMConnection(
+			qc,
+			p,
+			reactorsByCh,
+			msgTypeByChID,
+			chDescs,
+			onPeerError,
+			mConfig,
+		)
+	} else {
+		p.mconn = createMConnection(
+			pc.conn,
+			p,
+			reactorsByCh,
+			msgTypeByChID,
+			chDescs,
+			onPeerError,
+			mConfig,
+		)
+	}
 	p.BaseService = *service.NewBaseService(nil, "Peer", p)
 	for _, option := range options {
 		option(p)
@@ -361,6 +387,7 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerSendMsgsTotal.With(labels...).Add(1)
 	}
 	return res
 }
@@ -410,6 +437,7 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerSendMsgsTotal.With(labels...).Add(1)
 	}
 	return res
 }
@@ -541,6 +569,7 @@ func createMConnection(
 			}
 		}
 		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerReceiveMsgsTotal.With(labels...).Add(1)
 		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
 		if config.RecvAsync {
 			ch := reactor.GetRecvChan()
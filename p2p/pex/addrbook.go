@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/minio/highwayhash"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/libs/log"
@@ -98,9 +99,14 @@ type addrBook struct {
 	bucketsNew []map[string]*knownAddress
 	nOld       int
 	nNew       int
+	dirty      map[p2p.ID]struct{} // addresses to save to db
+
+	// serializes the writes to db, which are done without holding mtx
+	flushMtx tmsync.Mutex
 
 	// immutable after creation
 	filePath          string
+	db                dbm.DB // optional, replaces the file at filePath
 	key               string // random prefix for bucket placement
 	routabilityStrict bool
 	hashKey           []byte
@@ -130,6 +136,17 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 	return am
 }
 
+// NewDBAddrBook creates a new address book saving the changed addresses to the
+// DB every flushAddressInterval, and when it's stopped.
+// The address book file is migrated to the DB if the DB is empty.
+// Use Start to begin processing asynchronous address updates.
+func NewDBAddrBook(db dbm.DB, filePath string, routabilityStrict bool) AddrBook {
+	am := NewAddrBook(filePath, routabilityStrict).(*addrBook)
+	am.db = db
+	am.dirty = make(map[p2p.ID]struct{})
+	return am
+}
+
 // Initialize the buckets.
 // When modifying this, don't forget to update loadFromFile()
 func (a *addrBook) init() {
@@ -151,7 +168,13 @@ func (a *addrBook) OnStart() error {
 	if err := a.BaseService.OnStart(); err != nil {
 		return err
 	}
-	a.loadFromFile(a.filePath)
+	if a.db != nil {
+		if err := a.loadFromDB(); err != nil {
+			return fmt.Errorf("failed to load AddrBook from DB: %w", err)
+		}
+	} else {
+		a.loadFromFile(a.filePath)
+	}
 
 	// wg.Add to ensure that any invocation of .Wait()
 	// later on will wait for saveRoutine to terminate.
@@ -164,6 +187,8 @@ func (a *addrBook) OnStart() error {
 // OnStop implements Service.
 func (a *addrBook) OnStop() {
 	a.BaseService.OnStop()
+	// so the DB is up to date once stopped
+	a.flush()
 }
 
 func (a *addrBook) Wait() {
@@ -194,12 +219,17 @@ func (a *addrBook) OurAddress(addr *p2p.NetAddress) bool {
 	return ok
 }
 
+// AddPrivateIDs implements AddrBook - it removes the addresses of the IDs
+// already in the book (e.g. loaded from the file), so they're not gossiped.
 func (a *addrBook) AddPrivateIDs(ids []string) {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
 	for _, id := range ids {
 		a.privateIDs[p2p.ID(id)] = struct{}{}
+		if ka := a.addrLookup[p2p.ID(id)]; ka != nil {
+			a.removeAddress(ka.Addr)
+		}
 	}
 }
 
@@ -329,6 +359,7 @@ func (a *addrBook) MarkGood(id p2p.ID) {
 		return
 	}
 	ka.markGood()
+	a.markDirty(id)
 	if ka.isNew() {
 		if err := a.moveToOld(ka); err != nil {
 			a.Logger.Error("Error moving address to old", "err", err)
@@ -346,6 +377,7 @@ func (a *addrBook) MarkAttempt(addr *p2p.NetAddress) {
 		return
 	}
 	ka.markAttempt()
+	a.markDirty(ka.ID())
 }
 
 // MarkBad implements AddrBook. Kicks address out from book, places
@@ -489,24 +521,32 @@ func (a *addrBook) size() int {
 
 // Save persists the address book to disk.
 func (a *addrBook) Save() {
+	if a.db != nil {
+		a.flush() // thread safe
+		return
+	}
 	a.saveToFile(a.filePath) // thread safe
 }
 
 func (a *addrBook) saveRoutine() {
 	defer a.wg.Done()
 
-	saveFileTicker := time.NewTicker(dumpAddressInterval)
+	interval := dumpAddressInterval
+	if a.db != nil {
+		interval = flushAddressInterval
+	}
+	saveFileTicker := time.NewTicker(interval)
 out:
 	for {
 		select {
 		case <-saveFileTicker.C:
-			a.saveToFile(a.filePath)
+			a.Save()
 		case <-a.Quit():
 			break out
 		}
 	}
 	saveFileTicker.Stop()
-	a.saveToFile(a.filePath)
+	a.Save()
 }
 
 //----------------------------------------------------------
@@ -550,6 +590,7 @@ func (a *addrBook) addToNewBucket(ka *knownAddress, bucketIdx int) error {
 	if ka.addBucketRef(bucketIdx) == 1 {
 		a.nNew++
 	}
+	a.markDirty(ka.ID())
 
 	// Add it to addrLookup
 	a.addrLookup[ka.ID()] = ka
@@ -586,6 +627,7 @@ func (a *addrBook) addToOldBucket(ka *knownAddress, bucketIdx int) bool {
 	if ka.addBucketRef(bucketIdx) == 1 {
 		a.nOld++
 	}
+	a.markDirty(ka.ID())
 
 	// Ensure in addrLookup
 	a.addrLookup[ka.ID()] = ka
@@ -600,6 +642,7 @@ func (a *addrBook) removeFromBucket(ka *knownAddress, bucketType byte, bucketIdx
 	}
 	bucket := a.getBucket(bucketType, bucketIdx)
 	delete(bucket, ka.Addr.String())
+	a.markDirty(ka.ID())
 	if ka.removeBucketRef(bucketIdx) == 0 {
 		if bucketType == bucketTypeNew {
 			a.nNew--
@@ -622,6 +665,7 @@ func (a *addrBook) removeFromAllBuckets(ka *knownAddress) {
 		a.nOld--
 	}
 	delete(a.addrLookup, ka.ID())
+	a.markDirty(ka.ID())
 }
 
 //----------------------------------------------------------
@@ -671,6 +715,8 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 
 	ka := a.addrLookup[addr.ID]
 	if ka != nil {
+		ka.markSeen()
+		a.markDirty(ka.ID())
 		// If its already old and the address ID's are the same, ignore it.
 		// Thereby avoiding issues with a node on the network attempting to change
 		// the IP of a known node ID. (Which could yield an eclipse attack on the node)
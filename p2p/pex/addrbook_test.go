@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/libs/log"
 	tmmath "github.com/Finschia/ostracon/libs/math"
@@ -92,6 +93,97 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookDBMigrateFromFile(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	randAddrs := randNetAddressPairs(t, 100)
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	for _, addrSrc := range randAddrs {
+		err := book.AddAddress(addrSrc.addr, addrSrc.src)
+		require.NoError(t, err)
+	}
+	book.MarkGood(randAddrs[0].addr.ID)
+	book.Save()
+
+	db := dbm.NewMemDB()
+	book = NewDBAddrBook(db, fname, true)
+	book.SetLogger(log.TestingLogger())
+	err := book.Start()
+	require.NoError(t, err)
+	assert.Equal(t, 100, book.Size())
+	assert.True(t, book.IsGood(randAddrs[0].addr))
+	book.RemoveAddress(randAddrs[1].addr)
+	require.NoError(t, book.Stop())
+
+	entries, err := LoadAddrBookEntries(db)
+	require.NoError(t, err)
+	assert.Len(t, entries, 99)
+
+	// the file isn't migrated again
+	book = NewDBAddrBook(db, fname, true)
+	book.SetLogger(log.TestingLogger())
+	err = book.Start()
+	require.NoError(t, err)
+	assert.Equal(t, 99, book.Size())
+	assert.False(t, book.HasAddress(randAddrs[1].addr))
+	assert.Equal(t, book.(*addrBook).key, string(mustGet(t, db, keyKey)))
+}
+
+func TestAddrBookDBIncrementalUpdates(t *testing.T) {
+	db := dbm.NewMemDB()
+	book := NewDBAddrBook(db, "", true)
+	book.SetLogger(log.TestingLogger())
+	err := book.Start()
+	require.NoError(t, err)
+
+	randAddrs := randNetAddressPairs(t, 3)
+	for _, addrSrc := range randAddrs {
+		err := book.AddAddress(addrSrc.addr, addrSrc.src)
+		require.NoError(t, err)
+	}
+	book.MarkAttempt(randAddrs[0].addr)
+	book.MarkGood(randAddrs[0].addr.ID)
+	book.RemoveAddress(randAddrs[2].addr)
+
+	// saved by Save, without stopping the book
+	entries, err := LoadAddrBookEntries(db)
+	require.NoError(t, err)
+	require.Empty(t, entries, "not saved before the flush")
+	book.Save()
+	entries, err = LoadAddrBookEntries(db)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	byID := map[p2p.ID]AddrBookEntry{}
+	for _, entry := range entries {
+		byID[entry.Addr.ID] = entry
+	}
+	good := byID[randAddrs[0].addr.ID]
+	assert.Equal(t, "old", good.BucketType)
+	assert.Equal(t, randAddrs[0].src, good.Src)
+	require.Len(t, good.DialHistory, 2)
+	assert.True(t, good.DialHistory[0].Success)
+	assert.False(t, good.DialHistory[1].Success)
+	assert.False(t, good.LastSeen.IsZero())
+	assert.Equal(t, "new", byID[randAddrs[1].addr.ID].BucketType)
+	assert.NotContains(t, byID, randAddrs[2].addr.ID)
+
+	book = NewDBAddrBook(db, "", true)
+	book.SetLogger(log.TestingLogger())
+	err = book.Start()
+	require.NoError(t, err)
+	assert.Equal(t, 2, book.Size())
+	assert.True(t, book.IsGood(randAddrs[0].addr))
+	assert.False(t, book.HasAddress(randAddrs[2].addr))
+}
+
+func mustGet(t *testing.T, db dbm.DB, key []byte) []byte {
+	bz, err := db.Get(key)
+	require.NoError(t, err)
+	return bz
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -512,6 +604,26 @@ func TestPrivatePeers(t *testing.T) {
 	}
 }
 
+func TestPrivatePeersAlreadyInBook(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addrs, private := testCreatePrivateAddrs(t, 10)
+	for _, addr := range addrs {
+		err := book.AddAddress(addr, addr)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 10, book.Size())
+
+	// private addrs must be removed, not to be gossiped
+	book.AddPrivateIDs(private)
+	assert.True(t, book.Empty())
+	assert.Empty(t, book.GetSelection())
+}
+
 func testAddrBookAddressSelection(t *testing.T, bookSize int) {
 	// generate all combinations of old (m) and new addresses
 	for nBookOld := 0; nBookOld <= bookSize; nBookOld++ {
@@ -0,0 +1,208 @@
+package pex
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/p2p"
+)
+
+/* Loading & Saving to a DB */
+
+var (
+	// random prefix for bucket placement
+	keyKey = []byte("key")
+	// prefix of the known addresses, by ID
+	addrKeyPrefix = []byte("addr/")
+)
+
+func addrKey(id p2p.ID) []byte {
+	return append(append([]byte{}, addrKeyPrefix...), id...)
+}
+
+// loadFromDB loads the address book saved in the DB. If there is none yet,
+// the address book file is migrated to the DB, if it exists.
+func (a *addrBook) loadFromDB() error {
+	key, err := a.db.Get(keyKey)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return a.migrateFromFile()
+	}
+
+	addrs, err := loadKnownAddresses(a.db)
+	if err != nil {
+		return err
+	}
+	a.restore(string(key), addrs)
+	return nil
+}
+
+func (a *addrBook) migrateFromFile() error {
+	if a.loadFromFile(a.filePath) {
+		a.Logger.Info("Migrating AddrBook file to DB", "file", a.filePath, "size", a.size())
+		for id := range a.addrLookup {
+			a.dirty[id] = struct{}{}
+		}
+		if err := a.saveToDB(); err != nil {
+			return fmt.Errorf("failed to migrate AddrBook file %s: %w", a.filePath, err)
+		}
+	}
+	// the key is saved last, so that the migration is retried on failure
+	return a.db.SetSync(keyKey, []byte(a.key))
+}
+
+// markDirty marks the address of the ID to be saved to the DB, or removed
+// from it if it's not in the book anymore.
+// CONTRACT: a.mtx is locked.
+func (a *addrBook) markDirty(id p2p.ID) {
+	if a.db != nil {
+		a.dirty[id] = struct{}{}
+	}
+}
+
+// saveToDB saves the addresses changed since the last call, if any. They're
+// collected under a.mtx, but written to the DB without holding it; they're
+// saved again by the next call on failure.
+// CONTRACT: a.mtx is not locked.
+func (a *addrBook) saveToDB() error {
+	if a.db == nil {
+		return nil
+	}
+	a.flushMtx.Lock()
+	defer a.flushMtx.Unlock()
+
+	a.mtx.Lock()
+	changes, err := a.takeDirty()
+	a.mtx.Unlock()
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+
+	batch := a.db.NewBatch()
+	defer batch.Close()
+	for id, bz := range changes {
+		if bz == nil {
+			err = batch.Delete(addrKey(id))
+		} else {
+			err = batch.Set(addrKey(id), bz)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = batch.Write()
+	}
+	if err != nil {
+		a.mtx.Lock()
+		for id := range changes {
+			a.dirty[id] = struct{}{}
+		}
+		a.mtx.Unlock()
+		return err
+	}
+	return nil
+}
+
+// takeDirty returns the addresses changed since the last call, marshalled, or
+// nil for the ones removed from the book.
+// CONTRACT: a.mtx is locked.
+func (a *addrBook) takeDirty() (map[p2p.ID][]byte, error) {
+	changes := make(map[p2p.ID][]byte, len(a.dirty))
+	for id := range a.dirty {
+		ka, ok := a.addrLookup[id]
+		if !ok {
+			changes[id] = nil
+			continue
+		}
+		bz, err := json.Marshal(ka)
+		if err != nil {
+			return nil, err
+		}
+		changes[id] = bz
+	}
+	a.dirty = make(map[p2p.ID]struct{})
+	return changes, nil
+}
+
+// flush saves the changed addresses to the DB, logging the errors.
+// CONTRACT: a.mtx is not locked.
+func (a *addrBook) flush() {
+	if err := a.saveToDB(); err != nil {
+		a.Logger.Error("Failed to save AddrBook to DB", "err", err)
+	}
+}
+
+func loadKnownAddresses(db dbm.DB) ([]*knownAddress, error) {
+	iter, err := dbm.IteratePrefix(db, addrKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var addrs []*knownAddress
+	for ; iter.Valid(); iter.Next() {
+		ka := new(knownAddress)
+		if err := json.Unmarshal(iter.Value(), ka); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal address %s: %w", iter.Key()[len(addrKeyPrefix):], err)
+		}
+		addrs = append(addrs, ka)
+	}
+	return addrs, iter.Error()
+}
+
+// AddrBookEntry is an address of the address book saved in a DB.
+type AddrBookEntry struct {
+	Addr        *p2p.NetAddress `json:"addr"`
+	Src         *p2p.NetAddress `json:"src"`
+	BucketType  string          `json:"bucket_type"` // "new" or "old"
+	Buckets     []int           `json:"buckets"`
+	Attempts    int32           `json:"attempts"`
+	LastAttempt time.Time       `json:"last_attempt"`
+	LastSuccess time.Time       `json:"last_success"`
+	LastBanTime time.Time       `json:"last_ban_time"`
+	LastSeen    time.Time       `json:"last_seen"`
+	// most recent attempts first
+	DialHistory []DialRecord `json:"dial_history,omitempty"`
+}
+
+// DialRecord is an attempt to connect to an address, which succeeded if the
+// peer was marked as good.
+type DialRecord struct {
+	Time    time.Time `json:"time"`
+	Success bool      `json:"success"`
+}
+
+// LoadAddrBookEntries returns the addresses of the address book saved in the
+// DB, sorted by ID, e.g. to inspect it while the node is stopped.
+func LoadAddrBookEntries(db dbm.DB) ([]AddrBookEntry, error) {
+	addrs, err := loadKnownAddresses(db)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]AddrBookEntry, 0, len(addrs))
+	for _, ka := range addrs {
+		entry := AddrBookEntry{
+			Addr:        ka.Addr,
+			Src:         ka.Src,
+			BucketType:  "new",
+			Buckets:     ka.Buckets,
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+			LastBanTime: ka.LastBanTime,
+			LastSeen:    ka.LastSeen,
+			DialHistory: ka.DialHistory,
+		}
+		if ka.isOld() {
+			entry.BucketType = "old"
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
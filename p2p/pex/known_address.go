@@ -17,6 +17,10 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+	// last time the address was announced or the peer marked as good
+	LastSeen time.Time `json:"last_seen"`
+	// most recent attempts first, up to maxDialHistory
+	DialHistory []DialRecord `json:"dial_history,omitempty"`
 }
 
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
@@ -27,6 +31,7 @@ func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
 		LastAttempt: time.Now(),
 		BucketType:  bucketTypeNew,
 		Buckets:     nil,
+		LastSeen:    time.Now(),
 	}
 }
 
@@ -46,6 +51,7 @@ func (ka *knownAddress) markAttempt() {
 	now := time.Now()
 	ka.LastAttempt = now
 	ka.Attempts++
+	ka.recordDial(now, false)
 }
 
 func (ka *knownAddress) markGood() {
@@ -53,6 +59,20 @@ func (ka *knownAddress) markGood() {
 	ka.LastAttempt = now
 	ka.Attempts = 0
 	ka.LastSuccess = now
+	ka.LastSeen = now
+	ka.recordDial(now, true)
+}
+
+func (ka *knownAddress) markSeen() {
+	ka.LastSeen = time.Now()
+}
+
+func (ka *knownAddress) recordDial(t time.Time, success bool) {
+	history := append([]DialRecord{{Time: t, Success: success}}, ka.DialHistory...)
+	if len(history) > maxDialHistory {
+		history = history[:maxDialHistory]
+	}
+	ka.DialHistory = history
 }
 
 func (ka *knownAddress) ban(banTime time.Duration) {
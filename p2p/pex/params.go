@@ -9,6 +9,9 @@ const (
 	// interval used to dump the address cache to disk for future use.
 	dumpAddressInterval = time.Minute * 2
 
+	// interval used to save the changed addresses to the DB.
+	flushAddressInterval = time.Second * 10
+
 	// max addresses in each old address bucket.
 	oldBucketSize = 64
 
@@ -52,4 +55,7 @@ const (
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize"
 	maxGetSelection = 250
+
+	// dial attempts recorded for each address.
+	maxDialHistory = 10
 )
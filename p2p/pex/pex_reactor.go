@@ -15,6 +15,7 @@ import (
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	"github.com/Finschia/ostracon/libs/service"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/census"
 	"github.com/Finschia/ostracon/p2p/conn"
 )
 
@@ -126,6 +127,9 @@ type ReactorConfig struct {
 
 	// Receive channel buffer size
 	RecvBufSize int
+
+	// Census of the crawled nodes, kept in seed mode only if not nil
+	Census *census.Census
 }
 
 type _attemptsToDial struct {
@@ -203,6 +207,15 @@ func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 // AddPeer implements Reactor by adding peer to the address book (if inbound)
 // or by requesting more addresses (if outbound).
 func (r *Reactor) AddPeer(p Peer) {
+	if r.config.SeedMode && r.config.Census != nil {
+		// In a go-routine so the status probe doesn't block the switch.
+		go func() {
+			if err := r.config.Census.RecordPeer(p); err != nil {
+				r.Logger.Debug("Failed to record peer in census", "err", err, "peer", p)
+			}
+		}()
+	}
+
 	if p.IsOutbound() {
 		// For outbound peers, the address is already in the books -
 		// either via DialPeersAsync or r.Receive.
@@ -746,6 +759,11 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 				r.Logger.Debug(err.Error(), "addr", addr)
 			default:
 				r.Logger.Debug(err.Error(), "addr", addr)
+				if r.config.Census != nil {
+					if err := r.config.Census.RecordUnreachable(addr); err != nil {
+						r.Logger.Error("Failed to record unreachable address in census", "err", err, "addr", addr)
+					}
+				}
 			}
 			continue
 		}
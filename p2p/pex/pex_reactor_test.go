@@ -3,6 +3,7 @@ package pex
 import (
 	"encoding/hex"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,12 +12,14 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 
 	"github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/census"
 	"github.com/Finschia/ostracon/p2p/mock"
 )
 
@@ -334,6 +337,49 @@ func TestPEXReactorSeedMode(t *testing.T) {
 	assert.Equal(t, 0, sw.Peers().Size())
 }
 
+func TestPEXReactorSeedModeCensus(t *testing.T) {
+	// directory to store address books
+	dir, err := os.MkdirTemp("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	nodeCensus, err := census.NewCensus(dbm.NewMemDB(), nil, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+	pexR, book := createReactor(&ReactorConfig{SeedMode: true, Census: nodeCensus})
+	defer teardownReactor(book)
+
+	sw := createSwitchAndAddReactors(pexR)
+	sw.SetAddrBook(book)
+	err = sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop() // nolint:errcheck // ignore for tests
+
+	peerSwitch := testCreateDefaultPeer(dir, 1)
+	require.NoError(t, peerSwitch.Start())
+	defer peerSwitch.Stop() // nolint:errcheck // ignore for tests
+
+	// 1. a crawled peer is recorded as reachable, with its NodeInfo
+	pexR.crawlPeers([]*p2p.NetAddress{peerSwitch.NetAddress()})
+	require.Eventually(t, func() bool {
+		nodes := nodeCensus.Nodes()
+		return len(nodes) == 1 && nodes[0].Reachable()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, peerSwitch.NodeInfo().(p2p.DefaultNodeInfo).Moniker, nodeCensus.Nodes()[0].Moniker)
+
+	// 2. a peer failing to be dialed is recorded as unreachable
+	unreachable := p2p.NewNetAddressIPPort(net.IP{127, 0, 0, 1}, 1)
+	unreachable.ID = mock.NewPeer(nil).ID()
+	pexR.crawlPeers([]*p2p.NetAddress{unreachable})
+	nodes := nodeCensus.Nodes()
+	require.Len(t, nodes, 2)
+	for _, node := range nodes {
+		if node.ID == unreachable.ID {
+			assert.False(t, node.Reachable())
+			assert.Len(t, node.Probes, 1)
+		}
+	}
+}
+
 func TestPEXReactorDoesNotDisconnectFromPersistentPeerInSeedMode(t *testing.T) {
 	// directory to store address books
 	dir, err := os.MkdirTemp("", "pex_reactor")
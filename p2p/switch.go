@@ -1,8 +1,10 @@
 package p2p
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/Finschia/ostracon/libs/cmap"
 	"github.com/Finschia/ostracon/libs/rand"
 	"github.com/Finschia/ostracon/libs/service"
+	"github.com/Finschia/ostracon/p2p/banlist"
 	"github.com/Finschia/ostracon/p2p/conn"
 )
 
@@ -29,6 +32,9 @@ const (
 	// ie. 3**10 = 16hrs
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// check the sentries of a validator behind sentries that often
+	sentryCheckPeriod = 10 * time.Second
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -40,9 +46,22 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
 	mConfig.RecvAsync = cfg.RecvAsync
+	// malformed rates are rejected by P2PConfig.ValidateBasic
+	mConfig.ChannelSendRates, _ = config.ParseChannelRates(cfg.ChannelSendRates)
+	mConfig.ChannelRecvRates, _ = config.ParseChannelRates(cfg.ChannelRecvRates)
 	return mConfig
 }
 
+// SecretConnConfig returns a SecretConnectionConfig with fields updated
+// from the P2PConfig.
+func SecretConnConfig(cfg *config.P2PConfig) conn.SecretConnectionConfig {
+	scConfig := conn.DefaultSecretConnectionConfig()
+	scConfig.Noise = cfg.SecretConnNoise
+	scConfig.RekeyBytes = cfg.SecretConnRekeyBytes
+	scConfig.RekeyInterval = cfg.SecretConnRekeyInterval
+	return scConfig
+}
+
 //-----------------------------------------------------------------------------
 
 // An AddrBook represents an address book from the pex package, which is used
@@ -85,11 +104,17 @@ type Switch struct {
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	// sentries to connect to while some of the persistent peers are down, with
+	// the validator-behind-sentries topology
+	backupSentriesAddrs []*NetAddress
 
 	transport Transport
+	// transport of the QUIC addresses, if any
+	quicTransport Transport
 
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
+	banList       *banlist.BanList // optional
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
@@ -127,6 +152,7 @@ func NewSwitch(
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
+		backupSentriesAddrs:  make([]*NetAddress, 0),
 		mlc:                  newMetricsLabelCache(),
 	}
 
@@ -157,6 +183,59 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
 }
 
+// SwitchQUICTransport sets the transport to accept peers on and to dial the
+// QUIC addresses with, next to the main transport.
+func SwitchQUICTransport(transport Transport) SwitchOption {
+	return func(sw *Switch) { sw.quicTransport = transport }
+}
+
+// SwitchBanList sets the list of the peers to allow or deny.
+func SwitchBanList(banList *banlist.BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = banList }
+}
+
+// BanList returns the list of the peers to allow or deny, or nil if it's not
+// set.
+func (sw *Switch) BanList() *banlist.BanList {
+	return sw.banList
+}
+
+// StopDeniedPeers stops the peers denied by the ban list, e.g. after a rule
+// has been added. The persistent and unconditional peers are kept: the rules
+// only apply to them when they reconnect.
+func (sw *Switch) StopDeniedPeers() {
+	if sw.banList == nil {
+		return
+	}
+	for _, p := range sw.peers.List() {
+		if p.IsPersistent() || sw.IsPeerUnconditional(p.ID()) {
+			continue
+		}
+		if err := sw.banList.Check(string(p.ID()), []net.IP{p.RemoteIP()}); err != nil {
+			sw.Logger.Info("Stopping denied peer", "peer", p, "err", err)
+			sw.StopPeerGracefully(p)
+		}
+	}
+}
+
+// transportFor returns the transport to dial the address with.
+func (sw *Switch) transportFor(addr *NetAddress) (Transport, error) {
+	if addr != nil && addr.Protocol == ProtocolQUIC {
+		if sw.quicTransport == nil {
+			return nil, fmt.Errorf("no QUIC transport to dial %v", addr)
+		}
+		return sw.quicTransport, nil
+	}
+	return sw.transport, nil
+}
+
+// cleanupPeer cleans up the peer with the transport it was connected by.
+func (sw *Switch) cleanupPeer(peer Peer) {
+	if transport, err := sw.transportFor(peer.SocketAddr()); err == nil {
+		transport.Cleanup(peer)
+	}
+}
+
 //---------------------------------------------------------------------
 // Switch setup
 
@@ -240,7 +319,14 @@ func (sw *Switch) OnStart() error {
 	}
 
 	// Start accepting Peers.
-	go sw.acceptRoutine()
+	go sw.acceptRoutine(sw.transport)
+	if sw.quicTransport != nil {
+		go sw.acceptRoutine(sw.quicTransport)
+	}
+
+	if sw.behindSentries() {
+		go sw.sentriesRoutine()
+	}
 
 	return nil
 }
@@ -348,9 +434,13 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 	return
 }
 
+// IsPeerUnconditional returns true if the peer is connected whatever the
+// limits, which is the case of the sentries of a validator behind sentries.
 func (sw *Switch) IsPeerUnconditional(id ID) bool {
-	_, ok := sw.unconditionalPeerIDs[id]
-	return ok
+	if _, ok := sw.unconditionalPeerIDs[id]; ok {
+		return true
+	}
+	return sw.isSentry(id)
 }
 
 // MaxNumOutboundPeers returns a maximum number of outbound peers.
@@ -399,7 +489,7 @@ func (sw *Switch) StopPeerGracefully(peer Peer) {
 }
 
 func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
-	sw.transport.Cleanup(peer)
+	sw.cleanupPeer(peer)
 	if err := peer.Stop(); err != nil {
 		sw.Logger.Error("error while stopping peer", "error", err) // TODO: should return error to be handled accordingly
 	}
@@ -491,6 +581,106 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	}
 }
 
+//---------------------------------------------------------------------
+// Sentries
+
+// behindSentries returns true if the node is a validator behind sentries, its
+// persistent peers, and the backup sentries.
+func (sw *Switch) behindSentries() bool {
+	return sw.config.Topology == config.TopologyValidatorBehindSentries
+}
+
+func (sw *Switch) isSentry(id ID) bool {
+	if !sw.behindSentries() {
+		return false
+	}
+	for _, addr := range sw.persistentPeersAddrs {
+		if addr.ID == id {
+			return true
+		}
+	}
+	for _, addr := range sw.backupSentriesAddrs {
+		if addr.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (sw *Switch) sentriesRoutine() {
+	ticker := time.NewTicker(sentryCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sw.checkSentries()
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
+// checkSentries connects to as many backup sentries as there are persistent
+// peers down, and disconnects from them once the persistent peers are back.
+// The persistent peers themselves are reconnected by reconnectToPeer.
+func (sw *Switch) checkSentries() {
+	primaries := 0
+	for _, addr := range sw.persistentPeersAddrs {
+		if sw.peers.Has(addr.ID) {
+			primaries++
+		}
+	}
+	var backups []Peer
+	for _, addr := range sw.backupSentriesAddrs {
+		if p := sw.peers.Get(addr.ID); p != nil {
+			backups = append(backups, p)
+		}
+	}
+	sw.metrics.SentryPeers.With("backup", "false").Set(float64(primaries))
+	sw.metrics.SentryPeers.With("backup", "true").Set(float64(len(backups)))
+
+	down := len(sw.persistentPeersAddrs) - primaries
+	if down == 0 {
+		for _, p := range backups {
+			sw.Logger.Info("Disconnecting from backup sentry, all sentries are up", "peer", p)
+			sw.StopPeerGracefully(p)
+		}
+		return
+	}
+	sw.Logger.Error("Sentries are down", "down", down, "total", len(sw.persistentPeersAddrs),
+		"backups", len(backups))
+
+	// stop the backups beyond the number of sentries down
+	if len(backups) > down {
+		for _, p := range backups[down:] {
+			sw.Logger.Info("Disconnecting from backup sentry, not needed anymore", "peer", p)
+			sw.StopPeerGracefully(p)
+		}
+	}
+
+	// dial the next ones, considering those currently dialed
+	needed := down - len(backups)
+	for _, addr := range sw.backupSentriesAddrs {
+		if needed <= 0 {
+			return
+		}
+		if sw.peers.Has(addr.ID) {
+			continue
+		}
+		needed--
+		if sw.dialing.Has(string(addr.ID)) {
+			continue
+		}
+		go func(addr *NetAddress) {
+			sw.Logger.Info("Failing over to backup sentry", "addr", addr)
+			if err := sw.DialPeerWithAddress(addr); err != nil {
+				sw.Logger.Error("Error dialing backup sentry", "addr", addr, "err", err)
+			}
+		}(addr)
+	}
+}
+
 //---------------------------------------------------------------------
 // Dialing
 
@@ -535,6 +725,10 @@ func (sw *Switch) dialPeersAsync(netAddrs []*NetAddress) {
 	if sw.addrBook != nil {
 		// add peers to `addrBook`
 		for _, netAddr := range netAddrs {
+			// do not add QUIC addresses, as PEX can't tell them from TCP ones
+			if netAddr.Protocol == ProtocolQUIC {
+				continue
+			}
 			// do not add our address or ID
 			if !netAddr.Same(ourAddr) {
 				if err := sw.addrBook.AddAddress(netAddr, ourAddr); err != nil {
@@ -628,6 +822,28 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 	return nil
 }
 
+// AddBackupSentries sets the sentries to connect to while some of the
+// persistent peers are down, with the validator-behind-sentries topology. It
+// ignores ErrNetAddressLookup. However, if there are other errors, first
+// encounter is returned.
+func (sw *Switch) AddBackupSentries(addrs []string) error {
+	sw.Logger.Info("Adding backup sentries", "addrs", addrs)
+	netAddrs, errs := NewNetAddressStrings(addrs)
+	// report all the errors
+	for _, err := range errs {
+		sw.Logger.Error("Error in sentry's address", "err", err)
+	}
+	// return first non-ErrNetAddressLookup error
+	for _, err := range errs {
+		if _, ok := err.(ErrNetAddressLookup); ok {
+			continue
+		}
+		return err
+	}
+	sw.backupSentriesAddrs = netAddrs
+	return nil
+}
+
 func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 	sw.Logger.Info("Adding unconditional peer ids", "ids", ids)
 	for i, id := range ids {
@@ -664,9 +880,9 @@ func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 	return false
 }
 
-func (sw *Switch) acceptRoutine() {
+func (sw *Switch) acceptRoutine(transport Transport) {
 	for {
-		p, err := sw.transport.Accept(peerConfig{
+		p, err := transport.Accept(peerConfig{
 			chDescs:       sw.chDescs,
 			onPeerError:   sw.StopPeerForError,
 			reactorsByCh:  sw.reactorsByCh,
@@ -733,7 +949,7 @@ func (sw *Switch) acceptRoutine() {
 					"max", sw.config.MaxNumInboundPeers,
 				)
 
-				sw.transport.Cleanup(p)
+				transport.Cleanup(p)
 
 				continue
 			}
@@ -741,7 +957,7 @@ func (sw *Switch) acceptRoutine() {
 		}
 
 		if err := sw.addPeer(p); err != nil {
-			sw.transport.Cleanup(p)
+			transport.Cleanup(p)
 			if p.IsRunning() {
 				_ = p.Stop()
 			}
@@ -771,7 +987,12 @@ func (sw *Switch) addOutboundPeerWithConfig(
 		return fmt.Errorf("dial err (peerConfig.DialFail == true)")
 	}
 
-	p, err := sw.transport.Dial(*addr, peerConfig{
+	transport, err := sw.transportFor(addr)
+	if err != nil {
+		return err
+	}
+
+	p, err := transport.Dial(*addr, peerConfig{
 		chDescs:       sw.chDescs,
 		onPeerError:   sw.StopPeerForError,
 		isPersistent:  sw.IsPeerPersistent,
@@ -802,7 +1023,7 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	}
 
 	if err := sw.addPeer(p); err != nil {
-		sw.transport.Cleanup(p)
+		transport.Cleanup(p)
 		if p.IsRunning() {
 			_ = p.Stop()
 		}
@@ -818,6 +1039,17 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.banList != nil {
+		if err := sw.banList.Check(string(p.ID()), []net.IP{p.RemoteIP()}); err != nil {
+			return ErrRejected{id: p.ID(), err: err, isFiltered: true}
+		}
+	}
+
+	// a validator behind sentries is connected to them only
+	if sw.behindSentries() && !sw.isSentry(p.ID()) {
+		return ErrRejected{id: p.ID(), err: errors.New("not a sentry"), isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -19,12 +19,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/libs/log"
 	tmnet "github.com/Finschia/ostracon/libs/net"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/p2p/banlist"
 	"github.com/Finschia/ostracon/p2p/conn"
 )
 
@@ -298,6 +300,103 @@ func TestSwitchPeerFilter(t *testing.T) {
 	}
 }
 
+func TestSwitchBanList(t *testing.T) {
+	banList, err := banlist.NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchBanList(banList))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+
+	// and an unconditional one
+	up := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	up.Start()
+	t.Cleanup(up.Stop)
+	err = sw.AddUnconditionalPeerIDs([]string{string(up.ID())})
+	require.NoError(t, err)
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	require.NotNil(t, sw.Peers().Get(rp.ID()))
+	err = sw.DialPeerWithAddress(up.Addr())
+	require.NoError(t, err)
+	require.NotNil(t, sw.Peers().Get(up.ID()))
+
+	// the live peer is stopped once banned, but not the unconditional one
+	err = banList.SetRule(banlist.Rule{Target: string(rp.ID()), Policy: banlist.PolicyDeny})
+	require.NoError(t, err)
+	err = banList.SetRule(banlist.Rule{Target: string(up.ID()), Policy: banlist.PolicyDeny})
+	require.NoError(t, err)
+	sw.StopDeniedPeers()
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+	assert.NotNil(t, sw.Peers().Get(up.ID()))
+
+	// and it can't be added anymore
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if err, ok := err.(ErrRejected); ok {
+		assert.True(t, err.IsFiltered(), "expected peer to be filtered")
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+}
+
+func TestSwitchBehindSentries(t *testing.T) {
+	sentryCfg := *cfg
+	sentryCfg.Topology = config.TopologyValidatorBehindSentries
+	sw := MakeSwitch(&sentryCfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var rps [3]*remotePeer
+	for i := range rps {
+		rps[i] = &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+		rps[i].Start()
+		t.Cleanup(rps[i].Stop)
+	}
+	primary, backup, other := rps[0], rps[1], rps[2]
+	require.NoError(t, sw.AddPersistentPeers([]string{primary.Addr().String()}))
+	require.NoError(t, sw.AddBackupSentries([]string{backup.Addr().String()}))
+	assert.True(t, sw.IsPeerUnconditional(primary.ID()))
+	assert.True(t, sw.IsPeerUnconditional(backup.ID()))
+	assert.False(t, sw.IsPeerUnconditional(other.ID()))
+
+	// only the sentries are accepted
+	err = sw.DialPeerWithAddress(other.Addr())
+	if err, ok := err.(ErrRejected); ok {
+		assert.True(t, err.IsFiltered(), "expected peer to be filtered")
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+
+	// fail over to the backup while the primary is down
+	sw.checkSentries()
+	require.Eventually(t, func() bool {
+		return sw.Peers().Has(backup.ID())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// and disconnect from it once the primary is back
+	err = sw.DialPeerWithAddress(primary.Addr())
+	require.NoError(t, err)
+	sw.checkSentries()
+	assert.True(t, sw.Peers().Has(primary.ID()))
+	assert.False(t, sw.Peers().Has(backup.ID()))
+}
+
 func TestSwitchPeerFilterTimeout(t *testing.T) {
 	var (
 		filters = []PeerFilterFunc{
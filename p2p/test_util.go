@@ -251,7 +251,7 @@ func testPeerConn(
 	}
 
 	// Encrypt connection
-	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey)
+	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey, SecretConnConfig(cfg))
 	if err != nil {
 		return pc, fmt.Errorf("error creating peer: %w", err)
 	}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/libs/protoio"
+	"github.com/Finschia/ostracon/p2p/banlist"
 	"github.com/Finschia/ostracon/p2p/conn"
 )
 
@@ -134,6 +135,20 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportBanList sets the list of the peers to allow or deny,
+// whose IP rules are checked for the new connections.
+func MultiplexTransportBanList(banList *banlist.BanList) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.banList = banList }
+}
+
+// MultiplexTransportSecretConnConfig sets the config of the handshake and
+// the key rotation of the encrypted connections.
+func MultiplexTransportSecretConnConfig(
+	scConfig conn.SecretConnectionConfig,
+) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.scConfig = scConfig }
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
@@ -147,6 +162,7 @@ type MultiplexTransport struct {
 	// Lookup table for duplicate ip and id checks.
 	conns       ConnSet
 	connFilters []ConnFilterFunc
+	banList     *banlist.BanList
 
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
@@ -154,6 +170,7 @@ type MultiplexTransport struct {
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
+	scConfig         conn.SecretConnectionConfig
 
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
@@ -178,6 +195,7 @@ func NewMultiplexTransport(
 		filterTimeout:    defaultFilterTimeout,
 		handshakeTimeout: defaultHandshakeTimeout,
 		mConfig:          mConfig,
+		scConfig:         conn.DefaultSecretConnectionConfig(),
 		nodeInfo:         nodeInfo,
 		nodeKey:          nodeKey,
 		conns:            NewConnSet(),
@@ -202,7 +220,7 @@ func (mt *MultiplexTransport) Accept(cfg peerConfig) (Peer, error) {
 
 		cfg.outbound = false
 
-		return mt.wrapPeer(a.conn, a.nodeInfo, cfg, a.netAddr), nil
+		return wrapPeer(a.conn, a.nodeInfo, cfg, a.netAddr, mt.mConfig), nil
 	case <-mt.closec:
 		return nil, ErrTransportClosed{}
 	}
@@ -230,7 +248,7 @@ func (mt *MultiplexTransport) Dial(
 
 	cfg.outbound = true
 
-	p := mt.wrapPeer(secretConn, nodeInfo, cfg, &addr)
+	p := wrapPeer(secretConn, nodeInfo, cfg, &addr, mt.mConfig)
 
 	return p, nil
 }
@@ -363,7 +381,20 @@ func (mt *MultiplexTransport) cleanup(c net.Conn) error {
 	return c.Close()
 }
 
-func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
+func (mt *MultiplexTransport) filterConn(c net.Conn) error {
+	return filterConn(c, mt.conns, mt.connFilters, mt.banList, mt.resolver, mt.filterTimeout)
+}
+
+// filterConn runs the filters on a new connection and adds it to the
+// connections set if it's accepted. Otherwise, the connection is closed.
+func filterConn(
+	c net.Conn,
+	conns ConnSet,
+	connFilters []ConnFilterFunc,
+	banList *banlist.BanList,
+	resolver IPResolver,
+	filterTimeout time.Duration,
+) (err error) {
 	defer func() {
 		if err != nil {
 			_ = c.Close()
@@ -371,21 +402,28 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	}()
 
 	// Reject if connection is already present.
-	if mt.conns.Has(c) {
+	if conns.Has(c) {
 		return ErrRejected{conn: c, isDuplicate: true}
 	}
 
 	// Resolve ips for incoming conn.
-	ips, err := resolveIPs(mt.resolver, c)
+	ips, err := resolveIPs(resolver, c)
 	if err != nil {
 		return err
 	}
 
-	errc := make(chan error, len(mt.connFilters))
+	// Reject if the IP is denied. The ID is checked by the switch once known.
+	if banList != nil {
+		if err := banList.Check("", ips); err != nil {
+			return ErrRejected{conn: c, err: err, isFiltered: true}
+		}
+	}
+
+	errc := make(chan error, len(connFilters))
 
-	for _, f := range mt.connFilters {
+	for _, f := range connFilters {
 		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
-			errc <- f(mt.conns, c, ips)
+			errc <- f(conns, c, ips)
 		}(f, c, ips, errc)
 	}
 
@@ -395,13 +433,13 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 			if err != nil {
 				return ErrRejected{conn: c, err: err, isFiltered: true}
 			}
-		case <-time.After(mt.filterTimeout):
+		case <-time.After(filterTimeout):
 			return ErrFilterTimeout{}
 		}
 
 	}
 
-	mt.conns.Set(c, ips)
+	conns.Set(c, ips)
 
 	return nil
 }
@@ -416,7 +454,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey, mt.scConfig)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -451,8 +489,23 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	if err := checkNodeInfo(c, connID, mt.nodeInfo, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+// checkNodeInfo checks the NodeInfo received from a peer authenticated with
+// connID against ours.
+func checkNodeInfo(
+	c net.Conn,
+	connID ID,
+	ourNodeInfo NodeInfo,
+	nodeInfo NodeInfo,
+) error {
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
@@ -461,7 +514,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -474,8 +527,8 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+	if ourNodeInfo.ID() == nodeInfo.ID() {
+		return ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
@@ -483,8 +536,8 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -492,14 +545,15 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
-func (mt *MultiplexTransport) wrapPeer(
+func wrapPeer(
 	c net.Conn,
 	ni NodeInfo,
 	cfg peerConfig,
 	socketAddr *NetAddress,
+	mConfig conn.MConnConfig,
 ) Peer {
 
 	persistent := false
@@ -523,7 +577,7 @@ func (mt *MultiplexTransport) wrapPeer(
 
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.msgTypeByChID,
@@ -582,12 +636,13 @@ func upgradeSecretConn(
 	c net.Conn,
 	timeout time.Duration,
 	privKey crypto.PrivKey,
+	scConfig conn.SecretConnectionConfig,
 ) (*conn.SecretConnection, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, err
 	}
 
-	sc, err := conn.MakeSecretConnection(c, privKey)
+	sc, err := conn.MakeSecretConnectionWithConfig(c, privKey, scConfig)
 	if err != nil {
 		return nil, err
 	}
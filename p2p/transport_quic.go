@@ -0,0 +1,562 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/p2p/banlist"
+	"github.com/Finschia/ostracon/p2p/conn"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by TLS.
+	quicALPN = "ostracon-p2p"
+
+	// quicKeepAlivePeriod keeps the connections from reaching the idle timeout
+	// of QUIC, as the pings of MConnection are too rare.
+	quicKeepAlivePeriod = 10 * time.Second
+)
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(
+	filters ...ConnFilterFunc,
+) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.connFilters = filters }
+}
+
+// QUICTransportFilterTimeout sets the timeout waited for filter calls to
+// return.
+func QUICTransportFilterTimeout(
+	timeout time.Duration,
+) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.filterTimeout = timeout }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of
+// simultaneous connections (incoming). Default: 0 (unlimited)
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.maxIncomingConnections = n }
+}
+
+// QUICTransportBanList sets the list of the peers to allow or deny, whose IP
+// rules are checked for the new connections.
+func QUICTransportBanList(banList *banlist.BanList) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.banList = banList }
+}
+
+// QUICTransport accepts and dials QUIC connections and upgrades them to peers.
+// Peers are authenticated by TLS 1.3 with self-signed certificates of their
+// ed25519 node keys, so that their IDs are derived from the keys like with
+// SecretConnection. Each channel is sent on its own stream, so there's no
+// head-of-line blocking between the channels.
+type QUICTransport struct {
+	netAddr                NetAddress
+	listener               *quic.Listener
+	maxIncomingConnections int // see MaxIncomingConnections
+	// a slot is taken by each incoming connection until it's closed
+	incomingSlots chan struct{}
+
+	acceptc chan accept
+	closec  chan struct{}
+
+	// Lookup table for duplicate ip and id checks.
+	conns       ConnSet
+	connFilters []ConnFilterFunc
+	banList     *banlist.BanList
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	tlsConfig        *tls.Config
+	resolver         IPResolver
+
+	mConfig conn.MConnConfig
+}
+
+// Test QUICTransport for interface completeness.
+var _ Transport = (*QUICTransport)(nil)
+var _ transportLifecycle = (*QUICTransport)(nil)
+
+// NewQUICTransport returns a QUIC connected peer. It fails if the node key is
+// not an ed25519 key.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+	options ...QUICTransportOption,
+) (*QUICTransport, error) {
+	tlsConfig, err := newQUICTLSConfig(nodeKey.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+	qt := &QUICTransport{
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		mConfig:          mConfig,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		tlsConfig:        tlsConfig,
+		conns:            NewConnSet(),
+		resolver:         net.DefaultResolver,
+	}
+	for _, option := range options {
+		option(qt)
+	}
+	return qt, nil
+}
+
+// NetAddress implements Transport.
+func (qt *QUICTransport) NetAddress() NetAddress {
+	return qt.netAddr
+}
+
+// Accept implements Transport.
+func (qt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	// This case should never have any side-effectful/blocking operations to
+	// ensure that quality peers are ready to be used.
+	case a := <-qt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return wrapPeer(a.conn, a.nodeInfo, cfg, a.netAddr, qt.mConfig), nil
+	case <-qt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (qt *QUICTransport) Dial(
+	addr NetAddress,
+	cfg peerConfig,
+) (Peer, error) {
+	// the TLS handshake is part of dialing with QUIC
+	ctx, cancel := context.WithTimeout(context.Background(), qt.dialTimeout+qt.handshakeTimeout)
+	defer cancel()
+	qc, err := quic.DialAddr(ctx, addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	c := newQUICConn(qc)
+
+	if err := qt.filterConn(c); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := qt.upgrade(c, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return wrapPeer(c, nodeInfo, cfg, &addr, qt.mConfig), nil
+}
+
+// Close implements transportLifecycle.
+func (qt *QUICTransport) Close() error {
+	close(qt.closec)
+
+	if qt.listener != nil {
+		return qt.listener.Close()
+	}
+
+	return nil
+}
+
+// Listen implements transportLifecycle.
+func (qt *QUICTransport) Listen(addr NetAddress) error {
+	ln, err := quic.ListenAddr(addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return err
+	}
+
+	qt.netAddr = addr
+	qt.netAddr.Protocol = ProtocolQUIC
+	qt.listener = ln
+	if qt.maxIncomingConnections > 0 {
+		qt.incomingSlots = make(chan struct{}, qt.maxIncomingConnections)
+	}
+
+	go qt.acceptPeers()
+
+	return nil
+}
+
+// AddChannel registers a channel to nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else channels won't be updated
+func (qt *QUICTransport) AddChannel(chID byte) error {
+	ni, ok := qt.nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("nodeInfo type: %T is not supported", qt.nodeInfo)
+	}
+	if !ni.HasChannel(chID) {
+		ni.Channels = append(ni.Channels, chID)
+	}
+	qt.nodeInfo = ni
+	return nil
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the connection.
+func (qt *QUICTransport) Cleanup(p Peer) {
+	qt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (qt *QUICTransport) cleanup(c net.Conn) error {
+	qt.conns.Remove(c)
+
+	return c.Close()
+}
+
+func (qt *QUICTransport) filterConn(c net.Conn) error {
+	return filterConn(c, qt.conns, qt.connFilters, qt.banList, qt.resolver, qt.filterTimeout)
+}
+
+func (qt *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: qt.handshakeTimeout,
+		KeepAlivePeriod:      quicKeepAlivePeriod,
+	}
+}
+
+func (qt *QUICTransport) acceptPeers() {
+	for {
+		qc, err := qt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-qt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			qt.acceptc <- accept{err: err}
+			return
+		}
+
+		if !qt.takeIncomingSlot(qc) {
+			_ = qc.CloseWithError(0, "too many connections")
+			continue
+		}
+
+		// Connection upgrade and filtering should be asynchronous to avoid
+		// Head-of-line blocking.
+		go func(c *quicConn) {
+			defer func() {
+				if r := recover(); r != nil {
+					err := ErrRejected{
+						conn:          c,
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					select {
+					case qt.acceptc <- accept{err: err}:
+					case <-qt.closec:
+						// Give up if the transport was closed.
+						_ = c.Close()
+						return
+					}
+				}
+			}()
+
+			var (
+				nodeInfo NodeInfo
+				netAddr  *NetAddress
+			)
+
+			err := qt.filterConn(c)
+			if err == nil {
+				nodeInfo, err = qt.upgrade(c, nil)
+				if err == nil {
+					netAddr = NewNetAddress(nodeInfo.ID(), c.RemoteAddr())
+				}
+			}
+
+			select {
+			case qt.acceptc <- accept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-qt.closec:
+				// Give up if the transport was closed.
+				_ = c.Close()
+				return
+			}
+		}(newQUICConn(qc))
+	}
+}
+
+// takeIncomingSlot returns false if there are already maxIncomingConnections
+// incoming connections, or takes a slot until the connection is closed.
+func (qt *QUICTransport) takeIncomingSlot(qc quic.Connection) bool {
+	if qt.incomingSlots == nil {
+		return true
+	}
+	select {
+	case qt.incomingSlots <- struct{}{}:
+	default:
+		return false
+	}
+	go func() {
+		<-qc.Context().Done()
+		<-qt.incomingSlots
+	}()
+	return true
+}
+
+// upgrade authenticates the peer of a QUIC connection, exchanges the NodeInfos
+// on a first stream and sets up the streams of the channels.
+func (qt *QUICTransport) upgrade(
+	c *quicConn,
+	dialedAddr *NetAddress,
+) (nodeInfo NodeInfo, err error) {
+	defer func() {
+		if err != nil {
+			_ = qt.cleanup(c)
+		}
+	}()
+
+	connID, err := quicConnID(c.conn)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("tls identity failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	// Reject self before the handshake, as the other end of the connection
+	// would close it while we're still reading its NodeInfo.
+	if connID == qt.nodeKey.ID() {
+		return nil, ErrRejected{
+			addr:   *NewNetAddress(connID, c.RemoteAddr()),
+			conn:   c,
+			id:     connID,
+			isSelf: true,
+		}
+	}
+
+	// For outgoing conns, ensure connection key matches dialed key.
+	if dialedAddr != nil {
+		if dialedID := dialedAddr.ID; connID != dialedID {
+			return nil, ErrRejected{
+				conn: c,
+				id:   connID,
+				err: fmt.Errorf(
+					"conn.ID (%v) dialed ID (%v) mismatch",
+					connID,
+					dialedID,
+				),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), qt.handshakeTimeout)
+	defer cancel()
+
+	// The dialer opens the streams, and the listener accepts them.
+	outbound := dialedAddr != nil
+	if outbound {
+		c.Stream, err = c.conn.OpenStreamSync(ctx)
+	} else {
+		c.Stream, err = c.conn.AcceptStream(ctx)
+	}
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake stream failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	nodeInfo, err = handshake(c, qt.handshakeTimeout, qt.nodeInfo)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkNodeInfo(c, connID, qt.nodeInfo, nodeInfo); err != nil {
+		return nil, err
+	}
+
+	if err := qt.setupStreams(ctx, c, nodeInfo, outbound); err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("channel streams failed: %v", err),
+			id:            nodeInfo.ID(),
+			isAuthFailure: true,
+		}
+	}
+
+	return nodeInfo, nil
+}
+
+// setupStreams sets up a stream for each channel both nodes have. The dialer
+// opens them and writes the ID of the channel first, and the listener accepts
+// them.
+func (qt *QUICTransport) setupStreams(
+	ctx context.Context,
+	c *quicConn,
+	nodeInfo NodeInfo,
+	outbound bool,
+) error {
+	ourNodeInfo, ok := qt.nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("nodeInfo type: %T is not supported", qt.nodeInfo)
+	}
+	peerNodeInfo, ok := nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("nodeInfo type: %T is not supported", nodeInfo)
+	}
+	var chIDs []byte
+	for _, chID := range ourNodeInfo.Channels {
+		if peerNodeInfo.HasChannel(chID) {
+			chIDs = append(chIDs, chID)
+		}
+	}
+
+	for _, chID := range chIDs {
+		if outbound {
+			stream, err := c.conn.OpenStreamSync(ctx)
+			if err != nil {
+				return err
+			}
+			if _, err := stream.Write([]byte{chID}); err != nil {
+				return err
+			}
+			c.streams[chID] = &quicStreamConn{Stream: stream, conn: c.conn}
+			continue
+		}
+
+		stream, err := c.conn.AcceptStream(ctx)
+		if err != nil {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if err := stream.SetReadDeadline(deadline); err != nil {
+				return err
+			}
+		}
+		bz := make([]byte, 1)
+		if _, err := io.ReadFull(stream, bz); err != nil {
+			return err
+		}
+		if !peerNodeInfo.HasChannel(bz[0]) || !ourNodeInfo.HasChannel(bz[0]) {
+			return fmt.Errorf("unknown channel %X", bz[0])
+		}
+		if _, ok := c.streams[bz[0]]; ok {
+			return fmt.Errorf("duplicate stream of channel %X", bz[0])
+		}
+		if err := stream.SetReadDeadline(time.Time{}); err != nil {
+			return err
+		}
+		c.streams[bz[0]] = &quicStreamConn{Stream: stream, conn: c.conn}
+	}
+	return nil
+}
+
+// quicConnID returns the ID of the peer from its TLS certificate, which has
+// been verified by verifyQUICPeerCertificate.
+func quicConnID(qc quic.Connection) (ID, error) {
+	certs := qc.ConnectionState().TLS.PeerCertificates
+	if len(certs) != 1 {
+		return "", fmt.Errorf("expected a certificate, got %d", len(certs))
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ed25519 key, got %T", certs[0].PublicKey)
+	}
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
+
+// newQUICTLSConfig returns a TLS 1.3 config with a self-signed certificate of
+// the node key, which requires the peers to present theirs.
+func newQUICTLSConfig(privKey crypto.PrivKey) (*tls.Config, error) {
+	edKey, ok := privKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("QUIC requires an ed25519 node key, got %T", privKey)
+	}
+	key := stded25519.PrivateKey(edKey.Bytes())
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certDER},
+			PrivateKey:  key,
+		}},
+		MinVersion: tls.VersionTLS13,
+		NextProtos: []string{quicALPN},
+		ClientAuth: tls.RequireAnyClientCert,
+		// the certificates are self-signed, so they're verified by
+		// verifyQUICPeerCertificate instead of a CA
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyQUICPeerCertificate,
+	}, nil
+}
+
+// verifyQUICPeerCertificate verifies that the peer presents a single
+// certificate of an ed25519 key, which is signed by the key itself.
+func verifyQUICPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected a certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("expected an ed25519 key, got %T", cert.PublicKey)
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return errors.New("certificate is not signed by its key")
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return errors.New("certificate is expired or not yet valid")
+	}
+	return nil
+}
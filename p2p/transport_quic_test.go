@@ -0,0 +1,260 @@
+package p2p
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+
+	"github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p/conn"
+)
+
+func testSetupQUICTransport(
+	t *testing.T,
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	options ...QUICTransportOption,
+) *QUICTransport {
+	qt, err := NewQUICTransport(nodeInfo, nodeKey, conn.DefaultMConnConfig(), options...)
+	require.NoError(t, err)
+
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() {
+		if err := qt.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// give the transport the port picked by the OS
+	qt.netAddr = *NewNetAddress(nodeKey.ID(), qt.listener.Addr())
+	return qt
+}
+
+func TestQUICTransportDialAccept(t *testing.T) {
+	var (
+		pv1 = ed25519.GenPrivKey()
+		pv2 = ed25519.GenPrivKey()
+		ni1 = testNodeInfo(PubKeyToID(pv1.PubKey()), "node1")
+		ni2 = testNodeInfo(PubKeyToID(pv2.PubKey()), "node2")
+		qt1 = testSetupQUICTransport(t, ni1, NodeKey{PrivKey: pv1})
+		qt2 = testSetupQUICTransport(t, ni2, NodeKey{PrivKey: pv2})
+	)
+	assert.Equal(t, ProtocolQUIC, qt1.NetAddress().Protocol)
+
+	errc := make(chan error)
+	go func() {
+		p, err := qt2.Dial(qt1.NetAddress(), peerConfig{})
+		if err != nil {
+			errc <- err
+			return
+		}
+		if p.ID() != ni1.ID() {
+			errc <- fmt.Errorf("expected dialed peer %v, got %v", ni1.ID(), p.ID())
+			return
+		}
+		close(errc)
+	}()
+
+	p, err := qt1.Accept(peerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, <-errc)
+
+	assert.Equal(t, ni2.ID(), p.ID())
+	assert.False(t, p.IsOutbound())
+	assert.Equal(t, ProtocolQUIC, p.SocketAddr().Protocol)
+	assert.IsType(t, &streamMConnection{}, p.(*peer).mconn)
+}
+
+func TestQUICTransportDialRejectWrongID(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	qt := testSetupQUICTransport(t, testNodeInfo(PubKeyToID(pv.PubKey()), "node"), NodeKey{PrivKey: pv})
+
+	dialer, err := NewQUICTransport(
+		testNodeInfo(PubKeyToID(ed25519.GenPrivKey().PubKey()), "dialer"),
+		NodeKey{PrivKey: ed25519.GenPrivKey()},
+		conn.DefaultMConnConfig(),
+	)
+	require.NoError(t, err)
+
+	addr := qt.NetAddress()
+	addr.ID = PubKeyToID(ed25519.GenPrivKey().PubKey())
+	_, err = dialer.Dial(addr, peerConfig{})
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %T: %v", err, err)
+	assert.True(t, e.IsAuthFailure())
+}
+
+func TestQUICTransportRejectSelf(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	qt := testSetupQUICTransport(t, testNodeInfo(PubKeyToID(pv.PubKey()), "node"), NodeKey{PrivKey: pv})
+
+	_, err := qt.Dial(qt.NetAddress(), peerConfig{})
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %T: %v", err, err)
+	assert.True(t, e.IsSelf())
+}
+
+func TestQUICTransportMaxIncomingConnections(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	qt := testSetupQUICTransport(t, testNodeInfo(PubKeyToID(pv.PubKey()), "node"), NodeKey{PrivKey: pv},
+		QUICTransportMaxIncomingConnections(1))
+	go func() {
+		for {
+			if _, err := qt.Accept(peerConfig{}); err != nil {
+				return
+			}
+		}
+	}()
+
+	dial := func(name string) (Peer, error) {
+		dialerKey := ed25519.GenPrivKey()
+		dialer, err := NewQUICTransport(
+			testNodeInfo(PubKeyToID(dialerKey.PubKey()), name),
+			NodeKey{PrivKey: dialerKey},
+			conn.DefaultMConnConfig(),
+		)
+		require.NoError(t, err)
+		return dialer.Dial(qt.NetAddress(), peerConfig{})
+	}
+
+	p, err := dial("dialer1")
+	require.NoError(t, err)
+	_, err = dial("dialer2")
+	assert.Error(t, err)
+
+	// the slot is freed once the connection is closed
+	require.NoError(t, p.CloseConn())
+	assert.Eventually(t, func() bool {
+		p, err := dial("dialer3")
+		if err != nil {
+			return false
+		}
+		return p.CloseConn() == nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestQUICRateLimiterSharedByStreams(t *testing.T) {
+	const (
+		rate    = 10 * 1024
+		perConn = 5 * 1024
+	)
+	limiter := newQUICRateLimiter(rate, 0)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		c1, c2 := net.Pipe()
+		stream := &rateLimitedStreamConn{Conn: c1, limiter: limiter}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := io.Copy(io.Discard, c2)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			n, err := stream.Write(make([]byte, perConn))
+			assert.NoError(t, err)
+			assert.Equal(t, perConn, n)
+			assert.NoError(t, stream.Close())
+		}()
+	}
+	wg.Wait()
+
+	// the streams share the rate, so sending takes about twice as long as it
+	// would with a rate of their own
+	assert.Greater(t, time.Since(start), 700*time.Millisecond)
+}
+
+func TestNewQUICTransportRequiresEd25519(t *testing.T) {
+	pv := secp256k1.GenPrivKey()
+	_, err := NewQUICTransport(
+		testNodeInfo(PubKeyToID(pv.PubKey()), "node"),
+		NodeKey{PrivKey: pv},
+		conn.DefaultMConnConfig(),
+	)
+	assert.Error(t, err)
+}
+
+func makeQUICSwitch(t *testing.T, cfg *config.P2PConfig, i int) *Switch {
+	nodeKey := NodeKey{
+		PrivKey: ed25519.GenPrivKey(),
+	}
+	nodeInfo := testNodeInfo(nodeKey.ID(), fmt.Sprintf("node%d", i))
+	addr, err := NewNetAddressString(
+		IDAddressString(nodeKey.ID(), nodeInfo.(DefaultNodeInfo).ListenAddr),
+	)
+	require.NoError(t, err)
+
+	mt := NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+	require.NoError(t, mt.Listen(*addr))
+	qt := testSetupQUICTransport(t, nodeInfo, nodeKey)
+
+	sw := initSwitchFunc(i, NewSwitch(cfg, mt, SwitchQUICTransport(qt)), cfg)
+	sw.SetLogger(log.TestingLogger().With("switch", i))
+	sw.SetNodeKey(&nodeKey)
+
+	ni := nodeInfo.(DefaultNodeInfo)
+	ni.Channels = nil
+	for ch := range sw.reactorsByCh {
+		ni.Channels = append(ni.Channels, ch)
+	}
+	mt.nodeInfo = ni
+	qt.nodeInfo = ni
+	sw.SetNodeInfo(ni)
+
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return sw
+}
+
+func TestSwitchesOverQUIC(t *testing.T) {
+	cfg := config.DefaultP2PConfig()
+	s1 := makeQUICSwitch(t, cfg, 0)
+	s2 := makeQUICSwitch(t, cfg, 1)
+
+	addr := s2.quicTransport.NetAddress()
+	require.NoError(t, s1.DialPeerWithAddress(&addr))
+	assert.Eventually(t, func() bool {
+		return s1.Peers().Size() == 1 && s2.Peers().Size() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	ch0Msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "0"}}}
+	ch1Msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "1"}}}
+	ch2Msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "2"}}}
+	s1.BroadcastEnvelope(Envelope{ChannelID: byte(0x00), Message: ch0Msg})
+	s1.BroadcastEnvelope(Envelope{ChannelID: byte(0x01), Message: ch1Msg})
+	s1.BroadcastEnvelope(Envelope{ChannelID: byte(0x02), Message: ch2Msg})
+	for chID, msg := range map[byte]*p2pproto.PexAddrs{0x00: ch0Msg, 0x01: ch1Msg, 0x02: ch2Msg} {
+		reactor := s2.Reactor("foo").(*TestReactor)
+		if chID == 0x02 {
+			reactor = s2.Reactor("bar").(*TestReactor)
+		}
+		require.Eventually(t, func() bool {
+			return len(reactor.getMsgs(chID)) > 0
+		}, 5*time.Second, 50*time.Millisecond, "no message in channel #%v", chID)
+		assert.Equal(t, msg, reactor.getMsgs(chID)[0].Contents)
+	}
+
+	status := s2.Peers().List()[0].Status()
+	assert.Len(t, status.Channels, 4)
+}
@@ -0,0 +1,436 @@
+package privval
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	auto "github.com/Finschia/ostracon/libs/autofile"
+	tmbytes "github.com/Finschia/ostracon/libs/bytes"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+	tmtime "github.com/Finschia/ostracon/types/time"
+)
+
+// Types of the requests recorded in a signer audit log.
+const (
+	SignerAuditPubKey   = "pubkey"
+	SignerAuditVote     = "vote"
+	SignerAuditProposal = "proposal"
+	SignerAuditVRFProof = "vrf_proof"
+)
+
+// SignerAuditRequesterLocal is the requester of the messages signed by a
+// private validator running in the node process.
+const SignerAuditRequesterLocal = "local"
+
+const (
+	// how often the entries appended to a signer audit log are synced to disk
+	signerAuditLogSyncInterval = time.Second
+
+	// how much of the end of the head is read to find the last entry, which
+	// is much larger than an entry
+	signerAuditLogTailSize = 64 * 1024
+)
+
+// SignerAuditEntry is an entry of a signer audit log. Each entry commits to the
+// previous one by PrevHash, so the entries can't be removed, reordered or
+// altered without breaking the chain.
+type SignerAuditEntry struct {
+	Type          string           `json:"type"`
+	Height        int64            `json:"height,omitempty"`
+	Round         int32            `json:"round,omitempty"`
+	Step          int8             `json:"step,omitempty"`
+	SignBytesHash tmbytes.HexBytes `json:"sign_bytes_hash,omitempty"`
+	Time          time.Time        `json:"time"`
+	Requester     string           `json:"requester"`
+	Error         string           `json:"error,omitempty"`
+
+	PrevHash tmbytes.HexBytes `json:"prev_hash"`
+	Hash     tmbytes.HexBytes `json:"hash"`
+}
+
+// computeHash returns the hash of the entry, covering every field but Hash.
+func (entry SignerAuditEntry) computeHash() ([]byte, error) {
+	entry.Hash = nil
+	bz, err := tmjson.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return tmhash.Sum(bz), nil
+}
+
+// SignerAuditLog is an append-only, hash-chained log of the requests served by
+// a private validator: pubkey requests, votes, proposals and VRF proofs.
+// Entries are written as JSON lines to a group of files, like the consensus
+// WAL: the head is rotated once it reaches its size limit, and the oldest
+// files are removed once the group reaches its total size limit.
+type SignerAuditLog struct {
+	mtx      tmsync.Mutex
+	group    *auto.Group
+	lastHash []byte
+	lastSync time.Time
+}
+
+// OpenSignerAuditLog opens the signer audit log at path, creating it if it
+// doesn't exist, and appends to the chain of its last entry. The group options
+// override the size limits of the consensus WAL (10MB per file, 1GB in total).
+func OpenSignerAuditLog(path string, groupOptions ...func(*auto.Group)) (*SignerAuditLog, error) {
+	if err := tmos.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to ensure the signer audit log directory is in place: %w", err)
+	}
+	group, err := auto.OpenGroup(path, groupOptions...)
+	if err != nil {
+		return nil, err
+	}
+	last, err := lastSignerAuditEntry(group)
+	if err != nil {
+		group.Close()
+		return nil, err
+	}
+	if err := group.Start(); err != nil {
+		group.Close()
+		return nil, err
+	}
+	return &SignerAuditLog{group: group, lastHash: last.Hash, lastSync: time.Now()}, nil
+}
+
+// Close syncs the signer audit log to disk and closes it.
+func (l *SignerAuditLog) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if err := l.group.Stop(); err != nil {
+		return err
+	}
+	l.group.Wait()
+	err := l.group.FlushAndSync()
+	l.group.Close()
+	return err
+}
+
+// Append chains the entry to the log and writes it to the file. The entries
+// are synced to disk every signerAuditLogSyncInterval, so only a crash of the
+// host may lose the latest ones. It sets the Time of the entry if it's zero.
+func (l *SignerAuditLog) Append(entry SignerAuditEntry) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if entry.Time.IsZero() {
+		entry.Time = tmtime.Now()
+	}
+	entry.PrevHash = l.lastHash
+	hash, err := entry.computeHash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	bz, err := tmjson.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// the entry is written at once so the head is only rotated between entries
+	if _, err := l.group.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to write the signer audit log: %w", err)
+	}
+	if err := l.group.Flush(); err != nil {
+		return fmt.Errorf("failed to write the signer audit log: %w", err)
+	}
+	l.lastHash = hash
+
+	if now := time.Now(); now.Sub(l.lastSync) >= signerAuditLogSyncInterval {
+		if err := l.group.FlushAndSync(); err != nil {
+			return fmt.Errorf("failed to sync the signer audit log: %w", err)
+		}
+		l.lastSync = now
+	}
+	return nil
+}
+
+// LogPubKey records a pubkey request.
+func (l *SignerAuditLog) LogPubKey(requester string, err error) error {
+	return l.Append(SignerAuditEntry{
+		Type:      SignerAuditPubKey,
+		Requester: requester,
+		Error:     errorString(err),
+	})
+}
+
+// LogVote records a request to sign the vote. If the vote was signed, the
+// sign bytes are those of the signed vote.
+func (l *SignerAuditLog) LogVote(chainID, requester string, vote *tmproto.Vote, err error) error {
+	return l.Append(SignerAuditEntry{
+		Type:          SignerAuditVote,
+		Height:        vote.Height,
+		Round:         vote.Round,
+		Step:          voteStep(vote),
+		SignBytesHash: tmhash.Sum(types.VoteSignBytes(chainID, vote)),
+		Requester:     requester,
+		Error:         errorString(err),
+	})
+}
+
+// LogProposal records a request to sign the proposal. If the proposal was
+// signed, the sign bytes are those of the signed proposal.
+func (l *SignerAuditLog) LogProposal(chainID, requester string, proposal *tmproto.Proposal, err error) error {
+	return l.Append(SignerAuditEntry{
+		Type:          SignerAuditProposal,
+		Height:        proposal.Height,
+		Round:         proposal.Round,
+		Step:          stepPropose,
+		SignBytesHash: tmhash.Sum(types.ProposalSignBytes(chainID, proposal)),
+		Requester:     requester,
+		Error:         errorString(err),
+	})
+}
+
+// LogVRFProof records a request to prove the message.
+func (l *SignerAuditLog) LogVRFProof(requester string, message []byte, err error) error {
+	return l.Append(SignerAuditEntry{
+		Type:          SignerAuditVRFProof,
+		SignBytesHash: tmhash.Sum(message),
+		Requester:     requester,
+		Error:         errorString(err),
+	})
+}
+
+// voteStep is voteToStep without panicking on an invalid vote type, which the
+// private validator rejected.
+func voteStep(vote *tmproto.Vote) int8 {
+	switch vote.Type {
+	case tmproto.PrevoteType:
+		return stepPrevote
+	case tmproto.PrecommitType:
+		return stepPrecommit
+	default:
+		return stepNone
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// VerifySignerAuditLog checks the chain of the signer audit log at path, from
+// its first entry, and returns the number of entries. If the oldest files were
+// removed, the chain starts from the first entry left.
+func VerifySignerAuditLog(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, err
+	}
+	group, err := auto.OpenGroup(path)
+	if err != nil {
+		return 0, err
+	}
+	defer group.Close()
+	_, n, err := verifySignerAuditGroup(group)
+	return n, err
+}
+
+// verifySignerAuditGroup checks the chain of the entries of the group and
+// returns its last entry and the number of entries.
+func verifySignerAuditGroup(group *auto.Group) (last SignerAuditEntry, n int, err error) {
+	reader, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		return last, 0, err
+	}
+	defer reader.Close()
+	// the files are indexed from 0, so the lower ones were removed
+	pruned := group.MinIndex() > 0
+
+	br := bufio.NewReader(reader)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) != 0 {
+				return last, n, fmt.Errorf("entry %d is truncated", n+1)
+			}
+			return last, n, nil
+		} else if err != nil {
+			return last, n, err
+		}
+
+		var entry SignerAuditEntry
+		if err := tmjson.Unmarshal(line, &entry); err != nil {
+			return last, n, fmt.Errorf("entry %d is malformed: %w", n+1, err)
+		}
+		if !(n == 0 && pruned) && !bytes.Equal(entry.PrevHash, last.Hash) {
+			return last, n, fmt.Errorf("entry %d doesn't follow entry %d: prev_hash %X, want %X",
+				n+1, n, entry.PrevHash, last.Hash)
+		}
+		hash, err := entry.computeHash()
+		if err != nil {
+			return last, n, err
+		}
+		if !bytes.Equal(entry.Hash, hash) {
+			return last, n, fmt.Errorf("entry %d was altered: hash %X, want %X", n+1, entry.Hash, hash)
+		}
+		last = entry
+		n++
+	}
+}
+
+// lastSignerAuditEntry returns the last entry of the group, checking only its
+// own hash, or an empty entry if the group is empty. Only the end of the head
+// is read, or the file before it if it was just rotated.
+func lastSignerAuditEntry(group *auto.Group) (last SignerAuditEntry, err error) {
+	line, err := lastSignerAuditHeadLine(group.Head.Path)
+	if err != nil {
+		return last, err
+	}
+	if line == nil && group.MaxIndex() > group.MinIndex() {
+		reader, err := group.NewReader(group.MaxIndex() - 1)
+		if err != nil {
+			return last, err
+		}
+		defer reader.Close()
+		if line, err = lastSignerAuditLine(reader); err != nil {
+			return last, err
+		}
+	}
+	if line == nil {
+		return last, nil
+	}
+
+	if err := tmjson.Unmarshal(line, &last); err != nil {
+		return last, fmt.Errorf("the last entry is malformed: %w", err)
+	}
+	hash, err := last.computeHash()
+	if err != nil {
+		return last, err
+	}
+	if !bytes.Equal(last.Hash, hash) {
+		return last, fmt.Errorf("the last entry was altered: hash %X, want %X", last.Hash, hash)
+	}
+	return last, nil
+}
+
+func lastSignerAuditHeadLine(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if offset := info.Size() - signerAuditLogTailSize; offset > 0 {
+		// the first line read may be the end of an entry, but not the last one
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return lastSignerAuditLine(file)
+}
+
+// lastSignerAuditLine returns the last line read, or nil if there is none.
+func lastSignerAuditLine(r io.Reader) ([]byte, error) {
+	var last []byte
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) != 0 {
+				return nil, errors.New("the last entry is truncated")
+			}
+			return last, nil
+		} else if err != nil {
+			return nil, err
+		}
+		last = line
+	}
+}
+
+//-------------------------------------------------------------------------------
+
+// auditedPrivValidator records in an audit log the requests served by a
+// private validator for a requester.
+type auditedPrivValidator struct {
+	types.PrivValidator
+	auditLog  *SignerAuditLog
+	requester string
+}
+
+var _ types.PrivValidator = auditedPrivValidator{}
+
+// NewAuditedPrivValidator returns the private validator recording in the audit
+// log the pubkey requests, votes, proposals and VRF proofs it serves for the
+// requester. Serving a request fails if it can't be recorded. The key rotation
+// and the last sign state of a FilePV are kept available.
+func NewAuditedPrivValidator(pv types.PrivValidator, auditLog *SignerAuditLog, requester string) types.PrivValidator {
+	audited := auditedPrivValidator{PrivValidator: pv, auditLog: auditLog, requester: requester}
+	if filePV, ok := pv.(*FilePV); ok {
+		return auditedFilePV{auditedPrivValidator: audited, filePV: filePV}
+	}
+	return audited
+}
+
+var errSignerAuditLog = errors.New("failed to record the request in the signer audit log")
+
+func (pv auditedPrivValidator) GetPubKey() (crypto.PubKey, error) {
+	pubKey, err := pv.PrivValidator.GetPubKey()
+	if logErr := pv.auditLog.LogPubKey(pv.requester, err); logErr != nil {
+		return nil, fmt.Errorf("%w: %v", errSignerAuditLog, logErr)
+	}
+	return pubKey, err
+}
+
+func (pv auditedPrivValidator) SignVote(chainID string, vote *tmproto.Vote) error {
+	err := pv.PrivValidator.SignVote(chainID, vote)
+	if logErr := pv.auditLog.LogVote(chainID, pv.requester, vote, err); logErr != nil {
+		return fmt.Errorf("%w: %v", errSignerAuditLog, logErr)
+	}
+	return err
+}
+
+func (pv auditedPrivValidator) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	err := pv.PrivValidator.SignProposal(chainID, proposal)
+	if logErr := pv.auditLog.LogProposal(chainID, pv.requester, proposal, err); logErr != nil {
+		return fmt.Errorf("%w: %v", errSignerAuditLog, logErr)
+	}
+	return err
+}
+
+func (pv auditedPrivValidator) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	proof, err := pv.PrivValidator.GenerateVRFProof(message)
+	if logErr := pv.auditLog.LogVRFProof(pv.requester, message, err); logErr != nil {
+		return nil, fmt.Errorf("%w: %v", errSignerAuditLog, logErr)
+	}
+	return proof, err
+}
+
+// auditedFilePV is an auditedPrivValidator of a FilePV, which rotates keys and
+// provides its last sign state to consensus.
+type auditedFilePV struct {
+	auditedPrivValidator
+	filePV *FilePV
+}
+
+var _ types.KeyRotatingPrivValidator = auditedFilePV{}
+
+func (pv auditedFilePV) NextPubKey() (crypto.PubKey, error) {
+	return pv.filePV.NextPubKey()
+}
+
+func (pv auditedFilePV) RotateKey() error {
+	return pv.filePV.RotateKey()
+}
+
+func (pv auditedFilePV) LastSignedHRS() (height int64, round int32, step int8) {
+	return pv.filePV.LastSignedHRS()
+}
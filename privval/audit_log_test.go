@@ -0,0 +1,220 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	auto "github.com/Finschia/ostracon/libs/autofile"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestSignerAuditLogFilePV(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "signer_audit.log")
+	auditLog, err := OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+
+	filePV := GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+	privVal := NewAuditedPrivValidator(filePV, auditLog, SignerAuditRequesterLocal)
+	// consensus still rotates the key and checks the last sign state
+	assert.Implements(t, (*types.KeyRotatingPrivValidator)(nil), privVal)
+	assert.Implements(t, (*interface{ LastSignedHRS() (int64, int32, int8) })(nil), privVal)
+
+	_, err = privVal.GetPubKey()
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: tmrand.Bytes(tmhash.Size)}}
+	proposal := newProposal(10, 1, blockID).ToProto()
+	require.NoError(t, privVal.SignProposal("mychainid", proposal))
+	vote := newVote(filePV.Key.Address, 0, 10, 1, tmproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	_, err = privVal.GenerateVRFProof([]byte("seed"))
+	require.NoError(t, err)
+	// a double sign is refused and recorded
+	conflicting := newVote(filePV.Key.Address, 0, 10, 1, tmproto.PrevoteType, types.BlockID{}).ToProto()
+	require.Error(t, privVal.SignVote("mychainid", conflicting))
+	require.NoError(t, auditLog.Close())
+
+	entries := readSignerAuditLog(t, logFile)
+	require.Len(t, entries, 5)
+	assert.Equal(t, SignerAuditPubKey, entries[0].Type)
+	assert.Equal(t, SignerAuditProposal, entries[1].Type)
+	assert.Equal(t, stepPropose, entries[1].Step)
+	assert.EqualValues(t, tmhash.Sum(types.ProposalSignBytes("mychainid", proposal)), entries[1].SignBytesHash)
+	assert.Equal(t, SignerAuditVote, entries[2].Type)
+	assert.Equal(t, int64(10), entries[2].Height)
+	assert.Equal(t, int32(1), entries[2].Round)
+	assert.Equal(t, stepPrevote, entries[2].Step)
+	assert.EqualValues(t, tmhash.Sum(types.VoteSignBytes("mychainid", vote)), entries[2].SignBytesHash)
+	assert.Equal(t, SignerAuditVRFProof, entries[3].Type)
+	assert.EqualValues(t, tmhash.Sum([]byte("seed")), entries[3].SignBytesHash)
+	assert.NotEmpty(t, entries[4].Error)
+	for _, entry := range entries {
+		assert.Equal(t, SignerAuditRequesterLocal, entry.Requester)
+	}
+
+	n, err := VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// appending after reopening continues the chain
+	auditLog, err = OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.LogPubKey("tcp://127.0.0.1:26659", nil))
+	require.NoError(t, auditLog.Close())
+	n, err = VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+}
+
+func TestSignerAuditLogRotation(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "signer_audit.log")
+	auditLog, err := OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+	for i := 1; i <= 20; i++ {
+		require.NoError(t, auditLog.LogVRFProof(SignerAuditRequesterLocal, tmrand.Bytes(32), nil))
+		if i%4 == 0 {
+			auditLog.group.RotateFile()
+		}
+	}
+	require.NoError(t, auditLog.Close())
+
+	rotated, err := filepath.Glob(logFile + ".*")
+	require.NoError(t, err)
+	require.Len(t, rotated, 5)
+	n, err := VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+
+	// the chain continues from the last entry of the rotated files
+	auditLog, err = OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.LogVRFProof(SignerAuditRequesterLocal, tmrand.Bytes(32), nil))
+	require.NoError(t, auditLog.Close())
+	n, err = VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 21, n)
+
+	// the oldest files can be pruned
+	require.NoError(t, os.Remove(rotated[0]))
+	n, err = VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 17, n)
+
+	// but other files can't be removed without breaking the chain
+	require.NoError(t, os.Remove(rotated[2]))
+	_, err = VerifySignerAuditLog(logFile)
+	assert.Error(t, err)
+}
+
+func TestSignerAuditLogPruning(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "signer_audit.log")
+	auditLog, err := OpenSignerAuditLog(logFile,
+		auto.GroupTotalSizeLimit(2048), auto.GroupCheckDuration(10*time.Millisecond))
+	require.NoError(t, err)
+	for i := 1; i <= 20; i++ {
+		require.NoError(t, auditLog.LogVRFProof(SignerAuditRequesterLocal, tmrand.Bytes(32), nil))
+		if i%4 == 0 {
+			auditLog.group.RotateFile()
+		}
+	}
+	assert.Eventually(t, func() bool { return auditLog.group.ReadGroupInfo().MinIndex > 0 },
+		time.Second, 10*time.Millisecond)
+	require.NoError(t, auditLog.Close())
+
+	n, err := VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+	assert.Less(t, n, 20)
+}
+
+func TestSignerAuditLogTampered(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "signer_audit.log")
+	auditLog, err := OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+	for _, height := range []int64{1, 2, 3} {
+		vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: height, Timestamp: time.Now()}
+		require.NoError(t, auditLog.LogVote("mychainid", SignerAuditRequesterLocal, vote, nil))
+	}
+	require.NoError(t, auditLog.Close())
+
+	bz, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(bz), "\n")
+	require.Len(t, lines, 4) // the last line is empty
+
+	// alter an entry
+	altered := strings.Replace(lines[1], `"height":"2"`, `"height":"4"`, 1)
+	require.NotEqual(t, lines[1], altered)
+	require.NoError(t, os.WriteFile(logFile, []byte(lines[0]+altered+lines[2]), 0o600))
+	_, err = VerifySignerAuditLog(logFile)
+	assert.ErrorContains(t, err, "entry 2 was altered")
+
+	// remove an entry
+	require.NoError(t, os.WriteFile(logFile, []byte(lines[0]+lines[2]), 0o600))
+	_, err = VerifySignerAuditLog(logFile)
+	assert.ErrorContains(t, err, "entry 2 doesn't follow entry 1")
+
+	// the last entry is checked when opening the log
+	require.NoError(t, os.WriteFile(logFile, []byte(lines[0]+lines[1]+strings.TrimSpace(lines[2])), 0o600))
+	_, err = OpenSignerAuditLog(logFile)
+	assert.ErrorContains(t, err, "the last entry is truncated")
+	altered = strings.Replace(lines[2], `"height":"3"`, `"height":"4"`, 1)
+	require.NoError(t, os.WriteFile(logFile, []byte(lines[0]+lines[1]+altered), 0o600))
+	_, err = OpenSignerAuditLog(logFile)
+	assert.ErrorContains(t, err, "the last entry was altered")
+}
+
+func TestSignerAuditLogSignerServer(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "signer_audit.log")
+	auditLog, err := OpenSignerAuditLog(logFile)
+	require.NoError(t, err)
+
+	mockPV := types.NewMockPVWithParams(ed25519.GenPrivKey(), false, false)
+	for _, tc := range getSignerTestCases(t, mockPV, false) {
+		tc.signerServer.SetAuditLog(auditLog)
+		require.NoError(t, tc.signerServer.Start())
+
+		_, err := tc.signerClient.GetPubKey()
+		require.NoError(t, err)
+		vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1, Timestamp: time.Now()}
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, vote))
+
+		require.NoError(t, tc.signerServer.Stop())
+		require.NoError(t, tc.signerClient.Close())
+	}
+	require.NoError(t, auditLog.Close())
+
+	entries := readSignerAuditLog(t, logFile)
+	require.NotEmpty(t, entries)
+	for _, entry := range entries {
+		assert.Contains(t, []string{SignerAuditPubKey, SignerAuditVote}, entry.Type)
+		assert.NotEmpty(t, entry.Requester)
+	}
+	_, err = VerifySignerAuditLog(logFile)
+	require.NoError(t, err)
+}
+
+func readSignerAuditLog(t *testing.T, path string) []SignerAuditEntry {
+	t.Helper()
+	entries := []SignerAuditEntry{}
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(bz)), "\n") {
+		var entry SignerAuditEntry
+		require.NoError(t, tmjson.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
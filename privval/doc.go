@@ -23,5 +23,18 @@ SignerDialerEndpoint is a simple wrapper around a net.Conn. It's used by both IP
 SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
+
+# SignerCluster
+
+SignerCluster fans out to several signers holding the same key, e.g. a
+SignerClient per SignerListenerEndpoint, and requires a majority of them to
+agree on each signature. It skips the unavailable signers instead of retrying,
+and saves the last sign state they agreed on like FilePV.
+
+# SignerAuditLog
+
+SignerAuditLog is an append-only, hash-chained log of the requests served by a
+private validator wrapped by NewAuditedPrivValidator, or by a SignerServer.
+VerifySignerAuditLog checks its chain.
 */
 package privval
@@ -50,6 +50,39 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+
+	// if set, the key file is encrypted with a key derived from its passphrase
+	keyProvider KeyProvider
+	kdf         string
+}
+
+// EncryptWith makes Save encrypt the key file with the passphrase of the
+// keyProvider, derived into an encryption key by the kdf (KDFScrypt or
+// KDFArgon2id).
+func (pvKey *FilePVKey) EncryptWith(keyProvider KeyProvider, kdf string) error {
+	if keyProvider == nil {
+		return errors.New("no key provider")
+	}
+	if _, err := newKeyFileKDFParams(kdf); err != nil {
+		return err
+	}
+	pvKey.keyProvider = keyProvider
+	pvKey.kdf = kdf
+	return nil
+}
+
+// Encrypted returns true if the key file is encrypted.
+func (pvKey FilePVKey) Encrypted() bool {
+	return pvKey.keyProvider != nil
+}
+
+// Bytes returns the content of the key file, encrypted if EncryptWith was
+// called or the key file was loaded encrypted.
+func (pvKey FilePVKey) Bytes() ([]byte, error) {
+	if pvKey.Encrypted() {
+		return encryptFilePVKey(pvKey)
+	}
+	return tmjson.MarshalIndent(pvKey, "", "  ")
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -59,7 +92,7 @@ func (pvKey FilePVKey) Save() {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	jsonBytes, err := pvKey.Bytes()
 	if err != nil {
 		panic(err)
 	}
@@ -148,8 +181,13 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	// the key of a pending key rotation, if any
+	nextKey *FilePVKey
 }
 
+var _ types.KeyRotatingPrivValidator = (*FilePV)(nil)
+
 // NewFilePV generates a new validator from the given key and paths.
 func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
 	return &FilePV{
@@ -176,31 +214,68 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
 func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, true, nil)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, false, nil)
 }
 
-// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+// LoadFilePVWithKeyProvider is like LoadFilePV, but decrypts the key file with
+// the passphrase of the keyProvider if it's encrypted.
+func LoadFilePVWithKeyProvider(keyFilePath, stateFilePath string, keyProvider KeyProvider) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, keyProvider)
+}
+
+// LoadFilePVEmptyStateWithKeyProvider is like LoadFilePVEmptyState, but
+// decrypts the key file with the passphrase of the keyProvider if it's
+// encrypted.
+func LoadFilePVEmptyStateWithKeyProvider(keyFilePath, stateFilePath string, keyProvider KeyProvider) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, keyProvider)
+}
+
+// LoadFilePVKey loads a FilePVKey from the keyFilePath, decrypting it with the
+// passphrase of the keyProvider if it's encrypted. The keyProvider may be nil
+// for a plaintext key file.
+func LoadFilePVKey(keyFilePath string, keyProvider KeyProvider) (FilePVKey, error) {
 	keyJSONBytes, err := os.ReadFile(keyFilePath)
 	if err != nil {
-		tmos.Exit(err.Error())
+		return FilePVKey{}, err
 	}
+
 	pvKey := FilePVKey{}
-	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+	encrypted := encryptedFilePVKey{}
+	err = tmjson.Unmarshal(keyJSONBytes, &encrypted)
+	if err == nil && encrypted.Crypto != nil {
+		pvKey.PrivKey, err = decryptFilePVKey(encrypted, keyProvider)
+		if err != nil {
+			return FilePVKey{}, fmt.Errorf("error decrypting PrivValidator key from %v: %w", keyFilePath, err)
+		}
+		// keep it encrypted when saved again
+		pvKey.keyProvider = keyProvider
+		pvKey.kdf = encrypted.Crypto.KDF
+	} else {
+		err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
+		if err != nil {
+			return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+		}
 	}
 
 	// overwrite pubkey and address for convenience
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
+	return pvKey, nil
+}
+
+// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, keyProvider KeyProvider) *FilePV {
+	pvKey, err := LoadFilePVKey(keyFilePath, keyProvider)
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
 
 	pvState := FilePVLastSignState{}
 
@@ -236,6 +311,23 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
+// LoadOrGenFilePVWithKeyProvider is like LoadOrGenFilePV, but the key file is
+// decrypted, or generated encrypted with the kdf, with the passphrase of the
+// keyProvider if it isn't nil.
+func LoadOrGenFilePVWithKeyProvider(keyFilePath, stateFilePath string, keyProvider KeyProvider, kdf string) *FilePV {
+	if tmos.FileExists(keyFilePath) {
+		return LoadFilePVWithKeyProvider(keyFilePath, stateFilePath, keyProvider)
+	}
+	pv := GenFilePV(keyFilePath, stateFilePath)
+	if keyProvider != nil {
+		if err := pv.Key.EncryptWith(keyProvider, kdf); err != nil {
+			tmos.Exit(err.Error())
+		}
+	}
+	pv.Save()
+	return pv
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -271,6 +363,54 @@ func (pv *FilePV) GenerateVRFProof(message []byte) (crypto.Proof, error) {
 	return pv.Key.PrivKey.VRFProve(message)
 }
 
+// SetNextKey sets the key of a pending key rotation, which the validator set
+// switches to once the app returned the rotation.
+func (pv *FilePV) SetNextKey(nextKey FilePVKey) {
+	pv.nextKey = &nextKey
+}
+
+// NextPubKey returns the public key of the next key, or nil if there's none.
+// Implements KeyRotatingPrivValidator.
+func (pv *FilePV) NextPubKey() (crypto.PubKey, error) {
+	if pv.nextKey == nil {
+		return nil, nil
+	}
+	return pv.nextKey.PubKey, nil
+}
+
+// RotateKey replaces the key with the next key, persisted to the key file
+// (encrypted if the key file was), and removes the file of the next key.
+// The last sign state is kept: the next key signs from the height the
+// validator set uses it, after the last height signed by the old key.
+// Implements KeyRotatingPrivValidator.
+func (pv *FilePV) RotateKey() error {
+	if pv.nextKey == nil {
+		return errors.New("no next key to rotate to")
+	}
+	key := *pv.nextKey
+	key.filePath = pv.Key.filePath
+	if !key.Encrypted() && pv.Key.Encrypted() {
+		key.keyProvider, key.kdf = pv.Key.keyProvider, pv.Key.kdf
+	}
+	pv.Key = key
+	pv.Key.Save()
+
+	nextKeyFile := pv.nextKey.filePath
+	pv.nextKey = nil
+	if nextKeyFile != "" && nextKeyFile != pv.Key.filePath {
+		if err := os.Remove(nextKeyFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastSignedHRS returns the height, round and step of the last message signed
+// by the FilePV.
+func (pv *FilePV) LastSignedHRS() (height int64, round int32, step int8) {
+	return pv.LastSignState.Height, pv.LastSignState.Round, pv.LastSignState.Step
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
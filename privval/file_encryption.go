@@ -0,0 +1,247 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/xchacha20poly1305"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/types"
+)
+
+// KDFs deriving the encryption key of a private validator key file from the
+// passphrase.
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+const (
+	keyFileCipher = "xchacha20-poly1305"
+	keyFileSalt   = 32
+
+	// the "interactive" parameters recommended for scrypt
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// the parameters recommended by RFC 9106 for argon2id with less memory
+	argon2idTime    = 3
+	argon2idMemory  = 64 * 1024 // KiB
+	argon2idThreads = 4
+)
+
+// KeyProvider provides the passphrase encrypting a private validator key
+// file. A KMS can implement it to unwrap the passphrase of the node.
+type KeyProvider interface {
+	Passphrase() ([]byte, error)
+}
+
+// PassphraseFile is a KeyProvider reading the passphrase from a file, without
+// its trailing newline.
+type PassphraseFile string
+
+// Passphrase implements KeyProvider.
+func (path PassphraseFile) Passphrase() ([]byte, error) {
+	bz, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the passphrase file: %w", err)
+	}
+	passphrase := bytes.TrimRight(bz, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", path)
+	}
+	return passphrase, nil
+}
+
+// PassphraseEnv is a KeyProvider reading the passphrase from an environment
+// variable.
+type PassphraseEnv string
+
+// Passphrase implements KeyProvider.
+func (name PassphraseEnv) Passphrase() ([]byte, error) {
+	passphrase := os.Getenv(string(name))
+	if passphrase == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	return []byte(passphrase), nil
+}
+
+// NewKeyProvider returns the KeyProvider reading the passphrase from the file
+// or the environment variable, whichever is set, or nil if none is.
+func NewKeyProvider(passphraseFile, passphraseEnv string) (KeyProvider, error) {
+	switch {
+	case passphraseFile != "" && passphraseEnv != "":
+		return nil, errors.New("both a passphrase file and a passphrase environment variable are set")
+	case passphraseFile != "":
+		return PassphraseFile(passphraseFile), nil
+	case passphraseEnv != "":
+		return PassphraseEnv(passphraseEnv), nil
+	default:
+		return nil, nil
+	}
+}
+
+//-------------------------------------------------------------------------------
+
+// encryptedFilePVKey is the format of an encrypted private validator key
+// file. The address and the public key are kept in plaintext to identify the
+// validator without the passphrase, and the public key is authenticated by the
+// encryption.
+type encryptedFilePVKey struct {
+	Address types.Address  `json:"address"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
+	Crypto  *keyFileCrypto `json:"crypto"`
+}
+
+type keyFileCrypto struct {
+	Cipher     string           `json:"cipher"`
+	KDF        string           `json:"kdf"`
+	KDFParams  keyFileKDFParams `json:"kdf_params"`
+	Nonce      []byte           `json:"nonce"`
+	Ciphertext []byte           `json:"ciphertext"`
+}
+
+type keyFileKDFParams struct {
+	Salt []byte `json:"salt"`
+	// scrypt
+	N int32 `json:"n,omitempty"`
+	R int32 `json:"r,omitempty"`
+	P int32 `json:"p,omitempty"`
+	// argon2id
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint32 `json:"threads,omitempty"`
+}
+
+func newKeyFileKDFParams(kdf string) (keyFileKDFParams, error) {
+	params := keyFileKDFParams{Salt: crypto.CRandBytes(keyFileSalt)}
+	switch kdf {
+	case KDFScrypt:
+		params.N, params.R, params.P = scryptN, scryptR, scryptP
+	case KDFArgon2id:
+		params.Time, params.Memory, params.Threads = argon2idTime, argon2idMemory, argon2idThreads
+	default:
+		return params, fmt.Errorf("unknown kdf %q (must be %q or %q)", kdf, KDFScrypt, KDFArgon2id)
+	}
+	return params, nil
+}
+
+// deriveKey derives the encryption key from the passphrase.
+func (params keyFileKDFParams) deriveKey(kdf string, passphrase []byte) ([]byte, error) {
+	switch kdf {
+	case KDFScrypt:
+		return scrypt.Key(passphrase, params.Salt, int(params.N), int(params.R), int(params.P), xchacha20poly1305.KeySize)
+	case KDFArgon2id:
+		if params.Time == 0 || params.Memory == 0 || params.Threads == 0 || params.Threads > 255 {
+			return nil, errors.New("invalid argon2id parameters")
+		}
+		return argon2.IDKey(passphrase, params.Salt, params.Time, params.Memory, uint8(params.Threads),
+			xchacha20poly1305.KeySize), nil
+	default:
+		return nil, fmt.Errorf("unknown kdf %q", kdf)
+	}
+}
+
+// encryptFilePVKey returns the encrypted key file of pvKey.
+func encryptFilePVKey(pvKey FilePVKey) ([]byte, error) {
+	passphrase, err := pvKey.keyProvider.Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	params, err := newKeyFileKDFParams(pvKey.kdf)
+	if err != nil {
+		return nil, err
+	}
+	key, err := params.deriveKey(pvKey.kdf, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := xchacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := tmjson.Marshal(pvKey.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := encryptedFilePVKey{
+		Address: pvKey.Address,
+		PubKey:  pvKey.PubKey,
+		Crypto: &keyFileCrypto{
+			Cipher:    keyFileCipher,
+			KDF:       pvKey.kdf,
+			KDFParams: params,
+			Nonce:     crypto.CRandBytes(xchacha20poly1305.NonceSize),
+		},
+	}
+	additionalData, err := keyFileAdditionalData(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	encrypted.Crypto.Ciphertext = aead.Seal(nil, encrypted.Crypto.Nonce, plaintext, additionalData)
+	return tmjson.MarshalIndent(encrypted, "", "  ")
+}
+
+// decryptFilePVKey decrypts the private key of an encrypted key file.
+func decryptFilePVKey(encrypted encryptedFilePVKey, keyProvider KeyProvider) (crypto.PrivKey, error) {
+	if keyProvider == nil {
+		return nil, errors.New("the key file is encrypted but no passphrase is set")
+	}
+	if encrypted.Crypto.Cipher != keyFileCipher {
+		return nil, fmt.Errorf("unknown cipher %q", encrypted.Crypto.Cipher)
+	}
+	if encrypted.PubKey == nil {
+		return nil, errors.New("missing pub_key")
+	}
+	passphrase, err := keyProvider.Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	key, err := encrypted.Crypto.KDFParams.deriveKey(encrypted.Crypto.KDF, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := xchacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Crypto.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	additionalData, err := keyFileAdditionalData(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, encrypted.Crypto.Nonce, encrypted.Crypto.Ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted key file")
+	}
+
+	var privKey crypto.PrivKey
+	if err := tmjson.Unmarshal(plaintext, &privKey); err != nil {
+		return nil, err
+	}
+	if !privKey.PubKey().Equals(encrypted.PubKey) {
+		return nil, errors.New("the private key doesn't match pub_key")
+	}
+	return privKey, nil
+}
+
+// keyFileAdditionalData authenticates the plaintext part of an encrypted key
+// file: the public key and the encryption parameters.
+func keyFileAdditionalData(encrypted encryptedFilePVKey) ([]byte, error) {
+	return tmjson.Marshal(struct {
+		PubKey    crypto.PubKey    `json:"pub_key"`
+		Cipher    string           `json:"cipher"`
+		KDF       string           `json:"kdf"`
+		KDFParams keyFileKDFParams `json:"kdf_params"`
+	}{encrypted.PubKey, encrypted.Crypto.Cipher, encrypted.Crypto.KDF, encrypted.Crypto.KDFParams})
+}
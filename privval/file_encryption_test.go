@@ -0,0 +1,134 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/Finschia/ostracon/libs/json"
+)
+
+func TestNewKeyProvider(t *testing.T) {
+	keyProvider, err := NewKeyProvider("", "")
+	require.NoError(t, err)
+	assert.Nil(t, keyProvider)
+
+	_, err = NewKeyProvider("passphrase", "PASSPHRASE")
+	assert.Error(t, err)
+
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("secret\n"), 0o600))
+	keyProvider, err = NewKeyProvider(passphraseFile, "")
+	require.NoError(t, err)
+	passphrase, err := keyProvider.Passphrase()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), passphrase)
+
+	t.Setenv("OC_TEST_PASSPHRASE", "env secret")
+	keyProvider, err = NewKeyProvider("", "OC_TEST_PASSPHRASE")
+	require.NoError(t, err)
+	passphrase, err = keyProvider.Passphrase()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("env secret"), passphrase)
+
+	_, err = PassphraseEnv("OC_TEST_UNSET_PASSPHRASE").Passphrase()
+	assert.Error(t, err)
+}
+
+func TestEncryptedFilePVKey(t *testing.T) {
+	for _, kdf := range []string{KDFScrypt, KDFArgon2id} {
+		kdf := kdf
+		t.Run(kdf, func(t *testing.T) {
+			dir := t.TempDir()
+			keyFile := filepath.Join(dir, "priv_validator_key.json")
+			stateFile := filepath.Join(dir, "priv_validator_state.json")
+			t.Setenv("OC_TEST_PASSPHRASE", "secret")
+			keyProvider := PassphraseEnv("OC_TEST_PASSPHRASE")
+
+			privVal := LoadOrGenFilePVWithKeyProvider(keyFile, stateFile, keyProvider, kdf)
+			require.True(t, privVal.Key.Encrypted())
+
+			// the private key isn't written in plaintext
+			bz, err := os.ReadFile(keyFile)
+			require.NoError(t, err)
+			privKeyJSON, err := tmjson.Marshal(privVal.Key.PrivKey)
+			require.NoError(t, err)
+			assert.NotContains(t, string(bz), string(privKeyJSON))
+			assert.Contains(t, string(bz), kdf)
+
+			loaded, err := LoadFilePVKey(keyFile, keyProvider)
+			require.NoError(t, err)
+			assert.Equal(t, privVal.Key.PrivKey, loaded.PrivKey)
+			assert.Equal(t, privVal.GetAddress(), loaded.Address)
+			assert.True(t, loaded.Encrypted(), "saved encrypted again")
+
+			// wrong or missing passphrase
+			_, err = LoadFilePVKey(keyFile, nil)
+			assert.Error(t, err)
+			t.Setenv("OC_TEST_PASSPHRASE", "wrong")
+			_, err = LoadFilePVKey(keyFile, keyProvider)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEncryptedFilePVKeyTampered(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	stateFile := filepath.Join(dir, "priv_validator_state.json")
+	t.Setenv("OC_TEST_PASSPHRASE", "secret")
+	keyProvider := PassphraseEnv("OC_TEST_PASSPHRASE")
+
+	privVal := LoadOrGenFilePVWithKeyProvider(keyFile, stateFile, keyProvider, KDFScrypt)
+	bz, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+
+	// weaken the kdf parameters
+	encrypted := encryptedFilePVKey{}
+	require.NoError(t, tmjson.Unmarshal(bz, &encrypted))
+	encrypted.Crypto.KDFParams.N = 2
+	bz, err = tmjson.Marshal(encrypted)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, bz, 0o600))
+	_, err = LoadFilePVKey(keyFile, keyProvider)
+	assert.Error(t, err)
+
+	// replace the public key
+	privVal.Key.Save()
+	bz, err = os.ReadFile(keyFile)
+	require.NoError(t, err)
+	encrypted = encryptedFilePVKey{}
+	require.NoError(t, tmjson.Unmarshal(bz, &encrypted))
+	encrypted.PubKey = GenFilePV("", "").Key.PubKey
+	bz, err = tmjson.Marshal(encrypted)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, bz, 0o600))
+	_, err = LoadFilePVKey(keyFile, keyProvider)
+	assert.Error(t, err)
+}
+
+func TestPlaintextFilePVKeyWithKeyProvider(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	stateFile := filepath.Join(dir, "priv_validator_state.json")
+	privVal := LoadOrGenFilePV(keyFile, stateFile)
+
+	t.Setenv("OC_TEST_PASSPHRASE", "secret")
+	loaded, err := LoadFilePVKey(keyFile, PassphraseEnv("OC_TEST_PASSPHRASE"))
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.PrivKey)
+	assert.False(t, loaded.Encrypted())
+
+	// migrate the key file
+	require.Error(t, loaded.EncryptWith(PassphraseEnv("OC_TEST_PASSPHRASE"), "md5"))
+	require.NoError(t, loaded.EncryptWith(PassphraseEnv("OC_TEST_PASSPHRASE"), KDFScrypt))
+	loaded.Save()
+	_, err = LoadFilePVKey(keyFile, nil)
+	assert.Error(t, err)
+	loaded, err = LoadFilePVKey(keyFile, PassphraseEnv("OC_TEST_PASSPHRASE"))
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.PrivKey)
+}
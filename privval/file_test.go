@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -382,3 +383,35 @@ func newProposal(height int64, round int32, blockID types.BlockID) *types.Propos
 		Timestamp: tmtime.Now(),
 	}
 }
+
+func TestRotateKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	t.Setenv("OC_TEST_PASSPHRASE", "secret")
+	keyProvider := PassphraseEnv("OC_TEST_PASSPHRASE")
+	privVal := LoadOrGenFilePVWithKeyProvider(keyFile, stateFile, keyProvider, KDFScrypt)
+	privVal.LastSignState.Height = 10
+	privVal.Save()
+
+	require.Error(t, privVal.RotateKey(), "no next key")
+
+	nextKeyFile := filepath.Join(dir, "next_key.json")
+	next := GenFilePV(nextKeyFile, "")
+	next.Key.Save()
+	nextKey, err := LoadFilePVKey(nextKeyFile, nil)
+	require.NoError(t, err)
+	privVal.SetNextKey(nextKey)
+	nextPubKey, err := privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, next.Key.PubKey, nextPubKey)
+
+	require.NoError(t, privVal.RotateKey())
+	assert.Equal(t, next.Key.PubKey, privVal.Key.PubKey)
+	assert.NoFileExists(t, nextKeyFile)
+
+	// the key file stays encrypted, and the last sign state is kept
+	privVal = LoadFilePVWithKeyProvider(keyFile, stateFile, keyProvider)
+	assert.True(t, privVal.Key.Encrypted())
+	assert.Equal(t, next.Key.PrivKey, privVal.Key.PrivKey)
+	assert.Equal(t, int64(10), privVal.LastSignState.Height)
+}
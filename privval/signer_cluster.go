@@ -0,0 +1,393 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// ErrNoQuorum is returned when not enough signers of a SignerCluster agree
+// on a response.
+type ErrNoQuorum struct {
+	Op        string
+	Agreed    int
+	Threshold int
+	// errors of the signers which didn't agree, if any
+	Errs []error
+}
+
+func (e ErrNoQuorum) Error() string {
+	return fmt.Sprintf("%d signers agreed to %s, %d required (errors: %v)", e.Agreed, e.Op, e.Threshold, e.Errs)
+}
+
+// SignerCluster implements PrivValidator.
+// It fans out every request to several signers holding the same key (e.g.
+// RetrySignerClients of remote signers in different places), and returns a
+// response only once threshold of them agree on it. The signers agree on a
+// proposal or a vote if they sign the same sign bytes but for the timestamp,
+// which a signer replaces with the one it signed before for the same
+// height/round/step; VRF proofs are deterministic, so the honest signers
+// return the same bytes.
+//
+// The threshold must be a majority of the signers, so that two conflicting
+// requests (e.g. from two validator nodes sharing the cluster by mistake)
+// can't both be signed: the signers each keep their own last sign state, and
+// refuse to sign for a height/round/step twice. The cluster also keeps the
+// last height/round/step signed by a quorum in its own state file, to refuse
+// regressions without reaching the signers, and to return the same signature
+// if the consensus asks again (e.g. after a crash before writing it to the
+// WAL).
+//
+// The unavailable signers are skipped transparently as long as threshold of
+// them respond within the timeout. As the requests to the signers can't be
+// interrupted, a signer still busy with a request the cluster stopped waiting
+// for is skipped by the next ones.
+type SignerCluster struct {
+	signers   []types.PrivValidator
+	threshold int
+	timeout   time.Duration
+	// 1 while a request to the signer of the same index is running
+	busy []int32
+
+	mtx           tmsync.Mutex
+	pubKey        crypto.PubKey
+	lastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*SignerCluster)(nil)
+
+// NewSignerCluster returns a SignerCluster requiring threshold of the signers
+// to agree within the timeout. threshold must be more than half of the
+// signers. The last sign state of the cluster is loaded from stateFilePath if
+// it exists, and saved to it.
+func NewSignerCluster(
+	signers []types.PrivValidator,
+	threshold int,
+	timeout time.Duration,
+	stateFilePath string,
+) (*SignerCluster, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signer")
+	}
+	if threshold <= len(signers)/2 || threshold > len(signers) {
+		return nil, fmt.Errorf("threshold must be a majority of the %d signers, got %d", len(signers), threshold)
+	}
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	lss, err := loadSignerClusterState(stateFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the last sign state: %w", err)
+	}
+	return &SignerCluster{
+		signers:       signers,
+		threshold:     threshold,
+		timeout:       timeout,
+		busy:          make([]int32, len(signers)),
+		lastSignState: lss,
+	}, nil
+}
+
+// loadSignerClusterState loads the last sign state of a SignerCluster, or
+// returns an empty one if the file doesn't exist yet.
+func loadSignerClusterState(filePath string) (FilePVLastSignState, error) {
+	lss := FilePVLastSignState{filePath: filePath}
+	bz, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return lss, nil
+	} else if err != nil {
+		return lss, err
+	}
+	if err := tmjson.Unmarshal(bz, &lss); err != nil {
+		return lss, err
+	}
+	lss.filePath = filePath
+	return lss, nil
+}
+
+// LastSignedHRS returns the height, round and step of the last message signed
+// by a quorum of the signers.
+func (sc *SignerCluster) LastSignedHRS() (height int64, round int32, step int8) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.lastSignState.Height, sc.lastSignState.Round, sc.lastSignState.Step
+}
+
+// Close closes the signers which can be closed, e.g. SignerClients.
+func (sc *SignerCluster) Close() error {
+	var errs []error
+	for _, signer := range sc.signers {
+		if closer, ok := signer.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the public key threshold signers agree on. It's cached
+// once they do.
+func (sc *SignerCluster) GetPubKey() (crypto.PubKey, error) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.getPubKey()
+}
+
+// CONTRACT: sc.mtx is locked.
+func (sc *SignerCluster) getPubKey() (crypto.PubKey, error) {
+	if sc.pubKey != nil {
+		return sc.pubKey, nil
+	}
+	res, err := sc.fanOut("get the pubkey", func(signer types.PrivValidator) (string, interface{}, error) {
+		pk, err := signer.GetPubKey()
+		if err != nil {
+			return "", nil, err
+		}
+		return string(pk.Bytes()), pk, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sc.pubKey = res.(crypto.PubKey)
+	return sc.pubKey, nil
+}
+
+// SignVote requests the signers to sign a vote, and sets the signature and
+// the timestamp of one of the threshold signers which agree on it.
+func (sc *SignerCluster) SignVote(chainID string, vote *tmproto.Vote) error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	signBytes := types.VoteSignBytes(chainID, vote)
+	if done, err := sc.checkLastSignState(height, round, step, signBytes, vote.Timestamp, func(timestamp time.Time, sig []byte) {
+		vote.Timestamp = timestamp
+		vote.Signature = sig
+	}); done || err != nil {
+		return err
+	}
+	pubKey, err := sc.getPubKey()
+	if err != nil {
+		return err
+	}
+
+	// the late signers still clone it after the vote is set
+	template := proto.Clone(vote)
+	res, err := sc.fanOut("sign the vote", func(signer types.PrivValidator) (string, interface{}, error) {
+		v := proto.Clone(template).(*tmproto.Vote)
+		if err := signer.SignVote(chainID, v); err != nil {
+			return "", nil, err
+		}
+		if !pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature) {
+			return "", nil, errors.New("invalid vote signature")
+		}
+		return voteAgreementKey(chainID, v), v, nil
+	})
+	if err != nil {
+		return err
+	}
+	signed := res.(*tmproto.Vote)
+	vote.Timestamp = signed.Timestamp
+	vote.Signature = signed.Signature
+	sc.saveSigned(height, round, step, types.VoteSignBytes(chainID, vote), vote.Signature)
+	return nil
+}
+
+// SignProposal requests the signers to sign a proposal, and sets the
+// signature and the timestamp of one of the threshold signers which agree on
+// it.
+func (sc *SignerCluster) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	height, round, step := proposal.Height, proposal.Round, stepPropose
+	signBytes := types.ProposalSignBytes(chainID, proposal)
+	if done, err := sc.checkLastSignState(height, round, step, signBytes, proposal.Timestamp, func(timestamp time.Time, sig []byte) {
+		proposal.Timestamp = timestamp
+		proposal.Signature = sig
+	}); done || err != nil {
+		return err
+	}
+	pubKey, err := sc.getPubKey()
+	if err != nil {
+		return err
+	}
+
+	// the late signers still clone it after the proposal is set
+	template := proto.Clone(proposal)
+	res, err := sc.fanOut("sign the proposal", func(signer types.PrivValidator) (string, interface{}, error) {
+		p := proto.Clone(template).(*tmproto.Proposal)
+		if err := signer.SignProposal(chainID, p); err != nil {
+			return "", nil, err
+		}
+		if !pubKey.VerifySignature(types.ProposalSignBytes(chainID, p), p.Signature) {
+			return "", nil, errors.New("invalid proposal signature")
+		}
+		return proposalAgreementKey(chainID, p), p, nil
+	})
+	if err != nil {
+		return err
+	}
+	signed := res.(*tmproto.Proposal)
+	proposal.Timestamp = signed.Timestamp
+	proposal.Signature = signed.Signature
+	sc.saveSigned(height, round, step, types.ProposalSignBytes(chainID, proposal), proposal.Signature)
+	return nil
+}
+
+// GenerateVRFProof returns the proof of the message threshold signers agree
+// on.
+func (sc *SignerCluster) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	pubKey, err := sc.getPubKey()
+	if err != nil {
+		return nil, err
+	}
+	res, err := sc.fanOut("generate the vrf proof", func(signer types.PrivValidator) (string, interface{}, error) {
+		proof, err := signer.GenerateVRFProof(message)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := pubKey.VRFVerify(proof, message); err != nil {
+			return "", nil, fmt.Errorf("invalid vrf proof: %w", err)
+		}
+		return string(proof), proof, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(crypto.Proof), nil
+}
+
+// checkLastSignState returns an error on a height/round/step regression. If
+// the HRS was already signed, it calls set with the last signature and its
+// timestamp and returns true, unless signBytes conflict with the last ones
+// (other than by the timestamp).
+// CONTRACT: sc.mtx is locked.
+func (sc *SignerCluster) checkLastSignState(
+	height int64, round int32, step int8,
+	signBytes []byte, timestamp time.Time,
+	set func(timestamp time.Time, sig []byte),
+) (bool, error) {
+	lss := &sc.lastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil || !sameHRS {
+		return false, err
+	}
+
+	if !bytes.Equal(signBytes, lss.SignBytes) {
+		var ok bool
+		if step == stepPropose {
+			timestamp, ok = checkProposalsOnlyDifferByTimestamp(lss.SignBytes, signBytes)
+		} else {
+			timestamp, ok = checkVotesOnlyDifferByTimestamp(lss.SignBytes, signBytes)
+		}
+		if !ok {
+			return false, errors.New("conflicting data")
+		}
+	}
+	set(timestamp, lss.Signature)
+	return true, nil
+}
+
+// CONTRACT: sc.mtx is locked.
+func (sc *SignerCluster) saveSigned(height int64, round int32, step int8, signBytes []byte, sig []byte) {
+	sc.lastSignState.Height = height
+	sc.lastSignState.Round = round
+	sc.lastSignState.Step = step
+	sc.lastSignState.Signature = sig
+	sc.lastSignState.SignBytes = signBytes
+	sc.lastSignState.Save()
+}
+
+type signerResult struct {
+	key    string
+	result interface{}
+	err    error
+}
+
+// fanOut calls f on all the signers which aren't busy concurrently, and
+// returns the first result threshold of them agree on, i.e. for which f
+// returned the same key. It doesn't wait for the remaining signers once they
+// do.
+func (sc *SignerCluster) fanOut(
+	op string,
+	f func(signer types.PrivValidator) (key string, result interface{}, err error),
+) (interface{}, error) {
+	var errs []error
+	// buffered, so that the late signers don't block
+	resultCh := make(chan signerResult, len(sc.signers))
+	started := 0
+	for i, signer := range sc.signers {
+		if !atomic.CompareAndSwapInt32(&sc.busy[i], 0, 1) {
+			errs = append(errs, fmt.Errorf("signer %d is busy with a previous request", i))
+			continue
+		}
+		started++
+		go func(i int, signer types.PrivValidator) {
+			defer atomic.StoreInt32(&sc.busy[i], 0)
+			key, result, err := f(signer)
+			resultCh <- signerResult{key, result, err}
+		}(i, signer)
+	}
+
+	var (
+		timer  = time.NewTimer(sc.timeout)
+		agreed = make(map[string]int)
+		best   = 0
+	)
+	defer timer.Stop()
+	for i := 0; i < started; i++ {
+		select {
+		case res := <-resultCh:
+			if res.err != nil {
+				errs = append(errs, res.err)
+				continue
+			}
+			agreed[res.key]++
+			if agreed[res.key] >= sc.threshold {
+				return res.result, nil
+			}
+			if agreed[res.key] > best {
+				best = agreed[res.key]
+			}
+		case <-timer.C:
+			errs = append(errs, fmt.Errorf("%d signers timed out after %v", started-i, sc.timeout))
+			return nil, ErrNoQuorum{Op: op, Agreed: best, Threshold: sc.threshold, Errs: errs}
+		}
+	}
+	return nil, ErrNoQuorum{Op: op, Agreed: best, Threshold: sc.threshold, Errs: errs}
+}
+
+// voteAgreementKey returns the sign bytes of a vote without its timestamp.
+func voteAgreementKey(chainID string, vote *tmproto.Vote) string {
+	v := *vote
+	v.Timestamp = time.Time{}
+	return string(types.VoteSignBytes(chainID, &v))
+}
+
+// proposalAgreementKey returns the sign bytes of a proposal without its
+// timestamp.
+func proposalAgreementKey(chainID string, proposal *tmproto.Proposal) string {
+	p := *proposal
+	p.Timestamp = time.Time{}
+	return string(types.ProposalSignBytes(chainID, &p))
+}
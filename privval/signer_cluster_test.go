@@ -0,0 +1,325 @@
+package privval
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/types"
+)
+
+const clusterTestChainID = "test-chain"
+
+// downSigner is an unavailable signer.
+type downSigner struct {
+	delay time.Duration
+}
+
+var errSignerDown = errors.New("signer is down")
+
+func (s downSigner) GetPubKey() (crypto.PubKey, error) {
+	time.Sleep(s.delay)
+	return nil, errSignerDown
+}
+
+func (s downSigner) SignVote(chainID string, vote *tmproto.Vote) error {
+	time.Sleep(s.delay)
+	return errSignerDown
+}
+
+func (s downSigner) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	time.Sleep(s.delay)
+	return errSignerDown
+}
+
+func (s downSigner) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	time.Sleep(s.delay)
+	return nil, errSignerDown
+}
+
+// lockedSigner serializes the requests to a FilePV like a SignerServer. Once
+// closed, it refuses the requests a cluster didn't wait for.
+type lockedSigner struct {
+	*lockedSignerState
+	pv *FilePV
+}
+
+type lockedSignerState struct {
+	mtx    sync.Mutex
+	closed bool
+}
+
+func (s lockedSigner) lock() error {
+	s.mtx.Lock()
+	if s.closed {
+		s.mtx.Unlock()
+		return errSignerDown
+	}
+	return nil
+}
+
+func (s lockedSigner) close() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+}
+
+func (s lockedSigner) GetPubKey() (crypto.PubKey, error) {
+	if err := s.lock(); err != nil {
+		return nil, err
+	}
+	defer s.mtx.Unlock()
+	return s.pv.GetPubKey()
+}
+
+func (s lockedSigner) SignVote(chainID string, vote *tmproto.Vote) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.mtx.Unlock()
+	return s.pv.SignVote(chainID, vote)
+}
+
+func (s lockedSigner) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.mtx.Unlock()
+	return s.pv.SignProposal(chainID, proposal)
+}
+
+func (s lockedSigner) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	if err := s.lock(); err != nil {
+		return nil, err
+	}
+	defer s.mtx.Unlock()
+	return s.pv.GenerateVRFProof(message)
+}
+
+// newClusterSigners returns n in-process signers holding the same key.
+func newClusterSigners(t *testing.T, privKey crypto.PrivKey, n int) []types.PrivValidator {
+	signers := make([]types.PrivValidator, n)
+	for i := range signers {
+		dir := t.TempDir()
+		signer := lockedSigner{
+			lockedSignerState: new(lockedSignerState),
+			pv:                NewFilePV(privKey, filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")),
+		}
+		// before removing the directory
+		t.Cleanup(signer.close)
+		signers[i] = signer
+	}
+	return signers
+}
+
+// clusterStateFile returns the path of a new last sign state file.
+func clusterStateFile(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "cluster_state.json")
+}
+
+func newClusterTestVote(height int64, round int32, typ tmproto.SignedMsgType) *tmproto.Vote {
+	blockID := types.BlockID{
+		Hash:          tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: tmrand.Bytes(tmhash.Size)},
+	}
+	return newVote(tmrand.Bytes(crypto.AddressSize), 0, height, round, typ, blockID).ToProto()
+}
+
+func TestNewSignerCluster(t *testing.T) {
+	signers := newClusterSigners(t, ed25519.GenPrivKey(), 4)
+	testCases := []struct {
+		signers   []types.PrivValidator
+		threshold int
+		timeout   time.Duration
+		valid     bool
+	}{
+		{signers, 3, time.Second, true},
+		{signers, 4, time.Second, true},
+		{signers[:3], 2, time.Second, true},
+		{signers[:1], 1, time.Second, true},
+		{signers, 2, time.Second, false},
+		{signers, 5, time.Second, false},
+		{signers, 3, 0, false},
+		{nil, 1, time.Second, false},
+	}
+	for i, tc := range testCases {
+		_, err := NewSignerCluster(tc.signers, tc.threshold, tc.timeout, clusterStateFile(t))
+		if tc.valid {
+			assert.NoError(t, err, i)
+		} else {
+			assert.Error(t, err, i)
+		}
+	}
+}
+
+func TestSignerClusterFailover(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	signers := newClusterSigners(t, privKey, 2)
+	signers = append(signers, downSigner{}, types.NewMockPV())
+	cluster, err := NewSignerCluster(signers, 3, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+
+	_, err = cluster.GetPubKey()
+	require.Error(t, err, "only 2 signers hold the key")
+	assert.IsType(t, ErrNoQuorum{}, err)
+
+	cluster, err = NewSignerCluster(signers[:3], 2, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+	pubKey, err := cluster.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	vote := newClusterTestVote(1, 0, tmproto.PrevoteType)
+	require.NoError(t, cluster.SignVote(clusterTestChainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(clusterTestChainID, vote), vote.Signature))
+
+	blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{Total: 1}}
+	proposal := newProposal(2, 0, blockID).ToProto()
+	require.NoError(t, cluster.SignProposal(clusterTestChainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(clusterTestChainID, proposal), proposal.Signature))
+
+	message := []byte("seed")
+	proof, err := cluster.GenerateVRFProof(message)
+	require.NoError(t, err)
+	_, err = pubKey.VRFVerify(proof, message)
+	assert.NoError(t, err)
+}
+
+func TestSignerClusterTimeout(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	signers := newClusterSigners(t, privKey, 1)
+	signers = append(signers, downSigner{delay: time.Second}, downSigner{delay: time.Second})
+	cluster, err := NewSignerCluster(signers, 2, 100*time.Millisecond, clusterStateFile(t))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = cluster.GetPubKey()
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the slow signers aren't waited for")
+}
+
+func TestSignerClusterPreventsDoubleSigning(t *testing.T) {
+	signers := newClusterSigners(t, ed25519.GenPrivKey(), 3)
+	cluster1, err := NewSignerCluster(signers, 2, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+	// e.g. a second validator node using the same signers by mistake
+	cluster2, err := NewSignerCluster(signers, 2, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+
+	vote := newClusterTestVote(10, 0, tmproto.PrecommitType)
+	require.NoError(t, cluster1.SignVote(clusterTestChainID, vote))
+
+	// a conflicting vote for the same height/round/step isn't signed
+	conflicting := newClusterTestVote(10, 0, tmproto.PrecommitType)
+	assert.Error(t, cluster1.SignVote(clusterTestChainID, conflicting))
+	assert.Error(t, cluster2.SignVote(clusterTestChainID, conflicting))
+
+	// nor a regression
+	assert.Error(t, cluster1.SignVote(clusterTestChainID, newClusterTestVote(9, 0, tmproto.PrecommitType)))
+
+	// the same vote with another timestamp gets the same signature
+	resigned := *vote
+	resigned.Signature = nil
+	resigned.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, cluster1.SignVote(clusterTestChainID, &resigned))
+	assert.Equal(t, vote.Signature, resigned.Signature)
+	assert.True(t, vote.Timestamp.Equal(resigned.Timestamp))
+	resigned.Signature = nil
+	resigned.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, cluster2.SignVote(clusterTestChainID, &resigned), "the signers return their last signature")
+	assert.Equal(t, vote.Signature, resigned.Signature)
+	assert.True(t, vote.Timestamp.Equal(resigned.Timestamp))
+
+	// the next step is signed
+	require.NoError(t, cluster2.SignVote(clusterTestChainID, newClusterTestVote(11, 0, tmproto.PrevoteType)))
+}
+
+func TestSignerClusterSavesLastSignState(t *testing.T) {
+	signers := newClusterSigners(t, ed25519.GenPrivKey(), 3)
+	stateFile := clusterStateFile(t)
+	cluster, err := NewSignerCluster(signers, 2, time.Second, stateFile)
+	require.NoError(t, err)
+
+	vote := newClusterTestVote(10, 1, tmproto.PrecommitType)
+	require.NoError(t, cluster.SignVote(clusterTestChainID, vote))
+
+	// e.g. after a restart
+	cluster, err = NewSignerCluster(signers, 2, time.Second, stateFile)
+	require.NoError(t, err)
+	height, round, step := cluster.LastSignedHRS()
+	assert.Equal(t, int64(10), height)
+	assert.Equal(t, int32(1), round)
+	assert.Equal(t, stepPrecommit, step)
+
+	// the regressions are refused without reaching the signers
+	assert.Error(t, cluster.SignVote(clusterTestChainID, newClusterTestVote(10, 0, tmproto.PrecommitType)))
+
+	resigned := *vote
+	resigned.Signature = nil
+	resigned.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, cluster.SignVote(clusterTestChainID, &resigned))
+	assert.Equal(t, vote.Signature, resigned.Signature)
+}
+
+func TestSignerClusterAgreesWithoutTimestamp(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	signers := newClusterSigners(t, privKey, 3)
+	cluster, err := NewSignerCluster(signers, 3, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+
+	// a signer signed the vote before, e.g. for a cluster which then didn't
+	// reach a quorum, and returns its former timestamp
+	vote := newClusterTestVote(10, 0, tmproto.PrevoteType)
+	former := *vote
+	require.NoError(t, signers[0].SignVote(clusterTestChainID, &former))
+
+	vote.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, cluster.SignVote(clusterTestChainID, vote))
+	assert.True(t, privKey.PubKey().VerifySignature(types.VoteSignBytes(clusterTestChainID, vote), vote.Signature))
+}
+
+// blockingSigner is a signer whose requests block until it's released.
+type blockingSigner struct {
+	downSigner
+	release chan struct{}
+	calls   *int32
+}
+
+func (s blockingSigner) GetPubKey() (crypto.PubKey, error) {
+	atomic.AddInt32(s.calls, 1)
+	<-s.release
+	return nil, errSignerDown
+}
+
+func TestSignerClusterSkipsBusySigners(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	blocking := blockingSigner{release: make(chan struct{}), calls: new(int32)}
+	signers := append(newClusterSigners(t, privKey, 2), blocking)
+	cluster, err := NewSignerCluster(signers, 2, time.Second, clusterStateFile(t))
+	require.NoError(t, err)
+
+	_, err = cluster.getPubKey()
+	require.NoError(t, err)
+	cluster.pubKey = nil
+
+	// the blocking signer doesn't get a second request while the first runs
+	_, err = cluster.getPubKey()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(blocking.calls))
+
+	close(blocking.release)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cluster.busy[2]) == 0
+	}, time.Second, 10*time.Millisecond)
+}
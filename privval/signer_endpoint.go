@@ -37,6 +37,16 @@ func (se *signerEndpoint) IsConnected() bool {
 	return se.isConnected()
 }
 
+// remoteAddr returns the address of the other end of the connection, if any.
+func (se *signerEndpoint) remoteAddr() string {
+	se.connMtx.Lock()
+	defer se.connMtx.Unlock()
+	if se.conn == nil || se.conn.RemoteAddr() == nil {
+		return ""
+	}
+	return se.conn.RemoteAddr().String()
+}
+
 // TryGetConnection retrieves a connection if it is already available
 func (se *signerEndpoint) GetAvailableConnection(connectionAvailableCh chan net.Conn) bool {
 	se.connMtx.Lock()
@@ -24,6 +24,9 @@ type SignerServer struct {
 
 	handlerMtx               tmsync.Mutex
 	validationRequestHandler ValidationRequestHandlerFunc
+
+	// if set, records every request served with the connection requesting it
+	auditLog *SignerAuditLog
 }
 
 func NewSignerServer(endpoint *SignerDialerEndpoint, chainID string, privVal types.PrivValidator) *SignerServer {
@@ -58,6 +61,15 @@ func (ss *SignerServer) SetRequestHandler(validationRequestHandler ValidationReq
 	ss.validationRequestHandler = validationRequestHandler
 }
 
+// SetAuditLog makes the server record every request it serves in the audit
+// log, along with the address of the connection requesting it. A request
+// fails if it can't be recorded.
+func (ss *SignerServer) SetAuditLog(auditLog *SignerAuditLog) {
+	ss.handlerMtx.Lock()
+	defer ss.handlerMtx.Unlock()
+	ss.auditLog = auditLog
+}
+
 func (ss *SignerServer) servicePendingRequest() {
 	if !ss.IsRunning() {
 		return // Ignore error from closing.
@@ -76,7 +88,15 @@ func (ss *SignerServer) servicePendingRequest() {
 		// limit the scope of the lock
 		ss.handlerMtx.Lock()
 		defer ss.handlerMtx.Unlock()
-		res, err = ss.validationRequestHandler(ss.privVal, req, ss.chainID)
+		privVal := ss.privVal
+		if ss.auditLog != nil {
+			privVal = auditedPrivValidator{
+				PrivValidator: privVal,
+				auditLog:      ss.auditLog,
+				requester:     ss.endpoint.remoteAddr(),
+			}
+		}
+		res, err = ss.validationRequestHandler(privVal, req, ss.chainID)
 		if err != nil {
 			// only log the error; we'll reply with an error in res
 			ss.Logger.Error("SignerServer: handleMessage", "err", err)
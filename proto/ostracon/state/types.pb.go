@@ -5,10 +5,12 @@ package state
 
 import (
 	fmt "fmt"
+	types2 "github.com/Finschia/ostracon/abci/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types1 "github.com/tendermint/tendermint/abci/types"
 	state "github.com/tendermint/tendermint/proto/tendermint/state"
 	types "github.com/tendermint/tendermint/proto/tendermint/types"
 	io "io"
@@ -198,52 +200,177 @@ func (m *State) GetLastProofHash() []byte {
 	return nil
 }
 
+// ABCIResponses extends the ABCIResponses of Tendermint with the
+// ResponseEndBlock of Ostracon.
+type ABCIResponses struct {
+	DeliverTxs []*types1.ResponseDeliverTx `protobuf:"bytes,1,rep,name=deliver_txs,json=deliverTxs,proto3" json:"deliver_txs,omitempty"`
+	EndBlock   *types2.ResponseEndBlock    `protobuf:"bytes,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	BeginBlock *types1.ResponseBeginBlock  `protobuf:"bytes,3,opt,name=begin_block,json=beginBlock,proto3" json:"begin_block,omitempty"`
+}
+
+func (m *ABCIResponses) Reset()         { *m = ABCIResponses{} }
+func (m *ABCIResponses) String() string { return proto.CompactTextString(m) }
+func (*ABCIResponses) ProtoMessage()    {}
+func (*ABCIResponses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_898987a4421067cd, []int{1}
+}
+func (m *ABCIResponses) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ABCIResponses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ABCIResponses.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ABCIResponses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ABCIResponses.Merge(m, src)
+}
+func (m *ABCIResponses) XXX_Size() int {
+	return m.Size()
+}
+func (m *ABCIResponses) XXX_DiscardUnknown() {
+	xxx_messageInfo_ABCIResponses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ABCIResponses proto.InternalMessageInfo
+
+func (m *ABCIResponses) GetDeliverTxs() []*types1.ResponseDeliverTx {
+	if m != nil {
+		return m.DeliverTxs
+	}
+	return nil
+}
+
+func (m *ABCIResponses) GetEndBlock() *types2.ResponseEndBlock {
+	if m != nil {
+		return m.EndBlock
+	}
+	return nil
+}
+
+func (m *ABCIResponses) GetBeginBlock() *types1.ResponseBeginBlock {
+	if m != nil {
+		return m.BeginBlock
+	}
+	return nil
+}
+
+type ABCIResponsesInfo struct {
+	AbciResponses *ABCIResponses `protobuf:"bytes,1,opt,name=abci_responses,json=abciResponses,proto3" json:"abci_responses,omitempty"`
+	Height        int64          `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *ABCIResponsesInfo) Reset()         { *m = ABCIResponsesInfo{} }
+func (m *ABCIResponsesInfo) String() string { return proto.CompactTextString(m) }
+func (*ABCIResponsesInfo) ProtoMessage()    {}
+func (*ABCIResponsesInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_898987a4421067cd, []int{2}
+}
+func (m *ABCIResponsesInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ABCIResponsesInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ABCIResponsesInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ABCIResponsesInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ABCIResponsesInfo.Merge(m, src)
+}
+func (m *ABCIResponsesInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ABCIResponsesInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ABCIResponsesInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ABCIResponsesInfo proto.InternalMessageInfo
+
+func (m *ABCIResponsesInfo) GetAbciResponses() *ABCIResponses {
+	if m != nil {
+		return m.AbciResponses
+	}
+	return nil
+}
+
+func (m *ABCIResponsesInfo) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*State)(nil), "ostracon.state.State")
+	proto.RegisterType((*ABCIResponses)(nil), "ostracon.state.ABCIResponses")
+	proto.RegisterType((*ABCIResponsesInfo)(nil), "ostracon.state.ABCIResponsesInfo")
 }
 
 func init() { proto.RegisterFile("ostracon/state/types.proto", fileDescriptor_898987a4421067cd) }
 
 var fileDescriptor_898987a4421067cd = []byte{
-	// 593 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x3f, 0x6f, 0xd3, 0x40,
-	0x18, 0xc6, 0x63, 0xfa, 0xc7, 0xe9, 0xa5, 0x49, 0xc0, 0x30, 0xb8, 0x06, 0x1c, 0x83, 0xf8, 0x13,
-	0x31, 0xd8, 0x02, 0x26, 0x16, 0x06, 0xa7, 0x82, 0x46, 0x54, 0xa8, 0x72, 0x51, 0x07, 0x16, 0xeb,
-	0x62, 0x5f, 0xed, 0x13, 0xce, 0x9d, 0xe5, 0xbb, 0x54, 0xf0, 0x15, 0x98, 0xfa, 0xb1, 0x3a, 0x76,
-	0x64, 0x2a, 0x28, 0x59, 0xf8, 0x18, 0xe8, 0xee, 0x6c, 0xc7, 0xad, 0x19, 0xba, 0x25, 0xcf, 0xfb,
-	0x7b, 0x1f, 0x3f, 0xf7, 0xde, 0xab, 0x03, 0x16, 0x65, 0xbc, 0x80, 0x11, 0x25, 0x1e, 0xe3, 0x90,
-	0x23, 0x8f, 0xff, 0xc8, 0x11, 0x73, 0xf3, 0x82, 0x72, 0x6a, 0x0c, 0xaa, 0x9a, 0x2b, 0x6b, 0xd6,
-	0x83, 0x84, 0x26, 0x54, 0x96, 0x3c, 0xf1, 0x4b, 0x51, 0x96, 0xc3, 0x11, 0x89, 0x51, 0x31, 0xc7,
-	0x84, 0xab, 0x6e, 0xef, 0x0c, 0x66, 0x38, 0x86, 0x9c, 0x16, 0x25, 0xf1, 0xb8, 0x45, 0xe4, 0xb0,
-	0x80, 0xf3, 0xf2, 0x33, 0xd6, 0xa3, 0x56, 0xb9, 0x11, 0xc2, 0x1a, 0x25, 0x94, 0x26, 0x19, 0xf2,
-	0xe4, 0xbf, 0xd9, 0xe2, 0xd4, 0xe3, 0x78, 0x8e, 0x18, 0x87, 0xf3, 0xfc, 0x3f, 0xed, 0xad, 0x33,
-	0x3c, 0xfd, 0xa9, 0x83, 0xad, 0x63, 0xa1, 0x1a, 0xef, 0x80, 0x7e, 0x86, 0x0a, 0x86, 0x29, 0x31,
-	0x35, 0x47, 0x1b, 0xf7, 0xde, 0xec, 0xb9, 0xeb, 0x4e, 0x75, 0x42, 0xf7, 0x44, 0x01, 0xfe, 0xe6,
-	0xc5, 0xd5, 0xa8, 0x13, 0x54, 0xbc, 0xf1, 0x02, 0x74, 0xa3, 0x14, 0x62, 0x12, 0xe2, 0xd8, 0xbc,
-	0xe3, 0x68, 0xe3, 0x1d, 0xbf, 0xb7, 0xbc, 0x1a, 0xe9, 0x13, 0xa1, 0x4d, 0xf7, 0x03, 0x5d, 0x16,
-	0xa7, 0xb1, 0xf1, 0x1c, 0x0c, 0x30, 0xc1, 0x1c, 0xc3, 0x2c, 0x4c, 0x11, 0x4e, 0x52, 0x6e, 0x0e,
-	0x1c, 0x6d, 0xbc, 0x11, 0xf4, 0x4b, 0xf5, 0x40, 0x8a, 0xc6, 0x2b, 0x70, 0x2f, 0x83, 0x8c, 0x87,
-	0xb3, 0x8c, 0x46, 0xdf, 0x2a, 0x72, 0x43, 0x92, 0x43, 0x51, 0xf0, 0x85, 0x5e, 0xb2, 0x01, 0xe8,
-	0x37, 0x58, 0x1c, 0x9b, 0x9b, 0xed, 0xec, 0xea, 0xbc, 0xb2, 0x6b, 0xba, 0xef, 0xdf, 0x17, 0xd9,
-	0x97, 0x57, 0xa3, 0xde, 0x61, 0x65, 0x35, 0xdd, 0x0f, 0x7a, 0xb5, 0xef, 0x34, 0x36, 0x0e, 0xc1,
-	0xb0, 0xe1, 0x29, 0xe6, 0x69, 0x6e, 0x49, 0x57, 0xcb, 0x55, 0xc3, 0x76, 0xab, 0x61, 0xbb, 0x5f,
-	0xaa, 0x61, 0xfb, 0x5d, 0x61, 0x7b, 0xfe, 0x7b, 0xa4, 0x05, 0xfd, 0xda, 0x4b, 0x54, 0x8d, 0x8f,
-	0x60, 0x48, 0xd0, 0x77, 0x1e, 0xd6, 0xb7, 0xce, 0xcc, 0x6d, 0xe9, 0x66, 0xb7, 0x33, 0x9e, 0x54,
-	0xcc, 0x31, 0xe2, 0xc1, 0x40, 0xb4, 0xd5, 0x0a, 0x33, 0xde, 0x03, 0xd0, 0xf0, 0xd0, 0x6f, 0xe5,
-	0xd1, 0xe8, 0x10, 0x41, 0xe4, 0xb1, 0x1a, 0x26, 0xdd, 0xdb, 0x05, 0x11, 0x6d, 0x8d, 0x20, 0x13,
-	0x60, 0x4b, 0x23, 0x75, 0x33, 0x0d, 0xbf, 0x30, 0x4a, 0x21, 0x49, 0x50, 0x6c, 0xee, 0xc8, 0xcb,
-	0x7a, 0x28, 0x28, 0x75, 0x4f, 0xeb, 0xee, 0x89, 0x42, 0x8c, 0x00, 0xdc, 0x8d, 0x28, 0x61, 0x88,
-	0xb0, 0x05, 0x0b, 0xd5, 0xbe, 0x9b, 0x40, 0xc6, 0x79, 0xd2, 0x8e, 0x33, 0xa9, 0xc8, 0x23, 0x09,
-	0x96, 0xfb, 0x37, 0x8c, 0xae, 0xcb, 0xc6, 0x67, 0xf0, 0xac, 0x19, 0xec, 0xa6, 0x7f, 0x1d, 0xaf,
-	0x27, 0xe3, 0x39, 0xeb, 0x78, 0x37, 0xfc, 0xab, 0x8c, 0xd5, 0x22, 0x16, 0x88, 0x2d, 0x32, 0xce,
-	0xc2, 0x14, 0xb2, 0xd4, 0xdc, 0x75, 0xb4, 0xf1, 0xae, 0x5a, 0xc4, 0x40, 0xe9, 0x07, 0x90, 0xa5,
-	0xc6, 0x1e, 0xe8, 0xc2, 0x3c, 0x57, 0x48, 0x5f, 0x22, 0x3a, 0xcc, 0x73, 0x59, 0x7a, 0x59, 0x0e,
-	0x3e, 0x2f, 0x28, 0x3d, 0x55, 0xc4, 0x5f, 0x5d, 0x22, 0x72, 0x55, 0x8e, 0x84, 0x2c, 0x40, 0xff,
-	0xd3, 0xc5, 0xd2, 0xd6, 0x2e, 0x97, 0xb6, 0xf6, 0x67, 0x69, 0x6b, 0xe7, 0x2b, 0xbb, 0x73, 0xb9,
-	0xb2, 0x3b, 0xbf, 0x56, 0x76, 0xe7, 0xeb, 0xeb, 0x04, 0xf3, 0x74, 0x31, 0x73, 0x23, 0x3a, 0xf7,
-	0x3e, 0x60, 0xc2, 0xa2, 0x14, 0x43, 0xaf, 0x7e, 0x9a, 0xd4, 0x9b, 0x73, 0xfd, 0xa5, 0x9a, 0x6d,
-	0x4b, 0xf5, 0xed, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x3f, 0xbb, 0x81, 0xf5, 0xc2, 0x04, 0x00,
-	0x00,
+	// 746 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x4f, 0xdb, 0x48,
+	0x18, 0x8e, 0x09, 0x90, 0x30, 0x26, 0xc9, 0xe2, 0x5d, 0xad, 0x4c, 0x58, 0x9c, 0x6c, 0xf6, 0x2b,
+	0xda, 0x83, 0xad, 0xd2, 0x53, 0xa5, 0xaa, 0x52, 0x9d, 0xb4, 0x25, 0x2a, 0xaa, 0x90, 0x41, 0x1c,
+	0x7a, 0xb1, 0x26, 0xf6, 0x60, 0x8f, 0x9a, 0xcc, 0xb8, 0x9e, 0x09, 0xa2, 0x7f, 0xa1, 0x27, 0x7e,
+	0x16, 0x47, 0x8e, 0x9c, 0x68, 0x15, 0x2e, 0xfd, 0x19, 0xd5, 0xcc, 0xd8, 0x8e, 0x43, 0x5a, 0x89,
+	0xdb, 0xf8, 0x7d, 0x3e, 0xf2, 0xcc, 0xbc, 0xef, 0x4c, 0x40, 0x9b, 0x32, 0x9e, 0xc2, 0x80, 0x12,
+	0x87, 0x71, 0xc8, 0x91, 0xc3, 0x3f, 0x25, 0x88, 0xd9, 0x49, 0x4a, 0x39, 0x35, 0x9a, 0x39, 0x66,
+	0x4b, 0xac, 0xfd, 0x5b, 0x44, 0x23, 0x2a, 0x21, 0x47, 0xac, 0x14, 0xab, 0xdd, 0xe5, 0x88, 0x84,
+	0x28, 0x9d, 0x62, 0xc2, 0x95, 0xda, 0xb9, 0x80, 0x13, 0x1c, 0x42, 0x4e, 0xd3, 0x8c, 0xb1, 0xbf,
+	0xc2, 0x48, 0x60, 0x0a, 0xa7, 0xd9, 0xcf, 0xb4, 0xff, 0x58, 0x81, 0x4b, 0x21, 0xda, 0x9d, 0x88,
+	0xd2, 0x68, 0x82, 0x1c, 0xf9, 0x35, 0x9e, 0x9d, 0x3b, 0x1c, 0x4f, 0x11, 0xe3, 0x70, 0x9a, 0xfc,
+	0x40, 0xbe, 0xb2, 0x87, 0xf6, 0x5e, 0x09, 0x85, 0xe3, 0x00, 0x2f, 0x81, 0xbb, 0xc5, 0xe6, 0x1f,
+	0x42, 0xbd, 0xcf, 0x35, 0xb0, 0x71, 0x22, 0xdc, 0x8c, 0x67, 0xa0, 0x76, 0x81, 0x52, 0x86, 0x29,
+	0x31, 0xb5, 0xae, 0xd6, 0xd7, 0x0f, 0x76, 0xed, 0x85, 0xa7, 0x3a, 0x19, 0xfb, 0x4c, 0x11, 0xdc,
+	0xf5, 0xeb, 0xbb, 0x4e, 0xc5, 0xcb, 0xf9, 0xc6, 0xbf, 0xa0, 0x1e, 0xc4, 0x10, 0x13, 0x1f, 0x87,
+	0xe6, 0x5a, 0x57, 0xeb, 0x6f, 0xb9, 0xfa, 0xfc, 0xae, 0x53, 0x1b, 0x88, 0xda, 0x68, 0xe8, 0xd5,
+	0x24, 0x38, 0x0a, 0x8d, 0x7f, 0x40, 0x13, 0x13, 0xcc, 0x31, 0x9c, 0xf8, 0x31, 0xc2, 0x51, 0xcc,
+	0xcd, 0x66, 0x57, 0xeb, 0x57, 0xbd, 0x46, 0x56, 0x3d, 0x94, 0x45, 0xe3, 0x7f, 0xb0, 0x33, 0x81,
+	0x8c, 0xfb, 0xe3, 0x09, 0x0d, 0x3e, 0xe4, 0xcc, 0xaa, 0x64, 0xb6, 0x04, 0xe0, 0x8a, 0x7a, 0xc6,
+	0xf5, 0x40, 0xa3, 0xc4, 0xc5, 0xa1, 0xb9, 0xbe, 0x9a, 0x5d, 0xed, 0x57, 0xaa, 0x46, 0x43, 0xf7,
+	0x57, 0x91, 0x7d, 0x7e, 0xd7, 0xd1, 0x8f, 0x72, 0xab, 0xd1, 0xd0, 0xd3, 0x0b, 0xdf, 0x51, 0x68,
+	0x1c, 0x81, 0x56, 0xc9, 0x53, 0xf4, 0xc1, 0xdc, 0x90, 0xae, 0x6d, 0x5b, 0x35, 0xc9, 0xce, 0x9b,
+	0x64, 0x9f, 0xe6, 0x4d, 0x72, 0xeb, 0xc2, 0xf6, 0xea, 0x4b, 0x47, 0xf3, 0x1a, 0x85, 0x97, 0x40,
+	0x8d, 0x37, 0xa0, 0x45, 0xd0, 0x25, 0xf7, 0x8b, 0x69, 0x61, 0xe6, 0xa6, 0x74, 0xb3, 0x56, 0x33,
+	0x9e, 0xe5, 0x9c, 0x13, 0xc4, 0xbd, 0xa6, 0x90, 0x15, 0x15, 0x66, 0xbc, 0x00, 0xa0, 0xe4, 0x51,
+	0x7b, 0x94, 0x47, 0x49, 0x21, 0x82, 0xc8, 0x6d, 0x95, 0x4c, 0xea, 0x8f, 0x0b, 0x22, 0x64, 0xa5,
+	0x20, 0x03, 0x60, 0x49, 0x23, 0xd5, 0x99, 0x92, 0x9f, 0x1f, 0xc4, 0x90, 0x44, 0x28, 0x34, 0xb7,
+	0x64, 0xb3, 0xf6, 0x04, 0x4b, 0xf5, 0x69, 0xa1, 0x1e, 0x28, 0x8a, 0xe1, 0x81, 0x5f, 0x02, 0x4a,
+	0x18, 0x22, 0x6c, 0xc6, 0x7c, 0x75, 0x4f, 0x4c, 0x20, 0xe3, 0xfc, 0xb9, 0x1a, 0x67, 0x90, 0x33,
+	0x8f, 0x25, 0x31, 0x9b, 0xbf, 0x56, 0xb0, 0x5c, 0x36, 0xde, 0x81, 0xbf, 0xcb, 0xc1, 0x1e, 0xfa,
+	0x17, 0xf1, 0x74, 0x19, 0xaf, 0xbb, 0x88, 0xf7, 0xc0, 0x3f, 0xcf, 0x98, 0x0f, 0x62, 0x8a, 0xd8,
+	0x6c, 0xc2, 0x99, 0x1f, 0x43, 0x16, 0x9b, 0xdb, 0x5d, 0xad, 0xbf, 0xad, 0x06, 0xd1, 0x53, 0xf5,
+	0x43, 0xc8, 0x62, 0x63, 0x17, 0xd4, 0x61, 0x92, 0x28, 0x4a, 0x43, 0x52, 0x6a, 0x30, 0x49, 0x24,
+	0xf4, 0x5f, 0x76, 0xf0, 0x49, 0x4a, 0xe9, 0xb9, 0x62, 0x7c, 0xab, 0x49, 0x8a, 0x1c, 0x95, 0x63,
+	0x51, 0x16, 0xc4, 0xde, 0xad, 0x06, 0x1a, 0x2f, 0xdd, 0xc1, 0xc8, 0x43, 0x2c, 0x11, 0x89, 0xc4,
+	0x51, 0xeb, 0x21, 0x9a, 0xe0, 0x0b, 0x94, 0xfa, 0xfc, 0x92, 0x99, 0x5a, 0xb7, 0xda, 0xd7, 0x0f,
+	0x7a, 0xe5, 0x03, 0x12, 0x37, 0xda, 0xce, 0x05, 0x43, 0xc5, 0x3d, 0xbd, 0xf4, 0x40, 0x98, 0x2f,
+	0x99, 0xf1, 0x1c, 0x6c, 0x21, 0x12, 0xaa, 0x71, 0x96, 0xf7, 0x53, 0x3f, 0xe8, 0xd8, 0xc5, 0x9b,
+	0xb7, 0x64, 0xf0, 0x8a, 0x84, 0x72, 0x72, 0xbd, 0x3a, 0xca, 0x56, 0xc6, 0x10, 0xe8, 0x63, 0x14,
+	0x61, 0x92, 0xe9, 0xab, 0x52, 0xff, 0xd7, 0x4f, 0x23, 0xb8, 0x82, 0xab, 0x3c, 0xc0, 0xb8, 0x58,
+	0xf7, 0x3e, 0x82, 0x9d, 0xa5, 0x9d, 0x8d, 0xc8, 0x39, 0x35, 0x86, 0xa0, 0x29, 0xb4, 0x7e, 0x9a,
+	0x57, 0xb3, 0x97, 0x67, 0xdf, 0x5e, 0x7e, 0x91, 0xed, 0x25, 0xa9, 0xd7, 0x10, 0xa2, 0xc5, 0x19,
+	0xfd, 0x0e, 0x36, 0xb3, 0x37, 0x62, 0x4d, 0xf6, 0x35, 0xfb, 0x72, 0xdf, 0x5e, 0xcf, 0x2d, 0xed,
+	0x66, 0x6e, 0x69, 0x5f, 0xe7, 0x96, 0x76, 0x75, 0x6f, 0x55, 0x6e, 0xee, 0xad, 0xca, 0xed, 0xbd,
+	0x55, 0x79, 0xff, 0x24, 0xc2, 0x3c, 0x9e, 0x8d, 0xed, 0x80, 0x4e, 0x9d, 0xd7, 0x98, 0xb0, 0x20,
+	0xc6, 0xd0, 0x29, 0xde, 0x48, 0xf5, 0xf2, 0x2f, 0xff, 0x5f, 0x8c, 0x37, 0x65, 0xf5, 0xe9, 0xf7,
+	0x01, 0x00, 0x6e, 0x0f, 0x4b, 0x64, 0x48, 0x06, 0x00, 0x00,
 }
 
 func (m *State) Marshal() (dAtA []byte, err error) {
@@ -393,6 +520,107 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ABCIResponses) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ABCIResponses) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BeginBlock != nil {
+		{
+			size, err := m.BeginBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.EndBlock != nil {
+		{
+			size, err := m.EndBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.DeliverTxs) > 0 {
+		for iNdEx := len(m.DeliverTxs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeliverTxs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ABCIResponsesInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ABCIResponsesInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ABCIResponsesInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.AbciResponses != nil {
+		{
+			size, err := m.AbciResponses.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -461,6 +689,45 @@ func (m *State) Size() (n int) {
 	return n
 }
 
+func (m *ABCIResponses) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DeliverTxs) > 0 {
+		for _, e := range m.DeliverTxs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ABCIResponsesInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AbciResponses != nil {
+		l = m.AbciResponses.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -967,6 +1234,267 @@ func (m *State) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ABCIResponses) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ABCIResponses: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ABCIResponses: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTxs = append(m.DeliverTxs, &types1.ResponseDeliverTx{})
+			if err := m.DeliverTxs[len(m.DeliverTxs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndBlock == nil {
+				m.EndBlock = &types2.ResponseEndBlock{}
+			}
+			if err := m.EndBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BeginBlock == nil {
+				m.BeginBlock = &types1.ResponseBeginBlock{}
+			}
+			if err := m.BeginBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ABCIResponsesInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ABCIResponsesInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ABCIResponsesInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AbciResponses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AbciResponses == nil {
+				m.AbciResponses = &ABCIResponses{}
+			}
+			if err := m.AbciResponses.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
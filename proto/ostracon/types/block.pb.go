@@ -25,10 +25,10 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Block struct {
-	Header     types.Header       `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Data       types.Data         `protobuf:"bytes,2,opt,name=data,proto3" json:"data"`
-	Evidence   types.EvidenceList `protobuf:"bytes,3,opt,name=evidence,proto3" json:"evidence"`
-	LastCommit *types.Commit      `protobuf:"bytes,4,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	Header     types.Header  `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Data       types.Data    `protobuf:"bytes,2,opt,name=data,proto3" json:"data"`
+	Evidence   EvidenceList  `protobuf:"bytes,3,opt,name=evidence,proto3" json:"evidence"`
+	LastCommit *types.Commit `protobuf:"bytes,4,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
 	// *** Ostracon Extended Fields ***
 	Entropy Entropy `protobuf:"bytes,1000,opt,name=entropy,proto3" json:"entropy"`
 }
@@ -80,11 +80,11 @@ func (m *Block) GetData() types.Data {
 	return types.Data{}
 }
 
-func (m *Block) GetEvidence() types.EvidenceList {
+func (m *Block) GetEvidence() EvidenceList {
 	if m != nil {
 		return m.Evidence
 	}
-	return types.EvidenceList{}
+	return EvidenceList{}
 }
 
 func (m *Block) GetLastCommit() *types.Commit {
@@ -108,27 +108,27 @@ func init() {
 func init() { proto.RegisterFile("ostracon/types/block.proto", fileDescriptor_69510200dee501a6) }
 
 var fileDescriptor_69510200dee501a6 = []byte{
-	// 315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0x40, 0x93, 0x7e, 0xfd, 0x5a, 0x99, 0x82, 0x8b, 0x41, 0x74, 0x28, 0x32, 0x15, 0x57, 0xae,
-	0x26, 0xfe, 0x80, 0xe8, 0x4e, 0xaa, 0x15, 0x41, 0x57, 0x5d, 0xba, 0x91, 0xe9, 0x64, 0x68, 0x06,
-	0x9b, 0x99, 0x90, 0x5c, 0x85, 0xbe, 0x85, 0x8f, 0xe1, 0xa3, 0x74, 0xd9, 0xa5, 0x2b, 0x91, 0x64,
-	0xe3, 0x63, 0x48, 0x26, 0x93, 0x6a, 0x0d, 0x6e, 0xc2, 0x25, 0xe7, 0x9c, 0xe4, 0xc2, 0x45, 0x7d,
-	0x93, 0x41, 0xca, 0x85, 0xd1, 0x01, 0xcc, 0x13, 0x99, 0x05, 0x93, 0x99, 0x11, 0x8f, 0x2c, 0x49,
-	0x0d, 0x18, 0xbc, 0x59, 0x33, 0x66, 0x59, 0x7f, 0x6b, 0x6a, 0xa6, 0xc6, 0xa2, 0xa0, 0x9c, 0x2a,
-	0xab, 0xff, 0xfb, 0x0b, 0xf6, 0xe9, 0xd8, 0x00, 0xa4, 0x0e, 0x65, 0x1a, 0x2b, 0x0d, 0x8e, 0xca,
-	0x67, 0x15, 0x4a, 0x2d, 0xa4, 0x13, 0x76, 0x1b, 0xc2, 0x8f, 0x7c, 0xff, 0xb5, 0x85, 0xfe, 0x0f,
-	0xcb, 0x85, 0xf0, 0x29, 0xea, 0x44, 0x92, 0x87, 0x32, 0x25, 0xfe, 0x9e, 0x7f, 0xd0, 0x3b, 0x26,
-	0xec, 0x3b, 0xac, 0xb6, 0x63, 0x37, 0x96, 0x0f, 0xdb, 0x8b, 0xf7, 0x81, 0x37, 0x76, 0x36, 0x3e,
-	0x44, 0xed, 0x90, 0x03, 0x27, 0x2d, 0x5b, 0x6d, 0x37, 0xab, 0x2b, 0x0e, 0xdc, 0x35, 0xd6, 0xc4,
-	0x17, 0x68, 0xa3, 0xde, 0x91, 0xfc, 0xb3, 0x15, 0x6d, 0x56, 0x23, 0x67, 0xdc, 0xa9, 0x0c, 0x5c,
-	0xbd, 0xaa, 0xf0, 0x39, 0xea, 0xcd, 0x78, 0x06, 0x0f, 0xc2, 0xc4, 0xb1, 0x02, 0xd2, 0xfe, 0x6b,
-	0xe1, 0x4b, 0xcb, 0xc7, 0xa8, 0x94, 0xab, 0x19, 0x9f, 0xa1, 0xae, 0xd4, 0x90, 0x9a, 0x64, 0x4e,
-	0x3e, 0xbb, 0xb6, 0xdb, 0x61, 0xeb, 0x47, 0x60, 0xa3, 0x8a, 0xbb, 0xbf, 0xd6, 0xfa, 0xf0, 0x76,
-	0x91, 0x53, 0x7f, 0x99, 0x53, 0xff, 0x23, 0xa7, 0xfe, 0x4b, 0x41, 0xbd, 0x65, 0x41, 0xbd, 0xb7,
-	0x82, 0x7a, 0xf7, 0x47, 0x53, 0x05, 0xd1, 0xd3, 0x84, 0x09, 0x13, 0x07, 0xd7, 0x4a, 0x67, 0x22,
-	0x52, 0x3c, 0x58, 0xdd, 0xac, 0x3a, 0xe7, 0xfa, 0x09, 0x27, 0x1d, 0xfb, 0xf6, 0xe4, 0x2b, 0x00,
-	0x00, 0xff, 0xff, 0x34, 0x90, 0xd1, 0x5a, 0x1d, 0x02, 0x00, 0x00,
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xca, 0x2f, 0x2e, 0x29,
+	0x4a, 0x4c, 0xce, 0xcf, 0xd3, 0x2f, 0xa9, 0x2c, 0x48, 0x2d, 0xd6, 0x4f, 0xca, 0xc9, 0x4f, 0xce,
+	0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x83, 0xc9, 0xe9, 0x81, 0xe5, 0xa4, 0x44, 0xd2,
+	0xf3, 0xd3, 0xf3, 0xc1, 0x52, 0xfa, 0x20, 0x16, 0x44, 0x95, 0x94, 0x2c, 0x9a, 0x09, 0xa9, 0x65,
+	0x99, 0x29, 0xa9, 0x79, 0xc9, 0xa9, 0x50, 0x69, 0x74, 0x0b, 0xc0, 0x24, 0x54, 0x4e, 0xa6, 0x24,
+	0x35, 0x2f, 0x25, 0xb5, 0x28, 0x37, 0x33, 0xaf, 0x04, 0x53, 0x56, 0x69, 0x19, 0x13, 0x17, 0xab,
+	0x13, 0xc8, 0x39, 0x42, 0x66, 0x5c, 0x6c, 0x19, 0xa9, 0x89, 0x29, 0xa9, 0x45, 0x12, 0x8c, 0x0a,
+	0x8c, 0x1a, 0xdc, 0x46, 0x12, 0x7a, 0x08, 0x8d, 0x10, 0xb7, 0xe9, 0x79, 0x80, 0xe5, 0x9d, 0x58,
+	0x4e, 0xdc, 0x93, 0x67, 0x08, 0x82, 0xaa, 0x16, 0x32, 0xe0, 0x62, 0x49, 0x49, 0x2c, 0x49, 0x94,
+	0x60, 0x02, 0xeb, 0x12, 0xc3, 0xd4, 0xe5, 0x92, 0x58, 0x92, 0x08, 0xd5, 0x03, 0x56, 0x29, 0x64,
+	0xc7, 0xc5, 0x01, 0x73, 0xbf, 0x04, 0x33, 0x58, 0x97, 0x8c, 0x1e, 0x6a, 0x28, 0xe8, 0xb9, 0x42,
+	0xe5, 0x7d, 0x32, 0x8b, 0x4b, 0xa0, 0x7a, 0xe1, 0x7a, 0x84, 0x2c, 0xb9, 0xb8, 0x73, 0x12, 0x8b,
+	0x4b, 0xe2, 0x93, 0xf3, 0x73, 0x73, 0x33, 0x4b, 0x24, 0x58, 0x70, 0x39, 0xd7, 0x19, 0x2c, 0x1f,
+	0xc4, 0x05, 0x52, 0x0c, 0x61, 0x0b, 0x59, 0x70, 0xb1, 0xa7, 0xe6, 0x95, 0x14, 0xe5, 0x17, 0x54,
+	0x4a, 0xbc, 0x60, 0x07, 0xeb, 0x13, 0xc7, 0xb0, 0x1a, 0x22, 0x0f, 0xb5, 0x15, 0xa6, 0xdc, 0xc9,
+	0xfb, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58,
+	0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18, 0xa2, 0x0c, 0xd3, 0x33, 0x4b, 0x32,
+	0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0xdd, 0x32, 0xf3, 0x8a, 0x93, 0x33, 0x32, 0x13, 0xf5,
+	0xe1, 0x11, 0x02, 0x89, 0x4a, 0xd4, 0xf8, 0x49, 0x62, 0x03, 0x8b, 0x1a, 0x03, 0x06, 0x00, 0x4b,
+	0x24, 0x70, 0x4f, 0x19, 0x02, 0x00, 0x00,
 }
 
 func (m *Block) Marshal() (dAtA []byte, err error) {
@@ -6,6 +6,7 @@ package types
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -77,25 +78,98 @@ func (m *Entropy) GetProof() []byte {
 	return nil
 }
 
+// SimpleValidator extends the SimpleValidator of Tendermint with the address
+// of a validator which rotated its key.
+type SimpleValidator struct {
+	PubKey      *crypto.PublicKey `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	VotingPower int64             `protobuf:"varint,2,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	// *** Ostracon Extended Fields ***
+	// address is set only if the validator rotated its key, i.e. if it isn't
+	// the address of pub_key.
+	Address []byte `protobuf:"bytes,1000,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *SimpleValidator) Reset()         { *m = SimpleValidator{} }
+func (m *SimpleValidator) String() string { return proto.CompactTextString(m) }
+func (*SimpleValidator) ProtoMessage()    {}
+func (*SimpleValidator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0e52e849a4baef8c, []int{1}
+}
+func (m *SimpleValidator) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimpleValidator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimpleValidator.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimpleValidator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimpleValidator.Merge(m, src)
+}
+func (m *SimpleValidator) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimpleValidator) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimpleValidator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimpleValidator proto.InternalMessageInfo
+
+func (m *SimpleValidator) GetPubKey() *crypto.PublicKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *SimpleValidator) GetVotingPower() int64 {
+	if m != nil {
+		return m.VotingPower
+	}
+	return 0
+}
+
+func (m *SimpleValidator) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Entropy)(nil), "ostracon.types.Entropy")
+	proto.RegisterType((*SimpleValidator)(nil), "ostracon.types.SimpleValidator")
 }
 
 func init() { proto.RegisterFile("ostracon/types/types.proto", fileDescriptor_0e52e849a4baef8c) }
 
 var fileDescriptor_0e52e849a4baef8c = []byte{
-	// 161 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xca, 0x2f, 0x2e, 0x29,
-	0x4a, 0x4c, 0xce, 0xcf, 0xd3, 0x2f, 0xa9, 0x2c, 0x48, 0x2d, 0x86, 0x90, 0x7a, 0x05, 0x45, 0xf9,
-	0x25, 0xf9, 0x42, 0x7c, 0x30, 0x39, 0x3d, 0xb0, 0xa8, 0x92, 0x29, 0x17, 0xbb, 0x6b, 0x5e, 0x49,
-	0x51, 0x7e, 0x41, 0xa5, 0x90, 0x08, 0x17, 0x6b, 0x51, 0x7e, 0x69, 0x5e, 0x8a, 0x04, 0xa3, 0x02,
-	0xa3, 0x06, 0x6b, 0x10, 0x84, 0x03, 0x12, 0x2d, 0x28, 0xca, 0xcf, 0x4f, 0x93, 0x60, 0x52, 0x60,
-	0xd4, 0xe0, 0x09, 0x82, 0x70, 0x9c, 0xbc, 0x4f, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1,
-	0xc1, 0x23, 0x39, 0xc6, 0x09, 0x8f, 0xe5, 0x18, 0x2e, 0x3c, 0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e,
-	0x21, 0xca, 0x30, 0x3d, 0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57, 0xdf, 0x2d, 0x33,
-	0xaf, 0x38, 0x39, 0x23, 0x33, 0x51, 0x1f, 0xee, 0x20, 0xb0, 0x23, 0xf4, 0x51, 0xdd, 0x97, 0xc4,
-	0x06, 0x16, 0x35, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x47, 0xcc, 0x4e, 0xb8, 0x00, 0x00,
-	0x00,
+	// 284 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xcf, 0x4a, 0x03, 0x31,
+	0x10, 0xc6, 0x1b, 0xa5, 0x5d, 0x48, 0x8b, 0xc2, 0xe2, 0x61, 0x2d, 0x25, 0xd4, 0x9e, 0x7a, 0x4a,
+	0x50, 0xe9, 0x0b, 0x08, 0x7a, 0xd9, 0x4b, 0x59, 0xc1, 0x83, 0x97, 0xb2, 0x7f, 0x62, 0x1b, 0xba,
+	0x9b, 0x09, 0xd9, 0xac, 0x92, 0x17, 0xf0, 0xec, 0x63, 0x79, 0xec, 0xd1, 0xa3, 0xec, 0x5e, 0x7c,
+	0x0c, 0x69, 0x62, 0x15, 0x2f, 0x03, 0xdf, 0x6f, 0x86, 0xf9, 0x66, 0x3e, 0x3c, 0x86, 0xda, 0xe8,
+	0x34, 0x07, 0xc9, 0x8c, 0x55, 0xbc, 0xf6, 0x95, 0x2a, 0x0d, 0x06, 0xc2, 0x93, 0x43, 0x8f, 0x3a,
+	0x3a, 0x9e, 0x18, 0x2e, 0x0b, 0xae, 0x2b, 0x21, 0x0d, 0xcb, 0xb5, 0x55, 0x06, 0xd8, 0x96, 0xdb,
+	0x9f, 0xe9, 0xd9, 0x02, 0x07, 0xb7, 0xd2, 0x68, 0x50, 0x36, 0x3c, 0xc3, 0x7d, 0x0d, 0x8d, 0x2c,
+	0x22, 0x34, 0x45, 0xf3, 0x7e, 0xe2, 0xc5, 0x9e, 0x2a, 0x0d, 0xf0, 0x14, 0x1d, 0x4d, 0xd1, 0x7c,
+	0x94, 0x78, 0x31, 0x7b, 0x45, 0xf8, 0xf4, 0x5e, 0x54, 0xaa, 0xe4, 0x0f, 0x69, 0x29, 0x8a, 0xd4,
+	0x80, 0x0e, 0x17, 0x38, 0x50, 0x4d, 0xb6, 0xda, 0x72, 0xeb, 0x36, 0x0c, 0xaf, 0x26, 0xf4, 0xcf,
+	0x9a, 0x7a, 0x6b, 0xba, 0x6c, 0xb2, 0x52, 0xe4, 0x31, 0xb7, 0xc9, 0x40, 0x35, 0x59, 0xcc, 0x6d,
+	0x78, 0x81, 0x47, 0xcf, 0x60, 0x84, 0x5c, 0xaf, 0x14, 0xbc, 0x70, 0xed, 0x7c, 0x8e, 0x93, 0xa1,
+	0x67, 0xcb, 0x3d, 0x0a, 0xcf, 0x71, 0x90, 0x16, 0x85, 0xe6, 0x75, 0x1d, 0x7d, 0x05, 0xee, 0x8c,
+	0x83, 0xbe, 0x89, 0xdf, 0x5b, 0x82, 0x76, 0x2d, 0x41, 0x9f, 0x2d, 0x41, 0x6f, 0x1d, 0xe9, 0xed,
+	0x3a, 0xd2, 0xfb, 0xe8, 0x48, 0xef, 0xf1, 0x72, 0x2d, 0xcc, 0xa6, 0xc9, 0x68, 0x0e, 0x15, 0xbb,
+	0x13, 0xb2, 0xce, 0x37, 0x22, 0x65, 0xbf, 0xb9, 0xb9, 0xef, 0xd9, 0xff, 0x18, 0xb3, 0x81, 0xa3,
+	0xd7, 0xdf, 0x03, 0x00, 0xf3, 0x23, 0x62, 0x6d, 0x5f, 0x01, 0x00, 0x00,
 }
 
 func (m *Entropy) Marshal() (dAtA []byte, err error) {
@@ -133,6 +207,55 @@ func (m *Entropy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SimpleValidator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimpleValidator) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimpleValidator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.VotingPower != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.VotingPower))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.PubKey != nil {
+		{
+			size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -160,6 +283,26 @@ func (m *Entropy) Size() (n int) {
 	return n
 }
 
+func (m *SimpleValidator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.VotingPower != 0 {
+		n += 1 + sovTypes(uint64(m.VotingPower))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -269,6 +412,145 @@ func (m *Entropy) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SimpleValidator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimpleValidator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimpleValidator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PubKey == nil {
+				m.PubKey = &crypto.PublicKey{}
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VotingPower", wireType)
+			}
+			m.VotingPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VotingPower |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -10,6 +10,20 @@ import (
 //nolint
 //go:generate ../scripts/mockery_generate.sh AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
 
+// Reconnector is implemented by the connections of AppConns, which reconnect to
+// the application when a connection fails if they're resilient.
+type Reconnector interface {
+	// WaitReconnect returns false if the connection didn't fail or doesn't
+	// reconnect. Otherwise it waits until the connection reconnected to the
+	// application and the application was resynced, and returns true, or false
+	// if the AppConns stopped meanwhile.
+	WaitReconnect() bool
+
+	// Reconnections returns the number of times the connection reconnected to
+	// the application.
+	Reconnections() int64
+}
+
 //----------------------------------------------------------------------------------------
 // Enforce which abci msgs can be sent on a connection at the type level
 
@@ -21,8 +35,11 @@ type AppConnConsensus interface {
 
 	BeginBlockSync(ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	DeliverTxAsync(types.RequestDeliverTx, abcicli.ResponseCallback) *abcicli.ReqRes
-	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	DeliverTxBatchAsync(ocabci.RequestDeliverTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes
+	EndBlockSync(types.RequestEndBlock) (*ocabci.ResponseEndBlock, error)
 	CommitSync() (*types.ResponseCommit, error)
+	AbortBlockSync(ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error)
+	TxAccessSetsSync(ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error)
 }
 
 type AppConnMempool interface {
@@ -31,6 +48,7 @@ type AppConnMempool interface {
 
 	CheckTxAsync(types.RequestCheckTx, abcicli.ResponseCallback) *abcicli.ReqRes
 	CheckTxSync(types.RequestCheckTx) (*ocabci.ResponseCheckTx, error)
+	CheckTxBatchAsync(ocabci.RequestCheckTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes
 
 	BeginRecheckTxSync(ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error)
 	EndRecheckTxSync(ocabci.RequestEndRecheckTx) (*ocabci.ResponseEndRecheckTx, error)
@@ -62,148 +80,206 @@ type AppConnSnapshot interface {
 // Implements AppConnConsensus (subset of abcicli.Client)
 
 type appConnConsensus struct {
-	appConn abcicli.Client
+	appConn *appConnClient
 }
 
 func NewAppConnConsensus(appConn abcicli.Client) AppConnConsensus {
 	return &appConnConsensus{
-		appConn: appConn,
+		appConn: newAppConnClient(appConn),
 	}
 }
 
+// WaitReconnect implements Reconnector.
+func (app *appConnConsensus) WaitReconnect() bool {
+	return app.appConn.waitReconnect()
+}
+
+// Reconnections implements Reconnector.
+func (app *appConnConsensus) Reconnections() int64 {
+	return app.appConn.reconnectionCount()
+}
+
 func (app *appConnConsensus) SetGlobalCallback(globalCb abcicli.GlobalCallback) {
-	app.appConn.SetGlobalCallback(globalCb)
+	app.appConn.setGlobalCallback(globalCb)
 }
 
 func (app *appConnConsensus) Error() error {
-	return app.appConn.Error()
+	return app.appConn.current().Error()
 }
 
 func (app *appConnConsensus) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
-	return app.appConn.InitChainSync(req)
+	return app.appConn.get().InitChainSync(req)
 }
 
 func (app *appConnConsensus) BeginBlockSync(req ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
-	return app.appConn.BeginBlockSync(req)
+	return app.appConn.get().BeginBlockSync(req)
 }
 
 func (app *appConnConsensus) DeliverTxAsync(req types.RequestDeliverTx, cb abcicli.ResponseCallback) *abcicli.ReqRes {
-	return app.appConn.DeliverTxAsync(req, cb)
+	return app.appConn.get().DeliverTxAsync(req, cb)
 }
 
-func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
-	return app.appConn.EndBlockSync(req)
+func (app *appConnConsensus) DeliverTxBatchAsync(
+	req ocabci.RequestDeliverTxBatch, cb abcicli.ResponseCallback) *abcicli.ReqRes {
+	return app.appConn.get().DeliverTxBatchAsync(req, cb)
+}
+
+func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*ocabci.ResponseEndBlock, error) {
+	return app.appConn.get().EndBlockSync(req)
 }
 
 func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
-	return app.appConn.CommitSync()
+	return app.appConn.get().CommitSync()
+}
+
+func (app *appConnConsensus) AbortBlockSync(req ocabci.RequestAbortBlock) (*ocabci.ResponseAbortBlock, error) {
+	return app.appConn.get().AbortBlockSync(req)
+}
+
+func (app *appConnConsensus) TxAccessSetsSync(req ocabci.RequestTxAccessSets) (*ocabci.ResponseTxAccessSets, error) {
+	return app.appConn.get().TxAccessSetsSync(req)
 }
 
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
 type appConnMempool struct {
-	appConn abcicli.Client
+	appConn *appConnClient
 }
 
 func NewAppConnMempool(appConn abcicli.Client) AppConnMempool {
 	return &appConnMempool{
-		appConn: appConn,
+		appConn: newAppConnClient(appConn),
 	}
 }
 
+// WaitReconnect implements Reconnector.
+func (app *appConnMempool) WaitReconnect() bool {
+	return app.appConn.waitReconnect()
+}
+
+// Reconnections implements Reconnector.
+func (app *appConnMempool) Reconnections() int64 {
+	return app.appConn.reconnectionCount()
+}
+
 func (app *appConnMempool) SetGlobalCallback(globalCb abcicli.GlobalCallback) {
-	app.appConn.SetGlobalCallback(globalCb)
+	app.appConn.setGlobalCallback(globalCb)
 }
 
 func (app *appConnMempool) Error() error {
-	return app.appConn.Error()
+	return app.appConn.current().Error()
 }
 
 func (app *appConnMempool) FlushAsync(cb abcicli.ResponseCallback) *abcicli.ReqRes {
-	return app.appConn.FlushAsync(cb)
+	return app.appConn.get().FlushAsync(cb)
 }
 
 func (app *appConnMempool) FlushSync() (*types.ResponseFlush, error) {
-	return app.appConn.FlushSync()
+	return app.appConn.get().FlushSync()
 }
 
 func (app *appConnMempool) CheckTxAsync(req types.RequestCheckTx, cb abcicli.ResponseCallback) *abcicli.ReqRes {
-	return app.appConn.CheckTxAsync(req, cb)
+	return app.appConn.get().CheckTxAsync(req, cb)
 }
 
 func (app *appConnMempool) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
-	return app.appConn.CheckTxSync(req)
+	return app.appConn.get().CheckTxSync(req)
+}
+
+func (app *appConnMempool) CheckTxBatchAsync(
+	req ocabci.RequestCheckTxBatch, cb abcicli.ResponseCallback) *abcicli.ReqRes {
+	return app.appConn.get().CheckTxBatchAsync(req, cb)
 }
 
 func (app *appConnMempool) BeginRecheckTxSync(req ocabci.RequestBeginRecheckTx) (*ocabci.ResponseBeginRecheckTx, error) {
-	return app.appConn.BeginRecheckTxSync(req)
+	return app.appConn.get().BeginRecheckTxSync(req)
 }
 
 func (app *appConnMempool) EndRecheckTxSync(req ocabci.RequestEndRecheckTx) (*ocabci.ResponseEndRecheckTx, error) {
-	return app.appConn.EndRecheckTxSync(req)
+	return app.appConn.get().EndRecheckTxSync(req)
 }
 
 //------------------------------------------------
 // Implements AppConnQuery (subset of abcicli.Client)
 
 type appConnQuery struct {
-	appConn abcicli.Client
+	appConn *appConnClient
 }
 
 func NewAppConnQuery(appConn abcicli.Client) AppConnQuery {
 	return &appConnQuery{
-		appConn: appConn,
+		appConn: newAppConnClient(appConn),
 	}
 }
 
+// WaitReconnect implements Reconnector.
+func (app *appConnQuery) WaitReconnect() bool {
+	return app.appConn.waitReconnect()
+}
+
+// Reconnections implements Reconnector.
+func (app *appConnQuery) Reconnections() int64 {
+	return app.appConn.reconnectionCount()
+}
+
 func (app *appConnQuery) Error() error {
-	return app.appConn.Error()
+	return app.appConn.current().Error()
 }
 
 func (app *appConnQuery) EchoSync(msg string) (*types.ResponseEcho, error) {
-	return app.appConn.EchoSync(msg)
+	return app.appConn.get().EchoSync(msg)
 }
 
 func (app *appConnQuery) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
-	return app.appConn.InfoSync(req)
+	return app.appConn.get().InfoSync(req)
 }
 
 func (app *appConnQuery) QuerySync(reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
-	return app.appConn.QuerySync(reqQuery)
+	return app.appConn.get().QuerySync(reqQuery)
 }
 
 //------------------------------------------------
 // Implements AppConnSnapshot (subset of abcicli.Client)
 
 type appConnSnapshot struct {
-	appConn abcicli.Client
+	appConn *appConnClient
 }
 
 func NewAppConnSnapshot(appConn abcicli.Client) AppConnSnapshot {
 	return &appConnSnapshot{
-		appConn: appConn,
+		appConn: newAppConnClient(appConn),
 	}
 }
 
+// WaitReconnect implements Reconnector.
+func (app *appConnSnapshot) WaitReconnect() bool {
+	return app.appConn.waitReconnect()
+}
+
+// Reconnections implements Reconnector.
+func (app *appConnSnapshot) Reconnections() int64 {
+	return app.appConn.reconnectionCount()
+}
+
 func (app *appConnSnapshot) Error() error {
-	return app.appConn.Error()
+	return app.appConn.current().Error()
 }
 
 func (app *appConnSnapshot) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
-	return app.appConn.ListSnapshotsSync(req)
+	return app.appConn.get().ListSnapshotsSync(req)
 }
 
 func (app *appConnSnapshot) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
-	return app.appConn.OfferSnapshotSync(req)
+	return app.appConn.get().OfferSnapshotSync(req)
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
-	return app.appConn.LoadSnapshotChunkSync(req)
+	return app.appConn.get().LoadSnapshotChunkSync(req)
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
-	return app.appConn.ApplySnapshotChunkSync(req)
+	return app.appConn.get().ApplySnapshotChunkSync(req)
 }
@@ -2,11 +2,15 @@ package proxy
 
 import (
 	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	abcicli "github.com/Finschia/ostracon/abci/client"
 	"github.com/Finschia/ostracon/abci/example/counter"
 	"github.com/Finschia/ostracon/abci/example/kvstore"
 	"github.com/Finschia/ostracon/abci/types"
+	tmlog "github.com/Finschia/ostracon/libs/log"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 	e2e "github.com/Finschia/ostracon/test/e2e/app"
 )
@@ -40,6 +44,169 @@ func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
+//---------------------------------------------------------------
+// recording proxy records the requests and responses of another proxy's clients
+
+// connClientCreator is a ClientCreator whose clients depend on the connection
+// to the application they're created for.
+type connClientCreator interface {
+	ClientCreator
+	// newABCIClientFor returns a new ABCI client for the given connection.
+	newABCIClientFor(conn string) (abcicli.Client, error)
+}
+
+type recordingClientCreator struct {
+	creator  ClientCreator
+	recorder *abcicli.Recorder
+}
+
+// NewRecordingClientCreator returns a ClientCreator whose clients are the
+// clients of creator, recording their requests and responses with recorder.
+// The exchanges are recorded on the connection the clients are created for by
+// AppConns, e.g. "consensus".
+func NewRecordingClientCreator(creator ClientCreator, recorder *abcicli.Recorder) ClientCreator {
+	return &recordingClientCreator{
+		creator:  creator,
+		recorder: recorder,
+	}
+}
+
+func (r *recordingClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return r.newABCIClientFor("")
+}
+
+func (r *recordingClientCreator) newABCIClientFor(conn string) (abcicli.Client, error) {
+	client, err := newABCIClientFor(r.creator, conn)
+	if err != nil {
+		return nil, err
+	}
+	return abcicli.NewRecordingClient(client, conn, r.recorder), nil
+}
+
+// newABCIClientFor returns a new ABCI client created by creator for the given
+// connection.
+func newABCIClientFor(creator ClientCreator, conn string) (abcicli.Client, error) {
+	if creator, ok := creator.(connClientCreator); ok {
+		return creator.newABCIClientFor(conn)
+	}
+	return creator.NewABCIClient()
+}
+
+//---------------------------------------------------------------
+// routing proxy sends the requests of some connections to other processes
+
+type routingClientCreator struct {
+	primary  ClientCreator
+	query    ClientCreator
+	snapshot ClientCreator
+}
+
+// NewRoutingClientCreator returns a ClientCreator whose clients are created by
+// primary, except that:
+//   - the Query requests of the query connection are sent to the clients of
+//     query, e.g. to read replicas of the application, if it's set. The other
+//     requests of the query connection, like Info, are still sent to primary.
+//   - the clients of the snapshot connection are created by snapshot, e.g. for
+//     a snapshotting sidecar of the application, if it's set.
+func NewRoutingClientCreator(primary, query, snapshot ClientCreator) ClientCreator {
+	return &routingClientCreator{
+		primary:  primary,
+		query:    query,
+		snapshot: snapshot,
+	}
+}
+
+func (r *routingClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return r.primary.NewABCIClient()
+}
+
+func (r *routingClientCreator) newABCIClientFor(conn string) (abcicli.Client, error) {
+	switch {
+	case conn == connQuery && r.query != nil:
+		client, err := newABCIClientFor(r.primary, conn)
+		if err != nil {
+			return nil, err
+		}
+		queries, err := r.query.NewABCIClient()
+		if err != nil {
+			return nil, err
+		}
+		return &queryRoutingClient{Client: client, queries: queries}, nil
+	case conn == connSnapshot && r.snapshot != nil:
+		return r.snapshot.NewABCIClient()
+	default:
+		return newABCIClientFor(r.primary, conn)
+	}
+}
+
+// queryRoutingClient is a client sending the Query requests to queries, and
+// the other ones to the embedded client. It only fails if the embedded client
+// does.
+type queryRoutingClient struct {
+	abcicli.Client
+	queries abcicli.Client
+}
+
+func (cli *queryRoutingClient) SetLogger(logger tmlog.Logger) {
+	cli.Client.SetLogger(logger)
+	cli.queries.SetLogger(logger.With("route", "query"))
+}
+
+func (cli *queryRoutingClient) Start() error {
+	if err := cli.queries.Start(); err != nil {
+		return err
+	}
+	if err := cli.Client.Start(); err != nil {
+		cli.queries.Stop() //nolint:errcheck // already failing
+		return err
+	}
+	return nil
+}
+
+func (cli *queryRoutingClient) Stop() error {
+	err := cli.Client.Stop()
+	if qErr := cli.queries.Stop(); err == nil {
+		err = qErr
+	}
+	return err
+}
+
+func (cli *queryRoutingClient) QueryAsync(req abci.RequestQuery, cb abcicli.ResponseCallback) *abcicli.ReqRes {
+	return cli.queries.QueryAsync(req, cb)
+}
+
+func (cli *queryRoutingClient) QuerySync(req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	return cli.queries.QuerySync(req)
+}
+
+//---------------------------------------------------------------
+// pool proxy balances the requests between several processes
+
+type poolClientCreator struct {
+	creators            []ClientCreator
+	healthCheckInterval time.Duration
+}
+
+// NewPoolClientCreator returns a ClientCreator whose clients send each
+// request to the next healthy client of a pool, round-robin, with a client
+// created by each of creators. The health of the clients is checked every
+// healthCheckInterval. The clients of creators must fail to start if they
+// can't connect.
+func NewPoolClientCreator(creators []ClientCreator, healthCheckInterval time.Duration) ClientCreator {
+	return &poolClientCreator{
+		creators:            creators,
+		healthCheckInterval: healthCheckInterval,
+	}
+}
+
+func (p *poolClientCreator) NewABCIClient() (abcicli.Client, error) {
+	newClients := make([]abcicli.NewClientFunc, len(p.creators))
+	for i, creator := range p.creators {
+		newClients[i] = creator.NewABCIClient
+	}
+	return abcicli.NewPoolClient(newClients, p.healthCheckInterval), nil
+}
+
 //---------------------------------------------------------------
 // remote proxy opens new connections to an external app process
 
@@ -47,21 +214,24 @@ type remoteClientCreator struct {
 	addr        string
 	transport   string
 	mustConnect bool
+	opts        []abcicli.ClientOption
 }
 
 // NewRemoteClientCreator returns a ClientCreator for the given address (e.g.
 // "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
-// want the client to connect before reporting success.
-func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
+// want the client to connect before reporting success. The clients are created
+// with opts, e.g. to connect over TLS.
+func NewRemoteClientCreator(addr, transport string, mustConnect bool, opts ...abcicli.ClientOption) ClientCreator {
 	return &remoteClientCreator{
 		addr:        addr,
 		transport:   transport,
 		mustConnect: mustConnect,
+		opts:        opts,
 	}
 }
 
 func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
-	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect)
+	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect, r.opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
 	}
@@ -71,8 +241,8 @@ func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
 
 // DefaultClientCreator returns a default ClientCreator, which will create a
 // local client if addr is one of: 'counter', 'counter_serial', 'kvstore',
-// 'persistent_kvstore' or 'noop', otherwise - a remote client.
-func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
+// 'persistent_kvstore' or 'noop', otherwise - a remote client created with opts.
+func DefaultClientCreator(addr, transport, dbDir string, opts ...abcicli.ClientOption) ClientCreator {
 	switch addr {
 	case "counter":
 		return NewLocalClientCreator(counter.NewApplication(false))
@@ -92,6 +262,6 @@ func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
 		return NewLocalClientCreator(types.NewBaseApplication())
 	default:
 		mustConnect := false // loop retrying
-		return NewRemoteClientCreator(addr, transport, mustConnect)
+		return NewRemoteClientCreator(addr, transport, mustConnect, opts...)
 	}
 }
@@ -16,6 +16,32 @@ type AppConnConsensus struct {
 	mock.Mock
 }
 
+// AbortBlockSync provides a mock function with given fields: _a0
+func (_m *AppConnConsensus) AbortBlockSync(_a0 types.RequestAbortBlock) (*types.ResponseAbortBlock, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseAbortBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.RequestAbortBlock) (*types.ResponseAbortBlock, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(types.RequestAbortBlock) *types.ResponseAbortBlock); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseAbortBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.RequestAbortBlock) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BeginBlockSync provides a mock function with given fields: _a0
 func (_m *AppConnConsensus) BeginBlockSync(_a0 types.RequestBeginBlock) (*abcitypes.ResponseBeginBlock, error) {
 	ret := _m.Called(_a0)
@@ -84,20 +110,36 @@ func (_m *AppConnConsensus) DeliverTxAsync(_a0 abcitypes.RequestDeliverTx, _a1 a
 	return r0
 }
 
+// DeliverTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *AppConnConsensus) DeliverTxBatchAsync(_a0 types.RequestDeliverTxBatch, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(types.RequestDeliverTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
 // EndBlockSync provides a mock function with given fields: _a0
-func (_m *AppConnConsensus) EndBlockSync(_a0 abcitypes.RequestEndBlock) (*abcitypes.ResponseEndBlock, error) {
+func (_m *AppConnConsensus) EndBlockSync(_a0 abcitypes.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *abcitypes.ResponseEndBlock
+	var r0 *types.ResponseEndBlock
 	var r1 error
-	if rf, ok := ret.Get(0).(func(abcitypes.RequestEndBlock) (*abcitypes.ResponseEndBlock, error)); ok {
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestEndBlock) (*types.ResponseEndBlock, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(abcitypes.RequestEndBlock) *abcitypes.ResponseEndBlock); ok {
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestEndBlock) *types.ResponseEndBlock); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*abcitypes.ResponseEndBlock)
+			r0 = ret.Get(0).(*types.ResponseEndBlock)
 		}
 	}
 
@@ -155,6 +197,32 @@ func (_m *AppConnConsensus) SetGlobalCallback(_a0 abcicli.GlobalCallback) {
 	_m.Called(_a0)
 }
 
+// TxAccessSetsSync provides a mock function with given fields: _a0
+func (_m *AppConnConsensus) TxAccessSetsSync(_a0 types.RequestTxAccessSets) (*types.ResponseTxAccessSets, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseTxAccessSets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.RequestTxAccessSets) (*types.ResponseTxAccessSets, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(types.RequestTxAccessSets) *types.ResponseTxAccessSets); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseTxAccessSets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.RequestTxAccessSets) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAppConnConsensus creates a new instance of AppConnConsensus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAppConnConsensus(t interface {
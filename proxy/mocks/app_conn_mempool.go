@@ -58,6 +58,22 @@ func (_m *AppConnMempool) CheckTxAsync(_a0 abcitypes.RequestCheckTx, _a1 abcicli
 	return r0
 }
 
+// CheckTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *AppConnMempool) CheckTxBatchAsync(_a0 types.RequestCheckTxBatch, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(types.RequestCheckTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
 // CheckTxSync provides a mock function with given fields: _a0
 func (_m *AppConnMempool) CheckTxSync(_a0 abcitypes.RequestCheckTx) (*types.ResponseCheckTx, error) {
 	ret := _m.Called(_a0)
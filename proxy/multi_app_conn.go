@@ -2,11 +2,14 @@ package proxy
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	abcicli "github.com/Finschia/ostracon/abci/client"
 	tmlog "github.com/Finschia/ostracon/libs/log"
 	tmos "github.com/Finschia/ostracon/libs/os"
 	"github.com/Finschia/ostracon/libs/service"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
 )
 
 const (
@@ -14,6 +17,9 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+
+	// the first delay between the attempts to reconnect to the application
+	reconnectInitialBackoff = 500 * time.Millisecond
 )
 
 // AppConns is the Ostracon's interface to the application that consists of
@@ -36,11 +42,14 @@ func NewAppConns(clientCreator ClientCreator) AppConns {
 	return NewMultiAppConn(clientCreator)
 }
 
+// ResyncFunc resyncs the application with the node once the resilient AppConns
+// reconnected to it, using the new connections.
+type ResyncFunc func(AppConns) error
+
 // multiAppConn implements AppConns.
 //
 // A multiAppConn is made of a few appConns and manages their underlying abci
 // clients.
-// TODO: on app restart, clients must reboot together
 type multiAppConn struct {
 	service.BaseService
 
@@ -49,15 +58,20 @@ type multiAppConn struct {
 	queryConn     AppConnQuery
 	snapshotConn  AppConnSnapshot
 
-	consensusConnClient abcicli.Client
-	mempoolConnClient   abcicli.Client
-	queryConnClient     abcicli.Client
-	snapshotConnClient  abcicli.Client
+	consensusConnClient *appConnClient
+	mempoolConnClient   *appConnClient
+	queryConnClient     *appConnClient
+	snapshotConnClient  *appConnClient
 
 	clientCreator ClientCreator
+
+	// if set, the clients reconnect together when one of them fails
+	resync     ResyncFunc
+	maxBackoff time.Duration
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
+// Ostracon is killed if one of them fails.
 func NewMultiAppConn(clientCreator ClientCreator) AppConns {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
@@ -66,6 +80,23 @@ func NewMultiAppConn(clientCreator ClientCreator) AppConns {
 	return multiAppConn
 }
 
+// NewResilientAppConns makes all necessary abci connections to the
+// application. If one of them fails, e.g. because the application crashed, they
+// all reconnect, retrying with an exponential backoff up to maxBackoff, instead
+// of killing Ostracon. The calls on the connections wait until they
+// reconnected. Once reconnected, resync is called with the new connections
+// before they're used again. Ostracon is killed if it fails for another
+// reason than a connection failure.
+func NewResilientAppConns(clientCreator ClientCreator, maxBackoff time.Duration, resync ResyncFunc) AppConns {
+	multiAppConn := &multiAppConn{
+		clientCreator: clientCreator,
+		resync:        resync,
+		maxBackoff:    maxBackoff,
+	}
+	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	return multiAppConn
+}
+
 func (app *multiAppConn) Mempool() AppConnMempool {
 	return app.mempoolConn
 }
@@ -83,103 +114,189 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 }
 
 func (app *multiAppConn) OnStart() error {
-	c, err := app.abciClientFor(connQuery)
+	clients, err := app.startClients()
 	if err != nil {
 		return err
 	}
-	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c)
+	resilient := app.resync != nil
+	app.queryConnClient = newAppConnClient(clients[connQuery])
+	app.snapshotConnClient = newAppConnClient(clients[connSnapshot])
+	app.mempoolConnClient = newAppConnClient(clients[connMempool])
+	app.consensusConnClient = newAppConnClient(clients[connConsensus])
+	for _, c := range app.connClients() {
+		c.resilient = resilient
+	}
+	app.queryConn = &appConnQuery{appConn: app.queryConnClient}
+	app.snapshotConn = &appConnSnapshot{appConn: app.snapshotConnClient}
+	app.mempoolConn = &appConnMempool{appConn: app.mempoolConnClient}
+	app.consensusConn = &appConnConsensus{appConn: app.consensusConnClient}
 
-	c, err = app.abciClientFor(connSnapshot)
-	if err != nil {
-		app.stopAllClients()
-		return err
+	if resilient {
+		go app.reconnectOnClientError()
+	} else {
+		// Kill Ostracon if the ABCI application crashes.
+		go app.killOCOnClientError()
 	}
-	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c)
 
-	c, err = app.abciClientFor(connMempool)
-	if err != nil {
-		app.stopAllClients()
-		return err
+	return nil
+}
+
+func (app *multiAppConn) OnStop() {
+	for _, c := range app.connClients() {
+		c.stop()
 	}
-	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
+	app.stopAllClients(app.currentClients())
+}
 
-	c, err = app.abciClientFor(connConsensus)
-	if err != nil {
-		app.stopAllClients()
-		return err
+// startClients starts a client for each connection, in the order they're
+// needed by the handshake.
+func (app *multiAppConn) startClients() (map[string]abcicli.Client, error) {
+	clients := make(map[string]abcicli.Client, 4)
+	for _, conn := range []string{connQuery, connSnapshot, connMempool, connConsensus} {
+		c, err := app.abciClientFor(conn)
+		if err != nil {
+			app.stopAllClients(clients)
+			return nil, err
+		}
+		clients[conn] = c
 	}
-	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c)
+	return clients, nil
+}
 
-	// Kill Ostracon if the ABCI application crashes.
-	go app.killOCOnClientError()
+func (app *multiAppConn) connClients() map[string]*appConnClient {
+	return map[string]*appConnClient{
+		connConsensus: app.consensusConnClient,
+		connMempool:   app.mempoolConnClient,
+		connQuery:     app.queryConnClient,
+		connSnapshot:  app.snapshotConnClient,
+	}
+}
 
-	return nil
+func (app *multiAppConn) currentClients() map[string]abcicli.Client {
+	clients := make(map[string]abcicli.Client, 4)
+	for conn, c := range app.connClients() {
+		if c != nil {
+			clients[conn] = c.current()
+		}
+	}
+	return clients
 }
 
-func (app *multiAppConn) OnStop() {
-	app.stopAllClients()
+// waitClientError waits until one of the clients quits, and returns its
+// connection and error. It returns a nil error if the client was stopped
+// normally.
+func (app *multiAppConn) waitClientError(clients map[string]abcicli.Client) (string, error) {
+	select {
+	case <-clients[connConsensus].Quit():
+		return connConsensus, clients[connConsensus].Error()
+	case <-clients[connMempool].Quit():
+		return connMempool, clients[connMempool].Error()
+	case <-clients[connQuery].Quit():
+		return connQuery, clients[connQuery].Error()
+	case <-clients[connSnapshot].Quit():
+		return connSnapshot, clients[connSnapshot].Error()
+	}
 }
 
 func (app *multiAppConn) killOCOnClientError() {
-	killFn := func(conn string, err error, logger tmlog.Logger) {
-		logger.Error(
-			fmt.Sprintf("%s connection terminated. Did the application crash? Please restart ostracon", conn),
-			"err", err)
-		killErr := tmos.Kill()
-		if killErr != nil {
-			logger.Error("Failed to kill this process - please do so manually", "err", killErr)
-		}
+	conn, err := app.waitClientError(app.currentClients())
+	if err != nil {
+		killOC(conn, err, app.Logger)
 	}
+}
 
-	select {
-	case <-app.consensusConnClient.Quit():
-		if err := app.consensusConnClient.Error(); err != nil {
-			killFn(connConsensus, err, app.Logger)
-		}
-	case <-app.mempoolConnClient.Quit():
-		if err := app.mempoolConnClient.Error(); err != nil {
-			killFn(connMempool, err, app.Logger)
-		}
-	case <-app.queryConnClient.Quit():
-		if err := app.queryConnClient.Error(); err != nil {
-			killFn(connQuery, err, app.Logger)
+func killOC(conn string, err error, logger tmlog.Logger) {
+	logger.Error(
+		fmt.Sprintf("%s connection terminated. Did the application crash? Please restart ostracon", conn),
+		"err", err)
+	killErr := tmos.Kill()
+	if killErr != nil {
+		logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+	}
+}
+
+// reconnectOnClientError reconnects all the clients when one of them fails,
+// until multiAppConn stops.
+func (app *multiAppConn) reconnectOnClientError() {
+	for {
+		conn, err := app.waitClientError(app.currentClients())
+		if err == nil || !app.IsRunning() {
+			return
 		}
-	case <-app.snapshotConnClient.Quit():
-		if err := app.snapshotConnClient.Error(); err != nil {
-			killFn(connSnapshot, err, app.Logger)
+		app.Logger.Error(fmt.Sprintf("%s connection terminated. Did the application crash? Reconnecting", conn),
+			"err", err)
+		if err := app.reconnect(); err != nil {
+			if app.IsRunning() {
+				killOC(conn, err, app.Logger)
+			}
+			return
 		}
 	}
 }
 
-func (app *multiAppConn) stopAllClients() {
-	if app.consensusConnClient != nil {
-		if err := app.consensusConnClient.Stop(); err != nil {
-			app.Logger.Error("error while stopping consensus client", "error", err)
-		}
+// reconnect replaces the clients with new ones once they reconnected to the
+// application and resynced it. The calls on the connections wait meanwhile.
+func (app *multiAppConn) reconnect() error {
+	connClients := app.connClients()
+	for _, c := range connClients {
+		c.disconnect()
 	}
-	if app.mempoolConnClient != nil {
-		if err := app.mempoolConnClient.Stop(); err != nil {
-			app.Logger.Error("error while stopping mempool client", "error", err)
+	app.stopAllClients(app.currentClients())
+
+	backoff := reconnectInitialBackoff
+	for attempt := 1; ; attempt++ {
+		clients, err := app.startClients()
+		if err == nil {
+			err = app.resync(&multiAppConn{
+				consensusConn: NewAppConnConsensus(clients[connConsensus]),
+				mempoolConn:   NewAppConnMempool(clients[connMempool]),
+				queryConn:     NewAppConnQuery(clients[connQuery]),
+				snapshotConn:  NewAppConnSnapshot(clients[connSnapshot]),
+			})
+			if err == nil {
+				for conn, c := range connClients {
+					c.reconnect(clients[conn])
+				}
+				app.Logger.Info("Reconnected to the application", "attempts", attempt)
+				return nil
+			}
+			failed := false
+			for _, c := range clients {
+				failed = failed || c.Error() != nil
+			}
+			app.stopAllClients(clients)
+			if !failed {
+				return fmt.Errorf("error resyncing the application: %w", err)
+			}
 		}
-	}
-	if app.queryConnClient != nil {
-		if err := app.queryConnClient.Stop(); err != nil {
-			app.Logger.Error("error while stopping query client", "error", err)
+
+		app.Logger.Error("Failed to reconnect to the application", "attempt", attempt, "retryIn", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-app.Quit():
+			return nil
+		}
+		backoff *= 2
+		if backoff > app.maxBackoff {
+			backoff = app.maxBackoff
 		}
 	}
-	if app.snapshotConnClient != nil {
-		if err := app.snapshotConnClient.Stop(); err != nil {
-			app.Logger.Error("error while stopping snapshot client", "error", err)
+}
+
+func (app *multiAppConn) stopAllClients(clients map[string]abcicli.Client) {
+	for _, conn := range []string{connConsensus, connMempool, connQuery, connSnapshot} {
+		c, ok := clients[conn]
+		if !ok {
+			continue
+		}
+		if err := c.Stop(); err != nil && err != service.ErrAlreadyStopped {
+			app.Logger.Error(fmt.Sprintf("error while stopping %s client", conn), "error", err)
 		}
 	}
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
-	c, err := app.clientCreator.NewABCIClient()
+	c, err := newABCIClientFor(app.clientCreator, conn)
 	if err != nil {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
@@ -189,3 +306,101 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	}
 	return c, nil
 }
+
+//-----------------------------------------------------------------------------
+
+// appConnClient is the abci client of a connection to the application, which
+// the resilient AppConns replace when they reconnect. The calls on the
+// connection wait while they're reconnecting.
+type appConnClient struct {
+	mtx  tmsync.Mutex
+	cond *sync.Cond
+
+	client        abcicli.Client
+	globalCb      abcicli.GlobalCallback
+	resilient     bool
+	reconnecting  bool
+	reconnections int64
+	stopped       bool
+}
+
+func newAppConnClient(client abcicli.Client) *appConnClient {
+	c := &appConnClient{client: client}
+	c.cond = sync.NewCond(&c.mtx)
+	return c
+}
+
+// get returns the client once it's connected, or the last client if the
+// AppConns stopped.
+func (c *appConnClient) get() abcicli.Client {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for c.reconnecting && !c.stopped {
+		c.cond.Wait()
+	}
+	return c.client
+}
+
+// current returns the client without waiting.
+func (c *appConnClient) current() abcicli.Client {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.client
+}
+
+// setGlobalCallback sets the global callback of the client, and of the clients
+// replacing it.
+func (c *appConnClient) setGlobalCallback(globalCb abcicli.GlobalCallback) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.globalCb = globalCb
+	c.client.SetGlobalCallback(globalCb)
+}
+
+func (c *appConnClient) waitReconnect() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	failed := c.client
+	// the client failed, or was stopped to reconnect after another one failed
+	if !c.resilient || (!c.reconnecting && failed.Error() == nil && failed.IsRunning()) {
+		return false
+	}
+	for (c.reconnecting || c.client == failed) && !c.stopped {
+		c.cond.Wait()
+	}
+	return !c.stopped
+}
+
+func (c *appConnClient) reconnectionCount() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.reconnections
+}
+
+func (c *appConnClient) disconnect() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.reconnecting = true
+}
+
+func (c *appConnClient) reconnect(client abcicli.Client) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.globalCb != nil {
+		client.SetGlobalCallback(c.globalCb)
+	}
+	c.client = client
+	c.reconnecting = false
+	c.reconnections++
+	c.cond.Broadcast()
+}
+
+func (c *appConnClient) stop() {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.stopped = true
+	c.cond.Broadcast()
+}
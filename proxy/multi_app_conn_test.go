@@ -2,16 +2,29 @@ package proxy
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/abci/types"
+
+	abcicli "github.com/Finschia/ostracon/abci/client"
 	abcimocks "github.com/Finschia/ostracon/abci/client/mocks"
+	"github.com/Finschia/ostracon/abci/example/kvstore"
+	"github.com/Finschia/ostracon/abci/server"
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	"github.com/Finschia/ostracon/libs/log"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/libs/service"
 	"github.com/Finschia/ostracon/proxy/mocks"
 )
 
@@ -88,3 +101,173 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+func TestResilientAppConns_Reconnect(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/reconnect_%v.sock", tmrand.Str(6))
+	app := kvstore.NewApplication()
+	startServer := func() service.Service {
+		s := server.NewSocketServer(sockPath, app)
+		s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+		require.NoError(t, s.Start())
+		return s
+	}
+	s := startServer()
+
+	resynced := make(chan struct{}, 1)
+	appConns := NewResilientAppConns(NewRemoteClientCreator(sockPath, SOCKET, true), 100*time.Millisecond,
+		func(conns AppConns) error {
+			_, err := conns.Query().InfoSync(RequestInfo)
+			resynced <- struct{}{}
+			return err
+		})
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	globalCbCalled := make(chan struct{}, 10)
+	appConns.Mempool().SetGlobalCallback(func(*ocabci.Request, *ocabci.Response) {
+		globalCbCalled <- struct{}{}
+	})
+	_, err := appConns.Query().InfoSync(RequestInfo)
+	require.NoError(t, err)
+	// the connections didn't fail
+	assert.False(t, appConns.Consensus().(Reconnector).WaitReconnect())
+
+	// kill the app
+	require.NoError(t, s.Stop())
+	require.Eventually(t, func() bool {
+		c := appConns.(*multiAppConn).consensusConnClient
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		return c.reconnecting
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the calls wait for the app
+	waitReconnect := make(chan bool)
+	go func() {
+		waitReconnect <- appConns.Consensus().(Reconnector).WaitReconnect()
+	}()
+	info := make(chan error)
+	go func() {
+		_, err := appConns.Query().InfoSync(RequestInfo)
+		info <- err
+	}()
+	select {
+	case <-waitReconnect:
+		t.Fatal("reconnected without app")
+	case <-info:
+		t.Fatal("called the app while it's down")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// restart the app: the connections reconnect and resync it
+	s = startServer()
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	select {
+	case <-resynced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the app wasn't resynced")
+	}
+	assert.True(t, <-waitReconnect)
+	require.NoError(t, <-info)
+	assert.NoError(t, appConns.Consensus().Error())
+
+	// the global callback is kept
+	_, err = appConns.Mempool().CheckTxSync(types.RequestCheckTx{Tx: []byte("key=value")})
+	require.NoError(t, err)
+	select {
+	case <-globalCbCalled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the global callback wasn't called")
+	}
+}
+
+func TestAppConns_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abci_record.bin")
+	recorder, err := abcicli.NewRecorder(path)
+	require.NoError(t, err)
+
+	clientCreator := NewRecordingClientCreator(NewLocalClientCreator(kvstore.NewApplication()), recorder)
+	appConns := NewAppConns(clientCreator)
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	_, err = appConns.Query().InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	_, err = appConns.Mempool().CheckTxSync(types.RequestCheckTx{Tx: []byte("a=1")})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().CommitSync()
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	reader := abcicli.NewRecordReader(file)
+	for _, conn := range []string{connQuery, connMempool, connConsensus} {
+		exchange, err := reader.Read()
+		require.NoError(t, err)
+		assert.Equal(t, conn, exchange.Connection)
+	}
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+// namedApp responds to Info, Query and ListSnapshots with its name.
+type namedApp struct {
+	ocabci.BaseApplication
+	name string
+}
+
+func (app namedApp) Info(types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{Data: app.name}
+}
+
+func (app namedApp) Query(types.RequestQuery) types.ResponseQuery {
+	return types.ResponseQuery{Value: []byte(app.name)}
+}
+
+func (app namedApp) ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots {
+	return types.ResponseListSnapshots{Snapshots: []*types.Snapshot{{Metadata: []byte(app.name)}}}
+}
+
+func TestAppConns_Routing(t *testing.T) {
+	clientCreator := NewRoutingClientCreator(
+		NewLocalClientCreator(namedApp{name: "primary"}),
+		NewPoolClientCreator([]ClientCreator{
+			NewLocalClientCreator(namedApp{name: "replica0"}),
+			NewLocalClientCreator(namedApp{name: "replica1"}),
+		}, time.Second),
+		NewLocalClientCreator(namedApp{name: "sidecar"}),
+	)
+	appConns := NewAppConns(clientCreator)
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	for _, name := range []string{"replica0", "replica1", "replica0"} {
+		res, err := appConns.Query().QuerySync(types.RequestQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, name, string(res.Value))
+	}
+	info, err := appConns.Query().InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "primary", info.Data)
+	snapshots, err := appConns.Snapshot().ListSnapshotsSync(types.RequestListSnapshots{})
+	require.NoError(t, err)
+	assert.Equal(t, "sidecar", string(snapshots.Snapshots[0].Metadata))
+}
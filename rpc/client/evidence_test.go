@@ -161,6 +161,20 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		require.EqualValues(t, rawpub, pk, "Stored PubKey not equal with expected, value %v", string(qres.Value))
 		require.Equal(t, int64(9), v.Power, "Stored Power not equal with expected, value %v", string(qres.Value))
 
+		evRes, err := c.EvidenceByHash(context.Background(), correct.Hash())
+		require.NoError(t, err)
+		assert.EqualValues(t, correct.Hash(), evRes.Hash)
+		if evRes.Height > 0 {
+			committed, err := c.CommittedEvidence(context.Background(), &evRes.Height)
+			require.NoError(t, err)
+			require.Len(t, committed.Evidence, 1)
+			assert.EqualValues(t, correct.Hash(), committed.Evidence[0].Hash)
+		} else {
+			pending, err := c.PendingEvidence(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, pending.Count)
+		}
+
 		for _, fake := range fakes {
 			_, err := c.BroadcastEvidence(context.Background(), fake)
 			require.Error(t, err, "BroadcastEvidence(%s) succeeded, but the evidence was fake", fake)
@@ -88,6 +88,7 @@ type rpcClient interface {
 	rpcclient.ABCIClient
 	rpcclient.HistoryClient
 	rpcclient.NetworkClient
+	rpcclient.PeerBanClient
 	rpcclient.SignClient
 	rpcclient.StatusClient
 }
@@ -154,6 +155,7 @@ func NewWithClient(remote, wsEndpoint string, client *http.Client) (*HTTP, error
 }
 
 var _ rpcclient.Client = (*HTTP)(nil)
+var _ rpcclient.PeerBanClient = (*HTTP)(nil)
 
 // SetLogger sets a logger.
 func (c *HTTP) SetLogger(l log.Logger) {
@@ -370,6 +372,59 @@ func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error
 	return result, nil
 }
 
+func (c *baseRPCClient) NetCensus(ctx context.Context) (*ctypes.ResultNetCensus, error) {
+	result := new(ctypes.ResultNetCensus)
+	_, err := c.caller.Call(ctx, "net_census", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) BanPeer(
+	ctx context.Context,
+	peer, duration, reason string,
+) (*ctypes.ResultBanPeer, error) {
+	result := new(ctypes.ResultBanPeer)
+	_, err := c.caller.Call(ctx, "ban_peer",
+		map[string]interface{}{"peer": peer, "duration": duration, "reason": reason}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	result := new(ctypes.ResultUnbanPeer)
+	_, err := c.caller.Call(ctx, "unban_peer", map[string]interface{}{"peer": peer}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ListBans(ctx context.Context) (*ctypes.ResultListBans, error) {
+	result := new(ctypes.ResultListBans)
+	_, err := c.caller.Call(ctx, "list_bans", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SetPeerPolicy(
+	ctx context.Context,
+	policy, peer string,
+) (*ctypes.ResultSetPeerPolicy, error) {
+	result := new(ctypes.ResultSetPeerPolicy)
+	_, err := c.caller.Call(ctx, "set_peer_policy",
+		map[string]interface{}{"policy": policy, "peer": peer}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockchainInfo(
 	ctx context.Context,
 	minHeight,
@@ -565,6 +620,40 @@ func (c *baseRPCClient) BroadcastEvidence(
 	return result, nil
 }
 
+func (c *baseRPCClient) PendingEvidence(ctx context.Context) (*ctypes.ResultPendingEvidence, error) {
+	result := new(ctypes.ResultPendingEvidence)
+	_, err := c.caller.Call(ctx, "pending_evidence", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) CommittedEvidence(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultCommittedEvidence, error) {
+	result := new(ctypes.ResultCommittedEvidence)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "committed_evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) EvidenceByHash(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	result := new(ctypes.ResultEvidence)
+	_, err := c.caller.Call(ctx, "evidence_by_hash", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 //-----------------------------------------------------------------------------
 // WSEvents
 
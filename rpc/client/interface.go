@@ -113,6 +113,16 @@ type NetworkClient interface {
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
 	Health(context.Context) (*ctypes.ResultHealth, error)
+	NetCensus(context.Context) (*ctypes.ResultNetCensus, error)
+}
+
+// PeerBanClient allows or denies peers. It calls unsafe routes, so it is not a
+// part of Client and only works against a node exposing them.
+type PeerBanClient interface {
+	BanPeer(ctx context.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error)
+	UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error)
+	ListBans(context.Context) (*ctypes.ResultListBans, error)
+	SetPeerPolicy(ctx context.Context, policy, peer string) (*ctypes.ResultSetPeerPolicy, error)
 }
 
 // EventsClient is reactive, you can subscribe to any message, given the proper
@@ -143,6 +153,9 @@ type MempoolClient interface {
 // behaviour.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	PendingEvidence(context.Context) (*ctypes.ResultPendingEvidence, error)
+	CommittedEvidence(ctx context.Context, height *int64) (*ctypes.ResultCommittedEvidence, error)
+	EvidenceByHash(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
@@ -61,6 +61,7 @@ func New(node *nm.Node) *Local {
 }
 
 var _ rpcclient.Client = (*Local)(nil)
+var _ rpcclient.PeerBanClient = (*Local)(nil)
 
 // SetLogger allows to set a logger on the client.
 func (c *Local) SetLogger(l log.Logger) {
@@ -115,6 +116,10 @@ func (c *Local) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(c.ctx)
 }
 
+func (c *Local) NetCensus(ctx context.Context) (*ctypes.ResultNetCensus, error) {
+	return core.NetCensus(c.ctx)
+}
+
 func (c *Local) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return core.DumpConsensusState(c.ctx)
 }
@@ -145,6 +150,22 @@ func (c *Local) DialPeers(
 	return core.UnsafeDialPeers(c.ctx, peers, persistent, unconditional, private)
 }
 
+func (c *Local) BanPeer(ctx context.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	return core.UnsafeBanPeer(c.ctx, peer, duration, reason)
+}
+
+func (c *Local) UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	return core.UnsafeUnbanPeer(c.ctx, peer)
+}
+
+func (c *Local) ListBans(ctx context.Context) (*ctypes.ResultListBans, error) {
+	return core.UnsafeListBans(c.ctx)
+}
+
+func (c *Local) SetPeerPolicy(ctx context.Context, policy, peer string) (*ctypes.ResultSetPeerPolicy, error) {
+	return core.UnsafeSetPeerPolicy(c.ctx, policy, peer)
+}
+
 func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
@@ -205,6 +226,18 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctyp
 	return core.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) PendingEvidence(ctx context.Context) (*ctypes.ResultPendingEvidence, error) {
+	return core.PendingEvidence(c.ctx)
+}
+
+func (c *Local) CommittedEvidence(ctx context.Context, height *int64) (*ctypes.ResultCommittedEvidence, error) {
+	return core.CommittedEvidence(c.ctx, height)
+}
+
+func (c *Local) EvidenceByHash(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return core.EvidenceByHash(c.ctx, hash)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
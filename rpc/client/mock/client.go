@@ -44,6 +44,7 @@ type Client struct {
 }
 
 var _ client.Client = Client{}
+var _ client.PeerBanClient = Client{}
 
 // Call is used by recorders to save a call and response.
 // It can also be used to configure mock responses.
@@ -119,6 +120,10 @@ func (c Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(&rpctypes.Context{})
 }
 
+func (c Client) NetCensus(ctx context.Context) (*ctypes.ResultNetCensus, error) {
+	return core.NetCensus(&rpctypes.Context{})
+}
+
 func (c Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	return core.ConsensusState(&rpctypes.Context{})
 }
@@ -149,6 +154,22 @@ func (c Client) DialPeers(
 	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent, unconditional, private)
 }
 
+func (c Client) BanPeer(ctx context.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	return core.UnsafeBanPeer(&rpctypes.Context{}, peer, duration, reason)
+}
+
+func (c Client) UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	return core.UnsafeUnbanPeer(&rpctypes.Context{}, peer)
+}
+
+func (c Client) ListBans(ctx context.Context) (*ctypes.ResultListBans, error) {
+	return core.UnsafeListBans(&rpctypes.Context{})
+}
+
+func (c Client) SetPeerPolicy(ctx context.Context, policy, peer string) (*ctypes.ResultSetPeerPolicy, error) {
+	return core.UnsafeSetPeerPolicy(&rpctypes.Context{}, policy, peer)
+}
+
 func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
@@ -176,3 +197,15 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) PendingEvidence(ctx context.Context) (*ctypes.ResultPendingEvidence, error) {
+	return core.PendingEvidence(&rpctypes.Context{})
+}
+
+func (c Client) CommittedEvidence(ctx context.Context, height *int64) (*ctypes.ResultCommittedEvidence, error) {
+	return core.CommittedEvidence(&rpctypes.Context{}, height)
+}
+
+func (c Client) EvidenceByHash(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return core.EvidenceByHash(&rpctypes.Context{}, hash)
+}
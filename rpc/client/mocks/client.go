@@ -386,6 +386,32 @@ func (_m *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultC
 	return r0, r1
 }
 
+// CommittedEvidence provides a mock function with given fields: ctx, height
+func (_m *Client) CommittedEvidence(ctx context.Context, height *int64) (*coretypes.ResultCommittedEvidence, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultCommittedEvidence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) (*coretypes.ResultCommittedEvidence, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultCommittedEvidence); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultCommittedEvidence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusParams provides a mock function with given fields: ctx, height
 func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	ret := _m.Called(ctx, height)
@@ -464,6 +490,32 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// EvidenceByHash provides a mock function with given fields: ctx, hash
+func (_m *Client) EvidenceByHash(ctx context.Context, hash []byte) (*coretypes.ResultEvidence, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultEvidence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (*coretypes.ResultEvidence, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultEvidence); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -556,6 +608,32 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// NetCensus provides a mock function with given fields: _a0
+func (_m *Client) NetCensus(_a0 context.Context) (*coretypes.ResultNetCensus, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultNetCensus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*coretypes.ResultNetCensus, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultNetCensus); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultNetCensus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -641,6 +719,32 @@ func (_m *Client) OnStop() {
 	_m.Called()
 }
 
+// PendingEvidence provides a mock function with given fields: _a0
+func (_m *Client) PendingEvidence(_a0 context.Context) (*coretypes.ResultPendingEvidence, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultPendingEvidence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*coretypes.ResultPendingEvidence, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultPendingEvidence); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultPendingEvidence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Quit provides a mock function with given fields:
 func (_m *Client) Quit() <-chan struct{} {
 	ret := _m.Called()
@@ -131,6 +131,38 @@ func TestNetInfo(t *testing.T) {
 	}
 }
 
+func TestNetCensus(t *testing.T) {
+	for i, c := range GetClients() {
+		// the census is only enabled in seed mode
+		_, err := c.NetCensus(context.Background())
+		require.Error(t, err, "%d", i)
+		assert.Contains(t, err.Error(), "census is not enabled", "%d", i)
+	}
+}
+
+func TestBanPeer(t *testing.T) {
+	for i, c := range []client.PeerBanClient{getHTTPClient(), getLocalClient()} {
+		ban, err := c.BanPeer(context.Background(), "10.0.0.1", "1h", "test")
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, "10.0.0.1", ban.Rule.Target)
+		assert.Equal(t, "test", ban.Rule.Reason)
+
+		bans, err := c.ListBans(context.Background())
+		require.NoError(t, err, "%d", i)
+		require.Len(t, bans.Rules, 1)
+		assert.Equal(t, "10.0.0.1", bans.Rules[0].Target)
+
+		_, err = c.UnbanPeer(context.Background(), "10.0.0.1")
+		require.NoError(t, err, "%d", i)
+		_, err = c.UnbanPeer(context.Background(), "10.0.0.1")
+		assert.Error(t, err, "%d", i)
+
+		policy, err := c.SetPeerPolicy(context.Background(), "allow", "")
+		require.NoError(t, err, "%d", i)
+		assert.Nil(t, policy.Rule)
+	}
+}
+
 func TestDumpConsensusState(t *testing.T) {
 	for i, c := range GetClients() {
 		// FIXME: fix server so it doesn't panic on invalid input
@@ -11,12 +11,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	dbm "github.com/tendermint/tm-db"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	cfg "github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	ctypes "github.com/Finschia/ostracon/rpc/core/types"
 	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
 	sm "github.com/Finschia/ostracon/state"
@@ -80,13 +81,13 @@ func TestBlockchainInfo(t *testing.T) {
 }
 
 func TestBlockResults(t *testing.T) {
-	results := &tmstate.ABCIResponses{
+	results := &ocstate.ABCIResponses{
 		DeliverTxs: []*abci.ResponseDeliverTx{
 			{Code: 0, Data: []byte{0x01}, Log: "ok"},
 			{Code: 0, Data: []byte{0x02}, Log: "ok"},
 			{Code: 1, Log: "not ok"},
 		},
-		EndBlock:   &abci.ResponseEndBlock{},
+		EndBlock:   &ocabci.ResponseEndBlock{},
 		BeginBlock: &abci.ResponseBeginBlock{},
 	}
 
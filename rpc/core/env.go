@@ -12,6 +12,8 @@ import (
 	"github.com/Finschia/ostracon/libs/log"
 	mempl "github.com/Finschia/ostracon/mempool"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/banlist"
+	"github.com/Finschia/ostracon/p2p/census"
 	"github.com/Finschia/ostracon/proxy"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/state/indexer"
@@ -70,6 +72,15 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	BanList() *banlist.BanList
+	StopDeniedPeers()
+}
+
+type evidencePool interface {
+	sm.EvidencePool
+	PendingEvidenceByHash([]byte) (types.Evidence, error)
+	CommittedEvidenceHeight([]byte) (int64, error)
+	IsExpired(types.Evidence) bool
 }
 
 // ----------------------------------------------
@@ -83,10 +94,11 @@ type Environment struct {
 	// interfaces defined in types and above
 	StateStore     sm.Store
 	BlockStore     sm.BlockStore
-	EvidencePool   sm.EvidencePool
+	EvidencePool   evidencePool
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
+	Census         *census.Census // nil if the census is disabled
 
 	// objects
 	PubKey           crypto.PubKey
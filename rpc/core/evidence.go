@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -25,3 +26,90 @@ func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.Result
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// PendingEvidence returns the verified evidence waiting to be committed.
+func PendingEvidence(ctx *rpctypes.Context) (*ctypes.ResultPendingEvidence, error) {
+	evList, size := env.EvidencePool.PendingEvidence(-1)
+	result := make([]ctypes.ResultEvidence, 0, len(evList))
+	for _, ev := range evList {
+		result = append(result, pendingEvidence(ev))
+	}
+	return &ctypes.ResultPendingEvidence{
+		Count:    len(result),
+		Size:     size,
+		Evidence: result,
+	}, nil
+}
+
+// CommittedEvidence returns the evidence committed in the block at the given
+// height. If no height is provided, it fetches the evidence of the latest
+// block.
+func CommittedEvidence(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommittedEvidence, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	result := make([]ctypes.ResultEvidence, 0, len(block.Evidence.Evidence))
+	for _, ev := range block.Evidence.Evidence {
+		result = append(result, committedEvidence(ev, height))
+	}
+	return &ctypes.ResultCommittedEvidence{Height: height, Evidence: result}, nil
+}
+
+// EvidenceByHash returns the pending or committed evidence of the given hash.
+func EvidenceByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	if len(hash) == 0 {
+		return nil, errors.New("no evidence hash was provided")
+	}
+
+	ev, err := env.EvidencePool.PendingEvidenceByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if ev != nil {
+		result := pendingEvidence(ev)
+		return &result, nil
+	}
+
+	height, err := env.EvidencePool.CommittedEvidenceHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, fmt.Errorf("evidence %X not found", hash)
+	}
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("evidence %X is committed but its block %d is not available", hash, height)
+	}
+	for _, ev := range block.Evidence.Evidence {
+		if bytes.Equal(ev.Hash(), hash) {
+			result := committedEvidence(ev, height)
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("evidence %X is not found in its block %d", hash, height)
+}
+
+func pendingEvidence(ev types.Evidence) ctypes.ResultEvidence {
+	return ctypes.ResultEvidence{
+		Evidence: ev,
+		Hash:     ev.Hash(),
+		Status:   ctypes.EvidenceStatusPending,
+		Expired:  env.EvidencePool.IsExpired(ev),
+	}
+}
+
+func committedEvidence(ev types.Evidence, height int64) ctypes.ResultEvidence {
+	return ctypes.ResultEvidence{
+		Evidence: ev,
+		Hash:     ev.Hash(),
+		Status:   ctypes.EvidenceStatusCommitted,
+		Height:   height,
+		Expired:  env.EvidencePool.IsExpired(ev),
+	}
+}
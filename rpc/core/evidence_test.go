@@ -0,0 +1,135 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestEvidence(t *testing.T) {
+	var (
+		pendingEv   = types.NewMockDuplicateVoteEvidence(9, time.Now(), "test-chain")
+		committedEv = types.NewMockDuplicateVoteEvidence(5, time.Now(), "test-chain")
+		expiredEv   = types.NewMockDuplicateVoteEvidence(1, time.Now(), "test-chain")
+	)
+	env = &Environment{}
+	env.EvidencePool = &mockEvidencePool{
+		pending:   []types.Evidence{pendingEv},
+		committed: map[string]int64{string(committedEv.Hash()): 7},
+		expired:   expiredEv,
+	}
+	env.BlockStore = mockEvidenceBlockStore{
+		mockBlockStore: mockBlockStore{height: 10},
+		evidence:       map[int64][]types.Evidence{7: {committedEv}},
+	}
+
+	pending, err := PendingEvidence(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending.Count)
+	assert.Equal(t, []ctypes.ResultEvidence{{
+		Evidence: pendingEv,
+		Hash:     pendingEv.Hash(),
+		Status:   ctypes.EvidenceStatusPending,
+	}}, pending.Evidence)
+
+	height := int64(7)
+	committed, err := CommittedEvidence(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, committed.Height)
+	require.Len(t, committed.Evidence, 1)
+	assert.Equal(t, committedEv, committed.Evidence[0].Evidence)
+	assert.Equal(t, ctypes.EvidenceStatusCommitted, committed.Evidence[0].Status)
+	assert.Equal(t, height, committed.Evidence[0].Height)
+
+	// the latest block by default
+	committed, err = CommittedEvidence(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), committed.Height)
+	assert.Empty(t, committed.Evidence)
+
+	height = 11
+	_, err = CommittedEvidence(&rpctypes.Context{}, &height)
+	assert.Error(t, err)
+
+	testCases := []struct {
+		name   string
+		hash   []byte
+		status string
+		height int64
+		isErr  bool
+	}{
+		{"pending", pendingEv.Hash(), ctypes.EvidenceStatusPending, 0, false},
+		{"committed", committedEv.Hash(), ctypes.EvidenceStatusCommitted, 7, false},
+		{"unknown", expiredEv.Hash(), "", 0, true},
+		{"empty", nil, "", 0, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := EvidenceByHash(&rpctypes.Context{}, tc.hash)
+			if tc.isErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.hash, []byte(res.Hash))
+			assert.Equal(t, tc.status, res.Status)
+			assert.Equal(t, tc.height, res.Height)
+			assert.False(t, res.Expired)
+		})
+	}
+
+	// the expiry of the evidence is reported
+	env.EvidencePool.(*mockEvidencePool).pending = []types.Evidence{expiredEv}
+	pending, err = PendingEvidence(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Len(t, pending.Evidence, 1)
+	assert.True(t, pending.Evidence[0].Expired)
+}
+
+type mockEvidencePool struct {
+	sm.EmptyEvidencePool
+	pending   []types.Evidence
+	committed map[string]int64
+	expired   types.Evidence
+}
+
+func (pool *mockEvidencePool) PendingEvidence(int64) ([]types.Evidence, int64) {
+	return pool.pending, int64(len(pool.pending))
+}
+
+func (pool *mockEvidencePool) PendingEvidenceByHash(hash []byte) (types.Evidence, error) {
+	for _, ev := range pool.pending {
+		if string(ev.Hash()) == string(hash) {
+			return ev, nil
+		}
+	}
+	return nil, nil
+}
+
+func (pool *mockEvidencePool) CommittedEvidenceHeight(hash []byte) (int64, error) {
+	return pool.committed[string(hash)], nil
+}
+
+func (pool *mockEvidencePool) IsExpired(ev types.Evidence) bool {
+	return pool.expired != nil && ev == pool.expired
+}
+
+type mockEvidenceBlockStore struct {
+	mockBlockStore
+	evidence map[int64][]types.Evidence
+}
+
+func (store mockEvidenceBlockStore) LoadBlock(height int64) *types.Block {
+	if height > store.height {
+		return nil
+	}
+	return &types.Block{Evidence: types.EvidenceData{Evidence: store.evidence[height]}}
+}
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/banlist"
 	ctypes "github.com/Finschia/ostracon/rpc/core/types"
 	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+	tmtime "github.com/Finschia/ostracon/types/time"
 )
 
 // NetInfo returns network info.
@@ -91,6 +94,134 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeBanPeer denies the peers matching a node ID, an IP address or a CIDR
+// for the duration (e.g. "24h", or forever if empty), and stops the matching
+// peers.
+func UnsafeBanPeer(ctx *rpctypes.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	rule, err := setPeerRule(peer, banlist.PolicyDeny, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBanPeer{Rule: *rule}, nil
+}
+
+// UnsafeUnbanPeer removes the rule of a node ID, an IP address or a CIDR,
+// whether it allows or denies the peers.
+func UnsafeUnbanPeer(ctx *rpctypes.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	banList, err := getBanList()
+	if err != nil {
+		return nil, err
+	}
+	removed, err := banList.RemoveRule(peer)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, fmt.Errorf("no rule for %s", peer)
+	}
+	env.Logger.Info("UnbanPeer", "peer", peer)
+	return &ctypes.ResultUnbanPeer{Log: fmt.Sprintf("Removed the rule for %s", peer)}, nil
+}
+
+// UnsafeListBans returns the rules allowing or denying peers, and the default
+// policy for the others.
+func UnsafeListBans(ctx *rpctypes.Context) (*ctypes.ResultListBans, error) {
+	banList, err := getBanList()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultListBans{
+		DefaultPolicy: banList.DefaultPolicy(),
+		Rules:         banList.Rules(),
+	}, nil
+}
+
+// UnsafeSetPeerPolicy allows or denies ("allow" or "deny") the peers matching
+// a node ID, an IP address or a CIDR. Without peer, it sets the default policy
+// for the peers matching no rule; e.g. "deny" only allows the peers of the
+// allow rules.
+func UnsafeSetPeerPolicy(ctx *rpctypes.Context, policy, peer string) (*ctypes.ResultSetPeerPolicy, error) {
+	if peer != "" {
+		rule, err := setPeerRule(peer, banlist.Policy(policy), "", "")
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultSetPeerPolicy{Rule: rule}, nil
+	}
+
+	banList, err := getBanList()
+	if err != nil {
+		return nil, err
+	}
+	if err := banList.SetDefaultPolicy(banlist.Policy(policy)); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetPeerPolicy", "default", policy)
+	env.P2PPeers.StopDeniedPeers()
+	return &ctypes.ResultSetPeerPolicy{DefaultPolicy: banList.DefaultPolicy()}, nil
+}
+
+func setPeerRule(peer string, policy banlist.Policy, duration, reason string) (*banlist.Rule, error) {
+	banList, err := getBanList()
+	if err != nil {
+		return nil, err
+	}
+	rule := banlist.Rule{
+		Target:  strings.ToLower(strings.TrimSpace(peer)),
+		Policy:  policy,
+		Reason:  reason,
+		Created: tmtime.Now(),
+	}
+	if duration != "" {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		if d <= 0 {
+			return nil, errors.New("duration must be positive")
+		}
+		rule.Expires = rule.Created.Add(d)
+	}
+	if err := banList.SetRule(rule); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetPeerRule", "peer", peer, "policy", policy, "duration", duration, "reason", reason)
+	env.P2PPeers.StopDeniedPeers()
+	return &rule, nil
+}
+
+func getBanList() (*banlist.BanList, error) {
+	banList := env.P2PPeers.BanList()
+	if banList == nil {
+		return nil, errors.New("the ban list is not enabled")
+	}
+	return banList, nil
+}
+
+// NetCensus returns the census of the nodes crawled in seed mode, with the
+// number of nodes by version and how many were reachable the last time they
+// were dialed.
+func NetCensus(ctx *rpctypes.Context) (*ctypes.ResultNetCensus, error) {
+	if env.Census == nil {
+		return nil, errors.New("the census is not enabled (see p2p.census)")
+	}
+	nodes := env.Census.Nodes()
+	result := &ctypes.ResultNetCensus{
+		Total:    len(nodes),
+		Versions: make(map[string]int),
+		Nodes:    nodes,
+	}
+	for _, node := range nodes {
+		if node.Reachable() {
+			result.Reachable++
+		}
+		if node.Version != "" {
+			result.Versions[node.Version]++
+		}
+	}
+	return result, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/v0.34/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
@@ -5,10 +5,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	cfg "github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/banlist"
+	"github.com/Finschia/ostracon/p2p/census"
+	p2pmock "github.com/Finschia/ostracon/p2p/mock"
 	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
 )
 
@@ -139,3 +143,91 @@ func TestGenesisChunked(t *testing.T) {
 	assert.Contains(t, err.Error(), " is invalid")
 	assert.Nil(t, res)
 }
+
+func TestUnsafeBanPeer(t *testing.T) {
+	banList, err := banlist.NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123",
+		func(n int, sw *p2p.Switch, config *cfg.P2PConfig) *p2p.Switch { return sw },
+		p2p.SwitchBanList(banList))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	env.Logger = log.TestingLogger()
+	env.P2PPeers = sw
+
+	const id = "d51fb70907db1c6c2d5237e78379b25cf1a37ab4"
+
+	testCases := []struct {
+		peer     string
+		duration string
+		isErr    bool
+	}{
+		{"", "", true},
+		{"127.0.0.1:41198", "", true},
+		{id, "-1h", true},
+		{id, "1 day", true},
+		{id, "24h", false},
+		{"10.0.0.0/8", "", false},
+	}
+	for _, tc := range testCases {
+		res, err := UnsafeBanPeer(&rpctypes.Context{}, tc.peer, tc.duration, "spam")
+		if tc.isErr {
+			assert.Error(t, err, tc.peer)
+		} else {
+			require.NoError(t, err, tc.peer)
+			assert.Equal(t, banlist.PolicyDeny, res.Rule.Policy)
+			assert.Equal(t, tc.duration != "", !res.Rule.Expires.IsZero())
+		}
+	}
+
+	bans, err := UnsafeListBans(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, banlist.PolicyAllow, bans.DefaultPolicy)
+	require.Len(t, bans.Rules, 2)
+	assert.Equal(t, "10.0.0.0/8", bans.Rules[0].Target)
+	assert.Equal(t, id, bans.Rules[1].Target)
+
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	require.NoError(t, err)
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	assert.Error(t, err)
+
+	res, err := UnsafeSetPeerPolicy(&rpctypes.Context{}, "deny", "")
+	require.NoError(t, err)
+	assert.Equal(t, banlist.PolicyDeny, res.DefaultPolicy)
+	res, err = UnsafeSetPeerPolicy(&rpctypes.Context{}, "allow", id)
+	require.NoError(t, err)
+	require.NotNil(t, res.Rule)
+	assert.Equal(t, banlist.PolicyAllow, res.Rule.Policy)
+	_, err = UnsafeSetPeerPolicy(&rpctypes.Context{}, "ban", "")
+	assert.Error(t, err)
+}
+
+func TestNetCensus(t *testing.T) {
+	env.Census = nil
+	_, err := NetCensus(&rpctypes.Context{})
+	assert.Error(t, err, "the census is disabled")
+
+	c, err := census.NewCensus(dbm.NewMemDB(), nil, 0, p2p.NopMetrics())
+	require.NoError(t, err)
+	env.Census = c
+	t.Cleanup(func() { env.Census = nil })
+
+	reachable := p2pmock.NewPeer(nil)
+	reachable.Outbound = true
+	require.NoError(t, c.RecordPeer(reachable))
+	require.NoError(t, c.RecordUnreachable(p2pmock.NewPeer(nil).SocketAddr()))
+
+	res, err := NetCensus(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	assert.Equal(t, 1, res.Reachable)
+	assert.Len(t, res.Nodes, 2)
+	assert.Empty(t, res.Versions, "the mock peers have no version")
+}
@@ -17,6 +17,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"net_census":           rpc.NewRPCFunc(NetCensus, ""),
 	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
 	"genesis":              rpc.NewRPCFunc(Genesis, "", rpc.Cacheable()),
 	"genesis_chunked":      rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable()),
@@ -46,6 +47,9 @@ var Routes = map[string]*rpc.RPCFunc{
 
 	// evidence API
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"pending_evidence":   rpc.NewRPCFunc(PendingEvidence, ""),
+	"committed_evidence": rpc.NewRPCFunc(CommittedEvidence, "height", rpc.Cacheable("height")),
+	"evidence_by_hash":   rpc.NewRPCFunc(EvidenceByHash, "hash"),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
@@ -53,5 +57,9 @@ func AddUnsafeRoutes() {
 	// control API
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
+	Routes["ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "peer,duration,reason")
+	Routes["unban_peer"] = rpc.NewRPCFunc(UnsafeUnbanPeer, "peer")
+	Routes["list_bans"] = rpc.NewRPCFunc(UnsafeListBans, "")
+	Routes["set_peer_policy"] = rpc.NewRPCFunc(UnsafeSetPeerPolicy, "policy,peer")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
 }
@@ -47,8 +47,11 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 	// Return the very last voting power, not the voting power of this validator
 	// during the last block.
 	var votingPower int64
+	// the validator keeps its address across the rotations of its key
+	address := env.PubKey.Address()
 	if val := validatorAtHeight(latestUncommittedHeight()); val != nil {
 		votingPower = val.VotingPower
+		address = val.Address
 	}
 
 	result := &ctypes.ResultStatus{
@@ -65,7 +68,7 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
 		},
 		ValidatorInfo: ctypes.ValidatorInfo{
-			Address:     env.PubKey.Address(),
+			Address:     address,
 			PubKey:      env.PubKey,
 			VotingPower: votingPower,
 		},
@@ -79,7 +82,6 @@ func validatorAtHeight(h int64) *types.Validator {
 	if err != nil {
 		return nil
 	}
-	privValAddress := env.PubKey.Address()
-	_, val := vals.GetByAddress(privValAddress)
+	_, val := vals.GetByPubKey(env.PubKey)
 	return val
 }
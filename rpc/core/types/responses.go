@@ -11,6 +11,8 @@ import (
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/libs/bytes"
 	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/p2p/banlist"
+	"github.com/Finschia/ostracon/p2p/census"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -126,6 +128,36 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// A rule added to deny peers
+type ResultBanPeer struct {
+	Rule banlist.Rule `json:"rule"`
+}
+
+// Log from removing a rule
+type ResultUnbanPeer struct {
+	Log string `json:"log"`
+}
+
+// Rules allowing or denying peers
+type ResultListBans struct {
+	DefaultPolicy banlist.Policy `json:"default_policy"`
+	Rules         []banlist.Rule `json:"rules"`
+}
+
+// Either the rule added for a peer, or the default policy set
+type ResultSetPeerPolicy struct {
+	DefaultPolicy banlist.Policy `json:"default_policy,omitempty"`
+	Rule          *banlist.Rule  `json:"rule,omitempty"`
+}
+
+// Nodes crawled in seed mode
+type ResultNetCensus struct {
+	Total     int            `json:"total"`
+	Reachable int            `json:"reachable"`
+	Versions  map[string]int `json:"versions"` // number of nodes by version
+	Nodes     []census.Node  `json:"nodes"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -237,6 +269,35 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Status of the evidence
+const (
+	EvidenceStatusPending   = "pending" // verified and waiting to be committed
+	EvidenceStatusCommitted = "committed"
+)
+
+// Evidence of a misbehavior, pending or committed
+type ResultEvidence struct {
+	Evidence types.Evidence `json:"evidence"`
+	Hash     bytes.HexBytes `json:"hash"`
+	Status   string         `json:"status"`
+	// height of the block committing the evidence, 0 if pending
+	Height  int64 `json:"height"`
+	Expired bool  `json:"expired"`
+}
+
+// Pending evidence
+type ResultPendingEvidence struct {
+	Count    int              `json:"n_evidence"`
+	Size     int64            `json:"size"` // bytes of the pending evidence
+	Evidence []ResultEvidence `json:"evidence"`
+}
+
+// Evidence committed in a block
+type ResultCommittedEvidence struct {
+	Height   int64            `json:"height"`
+	Evidence []ResultEvidence `json:"evidence"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
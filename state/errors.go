@@ -55,6 +55,13 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	// ErrAbortBlock means the app may keep the changes of a block executed
+	// optimistically, so no other block can be executed on top of them.
+	ErrAbortBlock struct {
+		Height int64
+		Err    error
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -111,4 +118,12 @@ func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
 
+func (e ErrAbortBlock) Error() string {
+	return fmt.Sprintf("failed to abort the block executed optimistically at height %d: %v", e.Height, e.Err)
+}
+
+func (e ErrAbortBlock) Unwrap() error {
+	return e.Err
+}
+
 var ErrABCIResponsesNotPersisted = errors.New("node is not persisting abci responses")
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	ocabci "github.com/Finschia/ostracon/abci/types"
@@ -16,6 +15,7 @@ import (
 	"github.com/Finschia/ostracon/libs/fail"
 	"github.com/Finschia/ostracon/libs/log"
 	mempl "github.com/Finschia/ostracon/mempool"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	"github.com/Finschia/ostracon/proxy"
 	"github.com/Finschia/ostracon/types"
 	canonictime "github.com/Finschia/ostracon/types/time"
@@ -45,6 +45,28 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// deliver the txs in batches of non-conflicting txs
+	parallelDeliverTx bool
+
+	// execute the proposed blocks before they're decided
+	optimisticExecution bool
+	optimistic          *optimisticBlock // block executed before it was decided
+}
+
+// optimisticBlock is a block executed against the app before it was decided.
+// Its fields are set before done is closed.
+type optimisticBlock struct {
+	blockID       types.BlockID
+	height        int64
+	reconnections int64 // reconnections of the consensus connection before the execution
+	done          chan struct{}
+
+	abciResponses *ocstate.ABCIResponses
+	err           error
+	// error aborting the block executed optimistically before, in which case
+	// this block isn't executed
+	abortErr error
 }
 
 type CommitStepTimes struct {
@@ -79,6 +101,27 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithParallelDeliverTx makes the BlockExecutor deliver the txs of
+// a block in batches of txs not conflicting with each other, which the app may
+// execute concurrently. The conflicts are found by the access sets the app
+// returns to TxAccessSets for the txs of the block, so every node delivers the
+// same batches.
+func BlockExecutorWithParallelDeliverTx() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.parallelDeliverTx = true
+	}
+}
+
+// BlockExecutorWithOptimisticExecution makes the BlockExecutor execute the
+// proposed blocks given to ExecuteBlockOptimistically before they're decided.
+// The app must implement AbortBlock, to discard the changes of the blocks which
+// aren't decided.
+func BlockExecutorWithOptimisticExecution() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.optimisticExecution = true
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -171,9 +214,16 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	execStartTime := time.Now().UnixNano()
-	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
-	)
+	abciResponses, err := blockExec.takeOptimisticBlock(blockID)
+	if _, ok := err.(ErrAbortBlock); ok {
+		// executing the block on top of the changes of another block would diverge
+		return state, 0, err
+	}
+	if abciResponses == nil && err == nil {
+		abciResponses, err = execBlockOnProxyApp(
+			blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, blockExec.parallelDeliverTx,
+		)
+	}
 	execEndTime := time.Now().UnixNano()
 
 	execTimeMs := float64(execEndTime-execStartTime) / 1000000
@@ -181,6 +231,10 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	blockExec.metrics.BlockExecutionTime.Set(execTimeMs)
 
 	if err != nil {
+		if blockExec.waitReconnect() {
+			blockExec.logger.Info("reconnected to the app; executing the block again", "height", block.Height)
+			return blockExec.ApplyBlock(state, blockID, block, stepTimes)
+		}
 		return state, 0, ErrProxyAppConn(err)
 	}
 
@@ -193,7 +247,15 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
-	// validate the validator updates and convert to ostracon types
+	// validate the validator updates and key rotations and convert to ostracon types
+	keyRotations, err := types.PB2OC.ValidatorKeyRotations(abciResponses.EndBlock.ValidatorKeyRotations)
+	if err != nil {
+		return state, 0, fmt.Errorf("error in validator key rotations: %v", err)
+	}
+	err = validateValidatorKeyRotations(keyRotations, state.ConsensusParams.Validator)
+	if err != nil {
+		return state, 0, fmt.Errorf("error in validator key rotations: %v", err)
+	}
 	abciValUpdates := abciResponses.EndBlock.ValidatorUpdates
 	err = validateValidatorUpdates(abciValUpdates, state.ConsensusParams.Validator)
 	if err != nil {
@@ -207,12 +269,21 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	if len(validatorUpdates) > 0 {
 		blockExec.logger.Debug("updates to validators", "updates", types.ValidatorListString(validatorUpdates))
 	}
+	for _, rotation := range keyRotations {
+		blockExec.logger.Info("validator key rotation", "old", rotation.OldPubKey, "new", rotation.NewPubKey)
+	}
 
 	// Update the state with the block and responses.
-	state, err = updateState(state, blockID, &block.Header, &block.Entropy, abciResponses, validatorUpdates)
+	state, err = updateState(state, blockID, &block.Header, &block.Entropy, abciResponses, keyRotations, validatorUpdates)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
+	// the rotated validators are notified with their new keys
+	for _, rotation := range keyRotations {
+		if _, val := state.NextValidators.GetByPubKey(rotation.NewPubKey); val != nil {
+			validatorUpdates = append(validatorUpdates, val.Copy())
+		}
+	}
 
 	if stepTimes != nil {
 		stepTimes.ToCommitCommitting()
@@ -227,6 +298,10 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	blockExec.metrics.BlockCommitTime.Set(commitTimeMs)
 
 	if err != nil {
+		if blockExec.waitReconnect() {
+			blockExec.logger.Info("reconnected to the app; executing the block again", "height", block.Height)
+			return blockExec.ApplyBlock(state, blockID, block, stepTimes)
+		}
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
 
@@ -265,6 +340,127 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	return state, retainHeight, nil
 }
 
+// ExecuteBlockOptimistically starts executing the proposed block against the
+// app in the background, if the BlockExecutor executes blocks optimistically and
+// the block is valid. If the block is decided, ApplyBlock commits the result of
+// the execution instead of executing the block again; otherwise the app is told
+// to abort the block. A block executed before which isn't the same block is
+// aborted first, in the background too, so that the caller isn't blocked until
+// its execution finishes. If it fails to be aborted, ApplyBlock returns
+// ErrAbortBlock.
+func (blockExec *BlockExecutor) ExecuteBlockOptimistically(state State, blockID types.BlockID, block *types.Block) {
+	if !blockExec.optimisticExecution {
+		return
+	}
+	previous := blockExec.optimistic
+	if previous != nil && previous.blockID.Equals(blockID) {
+		return
+	}
+	if err := blockExec.ValidateBlock(state, block.Round, block); err != nil {
+		blockExec.logger.Debug("not executing invalid block optimistically", "height", block.Height, "err", err)
+		return
+	}
+
+	optimistic := &optimisticBlock{
+		blockID: blockID,
+		height:  block.Height,
+		done:    make(chan struct{}),
+	}
+	blockExec.optimistic = optimistic
+	blockExec.logger.Debug("executing block optimistically", "height", block.Height, "block", blockID)
+	go func() {
+		defer close(optimistic.done)
+		if previous != nil {
+			if optimistic.abortErr = blockExec.abortExecutedBlock(previous); optimistic.abortErr != nil {
+				return
+			}
+		}
+		optimistic.reconnections = blockExec.reconnections()
+		optimistic.abciResponses, optimistic.err = execBlockOnProxyApp(
+			blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, blockExec.parallelDeliverTx,
+		)
+	}()
+}
+
+// takeOptimisticBlock returns the responses of the block executed optimistically
+// if it's the given block, once its execution finished. Otherwise it aborts the
+// block executed optimistically, if any, and returns nil. It returns
+// ErrAbortBlock if a block executed optimistically failed to be aborted.
+func (blockExec *BlockExecutor) takeOptimisticBlock(blockID types.BlockID) (*ocstate.ABCIResponses, error) {
+	optimistic := blockExec.optimistic
+	if optimistic == nil {
+		return nil, nil
+	}
+	blockExec.optimistic = nil
+	<-optimistic.done
+	if optimistic.abortErr != nil {
+		return nil, optimistic.abortErr
+	}
+	if !optimistic.blockID.Equals(blockID) {
+		return nil, blockExec.abortExecutedBlock(optimistic)
+	}
+	// the app lost the execution if it reconnected meanwhile
+	if optimistic.reconnections != blockExec.reconnections() {
+		blockExec.logger.Info("the app reconnected; executing the block again", "block", blockID)
+		return nil, nil
+	}
+	return optimistic.abciResponses, optimistic.err
+}
+
+// AbortOptimisticBlock aborts the block executed optimistically, if any, once
+// its execution finished. It's called when consensus stops, e.g. when the node
+// leaves it for blocksync or statesync, since the block won't be decided by
+// consensus and the app mustn't keep its changes.
+func (blockExec *BlockExecutor) AbortOptimisticBlock() error {
+	optimistic := blockExec.optimistic
+	if optimistic == nil {
+		return nil
+	}
+	blockExec.optimistic = nil
+	return blockExec.abortExecutedBlock(optimistic)
+}
+
+// abortExecutedBlock waits for the execution of the block executed
+// optimistically to finish, and tells the app to discard its changes.
+func (blockExec *BlockExecutor) abortExecutedBlock(optimistic *optimisticBlock) error {
+	<-optimistic.done
+	if optimistic.abortErr != nil {
+		// the block wasn't executed
+		return optimistic.abortErr
+	}
+	if optimistic.reconnections != blockExec.reconnections() {
+		// the app lost the execution already
+		return nil
+	}
+
+	blockExec.logger.Debug("aborting block executed optimistically", "block", optimistic.blockID)
+	res, err := blockExec.proxyApp.AbortBlockSync(ocabci.RequestAbortBlock{Height: optimistic.height})
+	if err != nil {
+		return ErrAbortBlock{Height: optimistic.height, Err: err}
+	}
+	if res.Code != ocabci.CodeTypeOK {
+		return ErrAbortBlock{Height: optimistic.height, Err: fmt.Errorf("app returned code %d", res.Code)}
+	}
+	return nil
+}
+
+// reconnections returns the number of times the consensus connection
+// reconnected to the app, or 0 if it doesn't reconnect.
+func (blockExec *BlockExecutor) reconnections() int64 {
+	if reconnector, ok := blockExec.proxyApp.(proxy.Reconnector); ok {
+		return reconnector.Reconnections()
+	}
+	return 0
+}
+
+// waitReconnect waits until the consensus connection reconnected to the app
+// if it failed and is resilient, and returns whether it did. The app is then
+// synced to the state before the block being applied.
+func (blockExec *BlockExecutor) waitReconnect() bool {
+	reconnector, ok := blockExec.proxyApp.(proxy.Reconnector)
+	return ok && reconnector.WaitReconnect()
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -336,6 +532,8 @@ func (blockExec *BlockExecutor) Commit(
 // Helper functions for executing blocks and updating state
 
 // Executes block's transactions on proxyAppConn.
+// If parallel is true, the txs not conflicting with each other, by the access
+// sets the app returns to TxAccessSets, are delivered in batches.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
 	logger log.Logger,
@@ -343,30 +541,58 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
-) (*tmstate.ABCIResponses, error) {
+	parallel bool,
+) (*ocstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
 	txIndex := 0
-	abciResponses := new(tmstate.ABCIResponses)
+	abciResponses := new(ocstate.ABCIResponses)
 	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
 	abciResponses.DeliverTxs = dtxs
 
+	// the txs are delivered batch by batch, and the responses are stored in the
+	// order of the block
+	var (
+		deliverOrder []int
+		batchErr     error
+	)
+
+	deliverTxCb := func(txRes *abci.ResponseDeliverTx) {
+		// TODO: make use of res.Log
+		// TODO: make use of this info
+		// Blocks may include invalid txs.
+		if txRes.Code == ocabci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
+		}
+
+		abciResponses.DeliverTxs[deliverOrder[txIndex]] = txRes
+		txIndex++
+	}
+
 	// Execute transactions and get hash.
 	proxyCb := func(req *ocabci.Request, res *ocabci.Response) {
-		if r, ok := res.Value.(*ocabci.Response_DeliverTx); ok {
-			// TODO: make use of res.Log
-			// TODO: make use of this info
-			// Blocks may include invalid txs.
-			txRes := r.DeliverTx
-			if txRes.Code == ocabci.CodeTypeOK {
-				validTxs++
-			} else {
-				logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
-				invalidTxs++
+		switch r := res.Value.(type) {
+		case *ocabci.Response_DeliverTx:
+			deliverTxCb(r.DeliverTx)
+		case *ocabci.Response_DeliverTxBatch:
+			responses := r.DeliverTxBatch.Responses
+			if len(responses) != len(req.GetDeliverTxBatch().Txs) {
+				batchErr = fmt.Errorf("app returned %d responses to a batch of %d txs",
+					len(responses), len(req.GetDeliverTxBatch().Txs))
+				return
+			}
+			for _, txRes := range responses {
+				if txRes == nil {
+					batchErr = errors.New("app returned a nil response to a tx of a batch")
+					return
+				}
+			}
+			for _, txRes := range responses {
+				deliverTxCb(txRes)
 			}
-
-			abciResponses.DeliverTxs[txIndex] = txRes
-			txIndex++
 		}
 	}
 	proxyAppConn.SetGlobalCallback(proxyCb)
@@ -402,10 +628,32 @@ func execBlockOnProxyApp(
 		return nil, err
 	}
 
+	var accessSets []ocabci.TxAccessSet
+	if parallel && len(block.Txs) > 1 {
+		accessSets, err = txAccessSets(proxyAppConn, block.Txs)
+		if err != nil {
+			logger.Error("error in proxyAppConn.TxAccessSets", "err", err)
+			return nil, err
+		}
+	}
+	batches := scheduleTxBatches(len(block.Txs), accessSets)
+	deliverOrder = make([]int, 0, len(block.Txs))
+	for _, batch := range batches {
+		deliverOrder = append(deliverOrder, batch...)
+	}
+
 	startTime := time.Now()
 	// run txs of block
-	for _, tx := range block.Txs {
-		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx}, nil)
+	for _, batch := range batches {
+		if len(batch) == 1 {
+			proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: block.Txs[batch[0]]}, nil)
+		} else {
+			req := ocabci.RequestDeliverTxBatch{Txs: make([]*abci.RequestDeliverTx, len(batch))}
+			for i, j := range batch {
+				req.Txs[i] = &abci.RequestDeliverTx{Tx: block.Txs[j]}
+			}
+			proxyAppConn.DeliverTxBatchAsync(req, nil)
+		}
 		if err := proxyAppConn.Error(); err != nil {
 			return nil, err
 		}
@@ -419,6 +667,10 @@ func execBlockOnProxyApp(
 		logger.Error("error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
 	}
+	if batchErr != nil {
+		logger.Error("error in proxyAppConn.DeliverTxBatch", "err", batchErr)
+		return nil, batchErr
+	}
 
 	tps := 0
 	if execTime.Milliseconds() > 0 {
@@ -429,6 +681,27 @@ func execBlockOnProxyApp(
 	return abciResponses, nil
 }
 
+// txAccessSets asks the app for the access sets of txs. It returns nil if the
+// app returned none, so that every tx is delivered alone.
+func txAccessSets(proxyAppConn proxy.AppConnConsensus, txs types.Txs) ([]ocabci.TxAccessSet, error) {
+	req := ocabci.RequestTxAccessSets{Txs: make([][]byte, len(txs))}
+	for i, tx := range txs {
+		req.Txs[i] = tx
+	}
+	res, err := proxyAppConn.TxAccessSetsSync(req)
+	if err != nil {
+		return nil, err
+	}
+	switch len(res.AccessSets) {
+	case 0:
+		return nil, nil
+	case len(txs):
+		return res.AccessSets, nil
+	default:
+		return nil, fmt.Errorf("app returned %d access sets for %d txs", len(res.AccessSets), len(txs))
+	}
+}
+
 func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	initialHeight int64) abci.LastCommitInfo {
 	voteInfos := make([]abci.VoteInfo, block.LastCommit.Size())
@@ -494,13 +767,26 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	return nil
 }
 
+func validateValidatorKeyRotations(rotations []types.ValidatorKeyRotation,
+	params tmproto.ValidatorParams) error {
+	for _, rotation := range rotations {
+		// Check if the new pubkey matches an ABCI type in the consensus params
+		if !types.IsValidPubkeyType(params, rotation.NewPubKey.Type()) {
+			return fmt.Errorf("validator %X is rotated to pubkey %s, which is unsupported for consensus",
+				rotation.OldPubKey.Address(), rotation.NewPubKey.Type())
+		}
+	}
+	return nil
+}
+
 // updateState returns a new State updated according to the header and responses.
 func updateState(
 	state State,
 	blockID types.BlockID,
 	header *types.Header,
 	entropy *types.Entropy,
-	abciResponses *tmstate.ABCIResponses,
+	abciResponses *ocstate.ABCIResponses,
+	keyRotations []types.ValidatorKeyRotation,
 	validatorUpdates []*types.Validator,
 ) (State, error) {
 
@@ -510,12 +796,18 @@ func updateState(
 
 	// Update the validator set with the latest abciResponses.
 	lastHeightValsChanged := state.LastHeightValidatorsChanged
-	if len(validatorUpdates) > 0 {
-		err := nValSet.UpdateWithChangeSet(validatorUpdates)
+	if len(keyRotations) > 0 || len(validatorUpdates) > 0 {
+		// The key rotations first, the updates may refer to the new keys.
+		err := nValSet.RotateKeys(keyRotations)
+		if err != nil {
+			return state, fmt.Errorf("error rotating validator keys: %v", err)
+		}
+		err = nValSet.UpdateWithChangeSet(validatorUpdates)
 		if err != nil {
 			return state, fmt.Errorf("error changing validator set: %v", err)
 		}
 		// Change results from this height but only applies to the next next height.
+		// Until then, the validators sign with their old keys.
 		lastHeightValsChanged = header.Height + 1 + 1
 	}
 
@@ -575,7 +867,7 @@ func fireEvents(
 	logger log.Logger,
 	eventBus types.BlockEventPublisher,
 	block *types.Block,
-	abciResponses *tmstate.ABCIResponses,
+	abciResponses *ocstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
@@ -597,9 +889,15 @@ func fireEvents(
 
 	if len(block.Evidence.Evidence) != 0 {
 		for _, ev := range block.Evidence.Evidence {
+			abciEv := ev.ABCI()
+			validators := make([]abci.Validator, len(abciEv))
+			for i := range abciEv {
+				validators[i] = abciEv[i].Validator
+			}
 			if err := eventBus.PublishEventNewEvidence(types.EventDataNewEvidence{
-				Evidence: ev,
-				Height:   block.Height,
+				Evidence:   ev,
+				Height:     block.Height,
+				Validators: validators,
 			}); err != nil {
 				logger.Error("failed publishing new evidence", "err", err)
 			}
@@ -637,7 +935,7 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight, false)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
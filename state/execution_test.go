@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
+	"github.com/Finschia/ostracon/abci/example/kvstore"
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
@@ -61,6 +64,270 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, TestAppVersion, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// batchCountingApp records the sizes of the batches of txs delivered to the
+// kvstore, and declares no keys for the tx "unknown".
+type batchCountingApp struct {
+	*kvstore.Application
+	batchSizes []int
+	accessSets func(ocabci.ResponseTxAccessSets) ocabci.ResponseTxAccessSets
+}
+
+func (app *batchCountingApp) TxAccessSets(req ocabci.RequestTxAccessSets) ocabci.ResponseTxAccessSets {
+	res := app.Application.TxAccessSets(req)
+	for i, tx := range req.Txs {
+		if string(tx) == "unknown" {
+			res.AccessSets[i] = ocabci.TxAccessSet{}
+		}
+	}
+	if app.accessSets != nil {
+		res = app.accessSets(res)
+	}
+	return res
+}
+
+func (app *batchCountingApp) DeliverTxBatch(req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	app.batchSizes = append(app.batchSizes, len(req.Txs))
+	return app.Application.DeliverTxBatch(req)
+}
+
+func TestApplyBlockParallelDeliverTx(t *testing.T) {
+	app := &batchCountingApp{Application: kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	// the app declares no keys for the tx "unknown", so it's delivered alone
+	txs := types.Txs{
+		types.Tx("a=1"), types.Tx("b=1"), types.Tx("a=2"), types.Tx("c=1"),
+		types.Tx("unknown"), types.Tx("d=1"), types.Tx("b=2"),
+	}
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithParallelDeliverTx())
+
+	privVal := privVals[state.Validators.Validators[0].Address.String()]
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, pubKey.Address(), 0, proof)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+
+	// batches: a=1, b=1, c=1 | a=2 | unknown | d=1, b=2
+	assert.Equal(t, []int{3, 2}, app.batchSizes)
+
+	abciResponses, err := stateStore.LoadABCIResponses(1)
+	require.NoError(t, err)
+	require.Len(t, abciResponses.DeliverTxs, len(txs))
+	for i, res := range abciResponses.DeliverTxs {
+		key, _, _ := strings.Cut(string(txs[i]), "=")
+		assert.Equal(t, key, string(res.Events[0].Attributes[1].Value), "response %d", i)
+	}
+	for key, value := range map[string]string{"a": "2", "b": "2", "c": "1", "d": "1", "unknown": "unknown"} {
+		res := app.Query(abci.RequestQuery{Data: []byte(key)})
+		assert.Equal(t, value, string(res.Value), key)
+	}
+}
+
+// TestApplyBlockParallelDeliverTxWrongAccessSets ensures a block isn't applied
+// if the app doesn't return an access set for every tx.
+func TestApplyBlockParallelDeliverTxWrongAccessSets(t *testing.T) {
+	app := &batchCountingApp{
+		Application: kvstore.NewApplication(),
+		accessSets: func(res ocabci.ResponseTxAccessSets) ocabci.ResponseTxAccessSets {
+			res.AccessSets = res.AccessSets[1:]
+			return res
+		},
+	}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithParallelDeliverTx())
+
+	privVal := privVals[state.Validators.Validators[0].Address.String()]
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=1")}
+	block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, pubKey.Address(), 0, proof)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	assert.Error(t, err)
+	assert.Empty(t, app.batchSizes)
+}
+
+// abortCountingApp counts the txs delivered to the kvstore and the blocks it
+// aborts.
+type abortCountingApp struct {
+	*kvstore.Application
+	delivered int
+	aborted   int
+	abortCode uint32
+}
+
+func (app *abortCountingApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	app.delivered++
+	return app.Application.DeliverTx(req)
+}
+
+func (app *abortCountingApp) AbortBlock(req ocabci.RequestAbortBlock) ocabci.ResponseAbortBlock {
+	app.aborted++
+	if app.abortCode != ocabci.CodeTypeOK {
+		return ocabci.ResponseAbortBlock{Code: app.abortCode}
+	}
+	return app.Application.AbortBlock(req)
+}
+
+// TestApplyBlockOptimisticExecution ensures the block executed optimistically
+// is used if it's decided, and aborted otherwise.
+func TestApplyBlockOptimisticExecution(t *testing.T) {
+	app := &abortCountingApp{Application: kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithOptimisticExecution())
+
+	privVal := privVals[state.Validators.Validators[0].Address.String()]
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	makeBlock := func(txs ...types.Tx) (*types.Block, types.BlockID) {
+		block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, pubKey.Address(), 0, proof)
+		return block, types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	}
+
+	// a block which isn't decided is aborted when another one is proposed
+	block, blockID := makeBlock(types.Tx("a=2"), types.Tx("c=2"))
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+	block, blockID = makeBlock(types.Tx("a=1"), types.Tx("b=1"))
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+
+	// the block executed optimistically is decided, so it isn't executed again
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, app.delivered)
+	assert.Equal(t, 1, app.aborted)
+
+	for key, value := range map[string]string{"a": "1", "b": "1", "c": ""} {
+		res := app.Query(abci.RequestQuery{Data: []byte(key)})
+		assert.Equal(t, value, string(res.Value), key)
+	}
+}
+
+// TestApplyBlockOptimisticExecutionAbortFailure ensures no block is applied
+// once a block executed optimistically failed to be aborted.
+func TestApplyBlockOptimisticExecutionAbortFailure(t *testing.T) {
+	app := &abortCountingApp{Application: kvstore.NewApplication(), abortCode: 1}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithOptimisticExecution())
+
+	privVal := privVals[state.Validators.Validators[0].Address.String()]
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	makeBlock := func(txs ...types.Tx) (*types.Block, types.BlockID) {
+		block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, pubKey.Address(), 0, proof)
+		return block, types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	}
+
+	// the aborted block fails to be aborted, so the next one isn't executed
+	block, blockID := makeBlock(types.Tx("a=2"))
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+	block, blockID = makeBlock(types.Tx("a=1"))
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	var abortErr sm.ErrAbortBlock
+	require.ErrorAs(t, err, &abortErr)
+	assert.EqualValues(t, 1, abortErr.Height)
+	assert.Equal(t, 1, app.delivered)
+	assert.Equal(t, 1, app.aborted)
+}
+
+// TestAbortOptimisticBlock ensures the block executed optimistically is aborted
+// when consensus stops before it's decided.
+func TestAbortOptimisticBlock(t *testing.T) {
+	app := &abortCountingApp{Application: kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithOptimisticExecution())
+
+	privVal := privVals[state.Validators.Validators[0].Address.String()]
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	block, _ := state.MakeBlock(1, []types.Tx{types.Tx("a=1")}, new(types.Commit), nil, pubKey.Address(), 0, proof)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	// nothing to abort
+	require.NoError(t, blockExec.AbortOptimisticBlock())
+	assert.Equal(t, 0, app.aborted)
+
+	blockExec.ExecuteBlockOptimistically(state, blockID, block)
+	require.NoError(t, blockExec.AbortOptimisticBlock())
+	assert.Equal(t, 1, app.delivered)
+	assert.Equal(t, 1, app.aborted)
+	res := app.Query(abci.RequestQuery{Data: []byte("a")})
+	assert.Empty(t, res.Value)
+
+	// the block is executed again once decided, e.g. by blocksync
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, app.delivered)
+	assert.Equal(t, 1, app.aborted)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -277,12 +544,34 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 	proof, _ := privVal.GenerateVRFProof(message)
 	block.Proof = bytes.HexBytes(proof)
 
+	eventBus := types.NewEventBus()
+	err = eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	blockExec.SetEventBus(eventBus)
+	evidenceSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestBeginBlockByzantineValidators",
+		types.EventQueryNewEvidence,
+		len(ev),
+	)
+	require.NoError(t, err)
+
 	state, retainHeight, err := blockExec.ApplyBlock(state, blockID, block, nil)
 	require.Nil(t, err)
 	assert.EqualValues(t, retainHeight, 1)
 
 	// TODO check state and mempool
 	assert.Equal(t, abciEv, app.ByzantineValidators)
+
+	// the events of the new evidence carry the misbehaving validators
+	for i := range ev {
+		msg := <-evidenceSub.Out()
+		event, ok := msg.Data().(types.EventDataNewEvidence)
+		require.True(t, ok)
+		assert.Equal(t, ev[i], event.Evidence)
+		assert.Equal(t, []abci.Validator{ev[i].ABCI()[0].Validator}, event.Validators)
+	}
 }
 
 func TestValidateValidatorUpdates(t *testing.T) {
@@ -487,6 +776,54 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	}
 }
 
+// TestEndBlockValidatorKeyRotation ensures a validator keeps its voting power
+// under its new key, from the next next height.
+func TestEndBlockValidatorKeyRotation(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+
+	proposer := state.Validators.SelectProposer(state.LastProofHash, 1, 0)
+	block := makeBlockWithPrivVal(state, privVals[proposer.Address.String()], 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, rotated := state.NextValidators.GetByIndex(1)
+	newPubKey := ed25519.GenPrivKey().PubKey()
+	app.KeyRotations = []ocabci.ValidatorKeyRotation{ocabci.NewValidatorKeyRotation(rotated.PubKey, newPubKey)}
+
+	state, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.Nil(t, err)
+
+	// the validators still sign with the old key at the next height
+	_, val := state.Validators.GetByPubKey(rotated.PubKey)
+	assert.NotNil(t, val)
+
+	assert.Equal(t, state.Validators.Size(), state.NextValidators.Size())
+	assert.Equal(t, state.Validators.TotalVotingPower(), state.NextValidators.TotalVotingPower())
+	assert.False(t, state.NextValidators.HasAddress(newPubKey.Address()))
+	_, val = state.NextValidators.GetByPubKey(newPubKey)
+	require.NotNil(t, val)
+	assert.Equal(t, rotated.Address, val.Address, "keeps its address")
+	assert.Equal(t, rotated.VotingPower, val.VotingPower)
+	assert.Equal(t, block.Height+2, state.LastHeightValidatorsChanged)
+}
+
 // TestEndBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
 // would result in empty set causes no panic, an error is raised and NextValidators is not updated
 func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
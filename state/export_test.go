@@ -2,10 +2,11 @@ package state
 
 import (
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -29,10 +30,10 @@ func UpdateState(
 	blockID types.BlockID,
 	header *types.Header,
 	entropy *types.Entropy,
-	abciResponses *tmstate.ABCIResponses,
+	abciResponses *ocstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
 ) (State, error) {
-	return updateState(state, blockID, header, entropy, abciResponses, validatorUpdates)
+	return updateState(state, blockID, header, entropy, abciResponses, nil, validatorUpdates)
 }
 
 // ValidateValidatorUpdates is an alias for validateValidatorUpdates exported
@@ -60,3 +61,9 @@ func SaveProofHash(db dbm.DB, height int64, proofHash []byte) error {
 	stateStore := dbStore{db, StoreOptions{DiscardABCIResponses: false}}
 	return stateStore.saveProofHash(height, proofHash)
 }
+
+// ScheduleTxBatches is an alias for the private scheduleTxBatches function in
+// tx_scheduler.go, exported exclusively and explicitly for testing.
+func ScheduleTxBatches(numTxs int, accessSets []ocabci.TxAccessSet) [][]int {
+	return scheduleTxBatches(numTxs, accessSets)
+}
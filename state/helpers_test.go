@@ -6,7 +6,6 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	"github.com/Finschia/ostracon/proxy"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
@@ -174,17 +174,17 @@ func genValSet(size int) *types.ValidatorSet {
 func makeHeaderPartsResponsesValPubKeyChange(
 	state sm.State,
 	pubkey crypto.PubKey,
-) (types.Header, types.Entropy, types.BlockID, *tmstate.ABCIResponses) {
+) (types.Header, types.Entropy, types.BlockID, *ocstate.ABCIResponses) {
 
 	block := makeBlock(state, state.LastBlockHeight+1)
-	abciResponses := &tmstate.ABCIResponses{
+	abciResponses := &ocstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
-		EndBlock:   &abci.ResponseEndBlock{ValidatorUpdates: nil},
+		EndBlock:   &ocabci.ResponseEndBlock{ValidatorUpdates: nil},
 	}
 	// If the pubkey is new, remove the old and add the new.
 	_, val := state.NextValidators.GetByIndex(0)
 	if !bytes.Equal(pubkey.Bytes(), val.PubKey.Bytes()) {
-		abciResponses.EndBlock = &abci.ResponseEndBlock{
+		abciResponses.EndBlock = &ocabci.ResponseEndBlock{
 			ValidatorUpdates: []abci.ValidatorUpdate{
 				types.OC2PB.NewValidatorUpdate(val.PubKey, 0),
 				types.OC2PB.NewValidatorUpdate(pubkey, 10),
@@ -198,18 +198,18 @@ func makeHeaderPartsResponsesValPubKeyChange(
 func makeHeaderPartsResponsesValPowerChange(
 	state sm.State,
 	power int64,
-) (types.Header, types.Entropy, types.BlockID, *tmstate.ABCIResponses) {
+) (types.Header, types.Entropy, types.BlockID, *ocstate.ABCIResponses) {
 
 	block := makeBlock(state, state.LastBlockHeight+1)
-	abciResponses := &tmstate.ABCIResponses{
+	abciResponses := &ocstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
-		EndBlock:   &abci.ResponseEndBlock{ValidatorUpdates: nil},
+		EndBlock:   &ocabci.ResponseEndBlock{ValidatorUpdates: nil},
 	}
 
 	// If the pubkey is new, remove the old and add the new.
 	_, val := state.NextValidators.GetByIndex(0)
 	if val.VotingPower != power {
-		abciResponses.EndBlock = &abci.ResponseEndBlock{
+		abciResponses.EndBlock = &ocabci.ResponseEndBlock{
 			ValidatorUpdates: []abci.ValidatorUpdate{
 				types.OC2PB.NewValidatorUpdate(val.PubKey, power),
 			},
@@ -222,12 +222,12 @@ func makeHeaderPartsResponsesValPowerChange(
 func makeHeaderPartsResponsesParams(
 	state sm.State,
 	params tmproto.ConsensusParams,
-) (types.Header, types.Entropy, types.BlockID, *tmstate.ABCIResponses) {
+) (types.Header, types.Entropy, types.BlockID, *ocstate.ABCIResponses) {
 
 	block := makeBlock(state, state.LastBlockHeight+1)
-	abciResponses := &tmstate.ABCIResponses{
+	abciResponses := &ocstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
-		EndBlock:   &abci.ResponseEndBlock{ConsensusParamUpdates: types.OC2PB.ConsensusParams(&params)},
+		EndBlock:   &ocabci.ResponseEndBlock{ConsensusParamUpdates: types.OC2PB.ConsensusParams(&params)},
 	}
 	return block.Header, block.Entropy, types.BlockID{Hash: block.Hash(), PartSetHeader: types.PartSetHeader{}}, abciResponses
 }
@@ -257,6 +257,7 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	KeyRotations        []ocabci.ValidatorKeyRotation
 }
 
 var _ ocabci.Application = (*testApp)(nil)
@@ -272,9 +273,10 @@ func (app *testApp) BeginBlock(req ocabci.RequestBeginBlock) abci.ResponseBeginB
 	return abci.ResponseBeginBlock{}
 }
 
-func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return abci.ResponseEndBlock{
-		ValidatorUpdates: app.ValidatorUpdates,
+func (app *testApp) EndBlock(req abci.RequestEndBlock) ocabci.ResponseEndBlock {
+	return ocabci.ResponseEndBlock{
+		ValidatorUpdates:      app.ValidatorUpdates,
+		ValidatorKeyRotations: app.KeyRotations,
 		ConsensusParamUpdates: &abci.ConsensusParams{
 			Version: &tmproto.VersionParams{
 				AppVersion: TestAppVersion}}}
@@ -9,6 +9,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	db "github.com/tendermint/tm-db"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/libs/pubsub/query"
 	blockidxkv "github.com/Finschia/ostracon/state/indexer/block/kv"
 	"github.com/Finschia/ostracon/types"
@@ -33,7 +34,7 @@ func TestBlockIndexer(t *testing.T) {
 				},
 			},
 		},
-		ResultEndBlock: abci.ResponseEndBlock{
+		ResultEndBlock: ocabci.ResponseEndBlock{
 			Events: []abci.Event{
 				{
 					Type: "end_event",
@@ -71,7 +72,7 @@ func TestBlockIndexer(t *testing.T) {
 					},
 				},
 			},
-			ResultEndBlock: abci.ResponseEndBlock{
+			ResultEndBlock: ocabci.ResponseEndBlock{
 				Events: []abci.Event{
 					{
 						Type: "end_event",
@@ -264,7 +264,7 @@ func newTestBlockHeader() types.EventDataNewBlockHeader {
 				makeIndexedEvent("thingy.whatzit", "O.O"),
 			},
 		},
-		ResultEndBlock: abci.ResponseEndBlock{
+		ResultEndBlock: ocabci.ResponseEndBlock{
 			Events: []abci.Event{
 				makeIndexedEvent("end_event.foo", "100"),
 				makeIndexedEvent("thingy.whatzit", "-.O"),
@@ -8,7 +8,7 @@ import (
 
 	state "github.com/Finschia/ostracon/state"
 
-	tendermintstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	ostraconstate "github.com/Finschia/ostracon/proto/ostracon/state"
 
 	types "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -71,19 +71,19 @@ func (_m *Store) Load() (state.State, error) {
 }
 
 // LoadABCIResponses provides a mock function with given fields: _a0
-func (_m *Store) LoadABCIResponses(_a0 int64) (*tendermintstate.ABCIResponses, error) {
+func (_m *Store) LoadABCIResponses(_a0 int64) (*ostraconstate.ABCIResponses, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *tendermintstate.ABCIResponses
+	var r0 *ostraconstate.ABCIResponses
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*tendermintstate.ABCIResponses, error)); ok {
+	if rf, ok := ret.Get(0).(func(int64) (*ostraconstate.ABCIResponses, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *tendermintstate.ABCIResponses); ok {
+	if rf, ok := ret.Get(0).(func(int64) *ostraconstate.ABCIResponses); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*tendermintstate.ABCIResponses)
+			r0 = ret.Get(0).(*ostraconstate.ABCIResponses)
 		}
 	}
 
@@ -169,19 +169,19 @@ func (_m *Store) LoadFromDBOrGenesisFile(_a0 string) (state.State, error) {
 }
 
 // LoadLastABCIResponse provides a mock function with given fields: _a0
-func (_m *Store) LoadLastABCIResponse(_a0 int64) (*tendermintstate.ABCIResponses, error) {
+func (_m *Store) LoadLastABCIResponse(_a0 int64) (*ostraconstate.ABCIResponses, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *tendermintstate.ABCIResponses
+	var r0 *ostraconstate.ABCIResponses
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*tendermintstate.ABCIResponses, error)); ok {
+	if rf, ok := ret.Get(0).(func(int64) (*ostraconstate.ABCIResponses, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *tendermintstate.ABCIResponses); ok {
+	if rf, ok := ret.Get(0).(func(int64) *ostraconstate.ABCIResponses); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*tendermintstate.ABCIResponses)
+			r0 = ret.Get(0).(*ostraconstate.ABCIResponses)
 		}
 	}
 
@@ -275,11 +275,11 @@ func (_m *Store) Save(_a0 state.State) error {
 }
 
 // SaveABCIResponses provides a mock function with given fields: _a0, _a1
-func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *tendermintstate.ABCIResponses) error {
+func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *ostraconstate.ABCIResponses) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, *tendermintstate.ABCIResponses) error); ok {
+	if rf, ok := ret.Get(0).(func(int64, *ostraconstate.ABCIResponses) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
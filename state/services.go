@@ -59,3 +59,6 @@ func (EmptyEvidencePool) AddEvidence(types.Evidence) error                { retu
 func (EmptyEvidencePool) Update(State, types.EvidenceList)                {}
 func (EmptyEvidencePool) CheckEvidence(evList types.EvidenceList) error   { return nil }
 func (EmptyEvidencePool) ReportConflictingVotes(voteA, voteB *types.Vote) {}
+func (EmptyEvidencePool) ReportConflictingProposals(proposalA, proposalB *types.Proposal,
+	proposerAddr types.Address) {
+}
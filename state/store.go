@@ -68,15 +68,15 @@ type Store interface {
 	// LoadProofHash loads the proof hash at a given height
 	LoadProofHash(int64) ([]byte, error)
 	// LoadABCIResponses loads the abciResponse for a given height
-	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
+	LoadABCIResponses(int64) (*ocstate.ABCIResponses, error)
 	// LoadLastABCIResponse loads the last abciResponse for a given height
-	LoadLastABCIResponse(int64) (*tmstate.ABCIResponses, error)
+	LoadLastABCIResponse(int64) (*ocstate.ABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (tmproto.ConsensusParams, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
-	SaveABCIResponses(int64, *tmstate.ABCIResponses) error
+	SaveABCIResponses(int64, *ocstate.ABCIResponses) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
@@ -384,14 +384,14 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 // ResponseDeliverTx responses (see ABCIResults.Hash)
 //
 // See merkle.SimpleHashFromByteSlices
-func ABCIResponsesResultsHash(ar *tmstate.ABCIResponses) []byte {
+func ABCIResponsesResultsHash(ar *ocstate.ABCIResponses) []byte {
 	return types.NewResults(ar.DeliverTxs).Hash()
 }
 
 // LoadABCIResponses loads the ABCIResponses for the given height from the
 // database. If the node has DiscardABCIResponses set to true, ErrABCIResponsesNotPersisted
 // is persisted. If not found, ErrNoABCIResponsesForHeight is returned.
-func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, error) {
+func (store dbStore) LoadABCIResponses(height int64) (*ocstate.ABCIResponses, error) {
 	if store.DiscardABCIResponses {
 		return nil, ErrABCIResponsesNotPersisted
 	}
@@ -405,7 +405,7 @@ func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, er
 		return nil, ErrNoABCIResponsesForHeight{height}
 	}
 
-	abciResponses := new(tmstate.ABCIResponses)
+	abciResponses := new(ocstate.ABCIResponses)
 	err = abciResponses.Unmarshal(buf)
 	if err != nil {
 		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
@@ -423,7 +423,7 @@ func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, er
 //
 // This method is used for recovering in the case that we called the Commit ABCI
 // method on the application but crashed before persisting the results.
-func (store dbStore) LoadLastABCIResponse(height int64) (*tmstate.ABCIResponses, error) {
+func (store dbStore) LoadLastABCIResponse(height int64) (*ocstate.ABCIResponses, error) {
 	bz, err := store.db.Get(lastABCIResponseKey)
 	if err != nil {
 		return nil, err
@@ -433,7 +433,7 @@ func (store dbStore) LoadLastABCIResponse(height int64) (*tmstate.ABCIResponses,
 		return nil, errors.New("no last ABCI response has been persisted")
 	}
 
-	abciResponse := new(tmstate.ABCIResponsesInfo)
+	abciResponse := new(ocstate.ABCIResponsesInfo)
 	err = abciResponse.Unmarshal(bz)
 	if err != nil {
 		tmos.Exit(fmt.Sprintf(`LoadLastABCIResponses: Data has been corrupted or its spec has
@@ -454,7 +454,7 @@ func (store dbStore) LoadLastABCIResponse(height int64) (*tmstate.ABCIResponses,
 // Merkle proofs.
 //
 // CONTRACT: height must be monotonically increasing every time this is called.
-func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCIResponses) error {
+func (store dbStore) SaveABCIResponses(height int64, abciResponses *ocstate.ABCIResponses) error {
 	var dtxs []*abci.ResponseDeliverTx
 	// strip nil values,
 	for _, tx := range abciResponses.DeliverTxs {
@@ -478,7 +478,7 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 
 	// We always save the last ABCI response for crash recovery.
 	// This overwrites the previous saved ABCI Response.
-	response := &tmstate.ABCIResponsesInfo{
+	response := &ocstate.ABCIResponsesInfo{
 		AbciResponses: abciResponses,
 		Height:        height,
 	}
@@ -14,10 +14,12 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	cfg "github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	sm "github.com/Finschia/ostracon/state"
 	statemocks "github.com/Finschia/ostracon/state/mocks"
 	"github.com/Finschia/ostracon/types"
@@ -183,7 +185,7 @@ func TestPruneStates(t *testing.T) {
 				require.NoError(t, err)
 
 				currentHeight := state.LastBlockHeight + int64(1)
-				err = stateStore.SaveABCIResponses(currentHeight, &tmstate.ABCIResponses{
+				err = stateStore.SaveABCIResponses(currentHeight, &ocstate.ABCIResponses{
 					DeliverTxs: []*abci.ResponseDeliverTx{
 						{Data: []byte{1}},
 						{Data: []byte{2}},
@@ -295,12 +297,12 @@ func TestPruneStatesDeleteErrHandle(t *testing.T) {
 }
 
 func TestABCIResponsesResultsHash(t *testing.T) {
-	responses := &tmstate.ABCIResponses{
+	responses := &ocstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
 		DeliverTxs: []*abci.ResponseDeliverTx{
 			{Code: 32, Data: []byte("Hello"), Log: "Huh?"},
 		},
-		EndBlock: &abci.ResponseEndBlock{},
+		EndBlock: &ocabci.ResponseEndBlock{},
 	}
 
 	root := sm.ABCIResponsesResultsHash(responses)
@@ -371,12 +373,12 @@ func TestLastABCIResponses(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, responses)
 		// stub the abciresponses.
-		response1 := &tmstate.ABCIResponses{
+		response1 := &ocstate.ABCIResponses{
 			BeginBlock: &abci.ResponseBeginBlock{},
 			DeliverTxs: []*abci.ResponseDeliverTx{
 				{Code: 32, Data: []byte("Hello"), Log: "Huh?"},
 			},
-			EndBlock: &abci.ResponseEndBlock{},
+			EndBlock: &ocabci.ResponseEndBlock{},
 		}
 		// create new db and state store and set discard abciresponses to false.
 		stateDB = dbm.NewMemDB()
@@ -403,12 +405,12 @@ func TestLastABCIResponses(t *testing.T) {
 		stateDB := dbm.NewMemDB()
 		height := int64(10)
 		// stub the second abciresponse.
-		response2 := &tmstate.ABCIResponses{
+		response2 := &ocstate.ABCIResponses{
 			BeginBlock: &abci.ResponseBeginBlock{},
 			DeliverTxs: []*abci.ResponseDeliverTx{
 				{Code: 44, Data: []byte("Hello again"), Log: "????"},
 			},
-			EndBlock: &abci.ResponseEndBlock{},
+			EndBlock: &ocabci.ResponseEndBlock{},
 		}
 		// create a new statestore with the responses on.
 		stateStore := sm.NewStore(stateDB, sm.StoreOptions{
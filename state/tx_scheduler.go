@@ -0,0 +1,58 @@
+package state
+
+import (
+	ocabci "github.com/Finschia/ostracon/abci/types"
+)
+
+// scheduleTxBatches groups the txs of a block into batches of txs that don't
+// conflict with each other, and returns the indexes of the txs of each batch in
+// increasing order. accessSets are the access sets the app returned for the
+// txs, in the order of the block. Two txs conflict if one of them writes a key
+// the other one reads or writes. A tx whose access set has no keys conflicts
+// with every tx.
+//
+// Each tx is put in the batch following the last batch holding a tx it
+// conflicts with, so the conflicting txs keep the order of the block: executing
+// the batches in order, and the txs of a batch in any order, gives the results
+// of executing the txs in the order of the block. If accessSets is nil, every
+// tx is in its own batch.
+func scheduleTxBatches(numTxs int, accessSets []ocabci.TxAccessSet) [][]int {
+	var (
+		batches   [][]int
+		lastRead  = map[string]int{} // key -> last batch reading the key, plus one
+		lastWrite = map[string]int{} // key -> last batch writing the key, plus one
+		barrier   = 0                // last batch holding a tx with an unknown access set, plus one
+	)
+	for i := 0; i < numTxs; i++ {
+		var (
+			set   ocabci.TxAccessSet
+			batch int
+		)
+		if accessSets != nil {
+			set = accessSets[i]
+		}
+		if len(set.ReadKeys) == 0 && len(set.WriteKeys) == 0 {
+			batch = len(batches)
+			barrier = batch + 1
+		} else {
+			batch = barrier
+			for _, key := range set.ReadKeys {
+				batch = max(batch, lastWrite[string(key)])
+			}
+			for _, key := range set.WriteKeys {
+				batch = max(batch, lastWrite[string(key)], lastRead[string(key)])
+			}
+			for _, key := range set.ReadKeys {
+				lastRead[string(key)] = max(lastRead[string(key)], batch+1)
+			}
+			for _, key := range set.WriteKeys {
+				lastWrite[string(key)] = batch + 1
+			}
+		}
+		if batch == len(batches) {
+			batches = append(batches, nil)
+		}
+		batches[batch] = append(batches[batch], i)
+	}
+	return batches
+}
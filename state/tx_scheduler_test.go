@@ -0,0 +1,65 @@
+package state_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	sm "github.com/Finschia/ostracon/state"
+)
+
+// accessSetOf parses the access sets of txs of the form "r:k1,k2;w:k3", and
+// returns an access set without keys for the other txs.
+func accessSetOf(tx string) ocabci.TxAccessSet {
+	var set ocabci.TxAccessSet
+	for _, part := range strings.Split(tx, ";") {
+		kind, keys, ok := strings.Cut(part, ":")
+		if !ok {
+			return ocabci.TxAccessSet{}
+		}
+		for _, key := range strings.Split(keys, ",") {
+			switch kind {
+			case "r":
+				set.ReadKeys = append(set.ReadKeys, []byte(key))
+			case "w":
+				set.WriteKeys = append(set.WriteKeys, []byte(key))
+			}
+		}
+	}
+	return set
+}
+
+func TestScheduleTxBatches(t *testing.T) {
+	testCases := []struct {
+		name    string
+		txs     []string
+		batches [][]int
+	}{
+		{"no txs", nil, nil},
+		{"disjoint writes", []string{"w:a", "w:b", "w:c"}, [][]int{{0, 1, 2}}},
+		{"write after write", []string{"w:a", "w:b", "w:a"}, [][]int{{0, 1}, {2}}},
+		{"shared reads", []string{"r:a", "r:a;w:b", "r:a;w:c"}, [][]int{{0, 1, 2}}},
+		{"read after write", []string{"w:a", "r:a;w:b"}, [][]int{{0}, {1}}},
+		{"write after read", []string{"r:a;w:b", "w:a"}, [][]int{{0}, {1}}},
+		{"write after reads", []string{"w:a", "r:a;w:b", "r:a;w:c", "w:a"}, [][]int{{0}, {1, 2}, {3}}},
+		{"independent chains", []string{"w:a", "w:a", "w:b", "w:a", "w:c"}, [][]int{{0, 2, 4}, {1}, {3}}},
+		{"unknown access set", []string{"w:a", "w:b", "tx", "w:c", "w:a"}, [][]int{{0, 1}, {2}, {3, 4}}},
+		{"unknown access sets", []string{"tx1", "tx2", "w:a"}, [][]int{{0}, {1}, {2}}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			accessSets := make([]ocabci.TxAccessSet, len(tc.txs))
+			for i, tx := range tc.txs {
+				accessSets[i] = accessSetOf(tx)
+			}
+			assert.Equal(t, tc.batches, sm.ScheduleTxBatches(len(tc.txs), accessSets))
+		})
+	}
+}
+
+func TestScheduleTxBatchesWithoutAccessSets(t *testing.T) {
+	assert.Equal(t, [][]int{{0}, {1}, {2}}, sm.ScheduleTxBatches(3, nil))
+}
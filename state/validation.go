@@ -164,6 +164,8 @@ func validateBlock(state State, round int32, block *types.Block) error {
 	}
 
 	// validate vrf proof
+	// NOTE: there is no evidence for an invalid proof, since the signed proposal doesn't commit to
+	// the Entropy; see "Invalid VRF proofs" in spec/core/data_structures.md.
 	message := state.MakeHashMessage(block.Round)
 	proof := crypto.Proof(block.Proof)
 	_, err := proposer.PubKey.VRFVerify(proof, message)
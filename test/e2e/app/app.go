@@ -167,13 +167,13 @@ func (app *Application) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDelive
 }
 
 // EndBlock implements ABCI.
-func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+func (app *Application) EndBlock(req abci.RequestEndBlock) ocabci.ResponseEndBlock {
 	valUpdates, err := app.validatorUpdates(uint64(req.Height))
 	if err != nil {
 		panic(err)
 	}
 
-	return abci.ResponseEndBlock{
+	return ocabci.ResponseEndBlock{
 		ValidatorUpdates: valUpdates,
 		Events: []abci.Event{
 			{
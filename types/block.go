@@ -1173,13 +1173,13 @@ func (data *EvidenceData) StringIndented(indent string) string {
 }
 
 // ToProto converts EvidenceData to protobuf
-func (data *EvidenceData) ToProto() (*tmproto.EvidenceList, error) {
+func (data *EvidenceData) ToProto() (*ocproto.EvidenceList, error) {
 	if data == nil {
 		return nil, errors.New("nil evidence data")
 	}
 
-	evi := new(tmproto.EvidenceList)
-	eviBzs := make([]tmproto.Evidence, len(data.Evidence))
+	evi := new(ocproto.EvidenceList)
+	eviBzs := make([]ocproto.Evidence, len(data.Evidence))
 	for i := range data.Evidence {
 		protoEvi, err := EvidenceToProto(data.Evidence[i])
 		if err != nil {
@@ -1193,7 +1193,7 @@ func (data *EvidenceData) ToProto() (*tmproto.EvidenceList, error) {
 }
 
 // FromProto sets a protobuf EvidenceData to the given pointer.
-func (data *EvidenceData) FromProto(eviData *tmproto.EvidenceList) error {
+func (data *EvidenceData) FromProto(eviData *ocproto.EvidenceList) error {
 	if eviData == nil {
 		return errors.New("nil evidenceData")
 	}
@@ -12,6 +12,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
 	tmquery "github.com/Finschia/ostracon/libs/pubsub/query"
 )
@@ -81,7 +82,7 @@ func TestEventBusPublishEventNewBlock(t *testing.T) {
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("baz"), Value: []byte("1")}}},
 		},
 	}
-	resultEndBlock := abci.ResponseEndBlock{
+	resultEndBlock := ocabci.ResponseEndBlock{
 		Events: []abci.Event{
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("foz"), Value: []byte("2")}}},
 		},
@@ -240,7 +241,7 @@ func TestEventBusPublishEventNewBlockHeader(t *testing.T) {
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("baz"), Value: []byte("1")}}},
 		},
 	}
-	resultEndBlock := abci.ResponseEndBlock{
+	resultEndBlock := ocabci.ResponseEndBlock{
 		Events: []abci.Event{
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("foz"), Value: []byte("2")}}},
 		},
@@ -5,6 +5,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
 	tmquery "github.com/Finschia/ostracon/libs/pubsub/query"
@@ -66,7 +67,7 @@ type EventDataNewBlock struct {
 	Block *Block `json:"block"`
 
 	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
-	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+	ResultEndBlock   ocabci.ResponseEndBlock `json:"result_end_block"`
 }
 
 type EventDataNewBlockHeader struct {
@@ -74,13 +75,16 @@ type EventDataNewBlockHeader struct {
 
 	NumTxs           int64                   `json:"num_txs"` // Number of txs in a block
 	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
-	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+	ResultEndBlock   ocabci.ResponseEndBlock `json:"result_end_block"`
 }
 
 type EventDataNewEvidence struct {
 	Evidence Evidence `json:"evidence"`
 
 	Height int64 `json:"height"`
+
+	// the misbehaving validators with their voting power at the height of the infraction
+	Validators []abci.Validator `json:"validators"`
 }
 
 // All txs fire EventDataTx
@@ -12,10 +12,13 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
+	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 func MaxEvidenceBytes(ev Evidence) int64 {
@@ -26,6 +29,13 @@ func MaxEvidenceBytes(ev Evidence) int64 {
 			(1 + 9) + // TotalVotingPower
 			(1 + 9) + // ValidatorPower
 			(1 + 17 + 1) // Timestamp
+	case *DuplicateProposalEvidence:
+		return (1 + MaxProposalBytes + 2) + // ProposalA
+			(1 + MaxProposalBytes + 2) + // ProposalB
+			(1 + 9) + // TotalVotingPower
+			(1 + 9) + // ValidatorPower
+			(1 + 17 + 1) + // Timestamp
+			(1 + crypto.AddressSize + 1) // ValidatorAddress
 	case *LightClientAttackEvidence:
 		// FIXME 🏺 need this?
 		return 0
@@ -197,6 +207,172 @@ func DuplicateVoteEvidenceFromProto(pb *tmproto.DuplicateVoteEvidence) (*Duplica
 	return dve, dve.ValidateBasic()
 }
 
+//------------------------------------ PROPOSAL EVIDENCE -----------------------------------
+
+// DuplicateProposalEvidence contains evidence of a single proposer signing two conflicting
+// proposals for the same height and round.
+type DuplicateProposalEvidence struct {
+	ProposalA *Proposal `json:"proposal_a"`
+	ProposalB *Proposal `json:"proposal_b"`
+
+	// the proposals don't carry the address of their proposer
+	ValidatorAddress Address `json:"validator_address"`
+
+	// abci specific information
+	TotalVotingPower int64
+	ValidatorPower   int64
+	Timestamp        time.Time
+}
+
+var _ Evidence = &DuplicateProposalEvidence{}
+
+// NewDuplicateProposalEvidence creates DuplicateProposalEvidence with right ordering given
+// two conflicting proposals of the proposer of proposerAddr. If one of the proposals is nil,
+// or the proposer isn't in valSet, evidence returned is nil as well
+func NewDuplicateProposalEvidence(proposal1, proposal2 *Proposal, proposerAddr Address, blockTime time.Time,
+	valSet *ValidatorSet) *DuplicateProposalEvidence {
+	var proposalA, proposalB *Proposal
+	if proposal1 == nil || proposal2 == nil || valSet == nil {
+		return nil
+	}
+	idx, val := valSet.GetByAddress(proposerAddr)
+	if idx == -1 {
+		return nil
+	}
+
+	if strings.Compare(proposal1.BlockID.Key(), proposal2.BlockID.Key()) == -1 {
+		proposalA = proposal1
+		proposalB = proposal2
+	} else {
+		proposalA = proposal2
+		proposalB = proposal1
+	}
+	return &DuplicateProposalEvidence{
+		ProposalA:        proposalA,
+		ProposalB:        proposalB,
+		ValidatorAddress: proposerAddr,
+		TotalVotingPower: valSet.TotalVotingPower(),
+		ValidatorPower:   val.VotingPower,
+		Timestamp:        blockTime,
+	}
+}
+
+// ABCI returns the application relevant representation of the evidence
+func (dpe *DuplicateProposalEvidence) ABCI() []abci.Evidence {
+	return []abci.Evidence{{
+		Type: ocabci.EvidenceType_DUPLICATE_PROPOSAL,
+		Validator: abci.Validator{
+			Address: dpe.ValidatorAddress,
+			Power:   dpe.ValidatorPower,
+		},
+		Height:           dpe.ProposalA.Height,
+		Time:             dpe.Timestamp,
+		TotalVotingPower: dpe.TotalVotingPower,
+	}}
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (dpe *DuplicateProposalEvidence) Bytes() []byte {
+	pbe := dpe.ToProto()
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (dpe *DuplicateProposalEvidence) Hash() []byte {
+	return tmhash.Sum(dpe.Bytes())
+}
+
+// Height returns the height of the infraction
+func (dpe *DuplicateProposalEvidence) Height() int64 {
+	return dpe.ProposalA.Height
+}
+
+// String returns a string representation of the evidence.
+func (dpe *DuplicateProposalEvidence) String() string {
+	return fmt.Sprintf("DuplicateProposalEvidence{ProposalA: %v, ProposalB: %v, ValidatorAddress: %v}",
+		dpe.ProposalA, dpe.ProposalB, dpe.ValidatorAddress)
+}
+
+// Time returns the time of the infraction
+func (dpe *DuplicateProposalEvidence) Time() time.Time {
+	return dpe.Timestamp
+}
+
+// ValidateBasic performs basic validation.
+func (dpe *DuplicateProposalEvidence) ValidateBasic() error {
+	if dpe == nil {
+		return errors.New("empty duplicate proposal evidence")
+	}
+
+	if dpe.ProposalA == nil || dpe.ProposalB == nil {
+		return fmt.Errorf("one or both of the proposals are empty %v, %v", dpe.ProposalA, dpe.ProposalB)
+	}
+	if err := dpe.ProposalA.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalA: %w", err)
+	}
+	if err := dpe.ProposalB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalB: %w", err)
+	}
+	if dpe.ProposalA.Height != dpe.ProposalB.Height || dpe.ProposalA.Round != dpe.ProposalB.Round {
+		return fmt.Errorf("proposals are for different heights or rounds (%d/%d vs %d/%d)",
+			dpe.ProposalA.Height, dpe.ProposalA.Round, dpe.ProposalB.Height, dpe.ProposalB.Round)
+	}
+	if len(dpe.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(dpe.ValidatorAddress))
+	}
+	// Enforce Proposals are lexicographically sorted on blockID
+	if strings.Compare(dpe.ProposalA.BlockID.Key(), dpe.ProposalB.BlockID.Key()) >= 0 {
+		return errors.New("duplicate proposals in invalid order")
+	}
+	return nil
+}
+
+// ToProto encodes DuplicateProposalEvidence to protobuf
+func (dpe *DuplicateProposalEvidence) ToProto() *ocproto.DuplicateProposalEvidence {
+	return &ocproto.DuplicateProposalEvidence{
+		ProposalA:        dpe.ProposalA.ToProto(),
+		ProposalB:        dpe.ProposalB.ToProto(),
+		TotalVotingPower: dpe.TotalVotingPower,
+		ValidatorPower:   dpe.ValidatorPower,
+		Timestamp:        dpe.Timestamp,
+		ValidatorAddress: dpe.ValidatorAddress,
+	}
+}
+
+// DuplicateProposalEvidenceFromProto decodes protobuf into DuplicateProposalEvidence
+func DuplicateProposalEvidenceFromProto(pb *ocproto.DuplicateProposalEvidence) (*DuplicateProposalEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil duplicate proposal evidence")
+	}
+
+	pA, err := ProposalFromProto(pb.ProposalA)
+	if err != nil {
+		return nil, err
+	}
+
+	pB, err := ProposalFromProto(pb.ProposalB)
+	if err != nil {
+		return nil, err
+	}
+
+	dpe := &DuplicateProposalEvidence{
+		ProposalA:        pA,
+		ProposalB:        pB,
+		ValidatorAddress: pb.ValidatorAddress,
+		TotalVotingPower: pb.TotalVotingPower,
+		ValidatorPower:   pb.ValidatorPower,
+		Timestamp:        pb.Timestamp,
+	}
+
+	return dpe, dpe.ValidateBasic()
+}
+
 //------------------------------------ LIGHT EVIDENCE --------------------------------------
 
 // LightClientAttackEvidence is a generalized evidence that captures all forms of known attacks on
@@ -479,7 +655,7 @@ func (evl EvidenceList) Has(evidence Evidence) bool {
 
 // EvidenceToProto is a generalized function for encoding evidence that conforms to the
 // evidence interface to protobuf
-func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
+func EvidenceToProto(evidence Evidence) (*ocproto.Evidence, error) {
 	if evidence == nil {
 		return nil, errors.New("nil evidence")
 	}
@@ -487,8 +663,8 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 	switch evi := evidence.(type) {
 	case *DuplicateVoteEvidence:
 		pbev := evi.ToProto()
-		return &tmproto.Evidence{
-			Sum: &tmproto.Evidence_DuplicateVoteEvidence{
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_DuplicateVoteEvidence{
 				DuplicateVoteEvidence: pbev,
 			},
 		}, nil
@@ -498,12 +674,20 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &tmproto.Evidence{
-			Sum: &tmproto.Evidence_LightClientAttackEvidence{
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_LightClientAttackEvidence{
 				LightClientAttackEvidence: pbev,
 			},
 		}, nil
 
+	case *DuplicateProposalEvidence:
+		pbev := evi.ToProto()
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_DuplicateProposalEvidence{
+				DuplicateProposalEvidence: pbev,
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
 	}
@@ -511,16 +695,18 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 
 // EvidenceFromProto is a generalized function for decoding protobuf into the
 // evidence interface
-func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
+func EvidenceFromProto(evidence *ocproto.Evidence) (Evidence, error) {
 	if evidence == nil {
 		return nil, errors.New("nil evidence")
 	}
 
 	switch evi := evidence.Sum.(type) {
-	case *tmproto.Evidence_DuplicateVoteEvidence:
+	case *ocproto.Evidence_DuplicateVoteEvidence:
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
-	case *tmproto.Evidence_LightClientAttackEvidence:
+	case *ocproto.Evidence_LightClientAttackEvidence:
 		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
+	case *ocproto.Evidence_DuplicateProposalEvidence:
+		return DuplicateProposalEvidenceFromProto(evi.DuplicateProposalEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -529,6 +715,7 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 func init() {
 	tmjson.RegisterType(&DuplicateVoteEvidence{}, "ostracon/DuplicateVoteEvidence")
 	tmjson.RegisterType(&LightClientAttackEvidence{}, "ostracon/LightClientAttackEvidence")
+	tmjson.RegisterType(&DuplicateProposalEvidence{}, "ostracon/DuplicateProposalEvidence")
 }
 
 //-------------------------------------------- ERRORS --------------------------------------
@@ -604,6 +791,34 @@ func makeMockVote(height int64, round, index int32, addr Address,
 	}
 }
 
+// assumes the round to be 0 and voting power to be 10 and validator to be the only one in the set
+func NewMockDuplicateProposalEvidenceWithValidator(height int64, time time.Time,
+	pv PrivValidator, chainID string) *DuplicateProposalEvidence {
+	pubKey, _ := pv.GetPubKey()
+	val := NewValidator(pubKey, 10)
+	proposalA := makeMockProposal(height, 0, randBlockID(), time)
+	pA := proposalA.ToProto()
+	_ = pv.SignProposal(chainID, pA)
+	proposalA.Signature = pA.Signature
+	proposalB := makeMockProposal(height, 0, randBlockID(), time)
+	pB := proposalB.ToProto()
+	_ = pv.SignProposal(chainID, pB)
+	proposalB.Signature = pB.Signature
+	return NewDuplicateProposalEvidence(proposalA, proposalB, pubKey.Address(), time,
+		NewValidatorSet([]*Validator{val}))
+}
+
+func makeMockProposal(height int64, round int32, blockID BlockID, time time.Time) *Proposal {
+	return &Proposal{
+		Type:      tmproto.ProposalType,
+		Height:    height,
+		Round:     round,
+		POLRound:  -1,
+		BlockID:   blockID,
+		Timestamp: time,
+	}
+}
+
 func randBlockID() BlockID {
 	return BlockID{
 		Hash: tmrand.Bytes(tmhash.Size),
@@ -11,6 +11,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
@@ -51,6 +52,21 @@ func TestMaxEvidenceBytes(t *testing.T) {
 	bz, err := ev.ToProto().Marshal()
 	require.NoError(t, err)
 	assert.EqualValues(t, MaxEvidenceBytes(ev), len(bz))
+
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	dpe := &DuplicateProposalEvidence{
+		ProposalA:        makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, blockID, timestamp),
+		ProposalB:        makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, blockID2, timestamp),
+		ValidatorAddress: pubKey.Address(),
+		TotalVotingPower: math.MaxInt64,
+		ValidatorPower:   math.MaxInt64,
+		Timestamp:        timestamp,
+	}
+
+	bz, err = dpe.ToProto().Marshal()
+	require.NoError(t, err)
+	assert.EqualValues(t, MaxEvidenceBytes(dpe), len(bz))
 }
 
 func randomDuplicatedVoteEvidence(t *testing.T) *DuplicateVoteEvidence {
@@ -119,6 +135,66 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	}
 }
 
+func TestDuplicateProposalEvidence(t *testing.T) {
+	const height = int64(13)
+	ev := NewMockDuplicateProposalEvidenceWithValidator(height, time.Now(), NewMockPV(), "mock-chain-id")
+	assert.Equal(t, ev.Hash(), tmhash.Sum(ev.Bytes()))
+	assert.NotNil(t, ev.String())
+	assert.Equal(t, ev.Height(), height)
+	assert.NoError(t, ev.ValidateBasic())
+
+	abciEv := ev.ABCI()
+	require.Len(t, abciEv, 1)
+	assert.Equal(t, ocabci.EvidenceType_DUPLICATE_PROPOSAL, abciEv[0].Type)
+	assert.EqualValues(t, ev.ValidatorAddress, abciEv[0].Validator.Address)
+	assert.Equal(t, height, abciEv[0].Height)
+}
+
+func TestDuplicateProposalEvidenceValidation(t *testing.T) {
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+
+	testCases := []struct {
+		testName         string
+		malleateEvidence func(*DuplicateProposalEvidence)
+		expectErr        bool
+	}{
+		{"Good DuplicateProposalEvidence", func(ev *DuplicateProposalEvidence) {}, false},
+		{"Nil proposal A", func(ev *DuplicateProposalEvidence) { ev.ProposalA = nil }, true},
+		{"Nil proposal B", func(ev *DuplicateProposalEvidence) { ev.ProposalB = nil }, true},
+		{"Unsigned proposal", func(ev *DuplicateProposalEvidence) { ev.ProposalA.Signature = nil }, true},
+		{"Different heights", func(ev *DuplicateProposalEvidence) { ev.ProposalB.Height++ }, true},
+		{"Different rounds", func(ev *DuplicateProposalEvidence) { ev.ProposalB.Round++ }, true},
+		{"Invalid validator address", func(ev *DuplicateProposalEvidence) {
+			ev.ValidatorAddress = []byte("invalid")
+		}, true},
+		{"Same block IDs", func(ev *DuplicateProposalEvidence) { ev.ProposalB.BlockID = ev.ProposalA.BlockID }, true},
+		{"Invalid proposal order", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalA, ev.ProposalB = ev.ProposalB, ev.ProposalA
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			proposal1 := makeProposal(t, val, chainID, 10, 2, blockID, defaultVoteTime)
+			proposal2 := makeProposal(t, val, chainID, 10, 2, blockID2, defaultVoteTime)
+			valSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10)})
+			ev := NewDuplicateProposalEvidence(proposal1, proposal2, pubKey.Address(), defaultVoteTime, valSet)
+			tc.malleateEvidence(ev)
+			assert.Equal(t, tc.expectErr, ev.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+
+	// the proposer must be in the validator set
+	valSet := NewValidatorSet([]*Validator{NewMockPV().ExtractIntoValidator(10)})
+	assert.Nil(t, NewDuplicateProposalEvidence(makeProposal(t, val, chainID, 10, 2, blockID, defaultVoteTime),
+		makeProposal(t, val, chainID, 10, 2, blockID2, defaultVoteTime), pubKey.Address(), defaultVoteTime, valSet))
+}
+
 func TestLightClientAttackEvidenceBasic(t *testing.T) {
 	height := int64(5)
 	commonHeight := height - 1
@@ -283,6 +359,25 @@ func makeVote(
 	return v
 }
 
+func makeProposal(
+	t *testing.T, val PrivValidator, chainID string, height int64, round int32, blockID BlockID,
+	time time.Time) *Proposal {
+	p := &Proposal{
+		Type:      tmproto.ProposalType,
+		Height:    height,
+		Round:     round,
+		POLRound:  -1,
+		BlockID:   blockID,
+		Timestamp: time,
+	}
+
+	ppb := p.ToProto()
+	err := val.SignProposal(chainID, ppb)
+	require.NoError(t, err)
+	p.Signature = ppb.Signature
+	return p
+}
+
 func makeHeaderRandom() *Header {
 	return &Header{
 		Version:            tmversion.Consensus{Block: version.BlockProtocol, App: version.AppProtocol},
@@ -311,6 +406,10 @@ func TestEvidenceProto(t *testing.T) {
 	v := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 1, 0x01, blockID, defaultVoteTime)
 	v2 := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 2, 0x01, blockID2, defaultVoteTime)
 
+	// -------- Proposals --------
+	p := makeProposal(t, val, chainID, math.MaxInt64, 1, blockID, defaultVoteTime)
+	p2 := makeProposal(t, val, chainID, math.MaxInt64, 1, blockID2, defaultVoteTime)
+
 	// -------- SignedHeaders --------
 	const height int64 = 37
 
@@ -338,6 +437,10 @@ func TestEvidenceProto(t *testing.T) {
 		{"DuplicateVoteEvidence nil voteB", &DuplicateVoteEvidence{VoteA: v, VoteB: nil}, false, true},
 		{"DuplicateVoteEvidence nil voteA", &DuplicateVoteEvidence{VoteA: nil, VoteB: v}, false, true},
 		{"DuplicateVoteEvidence success", &DuplicateVoteEvidence{VoteA: v2, VoteB: v}, false, false},
+		{"DuplicateProposalEvidence empty fail", &DuplicateProposalEvidence{}, false, true},
+		{"DuplicateProposalEvidence nil proposalB", &DuplicateProposalEvidence{ProposalA: p, ProposalB: nil}, false, true},
+		{"DuplicateProposalEvidence success", &DuplicateProposalEvidence{ProposalA: p, ProposalB: p2,
+			ValidatorAddress: v.ValidatorAddress}, false, false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -22,6 +22,17 @@ type PrivValidator interface {
 	GenerateVRFProof(message []byte) (crypto.Proof, error)
 }
 
+// KeyRotatingPrivValidator is a PrivValidator holding the next key of a
+// validator key rotation, which it switches to once the validator set uses it.
+type KeyRotatingPrivValidator interface {
+	PrivValidator
+
+	// NextPubKey returns the public key of the next key, or nil if there's none.
+	NextPubKey() (crypto.PubKey, error)
+	// RotateKey switches to the next key.
+	RotateKey() error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -7,6 +7,7 @@ import (
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	"github.com/Finschia/ostracon/libs/protoio"
 	tmtime "github.com/Finschia/ostracon/types/time"
@@ -33,6 +34,14 @@ type Proposal struct {
 	Signature []byte    `json:"signature"`
 }
 
+const MaxProposalBytes int64 = (1 + 1) + // Type
+	(1 + 9) + // Height
+	(1 + 5) + // Round
+	(1 + 10) + // POLRound
+	(1 + 76 + 1) + // BlockID
+	(1 + 17 + 1) + // Timestamp
+	(1 + ed25519.SignatureSize + 1) // Signature
+
 // NewProposal returns a new Proposal.
 // If there is no POLRound, polRound should be -1.
 func NewProposal(height int64, round int32, polRound int32, blockID BlockID) *Proposal {
@@ -4,6 +4,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
@@ -58,7 +59,7 @@ func (oc2pb) Header(header *Header) tmproto.Header {
 
 func (oc2pb) Validator(val *Validator) abci.Validator {
 	return abci.Validator{
-		Address: val.PubKey.Address(),
+		Address: val.Address,
 		Power:   val.VotingPower,
 	}
 }
@@ -140,3 +141,20 @@ func (pb2tm) ValidatorUpdates(vals []abci.ValidatorUpdate) ([]*Validator, error)
 	}
 	return tmVals, nil
 }
+
+// ValidatorKeyRotations converts the validator key rotations of ResponseEndBlock.
+func (pb2tm) ValidatorKeyRotations(rots []ocabci.ValidatorKeyRotation) ([]ValidatorKeyRotation, error) {
+	rotations := make([]ValidatorKeyRotation, len(rots))
+	for i, r := range rots {
+		oldPubKey, err := cryptoenc.PubKeyFromProto(&r.OldPubKey)
+		if err != nil {
+			return nil, err
+		}
+		newPubKey, err := cryptoenc.PubKeyFromProto(&r.NewPubKey)
+		if err != nil {
+			return nil, err
+		}
+		rotations[i] = ValidatorKeyRotation{OldPubKey: oldPubKey, NewPubKey: newPubKey}
+	}
+	return rotations, nil
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/tendermint/go-amino"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	"github.com/tendermint/tendermint/proto/tendermint/version"
 
+	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
@@ -36,6 +38,20 @@ func testABCIPubKey(t *testing.T, pk crypto.PubKey, typeStr string) error {
 	return nil
 }
 
+func TestPB2OCValidatorKeyRotations(t *testing.T) {
+	pk1, pk2 := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+	rots := []ocabci.ValidatorKeyRotation{ocabci.NewValidatorKeyRotation(pk1, pk2)}
+
+	rotations, err := PB2OC.ValidatorKeyRotations(rots)
+	require.NoError(t, err)
+	assert.Equal(t, []ValidatorKeyRotation{{OldPubKey: pk1, NewPubKey: pk2}}, rotations)
+
+	// without the new public key
+	rots[0].NewPubKey = tmcrypto.PublicKey{}
+	_, err = PB2OC.ValidatorKeyRotations(rots)
+	assert.Error(t, err)
+}
+
 func TestABCIValidators(t *testing.T) {
 	pkEd := ed25519.GenPrivKey().PubKey()
 
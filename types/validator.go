@@ -11,6 +11,7 @@ import (
 	"github.com/Finschia/ostracon/crypto"
 	ce "github.com/Finschia/ostracon/crypto/encoding"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 // Volatile state for each Validator
@@ -113,18 +114,22 @@ func ValidatorListString(vals []*Validator) string {
 
 // Bytes computes the unique encoding of a validator with a given voting power.
 // These are the bytes that gets hashed in consensus. It excludes address
-// as its redundant with the pubkey. This also excludes ProposerPriority
-// which changes every round.
+// as its redundant with the pubkey, unless the validator rotated its key and
+// kept its address. This also excludes ProposerPriority which changes every
+// round.
 func (v *Validator) Bytes() []byte {
 	pk, err := ce.PubKeyToProto(v.PubKey)
 	if err != nil {
 		panic(err)
 	}
 
-	pbv := tmproto.SimpleValidator{
+	pbv := ocproto.SimpleValidator{
 		PubKey:      &pk,
 		VotingPower: v.VotingPower,
 	}
+	if !bytes.Equal(v.Address, v.PubKey.Address()) {
+		pbv.Address = v.Address
+	}
 
 	bz, err := pbv.Marshal()
 	if err != nil {
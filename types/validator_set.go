@@ -12,6 +12,7 @@ import (
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmmath "github.com/Finschia/ostracon/libs/math"
@@ -268,6 +269,19 @@ func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Valida
 	return -1, nil
 }
 
+// GetByPubKey returns an index of the validator with pubKey and validator
+// itself (copy) if found. Otherwise, -1 and nil are returned. Unlike the
+// address, which a validator keeps across the rotations of its key, pubKey is
+// the current key of the validator.
+func (vals *ValidatorSet) GetByPubKey(pubKey crypto.PubKey) (index int32, val *Validator) {
+	for idx, val := range vals.Validators {
+		if val.PubKey.Equals(pubKey) {
+			return int32(idx), val.Copy()
+		}
+	}
+	return -1, nil
+}
+
 // GetByIndex returns the validator's address and validator itself (copy) by
 // index.
 // It returns nil values if index is less than 0 or greater or equal to
@@ -376,6 +390,39 @@ func processChanges(origChanges []*Validator) (updates, removals []*Validator, e
 	return updates, removals, err
 }
 
+// resolveRotatedAddresses sets the addresses of the changes of the validators
+// which rotated their keys to the addresses they kept, and sorts the changes by
+// address again. It returns an error if a change has a public key the
+// validator rotated from, whose address is the address the validator kept.
+func (vals *ValidatorSet) resolveRotatedAddresses(changes []*Validator) error {
+	var rotated map[string]Address
+	for _, val := range vals.Validators {
+		if val.PubKey != nil && !bytes.Equal(val.Address, val.PubKey.Address()) {
+			if rotated == nil {
+				rotated = make(map[string]Address)
+			}
+			rotated[string(val.PubKey.Bytes())] = val.Address
+		}
+	}
+	if rotated == nil {
+		return nil
+	}
+
+	for _, change := range changes {
+		if change.PubKey == nil {
+			continue
+		}
+		if addr, ok := rotated[string(change.PubKey.Bytes())]; ok {
+			change.Address = addr
+		} else if _, val := vals.GetByAddress(change.Address); val != nil && !val.PubKey.Equals(change.PubKey) {
+			return fmt.Errorf("validator %X rotated its key, the change must have its current public key",
+				change.Address)
+		}
+	}
+	sort.Sort(ValidatorsByAddress(changes))
+	return nil
+}
+
 // verifyUpdates verifies a list of updates against a validator set, making sure the allowed
 // total voting power would not be exceeded if these updates would be applied to the set.
 //
@@ -568,6 +615,12 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 	if err != nil {
 		return err
 	}
+	if err := vals.resolveRotatedAddresses(updates); err != nil {
+		return err
+	}
+	if err := vals.resolveRotatedAddresses(deletes); err != nil {
+		return err
+	}
 
 	if !allowDeletes && len(deletes) != 0 {
 		return fmt.Errorf("cannot process validators with voting power 0: %v", deletes)
@@ -627,6 +680,66 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 	return vals.updateWithChangeSet(changes, true)
 }
 
+// ValidatorKeyRotation replaces the public key of a validator, which keeps
+// its address, voting power and proposer priority.
+type ValidatorKeyRotation struct {
+	OldPubKey crypto.PubKey
+	NewPubKey crypto.PubKey
+}
+
+// RotateKeys replaces the public keys of the validators according to
+// 'rotations'. A validator keeps its address, so it keeps its identity, e.g.
+// in the votes, the evidence and the ABCI, across the rotation. It's applied
+// before UpdateWithChangeSet, so that the changes of the same height refer to
+// the new public keys.
+//
+// If an error is detected, e.g. the old public key isn't in the set or the new
+// one, or its address, already is, it is returned and the validator set is not
+// changed.
+func (vals *ValidatorSet) RotateKeys(rotations []ValidatorKeyRotation) error {
+	if len(rotations) == 0 {
+		return nil
+	}
+
+	rotated := validatorListCopy(vals.Validators)
+	byPubKey := make(map[string]*Validator, len(rotated))
+	addresses := make(map[string]bool, len(rotated))
+	for _, val := range rotated {
+		byPubKey[string(val.PubKey.Bytes())] = val
+		addresses[string(val.Address)] = true
+	}
+	seen := make(map[string]bool, 2*len(rotations))
+	for _, rotation := range rotations {
+		if rotation.OldPubKey == nil || rotation.NewPubKey == nil {
+			return errors.New("nil public key in validator key rotation")
+		}
+		oldPk, newPk := string(rotation.OldPubKey.Bytes()), string(rotation.NewPubKey.Bytes())
+		if seen[oldPk] || seen[newPk] {
+			return fmt.Errorf("duplicate public key in validator key rotation %X -> %X",
+				rotation.OldPubKey.Address(), rotation.NewPubKey.Address())
+		}
+		seen[oldPk], seen[newPk] = true, true
+
+		val, ok := byPubKey[oldPk]
+		if !ok {
+			return fmt.Errorf("failed to find validator with public key %X to rotate its key",
+				rotation.OldPubKey.Address())
+		}
+		// the address of the new public key mustn't be the address of another
+		// validator, which would take it over with a validator update
+		newAddr := rotation.NewPubKey.Address()
+		if _, ok := byPubKey[newPk]; ok || (addresses[string(newAddr)] && !bytes.Equal(newAddr, val.Address)) {
+			return fmt.Errorf("validator %X already exists", newAddr)
+		}
+		delete(byPubKey, oldPk)
+		val.PubKey = rotation.NewPubKey
+		byPubKey[newPk] = val
+	}
+
+	vals.Validators = rotated
+	return nil
+}
+
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
 //
 // It checks all the signatures! While it's safe to exit as soon as we have
@@ -746,7 +859,10 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 // this commit.
 //
 // NOTE the given validators do not necessarily correspond to the validator set
-// for this commit, but there may be some intersection.
+// for this commit, but there may be some intersection. A validator keeps its
+// address across the rotations of its key, so the signature of a validator
+// whose key was rotated in between doesn't verify against its key in vals: it
+// doesn't count, instead of failing the verification.
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
@@ -789,7 +905,8 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 			// Verify Signature
 			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-				return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+				// the key of the validator may have been rotated in between
+				continue
 			}
 
 			talliedVotingPower += val.VotingPower
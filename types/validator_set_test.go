@@ -1405,6 +1405,63 @@ func verifyValSetUpdatePriorityOrder(t *testing.T, valSet *ValidatorSet, cfg tes
 	}
 }
 
+func TestValSetRotateKeys(t *testing.T) {
+	pk1, pk2, pk3 := randPubKey(), randPubKey(), randPubKey()
+	valSet := NewValidatorSet([]*Validator{NewValidator(pk1, 10), NewValidator(pk2, 20)})
+	valSet.IncrementProposerPriority(3)
+	_, val1 := valSet.GetByAddress(pk1.Address())
+	hash := valSet.Hash()
+
+	testCases := []ValidatorKeyRotation{
+		{OldPubKey: pk3, NewPubKey: randPubKey()}, // not in the set
+		{OldPubKey: pk1, NewPubKey: pk2},          // already in the set
+		{OldPubKey: pk1, NewPubKey: nil},
+	}
+	for i, rotation := range testCases {
+		assert.Error(t, valSet.RotateKeys([]ValidatorKeyRotation{rotation}), i)
+		assert.Equal(t, hash, valSet.Hash(), "unchanged on error")
+	}
+	assert.Error(t, valSet.RotateKeys([]ValidatorKeyRotation{
+		{OldPubKey: pk1, NewPubKey: pk3},
+		{OldPubKey: pk3, NewPubKey: randPubKey()},
+	}), "rotated twice")
+	assert.Equal(t, hash, valSet.Hash(), "unchanged on error")
+
+	require.NoError(t, valSet.RotateKeys([]ValidatorKeyRotation{{OldPubKey: pk1, NewPubKey: pk3}}))
+	assert.False(t, valSet.HasAddress(pk3.Address()))
+	_, val3 := valSet.GetByPubKey(pk3)
+	require.NotNil(t, val3)
+	assert.Equal(t, val1.Address, val3.Address, "keeps its address")
+	assert.Equal(t, val1.VotingPower, val3.VotingPower)
+	assert.Equal(t, val1.ProposerPriority, val3.ProposerPriority)
+	assert.Equal(t, int64(30), valSet.TotalVotingPower())
+	assert.NotEqual(t, hash, valSet.Hash())
+	_, first := valSet.GetByIndex(0)
+	assert.Equal(t, pk2, first.PubKey, "still sorted by voting power")
+
+	// the hash commits to the address the validator kept
+	assert.NotEqual(t, NewValidator(pk3, val3.VotingPower).Bytes(), val3.Bytes())
+	pbSet, err := valSet.ToProto()
+	require.NoError(t, err)
+	fromProto, err := ValidatorSetFromProto(pbSet)
+	require.NoError(t, err)
+	assert.Equal(t, valSet.Hash(), fromProto.Hash())
+
+	// the changes of the rotated validator refer to its current key
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(pk3, 15)}))
+	_, val3 = valSet.GetByAddress(val1.Address)
+	assert.Equal(t, int64(15), val3.VotingPower)
+	assert.Equal(t, pk3, val3.PubKey)
+	assert.Equal(t, 2, valSet.Size())
+	assert.Error(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(pk1, 15)}), "rotated from")
+
+	// a key whose address is the address another validator kept
+	assert.Error(t, valSet.RotateKeys([]ValidatorKeyRotation{{OldPubKey: pk2, NewPubKey: pk1}}))
+
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(pk3, 0)}))
+	assert.False(t, valSet.HasAddress(val1.Address))
+}
+
 func TestNewValidatorSetFromExistingValidators(t *testing.T) {
 	size := 5
 	vals := make([]*Validator, size)
@@ -166,6 +166,20 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	return nil
 }
 
+// VerifyValidator verifies the vote was signed by val. Unlike Verify, the
+// address of the vote is the address of the validator, which it keeps across
+// the rotations of its key.
+func (vote *Vote) VerifyValidator(chainID string, val *Validator) error {
+	if !bytes.Equal(val.Address, vote.ValidatorAddress) {
+		return ErrVoteInvalidValidatorAddress
+	}
+	v := vote.ToProto()
+	if !val.PubKey.VerifySignature(VoteSignBytes(chainID, v), vote.Signature) {
+		return ErrVoteInvalidSignature
+	}
+	return nil
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
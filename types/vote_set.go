@@ -7,7 +7,6 @@ import (
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
-	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/libs/bits"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
@@ -161,12 +160,12 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 	voteSet.mtx.Lock()
 	defer voteSet.mtx.Unlock()
 
-	return voteSet.addVote(vote, vote.Verify)
+	return voteSet.addVote(vote, vote.VerifyValidator)
 }
 
 // NOTE: Validates as much as possible before attempting to verify the signature.
 func (voteSet *VoteSet) addVote(vote *Vote, execVoteVerify func(chainID string,
-	pub crypto.PubKey) (err error)) (added bool, err error) {
+	val *Validator) (err error)) (added bool, err error) {
 	if vote == nil {
 		return false, ErrVoteNil
 	}
@@ -215,7 +214,7 @@ func (voteSet *VoteSet) addVote(vote *Vote, execVoteVerify func(chainID string,
 	}
 
 	// Check signature.
-	if err := execVoteVerify(voteSet.chainID, val.PubKey); err != nil {
+	if err := execVoteVerify(voteSet.chainID, val); err != nil {
 		return false, fmt.Errorf(
 			"failed to verify vote with ChainID %s and PubKey %s: %w",
 			voteSet.chainID,
@@ -45,6 +45,36 @@ func TestVoteSet_AddVote_Good(t *testing.T) {
 	assert.False(t, ok || !blockID.IsZero(), "there should be no 2/3 majority")
 }
 
+func TestVoteSet_AddVote_KeyRotation(t *testing.T) {
+	height, round := int64(1), int32(0)
+	valSet, privValidators := RandValidatorSet(1, 10)
+	oldPubKey, err := privValidators[0].GetPubKey()
+	require.NoError(t, err)
+	newVal := NewMockPV()
+	newPubKey, err := newVal.GetPubKey()
+	require.NoError(t, err)
+	require.NoError(t, valSet.RotateKeys([]ValidatorKeyRotation{{OldPubKey: oldPubKey, NewPubKey: newPubKey}}))
+
+	vote := &Vote{
+		ValidatorIndex: 0,
+		Height:         height,
+		Round:          round,
+		Type:           tmproto.PrevoteType,
+		Timestamp:      tmtime.Now(),
+		BlockID:        BlockID{nil, PartSetHeader{}},
+	}
+
+	// the validator votes with its new key and the address it kept
+	voteSet := NewVoteSet("test_chain_id", height, round, tmproto.PrevoteType, valSet)
+	_, err = signAddVote(newVal, withValidator(vote, newPubKey.Address(), 0), voteSet)
+	assert.ErrorIs(t, err, ErrVoteInvalidValidatorAddress)
+	_, err = signAddVote(privValidators[0], withValidator(vote, oldPubKey.Address(), 0), voteSet)
+	assert.ErrorIs(t, err, ErrVoteInvalidSignature)
+	added, err := signAddVote(newVal, withValidator(vote, oldPubKey.Address(), 0), voteSet)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
 func TestVoteSet_AddVote_Bad(t *testing.T) {
 	height, round := int64(1), int32(0)
 	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrevoteType, 10, 1)
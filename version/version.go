@@ -11,6 +11,10 @@ const (
 	ABCISemVer = "0.17.0"
 
 	ABCIVersion = ABCISemVer
+
+	// BlockProtocolDuplicateProposalEvidence is the first block protocol of
+	// which blocks can include DuplicateProposalEvidence.
+	BlockProtocolDuplicateProposalEvidence uint64 = 12
 )
 
 var (
@@ -20,7 +24,7 @@ var (
 
 	// BlockProtocol versions all block data structures and processing.
 	// This includes validity of blocks and state updates.
-	BlockProtocol uint64 = 11
+	BlockProtocol uint64 = 12
 
 	// AppProtocol versions ABCI application.
 	AppProtocol uint64 = 0